@@ -16,7 +16,9 @@ require (
 	github.com/prometheus/client_golang v1.14.0
 	github.com/spf13/afero v1.2.2
 	github.com/stretchr/testify v1.8.2
+	go.etcd.io/bbolt v1.3.6
 	golang.org/x/net v0.27.0
+	google.golang.org/protobuf v1.31.0
 	k8s.io/api v0.28.6
 	k8s.io/apiextensions-apiserver v0.0.0-20230112083153-33db789573b1
 	k8s.io/apimachinery v0.28.6
@@ -64,7 +66,6 @@ require (
 	golang.org/x/text v0.16.0 // indirect
 	golang.org/x/time v0.3.0 // indirect
 	google.golang.org/appengine v1.6.7 // indirect
-	google.golang.org/protobuf v1.31.0 // indirect
 	gopkg.in/inf.v0 v0.9.1 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
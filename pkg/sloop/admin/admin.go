@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+// Package admin has the sloop subcommands which work directly on a store root, for inspecting and fixing a store
+// without running the server.  The server must not be running on the same store, which badger enforces with a lock.
+package admin
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+type command struct {
+	name        string
+	description string
+	run         func(args []string, out io.Writer) error
+}
+
+var commands = []command{
+	{"store stats", "Print the size of the store on disk and its key counts as json", runStoreStats},
+	{"store partitions", "List the partitions in the store with their key counts by table", runStorePartitions},
+	{"store compact", "Flatten the store and run value log GC until there is nothing left to reclaim", runStoreCompact},
+	{"store drop", "Drop the partitions which end before a time", runStoreDrop},
+	{"store export", "Write every key and its decoded value as json lines", runStoreExport},
+	{"store verify", "Check the store checksums and that every key parses and its value decodes", runStoreVerify},
+	{"export", "Write the history of a table as flat json lines or parquet rows for offline analysis", runExport},
+	{"backup", "Write a backup of the store to a file or a backup directory", runBackup},
+	{"restore", "Restore backup files, a backup manifest or a backup directory into an empty store", runRestore},
+	{"bench", "Load generated history into a scratch store and report ingest, query and cleanup performance", runBench},
+}
+
+// IsCommand returns true when the first argument of the binary names a subcommand rather than the server
+func IsCommand(arg string) bool {
+	for _, c := range commands {
+		if strings.SplitN(c.name, " ", 2)[0] == arg {
+			return true
+		}
+	}
+	return false
+}
+
+// Run runs the subcommand named by the first one or two arguments, writing its output to out
+func Run(args []string, out io.Writer) error {
+	for _, c := range commands {
+		words := strings.Split(c.name, " ")
+		if len(args) >= len(words) && strings.Join(args[:len(words)], " ") == c.name {
+			err := c.run(args[len(words):], out)
+			if err == flag.ErrHelp {
+				// The flag set printed the usage
+				return nil
+			}
+			return err
+		}
+	}
+	return fmt.Errorf("unknown command %q\n%v", strings.Join(args, " "), usage())
+}
+
+func usage() string {
+	var b strings.Builder
+	b.WriteString("Commands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(&b, "  sloop %-18v %v\n", c.name, c.description)
+	}
+	b.WriteString("Run a command with -help to see its flags")
+	return b.String()
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("sloop "+name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
+
+// The flags which pick the store a command works on.  They match the flags of the server
+type storeFlags struct {
+	storeRoot string
+	context   string
+	engine    string
+	keyFile   string
+}
+
+func (f *storeFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.storeRoot, "store-root", "./data", "Path to store history data")
+	fs.StringVar(&f.context, "context", "", "Kubernetes context of the store.  Each context has its own directory under store-root")
+	fs.StringVar(&f.engine, "store-engine", badgerwrap.EngineBadger, "Storage engine of the store, badger or bolt")
+	fs.StringVar(&f.keyFile, "store-encryption-key-file", "", "File with the key the store is encrypted with")
+}
+
+func (f *storeFlags) path() string {
+	return path.Join(f.storeRoot, f.context)
+}
+
+// Opens the store.  Unless create is set the store has to exist already, so a typo in a flag is not an empty store
+func (f *storeFlags) open(create bool) (badgerwrap.DB, error) {
+	if !create {
+		_, err := os.Stat(f.path())
+		if err != nil {
+			return nil, errors.Wrapf(err, "there is no store at %q", f.path())
+		}
+	}
+	factory, err := badgerwrap.NewFactory(f.engine)
+	if err != nil {
+		return nil, err
+	}
+	config := &untyped.Config{
+		RootPath:                f.path(),
+		ConfigPartitionDuration: time.Hour,
+		EncryptionKeyFile:       f.keyFile,
+	}
+	db, err := untyped.OpenStore(factory, config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open store %q", f.path())
+	}
+	return db, nil
+}
@@ -0,0 +1,203 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package admin
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+var someWatchTime = time.Date(2019, 3, 4, 5, 6, 7, 0, time.UTC)
+
+// Makes a store under a temp dir with a watch record in each of the given hours after someWatchTime, and returns the
+// store root
+func helper_makeStore(t *testing.T, hours int) string {
+	dir, err := ioutil.TempDir("", "admin")
+	assert.Nil(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	sf := &storeFlags{storeRoot: dir, context: "someContext", engine: badgerwrap.EngineBadger}
+	db, err := sf.open(true)
+	assert.Nil(t, err)
+	defer untyped.CloseStore(db)
+
+	tables := typed.NewTableList(db)
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for hour := 0; hour < hours; hour++ {
+			ts := someWatchTime.Add(time.Duration(hour) * time.Hour)
+			key := typed.NewWatchTableKey(untyped.GetPartitionId(ts), "Pod", "someNamespace", "someName", ts).String()
+			pTs, _ := ptypes.TimestampProto(ts)
+			err := tables.WatchTable().Set(txn, key, &typed.KubeWatchResult{Kind: "Pod", Timestamp: pTs, Payload: "{}"})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	return dir
+}
+
+func helper_run(t *testing.T, args ...string) (string, error) {
+	var out bytes.Buffer
+	err := Run(args, &out)
+	return out.String(), err
+}
+
+func Test_Run_UnknownCommand(t *testing.T) {
+	assert.True(t, IsCommand("store"))
+	assert.True(t, IsCommand("restore"))
+	assert.False(t, IsCommand("-port"))
+	_, err := helper_run(t, "store", "nothing")
+	assert.Contains(t, err.Error(), "sloop store partitions")
+}
+
+func Test_StoreCommands_NeedExistingStore(t *testing.T) {
+	_, err := helper_run(t, "store", "stats", "-store-root", "/nonexistent/store")
+	assert.Contains(t, err.Error(), "there is no store")
+}
+
+func Test_StoreStatsAndPartitions(t *testing.T) {
+	root := helper_makeStore(t, 3)
+
+	out, err := helper_run(t, "store", "stats", "-store-root", root, "-context", "someContext")
+	assert.Nil(t, err)
+	stats := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal([]byte(out), &stats))
+	assert.Equal(t, float64(3), stats["TotalKeyCount"])
+
+	out, err = helper_run(t, "store", "partitions", "-store-root", root, "-context", "someContext")
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	assert.Len(t, lines, 5)
+	assert.Contains(t, lines[1], untyped.GetPartitionId(someWatchTime))
+	assert.Contains(t, lines[1], "2019-03-04T05:00:00Z")
+	assert.Contains(t, lines[1], "watch=1")
+}
+
+func Test_StoreDrop(t *testing.T) {
+	root := helper_makeStore(t, 3)
+	args := []string{"store", "drop", "-store-root", root, "-context", "someContext", "-before", "2019-03-04T07:00:00Z"}
+
+	out, err := helper_run(t, append(args, "-dry-run")...)
+	assert.Nil(t, err)
+	assert.Contains(t, out, "2 of 3 partitions end by")
+	out, err = helper_run(t, args...)
+	assert.Nil(t, err)
+	assert.Contains(t, out, "2 of 3 partitions end by")
+	out, err = helper_run(t, args...)
+	assert.Nil(t, err)
+	assert.Contains(t, out, "0 of 1 partitions end by")
+}
+
+func Test_parseBefore(t *testing.T) {
+	before, err := parseBefore("2019-03-04T07:00:00Z", someWatchTime)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2019, 3, 4, 7, 0, 0, 0, time.UTC), before)
+	before, err = parseBefore("1h", someWatchTime)
+	assert.Nil(t, err)
+	assert.Equal(t, someWatchTime.Add(-time.Hour), before)
+	_, err = parseBefore("yesterday", someWatchTime)
+	assert.NotNil(t, err)
+}
+
+func Test_StoreExportAndVerify(t *testing.T) {
+	root := helper_makeStore(t, 2)
+
+	out, err := helper_run(t, "store", "export", "-store-root", root, "-context", "someContext", "-prefix", "/watch/")
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	assert.Len(t, lines, 2)
+	exported := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal([]byte(lines[0]), &exported))
+	assert.Equal(t, "Pod", exported["value"].(map[string]interface{})["kind"])
+
+	out, err = helper_run(t, "store", "verify", "-store-root", root, "-context", "someContext")
+	assert.Nil(t, err)
+	assert.Contains(t, out, "Table watch has 2 keys")
+}
+
+func Test_StoreVerify_BadKey(t *testing.T) {
+	root := helper_makeStore(t, 1)
+	sf := &storeFlags{storeRoot: root, context: "someContext", engine: badgerwrap.EngineBadger}
+	db, err := sf.open(false)
+	assert.Nil(t, err)
+	assert.Nil(t, db.Update(func(txn badgerwrap.Txn) error { return txn.Set([]byte("/not/a/sloop/key"), []byte("x")) }))
+	untyped.CloseStore(db)
+
+	out, err := helper_run(t, "store", "verify", "-store-root", root, "-context", "someContext")
+	assert.NotNil(t, err)
+	assert.Contains(t, out, `Bad key "/not/a/sloop/key"`)
+}
+
+func Test_BackupAndRestore(t *testing.T) {
+	root := helper_makeStore(t, 3)
+	backupFile := filepath.Join(root, "backup.bak.zst")
+
+	out, err := helper_run(t, "backup", "-store-root", root, "-context", "someContext", "-out", backupFile)
+	assert.Nil(t, err)
+	assert.Contains(t, out, "Wrote backup")
+
+	restoreArgs := []string{"restore", "-store-root", root, "-context", "restored", "-files", backupFile}
+	out, err = helper_run(t, append(restoreArgs, "-until", "2019-03-04T06:30:00Z")...)
+	assert.Nil(t, err)
+	assert.Contains(t, out, "Restored 2 keys")
+	// The store is not empty any more
+	_, err = helper_run(t, restoreArgs...)
+	assert.Contains(t, err.Error(), "not empty")
+}
+
+func Test_BackupAndRestore_BackupDir(t *testing.T) {
+	root := helper_makeStore(t, 2)
+	backupDir := filepath.Join(root, "backups")
+
+	_, err := helper_run(t, "backup", "-store-root", root, "-context", "someContext", "-backup-dir", backupDir)
+	assert.Nil(t, err)
+	_, err = os.Stat(filepath.Join(backupDir, "someContext", "manifest.json"))
+	assert.Nil(t, err)
+
+	out, err := helper_run(t, "restore", "-store-root", filepath.Join(root, "restored"), "-context", "someContext", "-backup-dir", backupDir)
+	assert.Nil(t, err)
+	assert.Contains(t, out, "Restored 2 keys")
+}
+
+func Test_Export(t *testing.T) {
+	root := helper_makeStore(t, 3)
+
+	out, err := helper_run(t, "export", "-store-root", root, "-context", "someContext", "-name", "someName")
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	assert.Len(t, lines, 3)
+	row := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal([]byte(lines[0]), &row))
+	assert.Equal(t, "2019-03-04T05:06:07Z", row["timestamp"])
+	assert.Equal(t, "someName", row["name"])
+
+	out, err = helper_run(t, "export", "-store-root", root, "-context", "someContext", "-start", "2019-03-04T06:00:00Z", "-end", "2019-03-04T06:59:59Z")
+	assert.Nil(t, err)
+	assert.Len(t, strings.Split(strings.TrimSpace(out), "\n"), 1)
+
+	out, err = helper_run(t, "export", "-store-root", root, "-context", "someContext", "-format", "parquet")
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(out, "PAR1"))
+
+	_, err = helper_run(t, "export", "-store-root", root, "-context", "someContext", "-table", "alert")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,182 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package admin
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/backup"
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/ingress"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+)
+
+// The flags for the backup encryption keys.  They match the flags of the server
+type backupKeyFlags struct {
+	keyFile         string
+	previousKeyFile string
+}
+
+func (f *backupKeyFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.keyFile, "backup-encryption-key-file", "", "File with the key to encrypt backups with, and to decrypt them on restore")
+	fs.StringVar(&f.previousKeyFile, "backup-previous-key-file", "", "File with an older backup key, to restore backups made before a key rotation")
+}
+
+// Returns the key followed by the previous one, or nothing when backups are not encrypted
+func (f *backupKeyFlags) keys() ([][]byte, error) {
+	keys, err := common.ReadKeyFiles(f.keyFile, f.previousKeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read backup encryption key")
+	}
+	return keys, nil
+}
+
+func runBackup(args []string, out io.Writer) error {
+	sf := &storeFlags{}
+	kf := &backupKeyFlags{}
+	fs := newFlagSet("backup")
+	sf.register(fs)
+	kf.register(fs)
+	outFile := fs.String("out", "", "File to write a backup to")
+	since := fs.Uint64("since", 0, "With -out, only back up the versions from this one on, for an incremental backup")
+	backupDir := fs.String("backup-dir", "", "Directory of scheduled backups to add a backup to, recorded in its manifest.  Each context has its own directory under it")
+	fullFrequency := fs.Duration("backup-full-frequency", 24*time.Hour, "With -backup-dir, take a full backup when the last one is this old and an incremental one otherwise")
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	if (*outFile == "") == (*backupDir == "") {
+		return fmt.Errorf("one of -out and -backup-dir is required")
+	}
+	keys, err := kf.keys()
+	if err != nil {
+		return err
+	}
+	db, err := sf.open(false)
+	if err != nil {
+		return err
+	}
+	defer untyped.CloseStore(db)
+
+	if *backupDir != "" {
+		target, err := backup.NewLocalTarget(path.Join(*backupDir, sf.context))
+		if err != nil {
+			return err
+		}
+		config := backup.SchedulerConfig{FullFrequency: *fullFrequency}
+		if len(keys) > 0 {
+			config.EncryptionKey = keys[0]
+		}
+		err = backup.NewScheduler(db, target, config).RunOnce()
+		if err != nil {
+			return err
+		}
+		manifest, err := backup.LoadManifest(target)
+		if err != nil {
+			return err
+		}
+		last, _ := manifest.Last()
+		_, err = fmt.Fprintf(out, "Last backup in %v is %v up to version %v\n", target, last.Name, last.Version)
+		return err
+	}
+
+	file, err := os.Create(*outFile)
+	if err != nil {
+		return err
+	}
+	var encryptionKey []byte
+	if len(keys) > 0 {
+		encryptionKey = keys[0]
+	}
+	version, err := backup.Write(db, file, *since, encryptionKey)
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(*outFile)
+		return errors.Wrap(err, "backup failed")
+	}
+	_, err = fmt.Fprintf(out, "Wrote backup %v up to version %v.  Use -since %v for the next incremental backup\n", *outFile, version, version+1)
+	return err
+}
+
+func runRestore(args []string, out io.Writer) error {
+	sf := &storeFlags{}
+	kf := &backupKeyFlags{}
+	fs := newFlagSet("restore")
+	sf.register(fs)
+	kf.register(fs)
+	files := fs.String("files", "", "Comma separated backup files to load in order, a full backup followed by its incremental backups")
+	manifestFile := fs.String("manifest", "", "Backup manifest file to restore the latest chain from")
+	backupDir := fs.String("backup-dir", "", "Directory of scheduled backups to restore the latest chain from.  Each context has its own directory under it")
+	untilFlag := fs.String("until", "", "RFC3339 time to restore to.  Picks the backup chain covering it and drops the partitions after it")
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	sources := 0
+	for _, source := range []string{*files, *manifestFile, *backupDir} {
+		if source != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return fmt.Errorf("one of -files, -manifest and -backup-dir is required")
+	}
+	var until time.Time
+	if *untilFlag != "" {
+		until, err = time.Parse(time.RFC3339, *untilFlag)
+		if err != nil {
+			return errors.Wrapf(err, "-until %q is not an RFC3339 time", *untilFlag)
+		}
+	}
+	keys, err := kf.keys()
+	if err != nil {
+		return err
+	}
+	db, err := sf.open(true)
+	if err != nil {
+		return err
+	}
+	defer untyped.CloseStore(db)
+
+	_, keyCount := common.GetPartitionsInfo(db)
+	if keyCount > 0 {
+		return fmt.Errorf("store %q is not empty, restore needs a fresh store", sf.path())
+	}
+	switch {
+	case *files != "":
+		err = ingress.DatabaseRestore(db, strings.Split(*files, ","), keys)
+		if err == nil && !until.IsZero() {
+			err = backup.DropPartitionsAfter(db, until)
+		}
+	case *manifestFile != "":
+		err = backup.RestoreManifestFile(db, *manifestFile, until, keys)
+	case *backupDir != "":
+		var target *backup.LocalTarget
+		target, err = backup.NewLocalTarget(path.Join(*backupDir, sf.context))
+		if err == nil {
+			err = backup.RestoreUntil(db, target, until, keys)
+		}
+	}
+	if err != nil {
+		return errors.Wrap(err, "restore failed")
+	}
+	_, keyCount = common.GetPartitionsInfo(db)
+	_, err = fmt.Fprintf(out, "Restored %v keys into %v\n", keyCount, sf.path())
+	return err
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/salesforce/sloop/pkg/sloop/benchmark"
+)
+
+func runBench(args []string, out io.Writer) error {
+	config := benchmark.DefaultConfig()
+	fs := newFlagSet("bench")
+	fs.IntVar(&config.Hours, "hours", config.Hours, "Hours of generated history to load")
+	fs.StringVar(&config.StoreRoot, "store-root", "", "Directory to make the scratch store in.  Defaults to the temp directory")
+	fs.StringVar(&config.StoreEngine, "store-engine", config.StoreEngine, "Storage engine to benchmark, badger or bolt")
+	fs.Int64Var(&config.Generator.Seed, "seed", config.Generator.Seed, "Seed of the generated workload.  Keep it the same to compare runs")
+	fs.IntVar(&config.WorkerCount, "workers", config.WorkerCount, "Processing workers")
+	fs.IntVar(&config.BatchSize, "batch-size", config.BatchSize, "Watch results processed in each store transaction")
+	fs.IntVar(&config.QueryRepeats, "query-repeats", config.QueryRepeats, "Times to run each query")
+	label := fs.String("label", "", "Label for the report, like a commit hash")
+	outFile := fs.String("out", "", "File to write the json report to instead of stdout")
+	baselineFile := fs.String("baseline", "", "Report of an earlier run to compare with.  The command fails when results regressed")
+	maxRegression := fs.Float64("max-regression", 0.2, "How much worse than the baseline a result can be before it is a regression, 0.2 = 20%")
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	var baseline *benchmark.Report
+	if *baselineFile != "" {
+		baseline, err = benchmark.ReadReport(*baselineFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	report, err := benchmark.Run(config)
+	if err != nil {
+		return err
+	}
+	report.Label = *label
+	if *outFile != "" {
+		err = benchmark.WriteReport(*outFile, report)
+	} else {
+		var data []byte
+		data, err = json.MarshalIndent(report, "", "  ")
+		if err == nil {
+			_, err = fmt.Fprintln(out, string(data))
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if baseline != nil {
+		regressions := benchmark.Compare(baseline, report, *maxRegression)
+		if len(regressions) > 0 {
+			return fmt.Errorf("%v results regressed from %q:\n  %v", len(regressions), baseline.Label, strings.Join(regressions, "\n  "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package admin
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/export"
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+)
+
+func runExport(args []string, out io.Writer) error {
+	sf := &storeFlags{}
+	fs := newFlagSet("export")
+	sf.register(fs)
+	table := fs.String("table", queries.ExportWatchTable, "Table to export, watch for one row per object change, ressum for resource summaries or eventcount for event counts")
+	format := fs.String("format", export.FormatJsonLines, "Format to write, jsonl or parquet")
+	outFile := fs.String("out", "", "File to write to instead of stdout")
+	startFlag := fs.String("start", "", "RFC3339 time or a duration back from now to export from.  Defaults to the oldest partition")
+	endFlag := fs.String("end", "", "RFC3339 time or a duration back from now to export up to.  Defaults to the newest partition")
+	payload := fs.Bool("payload", false, "Add a payload column with the full object to a watch export")
+	params := url.Values{}
+	for _, param := range []string{queries.KindParam, queries.NamespaceParam, queries.NameParam, queries.NameMatchParam, queries.UuidParam, queries.LabelSelectorParam} {
+		param := param
+		fs.Func(param, fmt.Sprintf("Only export rows with this %v, like the %v query param", param, param), func(value string) error {
+			params.Set(param, value)
+			return nil
+		})
+	}
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	params.Set(queries.TableParam, *table)
+	if *payload {
+		params.Set(queries.PayloadParam, "true")
+	}
+	columns, err := queries.ExportColumns(params, nil)
+	if err != nil {
+		return err
+	}
+	if *format != export.FormatJsonLines && *format != export.FormatParquet {
+		return fmt.Errorf("-format must be %v or %v", export.FormatJsonLines, export.FormatParquet)
+	}
+	db, err := sf.open(false)
+	if err != nil {
+		return err
+	}
+	defer untyped.CloseStore(db)
+
+	tables := typed.NewTableList(db)
+	startTime, endTime, err := exportTimeRange(tables, *startFlag, *endFlag, time.Now())
+	if err != nil {
+		return err
+	}
+	if *outFile != "" {
+		file, err := os.Create(*outFile)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+	writer, err := export.NewWriter(*format, out, columns)
+	if err != nil {
+		return err
+	}
+	count, err := queries.Export(params, tables, startTime, endTime, "", queries.Unrestricted, writer)
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		return errors.Wrap(err, "export failed")
+	}
+	if *outFile != "" {
+		fmt.Fprintf(os.Stderr, "Exported %v %v rows from %v to %v to %v\n", count, *table, startTime.Format(time.RFC3339), endTime.Format(time.RFC3339), *outFile)
+	}
+	return nil
+}
+
+// Returns the time range from the flags, with a missing end of the range set to the end of the data in the store
+func exportTimeRange(tables typed.Tables, startFlag string, endFlag string, now time.Time) (time.Time, time.Time, error) {
+	var startTime, endTime time.Time
+	ok, minPartition, maxPartition, err := tables.GetMinAndMaxPartition()
+	if err != nil {
+		return startTime, endTime, errors.Wrap(err, "failed to read partitions")
+	}
+	if ok {
+		startTime, _, err = untyped.GetTimeRangeForPartition(minPartition)
+		if err != nil {
+			return startTime, endTime, err
+		}
+		_, endTime, err = untyped.GetTimeRangeForPartition(maxPartition)
+		if err != nil {
+			return startTime, endTime, err
+		}
+	}
+	if startFlag != "" {
+		startTime, err = parseTimeFlag("-start", startFlag, now)
+		if err != nil {
+			return startTime, endTime, err
+		}
+	}
+	if endFlag != "" {
+		endTime, err = parseTimeFlag("-end", endFlag, now)
+		if err != nil {
+			return startTime, endTime, err
+		}
+	}
+	if endTime.Before(startTime) {
+		return startTime, endTime, fmt.Errorf("the time range from %v to %v is empty", startTime.Format(time.RFC3339), endTime.Format(time.RFC3339))
+	}
+	return startTime, endTime, nil
+}
@@ -0,0 +1,322 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	badger "github.com/dgraph-io/badger/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/salesforce/sloop/pkg/sloop/storemanager"
+)
+
+// Verify prints this many problems and counts the rest
+const maxPrintedProblems = 20
+
+func runStoreStats(args []string, out io.Writer) error {
+	sf := &storeFlags{}
+	fs := newFlagSet("store stats")
+	sf.register(fs)
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	db, err := sf.open(false)
+	if err != nil {
+		return err
+	}
+	defer untyped.CloseStore(db)
+
+	stats := storemanager.GetStoreStats(sf.path(), db, &afero.Afero{Fs: afero.NewOsFs()})
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, string(data))
+	return err
+}
+
+func runStorePartitions(args []string, out io.Writer) error {
+	sf := &storeFlags{}
+	fs := newFlagSet("store partitions")
+	sf.register(fs)
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	db, err := sf.open(false)
+	if err != nil {
+		return err
+	}
+	defer untyped.CloseStore(db)
+
+	partitions, totalKeyCount := common.GetPartitionsInfo(db)
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "PARTITION\tSTART\tEND\tKEYS\tTABLES")
+	for _, partitionId := range common.GetSortedPartitionIDs(partitions) {
+		info := partitions[partitionId]
+		var start, end string
+		oldest, newest, err := untyped.GetTimeRangeForPartition(partitionId)
+		if err == nil {
+			start, end = oldest.Format(time.RFC3339), newest.Format(time.RFC3339)
+		}
+		var tables []string
+		for tableName, keyCount := range info.TableNameToKeyCountMap {
+			tables = append(tables, fmt.Sprintf("%v=%v", tableName, keyCount))
+		}
+		sort.Strings(tables)
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", partitionId, start, end, info.TotalKeyCount, strings.Join(tables, " "))
+	}
+	fmt.Fprintf(w, "total\t\t\t%v\t\n", totalKeyCount)
+	return w.Flush()
+}
+
+func runStoreCompact(args []string, out io.Writer) error {
+	sf := &storeFlags{}
+	fs := newFlagSet("store compact")
+	sf.register(fs)
+	discardRatio := fs.Float64("discard-ratio", 0.5, "Value log files with at least this fraction of stale data are rewritten")
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	db, err := sf.open(false)
+	if err != nil {
+		return err
+	}
+	defer untyped.CloseStore(db)
+
+	osFs := &afero.Afero{Fs: afero.NewOsFs()}
+	before := storemanager.GetStoreStats(sf.path(), db, osFs)
+	err = db.Flatten(runtime.NumCPU())
+	if err != nil {
+		return errors.Wrap(err, "failed to flatten store")
+	}
+	rewrites := 0
+	for {
+		err = db.RunValueLogGC(*discardRatio)
+		if err == badger.ErrNoRewrite || err == badger.ErrRejected {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "value log GC failed")
+		}
+		rewrites++
+	}
+	after := storemanager.GetStoreStats(sf.path(), db, osFs)
+	_, err = fmt.Fprintf(out, "Rewrote %v value log files, store went from %v to %v bytes\n", rewrites, before.DiskSizeBytes, after.DiskSizeBytes)
+	return err
+}
+
+func runStoreDrop(args []string, out io.Writer) error {
+	sf := &storeFlags{}
+	fs := newFlagSet("store drop")
+	sf.register(fs)
+	beforeFlag := fs.String("before", "", "Drop the partitions which end by this RFC3339 time, or which are older than this duration")
+	dryRun := fs.Bool("dry-run", false, "List the partitions which would be dropped without dropping them")
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	before, err := parseBefore(*beforeFlag, time.Now())
+	if err != nil {
+		return err
+	}
+	db, err := sf.open(false)
+	if err != nil {
+		return err
+	}
+	defer untyped.CloseStore(db)
+
+	partitions, _ := common.GetPartitionsInfo(db)
+	dropped := 0
+	for _, partitionId := range common.GetSortedPartitionIDs(partitions) {
+		_, end, err := untyped.GetTimeRangeForPartition(partitionId)
+		if err != nil || end.After(before) {
+			continue
+		}
+		info := partitions[partitionId]
+		if !*dryRun {
+			err = common.DropPartition(db, partitionId, info)
+			if err != nil {
+				return errors.Wrapf(err, "failed to drop partition %v", partitionId)
+			}
+		}
+		fmt.Fprintf(out, "Dropped partition %v ending %v with %v keys\n", partitionId, end.Format(time.RFC3339), info.TotalKeyCount)
+		dropped++
+	}
+	if *dryRun {
+		fmt.Fprintf(out, "Dry run, nothing was dropped\n")
+	}
+	_, err = fmt.Fprintf(out, "%v of %v partitions end by %v\n", dropped, len(partitions), before.Format(time.RFC3339))
+	return err
+}
+
+func parseBefore(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("-before is required")
+	}
+	return parseTimeFlag("-before", value, now)
+}
+
+// Parses an RFC3339 time, or a duration to go back from now
+func parseTimeFlag(name string, value string, now time.Time) (time.Time, error) {
+	ts, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return ts, nil
+	}
+	age, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%v %q is neither an RFC3339 time nor a duration", name, value)
+	}
+	return now.Add(-age), nil
+}
+
+type exportedKey struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value,omitempty"`
+	Error string      `json:"error,omitempty"`
+}
+
+func runStoreExport(args []string, out io.Writer) error {
+	sf := &storeFlags{}
+	fs := newFlagSet("store export")
+	sf.register(fs)
+	outFile := fs.String("out", "", "File to write to instead of stdout")
+	prefix := fs.String("prefix", "", "Only export keys with this prefix, such as /watch/")
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	db, err := sf.open(false)
+	if err != nil {
+		return err
+	}
+	defer untyped.CloseStore(db)
+
+	if *outFile != "" {
+		file, err := os.Create(*outFile)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+	encoder := json.NewEncoder(out)
+	tables := typed.NewTableList(db)
+	count := 0
+	err = db.View(func(txn badgerwrap.Txn) error {
+		iterOpt := badger.DefaultIteratorOptions
+		iterOpt.Prefix = []byte(*prefix)
+		iterator := txn.NewIterator(iterOpt)
+		defer iterator.Close()
+		for iterator.Seek(iterOpt.Prefix); iterator.ValidForPrefix(iterOpt.Prefix); iterator.Next() {
+			exported := exportedKey{Key: string(iterator.Item().Key())}
+			value, err := typed.GetValue(tables, txn, exported.Key)
+			if err != nil {
+				exported.Error = err.Error()
+			} else {
+				exported.Value = value
+			}
+			err = encoder.Encode(exported)
+			if err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "export failed")
+	}
+	if *outFile != "" {
+		fmt.Fprintf(os.Stderr, "Exported %v keys to %v\n", count, *outFile)
+	}
+	return nil
+}
+
+func runStoreVerify(args []string, out io.Writer) error {
+	sf := &storeFlags{}
+	fs := newFlagSet("store verify")
+	sf.register(fs)
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	db, err := sf.open(false)
+	if err != nil {
+		return err
+	}
+	defer untyped.CloseStore(db)
+
+	problems := 0
+	report := func(format string, args ...interface{}) {
+		problems++
+		if problems <= maxPrintedProblems {
+			fmt.Fprintf(out, format+"\n", args...)
+		}
+	}
+	err = db.VerifyChecksum()
+	if err != nil {
+		report("Checksum verification failed: %v", err)
+	}
+
+	tables := typed.NewTableList(db)
+	keyCounts := map[string]int{}
+	err = db.View(func(txn badgerwrap.Txn) error {
+		iterOpt := badger.DefaultIteratorOptions
+		iterOpt.PrefetchValues = false
+		iterator := txn.NewIterator(iterOpt)
+		defer iterator.Close()
+		for iterator.Rewind(); iterator.Valid(); iterator.Next() {
+			key := string(iterator.Item().Key())
+			err, parts := common.ParseKey(key)
+			if err != nil {
+				report("Bad key %q: %v", key, err)
+				continue
+			}
+			keyCounts[parts[1]]++
+			_, err = typed.GetValue(tables, txn, key)
+			if err != nil {
+				report("Bad value for key %q: %v", key, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to read store")
+	}
+
+	var tableNames []string
+	for tableName := range keyCounts {
+		tableNames = append(tableNames, tableName)
+	}
+	sort.Strings(tableNames)
+	for _, tableName := range tableNames {
+		fmt.Fprintf(out, "Table %v has %v keys\n", tableName, keyCounts[tableName])
+	}
+	if problems > 0 {
+		return fmt.Errorf("store %q has %v problems", sf.path(), problems)
+	}
+	_, err = fmt.Fprintf(out, "Store %q is ok\n", sf.path())
+	return err
+}
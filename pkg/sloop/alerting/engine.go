@@ -0,0 +1,300 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package alerting
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/notifier"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+var (
+	metricAlertFiredCount           = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_alert_fired_count"}, []string{"rule"})
+	metricAlertEvaluationErrorCount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_alert_evaluation_error_count"}, []string{"rule"})
+)
+
+// Engine evaluates the configured alert rules against watch records and records the alerts which fire in the
+// alert table.  It is called by processing for every record after the other tables have been updated.
+// Alerts which fire are also sent to the notifier, which may be nil, tagged with the kube context of the cluster,
+// once the caller has committed them.
+type Engine struct {
+	rules       []*compiledRule
+	notifier    *notifier.Notifier
+	kubeContext string
+}
+
+func NewEngine(rules []Rule, n *notifier.Notifier, kubeContext string) (*Engine, error) {
+	e := &Engine{notifier: n, kubeContext: kubeContext}
+	names := map[string]bool{}
+	for _, rule := range rules {
+		compiled, err := compileRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		if names[rule.Name] {
+			return nil, errors.Errorf("duplicate alert rule name %v", rule.Name)
+		}
+		names[rule.Name] = true
+		e.rules = append(e.rules, compiled)
+	}
+	return e, nil
+}
+
+// Evaluate runs every rule against one watch record and writes an alert for each rule that fires.  A rule which
+// cannot be evaluated is logged and skipped, so only store errors are returned.
+//
+// The notifications for the alerts which fired are returned rather than sent, as txn can still fail or be retried.
+// Pass them to Notify once txn has committed.
+func (e *Engine) Evaluate(tables typed.Tables, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata, involvedObject *kubeextractor.KubeInvolvedObject) ([]notifier.Notification, error) {
+	if e == nil || len(e.rules) == 0 || watchRec.WatchType == typed.KubeWatchResult_DELETE {
+		return nil, nil
+	}
+	ts, err := ptypes.Timestamp(watchRec.Timestamp)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not convert watch timestamp")
+	}
+
+	var notifications []notifier.Notification
+	var eventInfo *kubeextractor.EventInfo
+	// Read at most once, for the first change rule which needs it
+	var previousPayload *string
+	for _, rule := range e.rules {
+		if rule.EventReason == "" {
+			if !rule.matchesKind(watchRec.Kind) || !e.logicMatches(rule, watchRec.Payload) {
+				continue
+			}
+			if rule.ChangedPath != "" {
+				if previousPayload == nil {
+					payload, err := getPreviousPayload(tables, txn, watchRec.Kind, metadata, ts)
+					if err != nil {
+						return nil, err
+					}
+					previousPayload = &payload
+				}
+				if !e.pathChanged(rule, *previousPayload, watchRec.Payload) {
+					continue
+				}
+			}
+			data := MessageData{Rule: rule.Name, Severity: rule.Severity, Kind: watchRec.Kind, Namespace: metadata.Namespace, Name: metadata.Name, Count: 1, Path: rule.ChangedPath}
+			notification, err := e.fireAlert(tables, txn, rule, ts, data)
+			if err != nil {
+				return nil, err
+			}
+			notifications = append(notifications, notification)
+			continue
+		}
+
+		if watchRec.Kind != kubeextractor.EventKind || !rule.matchesKind(involvedObject.Kind) {
+			continue
+		}
+		if eventInfo == nil {
+			eventInfo, err = kubeextractor.ExtractEventInfo(watchRec.Payload)
+			if err != nil {
+				glog.Errorf("Alert rule %v could not extract event info: %v", rule.Name, err)
+				metricAlertEvaluationErrorCount.WithLabelValues(rule.Name).Inc()
+				return notifications, nil
+			}
+		}
+		if eventInfo.Reason != rule.EventReason || !e.logicMatches(rule, watchRec.Payload) {
+			continue
+		}
+		windowEnd := eventInfo.LastTimestamp
+		if windowEnd.IsZero() {
+			windowEnd = ts
+		}
+		count, err := countEventsInWindow(tables, txn, involvedObject, rule.EventReason, windowEnd.Add(-rule.window), windowEnd)
+		if err != nil {
+			return nil, err
+		}
+		if count <= rule.Threshold {
+			continue
+		}
+		data := MessageData{Rule: rule.Name, Severity: rule.Severity, Kind: involvedObject.Kind, Namespace: involvedObject.Namespace, Name: involvedObject.Name, Reason: rule.EventReason, Count: count}
+		notification, err := e.fireAlert(tables, txn, rule, ts, data)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications, nil
+}
+
+// Notify sends notifications returned by Evaluate.  Only call it after the transaction passed to Evaluate commits,
+// so an alert is never announced without being recorded
+func (e *Engine) Notify(notifications []notifier.Notification) {
+	if e == nil {
+		return
+	}
+	for _, notification := range notifications {
+		e.notifier.Notify(notification)
+	}
+}
+
+func (e *Engine) logicMatches(rule *compiledRule, payload string) bool {
+	if rule.logicJson == "" {
+		return true
+	}
+	matched, err := common.JsonLogicMatches(rule.logicJson, payload)
+	if err != nil {
+		glog.Errorf(`Failed to apply alert rule %v "%s": %s`, rule.Name, rule.logicJson, err)
+		metricAlertEvaluationErrorCount.WithLabelValues(rule.Name).Inc()
+		return false
+	}
+	return matched
+}
+
+// Returns the payload of the newest watch record for the object before ts, or "" when there is none.  A record for
+// an earlier object with the same name but another uid does not count
+func getPreviousPayload(tables typed.Tables, txn badgerwrap.Txn, kind string, metadata *kubeextractor.KubeMetadata, ts time.Time) (string, error) {
+	seekKey := typed.NewWatchTableKey(untyped.GetPartitionId(ts), kind, metadata.Namespace, metadata.Name, ts)
+	keyComparator := typed.NewWatchTableKeyComparator(kind, metadata.Namespace, metadata.Name, time.Time{})
+	prevKey, err := tables.WatchTable().GetPreviousKey(txn, seekKey, keyComparator)
+	if err == badger.ErrKeyNotFound {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Wrap(err, "could not get previous watch key")
+	}
+	prevRec, err := tables.WatchTable().Get(txn, prevKey.String())
+	if err == badger.ErrKeyNotFound {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Wrap(err, "could not get previous watch record")
+	}
+	prevMetadata, err := kubeextractor.ExtractMetadata(prevRec.Payload)
+	if err != nil || prevMetadata.Uid != metadata.Uid {
+		return "", nil
+	}
+	return prevRec.Payload, nil
+}
+
+// A path is only changed when there is a previous payload, so creating an object does not fire change rules
+func (e *Engine) pathChanged(rule *compiledRule, previousPayload string, payload string) bool {
+	if previousPayload == "" {
+		return false
+	}
+	previousValue, err := valueAtPath(previousPayload, rule.ChangedPath)
+	if err == nil {
+		var value string
+		value, err = valueAtPath(payload, rule.ChangedPath)
+		if err == nil {
+			return value != previousValue
+		}
+	}
+	glog.Errorf("Alert rule %v could not read %v: %v", rule.Name, rule.ChangedPath, err)
+	metricAlertEvaluationErrorCount.WithLabelValues(rule.Name).Inc()
+	return false
+}
+
+// Returns the value at a dotted path in a json payload re-encoded as json, which sorts object keys so equal values
+// compare equal.  A missing value is returned as ""
+func valueAtPath(payload string, path string) (string, error) {
+	var value any
+	err := json.Unmarshal([]byte(payload), &value)
+	if err != nil {
+		return "", err
+	}
+	for _, part := range strings.Split(path, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return "", nil
+		}
+		value, ok = object[part]
+		if !ok {
+			return "", nil
+		}
+	}
+	encoded, err := json.Marshal(value)
+	return string(encoded), err
+}
+
+// Sums the events with the given reason for one object over all minutes in [startTime, endTime]
+func countEventsInWindow(tables typed.Tables, txn badgerwrap.Txn, involvedObject *kubeextractor.KubeInvolvedObject, reason string, startTime time.Time, endTime time.Time) (int, error) {
+	keyPrefix := typed.NewEventCountKeyComparator(involvedObject.Kind, involvedObject.Namespace, involvedObject.Name, involvedObject.Uid)
+	// Without a uid the prefix would also match other objects whose name starts with this one
+	sameName := func(key string) bool {
+		k := &typed.EventCountKey{}
+		return k.Parse(key) == nil && k.Name == involvedObject.Name
+	}
+	eventCounts, _, err := tables.EventCountTable().RangeRead(txn, keyPrefix, sameName, nil, startTime, endTime)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not read event counts")
+	}
+
+	// Event counts are stored per minute rounded to the nearest minute, see processing.spreadOutEvents
+	startMinute := startTime.Round(time.Minute).Unix()
+	endMinute := endTime.Round(time.Minute).Unix()
+	count := 0
+	for _, val := range eventCounts {
+		for minute, counts := range val.MapMinToEvents {
+			if minute < startMinute || minute > endMinute {
+				continue
+			}
+			for reasonAndType, reasonCount := range counts.MapReasonToCount {
+				// Keys are <reason>:<type>, see processing.storeMinutes
+				if strings.HasPrefix(reasonAndType, reason+":") {
+					count += int(reasonCount)
+				}
+			}
+		}
+	}
+	return count, nil
+}
+
+// Writes the alert and returns the notification for it
+func (e *Engine) fireAlert(tables typed.Tables, txn badgerwrap.Txn, rule *compiledRule, ts time.Time, data MessageData) (notifier.Notification, error) {
+	key := typed.NewAlertKey(ts, data.Kind, data.Namespace, data.Name, rule.Name)
+	alert, err := tables.AlertTable().GetOrDefault(txn, key.String())
+	if err != nil {
+		return notifier.Notification{}, errors.Wrap(err, "could not get alert record")
+	}
+
+	pTs, err := ptypes.TimestampProto(ts)
+	if err != nil {
+		return notifier.Notification{}, errors.Wrap(err, "could not convert alert timestamp")
+	}
+	if alert.FirstFired == nil {
+		alert.FirstFired = pTs
+	}
+	alert.LastFired = pTs
+	alert.FireCount += 1
+	alert.RuleName = rule.Name
+	alert.Severity = rule.Severity
+	alert.Message = rule.renderMessage(data)
+
+	err = tables.AlertTable().Set(txn, key.String(), alert)
+	if err != nil {
+		return notifier.Notification{}, errors.Wrap(err, "failed to put alert")
+	}
+	glog.V(common.GlogVerbose).Infof("Alert %v fired for %v: %v", rule.Name, key.String(), alert.Message)
+	metricAlertFiredCount.WithLabelValues(rule.Name).Inc()
+
+	return notifier.Notification{
+		Context:   e.kubeContext,
+		Rule:      rule.Name,
+		Severity:  rule.Severity,
+		Kind:      data.Kind,
+		Namespace: data.Namespace,
+		Name:      data.Name,
+		Message:   alert.Message,
+		Count:     data.Count,
+		FiredAt:   ts,
+	}, nil
+}
@@ -0,0 +1,216 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package alerting
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/notifier"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+var someAlertTs = time.Date(2019, 3, 4, 3, 30, 0, 0, time.UTC)
+
+const somePodTemplate = `{"metadata": {"name": "somePod", "namespace": "someNamespace"}, "status": {"phase": "%v"}}`
+const someBackOffEvent = `{"metadata": {"name": "somePod.1", "namespace": "someNamespace"}, "involvedObject": {"kind": "Pod", "name": "somePod", "namespace": "someNamespace", "uid": "someUid"}, "reason": "BackOff", "type": "Warning", "count": 1, "firstTimestamp": "2019-03-04T03:30:00Z", "lastTimestamp": "2019-03-04T03:30:00Z"}`
+
+var somePodFailedRule = Rule{
+	Name:    "PodFailed",
+	Kind:    "Pod",
+	Message: "{{.Name}} failed",
+	Logic:   map[string]any{"==": []any{map[string]any{"var": "status.phase"}, "Failed"}},
+}
+
+var somePodBackOffRule = Rule{
+	Name:        "PodBackOff",
+	Severity:    "critical",
+	Kind:        "Pod",
+	EventReason: "BackOff",
+	Threshold:   5,
+	Window:      "10m",
+}
+
+func helper_alertTables(t *testing.T, minuteOffsetToBackOffs map[int]int32) typed.Tables {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		key := typed.NewEventCountKey(someAlertTs, "Pod", "someNamespace", "somePod", "someUid")
+		val := &typed.ResourceEventCounts{MapMinToEvents: map[int64]*typed.EventCounts{}}
+		for offset, count := range minuteOffsetToBackOffs {
+			minute := someAlertTs.Add(time.Duration(offset) * time.Minute).Unix()
+			val.MapMinToEvents[minute] = &typed.EventCounts{MapReasonToCount: map[string]int32{"BackOff:Warning": count, "Pulled:Normal": 100}}
+		}
+		return tables.EventCountTable().Set(txn, key.String(), val)
+	})
+	assert.Nil(t, err)
+	return tables
+}
+
+func helper_evaluate(t *testing.T, engine *Engine, tables typed.Tables, kind string, payload string) {
+	ts, err := ptypes.TimestampProto(someAlertTs)
+	assert.Nil(t, err)
+	watchRec := &typed.KubeWatchResult{Kind: kind, Timestamp: ts, WatchType: typed.KubeWatchResult_UPDATE, Payload: payload}
+	metadata, err := kubeextractor.ExtractMetadata(payload)
+	assert.Nil(t, err)
+	involvedObject, err := kubeextractor.ExtractInvolvedObject(payload)
+	assert.Nil(t, err)
+	var notifications []notifier.Notification
+	err = tables.Db().Update(func(txn badgerwrap.Txn) error {
+		var txerr error
+		notifications, txerr = engine.Evaluate(tables, txn, watchRec, &metadata, &involvedObject)
+		return txerr
+	})
+	assert.Nil(t, err)
+	engine.Notify(notifications)
+}
+
+func helper_getAlert(t *testing.T, tables typed.Tables, kind string, namespace string, name string, rule string) *typed.Alert {
+	var alert *typed.Alert
+	err := tables.Db().View(func(txn badgerwrap.Txn) error {
+		var txerr error
+		alert, txerr = tables.AlertTable().GetOrDefault(txn, typed.NewAlertKey(someAlertTs, kind, namespace, name, rule).String())
+		return txerr
+	})
+	assert.Nil(t, err)
+	return alert
+}
+
+func Test_Engine_StateRule(t *testing.T) {
+	tables := helper_alertTables(t, nil)
+	engine, err := NewEngine([]Rule{somePodFailedRule}, nil, "")
+	assert.Nil(t, err)
+
+	helper_evaluate(t, engine, tables, "Pod", fmt.Sprintf(somePodTemplate, "Running"))
+	assert.Equal(t, int32(0), helper_getAlert(t, tables, "Pod", "someNamespace", "somePod", "PodFailed").FireCount)
+
+	helper_evaluate(t, engine, tables, "Pod", fmt.Sprintf(somePodTemplate, "Failed"))
+	helper_evaluate(t, engine, tables, "Pod", fmt.Sprintf(somePodTemplate, "Failed"))
+	alert := helper_getAlert(t, tables, "Pod", "someNamespace", "somePod", "PodFailed")
+	assert.Equal(t, int32(2), alert.FireCount)
+	assert.Equal(t, "warning", alert.Severity)
+	assert.Equal(t, "somePod failed", alert.Message)
+
+	// The rule is limited to pods
+	helper_evaluate(t, engine, tables, "Job", fmt.Sprintf(somePodTemplate, "Failed"))
+	assert.Equal(t, int32(0), helper_getAlert(t, tables, "Job", "someNamespace", "somePod", "PodFailed").FireCount)
+}
+
+func Test_Engine_EventRule_OverThreshold(t *testing.T) {
+	// 6 BackOff events inside the 10 minute window and some older ones outside it
+	tables := helper_alertTables(t, map[int]int32{-30: 20, -8: 2, -3: 3, 0: 1})
+	engine, err := NewEngine([]Rule{somePodBackOffRule}, nil, "")
+	assert.Nil(t, err)
+
+	helper_evaluate(t, engine, tables, kubeextractor.EventKind, someBackOffEvent)
+	alert := helper_getAlert(t, tables, "Pod", "someNamespace", "somePod", "PodBackOff")
+	assert.Equal(t, int32(1), alert.FireCount)
+	assert.Equal(t, "critical", alert.Severity)
+	assert.Equal(t, "6 BackOff events for Pod someNamespace/somePod", alert.Message)
+}
+
+func Test_Engine_EventRule_UnderThreshold(t *testing.T) {
+	tables := helper_alertTables(t, map[int]int32{-30: 20, -3: 4, 0: 1})
+	engine, err := NewEngine([]Rule{somePodBackOffRule}, nil, "")
+	assert.Nil(t, err)
+
+	helper_evaluate(t, engine, tables, kubeextractor.EventKind, someBackOffEvent)
+	assert.Equal(t, int32(0), helper_getAlert(t, tables, "Pod", "someNamespace", "somePod", "PodBackOff").FireCount)
+}
+
+const someDeploymentTemplate = `{"metadata": {"name": "%v", "namespace": "someNamespace", "uid": "%v"}, "spec": {"replicas": %v}, "status": {"observedGeneration": %v}}`
+
+func Test_Engine_ChangeRule(t *testing.T) {
+	tables := helper_alertTables(t, nil)
+	err := tables.Db().Update(func(txn badgerwrap.Txn) error {
+		prevTs := someAlertTs.Add(-10 * time.Minute)
+		pTs, err := ptypes.TimestampProto(prevTs)
+		assert.Nil(t, err)
+		key := typed.NewWatchTableKey(untyped.GetPartitionId(prevTs), "Deployment", "someNamespace", "someDeployment", prevTs)
+		watchRec := &typed.KubeWatchResult{Kind: "Deployment", Timestamp: pTs, WatchType: typed.KubeWatchResult_ADD, Payload: fmt.Sprintf(someDeploymentTemplate, "someDeployment", "someUid", 1, 1)}
+		return tables.WatchTable().Set(txn, key.String(), watchRec)
+	})
+	assert.Nil(t, err)
+	engine, err := NewEngine([]Rule{{Name: "DeploymentSpecChanged", Kind: "Deployment", ChangedPath: "spec"}}, nil, "")
+	assert.Nil(t, err)
+
+	// Only the status changed
+	helper_evaluate(t, engine, tables, "Deployment", fmt.Sprintf(someDeploymentTemplate, "someDeployment", "someUid", 1, 2))
+	assert.Equal(t, int32(0), helper_getAlert(t, tables, "Deployment", "someNamespace", "someDeployment", "DeploymentSpecChanged").FireCount)
+
+	helper_evaluate(t, engine, tables, "Deployment", fmt.Sprintf(someDeploymentTemplate, "someDeployment", "someUid", 2, 2))
+	alert := helper_getAlert(t, tables, "Deployment", "someNamespace", "someDeployment", "DeploymentSpecChanged")
+	assert.Equal(t, int32(1), alert.FireCount)
+	assert.Equal(t, "spec of Deployment someNamespace/someDeployment changed", alert.Message)
+
+	// A deployment recreated under the same name, and one with no earlier record, have not changed
+	helper_evaluate(t, engine, tables, "Deployment", fmt.Sprintf(someDeploymentTemplate, "someDeployment", "otherUid", 3, 1))
+	assert.Equal(t, int32(1), helper_getAlert(t, tables, "Deployment", "someNamespace", "someDeployment", "DeploymentSpecChanged").FireCount)
+	helper_evaluate(t, engine, tables, "Deployment", fmt.Sprintf(someDeploymentTemplate, "otherDeployment", "someUid", 3, 1))
+	assert.Equal(t, int32(0), helper_getAlert(t, tables, "Deployment", "someNamespace", "otherDeployment", "DeploymentSpecChanged").FireCount)
+}
+
+func Test_Engine_NotifiesFiredAlerts(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := ioutil.ReadAll(request.Body)
+		bodies = append(bodies, string(body))
+	}))
+	defer server.Close()
+	sinks := []notifier.SinkConfig{{Name: "hook", Type: notifier.SinkTypeWebhook, Url: server.URL}}
+	n, err := notifier.NewNotifier(notifier.Config{Sinks: sinks, RepeatInterval: time.Hour})
+	assert.Nil(t, err)
+	n.Start()
+
+	tables := helper_alertTables(t, nil)
+	engine, err := NewEngine([]Rule{somePodFailedRule}, n, "")
+	assert.Nil(t, err)
+	helper_evaluate(t, engine, tables, "Pod", fmt.Sprintf(somePodTemplate, "Running"))
+	helper_evaluate(t, engine, tables, "Pod", fmt.Sprintf(somePodTemplate, "Failed"))
+	helper_evaluate(t, engine, tables, "Pod", fmt.Sprintf(somePodTemplate, "Failed"))
+	n.Close()
+
+	assert.Len(t, bodies, 1)
+	var got notifier.Notification
+	assert.Nil(t, json.Unmarshal([]byte(bodies[0]), &got))
+	assert.Equal(t, "PodFailed", got.Rule)
+	assert.Equal(t, "somePod", got.Name)
+	assert.Equal(t, "somePod failed", got.Message)
+}
+
+func Test_NewEngine_BadRules(t *testing.T) {
+	badRules := []Rule{
+		{Kind: "Pod", EventReason: "BackOff"},
+		{Name: "a/b", EventReason: "BackOff"},
+		{Name: "noLogicOrReason"},
+		{Name: "badWindow", EventReason: "BackOff", Window: "ten minutes"},
+		{Name: "badMessage", EventReason: "BackOff", Message: "{{.Count"},
+		{Name: "badChangedPath", ChangedPath: "spec..replicas"},
+		{Name: "changedPathAndReason", EventReason: "BackOff", ChangedPath: "spec"},
+	}
+	for _, rule := range badRules {
+		_, err := NewEngine([]Rule{rule}, nil, "")
+		assert.NotNil(t, err, rule.Name)
+	}
+
+	_, err := NewEngine([]Rule{somePodBackOffRule, somePodBackOffRule}, nil, "")
+	assert.NotNil(t, err)
+}
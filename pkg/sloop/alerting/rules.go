@@ -0,0 +1,147 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package alerting
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const (
+	// Rules with this kind are evaluated against objects of any kind, like the "_all" exclusion rules
+	AllKinds        = "_all"
+	defaultSeverity = "warning"
+	defaultWindow   = 10 * time.Minute
+)
+
+// An alert rule from the config file.  There are three types of rule:
+//
+// A state rule has only Logic, a JsonLogic rule which is evaluated against the payload of every watch record of Kind.
+// It fires each time the payload matches, for example when a node reports NotReady.
+//
+// A change rule has a ChangedPath, a dotted path into the payload like "spec".  It fires when the value at that path
+// differs from the previous payload recorded for the same object, for example when a deployment spec is edited.
+// Logic is optional for change rules and is evaluated against the new payload.
+//
+// An event rule has an EventReason.  It fires when the events with that reason for one object of Kind add up to more
+// than Threshold within Window, for example more than 5 BackOff events for one pod in 10 minutes.  Counts come from
+// the event count table.  Logic is optional for event rules and is evaluated against the event payload.
+type Rule struct {
+	Name        string `json:"name"`
+	Severity    string `json:"severity"`
+	Kind        string `json:"kind"`
+	Message     string `json:"message"`
+	Logic       any    `json:"logic"`
+	EventReason string `json:"eventReason"`
+	Threshold   int    `json:"threshold"`
+	Window      string `json:"window"`
+	ChangedPath string `json:"changedPath"`
+}
+
+// The fields available to the text/template in Rule.Message
+type MessageData struct {
+	Rule      string
+	Severity  string
+	Kind      string
+	Namespace string
+	Name      string
+	Reason    string
+	// Number of events in the window for event rules, 1 for state and change rules
+	Count int
+	// The changed path for change rules
+	Path string
+}
+
+type compiledRule struct {
+	Rule
+	logicJson string
+	window    time.Duration
+	message   *template.Template
+}
+
+func compileRule(rule Rule) (*compiledRule, error) {
+	if rule.Name == "" {
+		return nil, fmt.Errorf("alert rule is missing a name")
+	}
+	if strings.Contains(rule.Name, "/") {
+		return nil, fmt.Errorf("alert rule name %q must not contain /", rule.Name)
+	}
+	if rule.Logic == nil && rule.EventReason == "" && rule.ChangedPath == "" {
+		return nil, fmt.Errorf("alert rule %v needs logic, an eventReason or a changedPath", rule.Name)
+	}
+	if rule.EventReason != "" && rule.ChangedPath != "" {
+		return nil, fmt.Errorf("alert rule %v can not have both an eventReason and a changedPath", rule.Name)
+	}
+	if rule.ChangedPath != "" {
+		for _, part := range strings.Split(rule.ChangedPath, ".") {
+			if part == "" {
+				return nil, fmt.Errorf("alert rule %v has bad changedPath %q", rule.Name, rule.ChangedPath)
+			}
+		}
+	}
+	ret := &compiledRule{Rule: rule}
+	if ret.Kind == "" {
+		ret.Kind = AllKinds
+	}
+	if ret.Severity == "" {
+		ret.Severity = defaultSeverity
+	}
+	if rule.Logic != nil {
+		logicJson, err := json.Marshal(rule.Logic)
+		if err != nil {
+			return nil, fmt.Errorf("alert rule %v has bad logic: %v", rule.Name, err)
+		}
+		ret.logicJson = string(logicJson)
+	}
+	if rule.EventReason != "" {
+		ret.window = defaultWindow
+		if rule.Window != "" {
+			window, err := time.ParseDuration(rule.Window)
+			if err != nil || window <= 0 {
+				return nil, fmt.Errorf("alert rule %v has bad window %q", rule.Name, rule.Window)
+			}
+			ret.window = window
+		}
+		if rule.Threshold < 0 {
+			return nil, fmt.Errorf("alert rule %v has negative threshold %v", rule.Name, rule.Threshold)
+		}
+	}
+	messageText := rule.Message
+	if messageText == "" {
+		if rule.EventReason != "" {
+			messageText = "{{.Count}} {{.Reason}} events for {{.Kind}} {{.Namespace}}/{{.Name}}"
+		} else if rule.ChangedPath != "" {
+			messageText = "{{.Path}} of {{.Kind}} {{.Namespace}}/{{.Name}} changed"
+		} else {
+			messageText = "{{.Rule}} matched {{.Kind}} {{.Namespace}}/{{.Name}}"
+		}
+	}
+	tmpl, err := template.New(rule.Name).Parse(messageText)
+	if err != nil {
+		return nil, fmt.Errorf("alert rule %v has bad message template: %v", rule.Name, err)
+	}
+	ret.message = tmpl
+	return ret, nil
+}
+
+func (r *compiledRule) matchesKind(kind string) bool {
+	return r.Kind == AllKinds || r.Kind == kind
+}
+
+func (r *compiledRule) renderMessage(data MessageData) string {
+	var buf bytes.Buffer
+	err := r.message.Execute(&buf, data)
+	if err != nil {
+		return fmt.Sprintf("%v matched %v %v/%v (message template failed: %v)", data.Rule, data.Kind, data.Namespace, data.Name, err)
+	}
+	return buf.String()
+}
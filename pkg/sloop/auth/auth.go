@@ -0,0 +1,242 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	RoleViewer = "viewer"
+	RoleAdmin  = "admin"
+
+	MethodProxy = "proxy"
+	MethodToken = "token"
+	MethodOidc  = "oidc"
+)
+
+var (
+	metricAuthSuccessCount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_auth_success_count"}, []string{"method"})
+	metricAuthFailureCount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_auth_failure_count"}, []string{"reason"})
+)
+
+// Config turns on authentication when at least one of Proxy, Tokens or Oidc is set.  Every authenticated user can
+// view history, and users in AdminUsers or AdminGroups can also use the debug pages and download backups.
+// When NamespaceRules is set, users who are not admins only see the namespaces their rules allow.
+type Config struct {
+	Proxy          *ProxyConfig    `json:"proxy"`
+	Tokens         []StaticToken   `json:"tokens"`
+	Oidc           *OidcConfig     `json:"oidc"`
+	AdminUsers     []string        `json:"adminUsers"`
+	AdminGroups    []string        `json:"adminGroups"`
+	NamespaceRules []NamespaceRule `json:"namespaceRules"`
+}
+
+// NamespaceRule lets the listed users and groups see the listed namespaces.  Namespaces are glob patterns like
+// "team-*", "*" allows every namespace and "_cluster" allows cluster scoped resources like nodes.
+type NamespaceRule struct {
+	Users      []string `json:"users"`
+	Groups     []string `json:"groups"`
+	Namespaces []string `json:"namespaces"`
+}
+
+func (r NamespaceRule) matches(identity *Identity) bool {
+	if contains(r.Users, identity.User) {
+		return true
+	}
+	for _, group := range identity.Groups {
+		if contains(r.Groups, group) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c Config) Enabled() bool {
+	return c.Proxy != nil || len(c.Tokens) > 0 || c.Oidc != nil
+}
+
+// The user a request was made by.  When NamespaceRestricted is set the user can only see Namespaces.
+type Identity struct {
+	User                string   `json:"user"`
+	Groups              []string `json:"groups"`
+	Role                string   `json:"role"`
+	Method              string   `json:"method"`
+	NamespaceRestricted bool     `json:"namespaceRestricted,omitempty"`
+	Namespaces          []string `json:"namespaces,omitempty"`
+}
+
+func (i *Identity) IsAdmin() bool {
+	return i != nil && i.Role == RoleAdmin
+}
+
+// Each provider looks for one kind of credential.  It returns nil and no error when the request does not have that
+// kind of credential, so the next provider can try
+type provider interface {
+	authenticate(request *http.Request) (*Identity, error)
+}
+
+// Authenticator checks the credentials of each request against the configured providers in turn
+type Authenticator struct {
+	providers      []provider
+	adminUsers     map[string]bool
+	adminGroups    map[string]bool
+	namespaceRules []NamespaceRule
+}
+
+// Returns nil when authentication is not configured.  A nil Authenticator lets every request through as an admin,
+// which is how sloop has always behaved
+func NewAuthenticator(config Config) (*Authenticator, error) {
+	if !config.Enabled() {
+		return nil, nil
+	}
+	err := validateNamespaceRules(config.NamespaceRules)
+	if err != nil {
+		return nil, err
+	}
+	a := &Authenticator{adminUsers: toSet(config.AdminUsers), adminGroups: toSet(config.AdminGroups), namespaceRules: config.NamespaceRules}
+	if config.Proxy != nil {
+		p, err := newProxyProvider(*config.Proxy)
+		if err != nil {
+			return nil, err
+		}
+		a.providers = append(a.providers, p)
+	}
+	if len(config.Tokens) > 0 {
+		p, err := newTokenProvider(config.Tokens)
+		if err != nil {
+			return nil, err
+		}
+		a.providers = append(a.providers, p)
+	}
+	if config.Oidc != nil {
+		p, err := newOidcProvider(*config.Oidc)
+		if err != nil {
+			return nil, err
+		}
+		a.providers = append(a.providers, p)
+	}
+	return a, nil
+}
+
+// Authenticate returns who made the request, or an error when they could not be identified
+func (a *Authenticator) Authenticate(request *http.Request) (*Identity, error) {
+	if a == nil {
+		return &Identity{Role: RoleAdmin}, nil
+	}
+	for _, p := range a.providers {
+		identity, err := p.authenticate(request)
+		if err != nil {
+			metricAuthFailureCount.WithLabelValues("invalid").Inc()
+			return nil, err
+		}
+		if identity != nil {
+			identity.Role = RoleViewer
+			if a.isAdmin(identity) {
+				identity.Role = RoleAdmin
+			} else {
+				a.restrictNamespaces(identity)
+			}
+			metricAuthSuccessCount.WithLabelValues(identity.Method).Inc()
+			return identity, nil
+		}
+	}
+	if _, ok := bearerToken(request); ok {
+		metricAuthFailureCount.WithLabelValues("invalid").Inc()
+		return nil, fmt.Errorf("bearer token is not valid")
+	}
+	metricAuthFailureCount.WithLabelValues("missing").Inc()
+	return nil, fmt.Errorf("no credentials")
+}
+
+func (a *Authenticator) isAdmin(identity *Identity) bool {
+	if a.adminUsers[identity.User] {
+		return true
+	}
+	for _, group := range identity.Groups {
+		if a.adminGroups[group] {
+			return true
+		}
+	}
+	return false
+}
+
+// Without rules everyone sees every namespace.  With rules a user sees the namespaces of every rule they match,
+// and nothing when they match none.
+func (a *Authenticator) restrictNamespaces(identity *Identity) {
+	if len(a.namespaceRules) == 0 {
+		return
+	}
+	identity.NamespaceRestricted = true
+	identity.Namespaces = []string{}
+	for _, rule := range a.namespaceRules {
+		if !rule.matches(identity) {
+			continue
+		}
+		if contains(rule.Namespaces, "*") {
+			identity.NamespaceRestricted = false
+			identity.Namespaces = nil
+			return
+		}
+		identity.Namespaces = append(identity.Namespaces, rule.Namespaces...)
+	}
+}
+
+func validateNamespaceRules(rules []NamespaceRule) error {
+	for i, rule := range rules {
+		if len(rule.Users) == 0 && len(rule.Groups) == 0 {
+			return fmt.Errorf("namespace rule %v has no users or groups", i)
+		}
+		if len(rule.Namespaces) == 0 {
+			return fmt.Errorf("namespace rule %v has no namespaces", i)
+		}
+		for _, pattern := range rule.Namespaces {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("namespace rule %v has a bad pattern %q", i, pattern)
+			}
+		}
+	}
+	return nil
+}
+
+type identityKey struct{}
+
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// Returns nil when the request was not authenticated
+func IdentityFrom(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(identityKey{}).(*Identity)
+	return identity
+}
+
+func bearerToken(request *http.Request) (string, bool) {
+	header := request.Header.Get("Authorization")
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+func toSet(values []string) map[string]bool {
+	ret := map[string]bool{}
+	for _, value := range values {
+		ret[value] = true
+	}
+	return ret
+}
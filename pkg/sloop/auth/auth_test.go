@@ -0,0 +1,153 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_request(t *testing.T, remoteAddr string, headers map[string]string) *http.Request {
+	request, err := http.NewRequest("GET", "/someContext", nil)
+	assert.Nil(t, err)
+	request.RemoteAddr = remoteAddr
+	for k, v := range headers {
+		request.Header.Set(k, v)
+	}
+	return request
+}
+
+func helper_authenticator(t *testing.T) *Authenticator {
+	a, err := NewAuthenticator(Config{
+		Proxy:       &ProxyConfig{TrustedProxies: []string{"10.0.0.0/8"}},
+		Tokens:      []StaticToken{{Token: "ci-token", User: "ci", Groups: []string{"bots"}}, {Token: "ops-token", User: "ops", Groups: []string{"sre"}}},
+		AdminGroups: []string{"sre"},
+		AdminUsers:  []string{"alice"},
+	})
+	assert.Nil(t, err)
+	return a
+}
+
+func Test_Authenticate_NilAuthenticatorIsAdmin(t *testing.T) {
+	a, err := NewAuthenticator(Config{})
+	assert.Nil(t, err)
+	assert.Nil(t, a)
+	identity, err := a.Authenticate(helper_request(t, "1.2.3.4:5", nil))
+	assert.Nil(t, err)
+	assert.True(t, identity.IsAdmin())
+}
+
+func Test_Authenticate_Proxy(t *testing.T) {
+	a := helper_authenticator(t)
+
+	identity, err := a.Authenticate(helper_request(t, "10.1.2.3:4567", map[string]string{"X-Forwarded-User": "bob", "X-Forwarded-Groups": "dev, qa"}))
+	assert.Nil(t, err)
+	assert.Equal(t, &Identity{User: "bob", Groups: []string{"dev", "qa"}, Role: RoleViewer, Method: MethodProxy}, identity)
+
+	identity, err = a.Authenticate(helper_request(t, "10.1.2.3:4567", map[string]string{"X-Forwarded-User": "alice"}))
+	assert.Nil(t, err)
+	assert.True(t, identity.IsAdmin())
+
+	// Headers from outside the trusted proxies are ignored
+	_, err = a.Authenticate(helper_request(t, "192.168.1.1:4567", map[string]string{"X-Forwarded-User": "alice"}))
+	assert.NotNil(t, err)
+}
+
+func Test_Authenticate_StaticTokens(t *testing.T) {
+	a := helper_authenticator(t)
+
+	identity, err := a.Authenticate(helper_request(t, "1.2.3.4:5", map[string]string{"Authorization": "Bearer ci-token"}))
+	assert.Nil(t, err)
+	assert.Equal(t, "ci", identity.User)
+	assert.Equal(t, RoleViewer, identity.Role)
+	assert.Equal(t, MethodToken, identity.Method)
+
+	identity, err = a.Authenticate(helper_request(t, "1.2.3.4:5", map[string]string{"Authorization": "bearer ops-token"}))
+	assert.Nil(t, err)
+	assert.True(t, identity.IsAdmin())
+
+	_, err = a.Authenticate(helper_request(t, "1.2.3.4:5", map[string]string{"Authorization": "Bearer wrong"}))
+	assert.NotNil(t, err)
+	_, err = a.Authenticate(helper_request(t, "1.2.3.4:5", map[string]string{"Authorization": "Basic Y2k6Y2k="}))
+	assert.NotNil(t, err)
+	_, err = a.Authenticate(helper_request(t, "1.2.3.4:5", nil))
+	assert.NotNil(t, err)
+}
+
+func Test_NewAuthenticator_BadConfig(t *testing.T) {
+	badConfigs := []Config{
+		{Proxy: &ProxyConfig{}},
+		{Proxy: &ProxyConfig{TrustedProxies: []string{"10.0.0.1"}}},
+		{Tokens: []StaticToken{{Token: "noUser"}}},
+		{Oidc: &OidcConfig{Issuer: "https://issuer"}},
+		{Oidc: &OidcConfig{Issuer: "https://issuer", ClientId: "sloop", JwksFile: "/does/not/exist"}},
+	}
+	for _, config := range badConfigs {
+		_, err := NewAuthenticator(config)
+		assert.NotNil(t, err)
+	}
+}
+
+func Test_StaticToken_RedactedWhenPrinted(t *testing.T) {
+	bytes, err := json.Marshal(Config{Tokens: []StaticToken{{Token: "secret", User: "ci"}}})
+	assert.Nil(t, err)
+	assert.NotContains(t, string(bytes), "secret")
+	assert.Contains(t, string(bytes), `"user":"ci"`)
+}
+
+func Test_Authenticate_NamespaceRules(t *testing.T) {
+	a, err := NewAuthenticator(Config{
+		Tokens: []StaticToken{
+			{Token: "dev-token", User: "dev", Groups: []string{"team-a", "team-b"}},
+			{Token: "ops-token", User: "ops", Groups: []string{"sre"}},
+			{Token: "viewer-token", User: "viewer"},
+			{Token: "other-token", User: "other"},
+		},
+		AdminGroups: []string{"sre"},
+		NamespaceRules: []NamespaceRule{
+			{Groups: []string{"team-a"}, Namespaces: []string{"team-a-*"}},
+			{Groups: []string{"team-b"}, Namespaces: []string{"team-b"}},
+			{Users: []string{"viewer"}, Namespaces: []string{"*"}},
+		},
+	})
+	assert.Nil(t, err)
+
+	identity, err := a.Authenticate(helper_request(t, "1.2.3.4:5", map[string]string{"Authorization": "Bearer dev-token"}))
+	assert.Nil(t, err)
+	assert.True(t, identity.NamespaceRestricted)
+	assert.Equal(t, []string{"team-a-*", "team-b"}, identity.Namespaces)
+
+	// Admins and users with a "*" rule see everything
+	for _, token := range []string{"ops-token", "viewer-token"} {
+		identity, err = a.Authenticate(helper_request(t, "1.2.3.4:5", map[string]string{"Authorization": "Bearer " + token}))
+		assert.Nil(t, err)
+		assert.False(t, identity.NamespaceRestricted, token)
+	}
+
+	// Users without a rule see nothing
+	identity, err = a.Authenticate(helper_request(t, "1.2.3.4:5", map[string]string{"Authorization": "Bearer other-token"}))
+	assert.Nil(t, err)
+	assert.True(t, identity.NamespaceRestricted)
+	assert.Len(t, identity.Namespaces, 0)
+}
+
+func Test_NewAuthenticator_BadNamespaceRules(t *testing.T) {
+	tokens := []StaticToken{{Token: "ci-token", User: "ci"}}
+	badRules := [][]NamespaceRule{
+		{{Namespaces: []string{"default"}}},
+		{{Users: []string{"ci"}}},
+		{{Users: []string{"ci"}, Namespaces: []string{"team-["}}},
+	}
+	for _, rules := range badRules {
+		_, err := NewAuthenticator(Config{Tokens: tokens, NamespaceRules: rules})
+		assert.NotNil(t, err)
+	}
+}
@@ -0,0 +1,339 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultUsernameClaim = "sub"
+	defaultGroupsClaim   = "groups"
+	// Allowed difference between our clock and the issuer's
+	clockSkew = time.Minute
+	// Min time between reloads of the jwks file when a token is signed with an unknown key
+	jwksReloadInterval = time.Minute
+)
+
+// OidcConfig validates OIDC id tokens sent as bearer tokens.  Sloop does not talk to the issuer, the signing keys are
+// read from JwksFile, which should be kept up to date with the issuer's jwks_uri.  The file is read again when a token
+// is signed by a key it does not have.
+type OidcConfig struct {
+	Issuer        string `json:"issuer"`
+	ClientId      string `json:"clientId"`
+	JwksFile      string `json:"jwksFile"`
+	UsernameClaim string `json:"usernameClaim"`
+	GroupsClaim   string `json:"groupsClaim"`
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type oidcProvider struct {
+	config     OidcConfig
+	lock       *sync.Mutex
+	keys       map[string]crypto.PublicKey
+	lastLoaded time.Time
+	now        func() time.Time
+}
+
+func newOidcProvider(config OidcConfig) (*oidcProvider, error) {
+	if config.Issuer == "" || config.ClientId == "" || config.JwksFile == "" {
+		return nil, fmt.Errorf("oidc auth needs an issuer, clientId and jwksFile")
+	}
+	if config.UsernameClaim == "" {
+		config.UsernameClaim = defaultUsernameClaim
+	}
+	if config.GroupsClaim == "" {
+		config.GroupsClaim = defaultGroupsClaim
+	}
+	p := &oidcProvider{config: config, lock: &sync.Mutex{}, now: time.Now}
+	err := p.loadKeys()
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *oidcProvider) loadKeys() error {
+	data, err := ioutil.ReadFile(p.config.JwksFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to read jwks file")
+	}
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	err = json.Unmarshal(data, &jwks)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse jwks file")
+	}
+	keys := map[string]crypto.PublicKey{}
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			glog.Errorf("Skipping key %q in jwks file: %v", jwk.Kid, err)
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("no usable signing keys in jwks file %v", p.config.JwksFile)
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.keys = keys
+	p.lastLoaded = p.now()
+	return nil
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, errors.Wrap(err, "bad modulus")
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, errors.Wrap(err, "bad exponent")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, errors.Wrap(err, "bad x")
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, errors.Wrap(err, "bad y")
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, fmt.Errorf("point is not on curve %v", k.Crv)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+// Returns the key for kid, reloading the jwks file if it is not known and the file has not been read recently
+func (p *oidcProvider) getKey(kid string) (crypto.PublicKey, error) {
+	p.lock.Lock()
+	key, ok := p.lookupKey(kid)
+	canReload := p.now().Sub(p.lastLoaded) >= jwksReloadInterval
+	p.lock.Unlock()
+	if ok {
+		return key, nil
+	}
+	if canReload {
+		err := p.loadKeys()
+		if err != nil {
+			glog.Errorf("Failed to reload jwks file: %v", err)
+		}
+		p.lock.Lock()
+		key, ok = p.lookupKey(kid)
+		p.lock.Unlock()
+		if ok {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("token is signed by unknown key %q", kid)
+}
+
+// A token without a kid can be used when there is only one key.  Must be called with the lock held
+func (p *oidcProvider) lookupKey(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+	key, ok := p.keys[kid]
+	return key, ok
+}
+
+func (p *oidcProvider) authenticate(request *http.Request) (*Identity, error) {
+	token, ok := bearerToken(request)
+	if !ok || strings.Count(token, ".") != 2 {
+		return nil, nil
+	}
+	claims, err := p.verify(token)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid oidc token")
+	}
+	user, _ := claims[p.config.UsernameClaim].(string)
+	if user == "" {
+		return nil, fmt.Errorf("oidc token has no %v claim", p.config.UsernameClaim)
+	}
+	return &Identity{User: user, Groups: stringsClaim(claims[p.config.GroupsClaim]), Method: MethodOidc}, nil
+}
+
+// Checks the signature, issuer, audience and lifetime of a jwt and returns its claims
+func (p *oidcProvider) verify(token string) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	headerJson, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, errors.Wrap(err, "bad header encoding")
+	}
+	var header jwtHeader
+	err = json.Unmarshal(headerJson, &header)
+	if err != nil {
+		return nil, errors.Wrap(err, "bad header")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.Wrap(err, "bad signature encoding")
+	}
+	key, err := p.getKey(header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	err = verifySignature(header.Alg, key, []byte(parts[0]+"."+parts[1]), signature)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errors.Wrap(err, "bad payload encoding")
+	}
+	claims := map[string]interface{}{}
+	err = json.Unmarshal(payload, &claims)
+	if err != nil {
+		return nil, errors.Wrap(err, "bad payload")
+	}
+	if iss, _ := claims["iss"].(string); iss != p.config.Issuer {
+		return nil, fmt.Errorf("issuer %q is not %q", iss, p.config.Issuer)
+	}
+	if !contains(stringsClaim(claims["aud"]), p.config.ClientId) {
+		return nil, fmt.Errorf("audience does not include %q", p.config.ClientId)
+	}
+	now := p.now()
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("token has no expiry")
+	}
+	if now.After(time.Unix(int64(exp), 0).Add(clockSkew)) {
+		return nil, fmt.Errorf("token expired")
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(clockSkew).Before(time.Unix(int64(nbf), 0)) {
+		return nil, fmt.Errorf("token is not valid yet")
+	}
+	return claims, nil
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signed []byte, signature []byte) error {
+	var hash crypto.Hash
+	switch alg {
+	case "RS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512", "ES512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported signing algorithm %q", alg)
+	}
+	hasher := hash.New()
+	hasher.Write(signed)
+	digest := hasher.Sum(nil)
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "RS") {
+			return fmt.Errorf("algorithm %v can not be used with an RSA key", alg)
+		}
+		return errors.Wrap(rsa.VerifyPKCS1v15(k, hash, digest, signature), "bad signature")
+	case *ecdsa.PublicKey:
+		if !strings.HasPrefix(alg, "ES") {
+			return fmt.Errorf("algorithm %v can not be used with an EC key", alg)
+		}
+		// The signature is r and s, each padded to the size of the curve
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return fmt.Errorf("bad signature length")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(k, digest, r, s) {
+			return fmt.Errorf("bad signature")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported key")
+	}
+}
+
+// Claims like aud and groups can be a string or a list of strings
+func stringsClaim(claim interface{}) []string {
+	switch value := claim.(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		var ret []string
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				ret = append(ret, s)
+			}
+		}
+		return ret
+	}
+	return nil
+}
+
+func contains(values []string, wanted string) bool {
+	for _, value := range values {
+		if value == wanted {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,173 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	someIssuer   = "https://issuer.example.com"
+	someClientId = "sloop"
+)
+
+var someNow = time.Date(2019, 3, 4, 5, 6, 7, 0, time.UTC)
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func helper_writeJwks(t *testing.T, path string, keys ...jsonWebKey) {
+	data, err := json.Marshal(map[string]interface{}{"keys": keys})
+	assert.Nil(t, err)
+	assert.Nil(t, ioutil.WriteFile(path, data, 0600))
+}
+
+func helper_rsaJwk(kid string, key *rsa.PrivateKey) jsonWebKey {
+	return jsonWebKey{Kid: kid, Kty: "RSA", Use: "sig", N: b64(key.N.Bytes()), E: b64(big.NewInt(int64(key.E)).Bytes())}
+}
+
+func helper_ecJwk(kid string, key *ecdsa.PrivateKey) jsonWebKey {
+	return jsonWebKey{Kid: kid, Kty: "EC", Crv: "P-256", X: b64(key.X.FillBytes(make([]byte, 32))), Y: b64(key.Y.FillBytes(make([]byte, 32)))}
+}
+
+func helper_claims(user string, groups ...string) map[string]interface{} {
+	return map[string]interface{}{
+		"iss":    someIssuer,
+		"aud":    []string{"other", someClientId},
+		"sub":    user,
+		"groups": groups,
+		"exp":    someNow.Add(time.Hour).Unix(),
+	}
+}
+
+func helper_sign(t *testing.T, alg string, kid string, key crypto.Signer, claims map[string]interface{}) string {
+	header, err := json.Marshal(jwtHeader{Alg: alg, Kid: kid})
+	assert.Nil(t, err)
+	payload, err := json.Marshal(claims)
+	assert.Nil(t, err)
+	signed := b64(header) + "." + b64(payload)
+	digest := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+		assert.Nil(t, err)
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		assert.Nil(t, err)
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	return signed + "." + b64(signature)
+}
+
+func helper_oidcProvider(t *testing.T, jwksFile string) *oidcProvider {
+	p, err := newOidcProvider(OidcConfig{Issuer: someIssuer, ClientId: someClientId, JwksFile: jwksFile})
+	assert.Nil(t, err)
+	p.now = func() time.Time { return someNow }
+	return p
+}
+
+func Test_Oidc_ValidTokens(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	helper_writeJwks(t, jwksFile, helper_rsaJwk("rsa1", rsaKey), helper_ecJwk("ec1", ecKey))
+	p := helper_oidcProvider(t, jwksFile)
+
+	identity, err := p.authenticate(helper_request(t, "1.2.3.4:5", map[string]string{"Authorization": "Bearer " + helper_sign(t, "RS256", "rsa1", rsaKey, helper_claims("alice", "dev"))}))
+	assert.Nil(t, err)
+	assert.Equal(t, &Identity{User: "alice", Groups: []string{"dev"}, Method: MethodOidc}, identity)
+
+	identity, err = p.authenticate(helper_request(t, "1.2.3.4:5", map[string]string{"Authorization": "Bearer " + helper_sign(t, "ES256", "ec1", ecKey, helper_claims("bob"))}))
+	assert.Nil(t, err)
+	assert.Equal(t, "bob", identity.User)
+
+	// Not a jwt, so another provider can try it
+	identity, err = p.authenticate(helper_request(t, "1.2.3.4:5", map[string]string{"Authorization": "Bearer opaque"}))
+	assert.Nil(t, err)
+	assert.Nil(t, identity)
+}
+
+func Test_Oidc_InvalidTokens(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	helper_writeJwks(t, jwksFile, helper_rsaJwk("rsa1", rsaKey))
+	p := helper_oidcProvider(t, jwksFile)
+
+	expired := helper_claims("alice")
+	expired["exp"] = someNow.Add(-time.Hour).Unix()
+	wrongIssuer := helper_claims("alice")
+	wrongIssuer["iss"] = "https://evil.example.com"
+	wrongAudience := helper_claims("alice")
+	wrongAudience["aud"] = "other"
+	notYetValid := helper_claims("alice")
+	notYetValid["nbf"] = someNow.Add(time.Hour).Unix()
+	noUser := helper_claims("")
+
+	tokens := map[string]string{
+		"expired":       helper_sign(t, "RS256", "rsa1", rsaKey, expired),
+		"wrongIssuer":   helper_sign(t, "RS256", "rsa1", rsaKey, wrongIssuer),
+		"wrongAudience": helper_sign(t, "RS256", "rsa1", rsaKey, wrongAudience),
+		"notYetValid":   helper_sign(t, "RS256", "rsa1", rsaKey, notYetValid),
+		"noUser":        helper_sign(t, "RS256", "rsa1", rsaKey, noUser),
+		"wrongKey":      helper_sign(t, "RS256", "rsa1", otherKey, helper_claims("alice")),
+		"unknownKid":    helper_sign(t, "RS256", "rsa2", otherKey, helper_claims("alice")),
+		"wrongAlg":      helper_sign(t, "ES256", "rsa1", rsaKey, helper_claims("alice")),
+		"algNone":       b64([]byte(`{"alg":"none","kid":"rsa1"}`)) + "." + b64([]byte(`{"sub":"alice"}`)) + ".",
+	}
+	for name, token := range tokens {
+		identity, err := p.authenticate(helper_request(t, "1.2.3.4:5", map[string]string{"Authorization": "Bearer " + token}))
+		assert.NotNil(t, err, name)
+		assert.Nil(t, identity, name)
+	}
+}
+
+func Test_Oidc_ReloadsJwksForUnknownKey(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	helper_writeJwks(t, jwksFile, helper_rsaJwk("old", oldKey))
+	p := helper_oidcProvider(t, jwksFile)
+
+	// The issuer rotates its key
+	helper_writeJwks(t, jwksFile, helper_rsaJwk("old", oldKey), helper_rsaJwk("new", newKey))
+	request := helper_request(t, "1.2.3.4:5", map[string]string{"Authorization": "Bearer " + helper_sign(t, "RS256", "new", newKey, helper_claims("alice"))})
+
+	// The file was just read, so it is not read again yet
+	p.lastLoaded = someNow
+	_, err = p.authenticate(request)
+	assert.NotNil(t, err)
+
+	p.lastLoaded = someNow.Add(-2 * jwksReloadInterval)
+	identity, err := p.authenticate(request)
+	assert.Nil(t, err)
+	assert.Equal(t, "alice", identity.User)
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package auth
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultUserHeader   = "X-Forwarded-User"
+	defaultGroupsHeader = "X-Forwarded-Groups"
+)
+
+// ProxyConfig trusts the user and groups set in headers by a reverse proxy which has already authenticated them.
+// The headers are only read from requests sent by TrustedProxies, which are CIDRs like 10.0.0.0/8.  Groups are
+// separated by commas.
+type ProxyConfig struct {
+	UserHeader     string   `json:"userHeader"`
+	GroupsHeader   string   `json:"groupsHeader"`
+	TrustedProxies []string `json:"trustedProxies"`
+}
+
+type proxyProvider struct {
+	userHeader   string
+	groupsHeader string
+	trusted      []*net.IPNet
+}
+
+func newProxyProvider(config ProxyConfig) (*proxyProvider, error) {
+	if len(config.TrustedProxies) == 0 {
+		return nil, fmt.Errorf("proxy auth needs at least one trusted proxy")
+	}
+	p := &proxyProvider{userHeader: config.UserHeader, groupsHeader: config.GroupsHeader}
+	if p.userHeader == "" {
+		p.userHeader = defaultUserHeader
+	}
+	if p.groupsHeader == "" {
+		p.groupsHeader = defaultGroupsHeader
+	}
+	for _, cidr := range config.TrustedProxies {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "bad trusted proxy %q", cidr)
+		}
+		p.trusted = append(p.trusted, ipNet)
+	}
+	return p, nil
+}
+
+func (p *proxyProvider) authenticate(request *http.Request) (*Identity, error) {
+	user := request.Header.Get(p.userHeader)
+	if user == "" {
+		return nil, nil
+	}
+	if !p.isTrusted(request.RemoteAddr) {
+		glog.V(2).Infof("Ignoring %v header from untrusted address %v", p.userHeader, request.RemoteAddr)
+		return nil, nil
+	}
+	var groups []string
+	for _, group := range strings.Split(request.Header.Get(p.groupsHeader), ",") {
+		group = strings.TrimSpace(group)
+		if group != "" {
+			groups = append(groups, group)
+		}
+	}
+	return &Identity{User: user, Groups: groups, Method: MethodProxy}, nil
+}
+
+func (p *proxyProvider) isTrusted(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, ipNet := range p.trusted {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
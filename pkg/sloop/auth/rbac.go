@@ -0,0 +1,228 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package auth
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	namespaceKind = "Namespace"
+	// Timeout for each SubjectAccessReview
+	reviewTimeout = 10 * time.Second
+	// Min time between discovery calls when a kind is not known
+	discoveryRefreshInterval = time.Minute
+	// How long a failed review is remembered, so an api server which is down is not asked again for every row
+	errorCacheTtl = 10 * time.Second
+)
+
+var (
+	metricRbacReviewCount   = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_rbac_review_count"}, []string{"result"})
+	metricRbacCacheHitCount = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_rbac_cache_hit_count"})
+)
+
+// RbacAuthorizer asks the api server of a cluster whether a user can get a kind in a namespace, using a
+// SubjectAccessReview.  Answers are cached per user, namespace and kind for cacheTtl, and failed reviews for
+// errorCacheTtl.  Anything which can not be checked, like a kind the api server does not know or a failed review,
+// is denied.
+type RbacAuthorizer struct {
+	client   kubernetes.Interface
+	cacheTtl time.Duration
+	lock     *sync.Mutex
+	cache    map[rbacCacheKey]rbacCacheEntry
+	// Expired entries are removed from the cache at most once per cacheTtl
+	nextPrune time.Time
+	// Kind to resource, from discovery
+	resources         map[string]schema.GroupVersionResource
+	resourcesLoadedAt time.Time
+	now               func() time.Time
+}
+
+type rbacCacheKey struct {
+	user      string
+	groups    string
+	namespace string
+	kind      string
+}
+
+type rbacCacheEntry struct {
+	allowed bool
+	err     error
+	expires time.Time
+}
+
+func NewRbacAuthorizer(client kubernetes.Interface, cacheTtl time.Duration) *RbacAuthorizer {
+	return &RbacAuthorizer{
+		client:    client,
+		cacheTtl:  cacheTtl,
+		lock:      &sync.Mutex{},
+		cache:     map[rbacCacheKey]rbacCacheEntry{},
+		resources: map[string]schema.GroupVersionResource{},
+		now:       time.Now,
+	}
+}
+
+// UserAuthorizer checks access for one user in one request.  Once a review fails every other check in the request
+// is denied without asking the api server, and Err returns the failure so the request can fail rather than wait on
+// each row or return partial results.
+type UserAuthorizer struct {
+	authorizer *RbacAuthorizer
+	identity   *Identity
+	lock       sync.Mutex
+	err        error
+}
+
+func (a *RbacAuthorizer) ForUser(identity *Identity) *UserAuthorizer {
+	return &UserAuthorizer{authorizer: a, identity: identity}
+}
+
+// Returns true when the user can get objects of kind in namespace.  Namespace is empty for cluster scoped kinds.
+// For a Namespace object the namespace is its name, and the user needs to be able to get that namespace.
+func (u *UserAuthorizer) CanGet(kind string, namespace string) bool {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	if u.err != nil {
+		return false
+	}
+	allowed, err := u.authorizer.canGet(u.identity, kind, namespace)
+	if err != nil {
+		u.err = err
+		return false
+	}
+	return allowed
+}
+
+// Returns the error of the first review which failed, or nil
+func (u *UserAuthorizer) Err() error {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	return u.err
+}
+
+func (a *RbacAuthorizer) canGet(identity *Identity, kind string, namespace string) (bool, error) {
+	key := rbacCacheKey{user: identity.User, groups: strings.Join(identity.Groups, ","), namespace: namespace, kind: kind}
+	now := a.now()
+	a.lock.Lock()
+	entry, ok := a.cache[key]
+	a.lock.Unlock()
+	if ok && now.Before(entry.expires) {
+		metricRbacCacheHitCount.Inc()
+		return entry.allowed, entry.err
+	}
+
+	allowed, err := a.review(identity, kind, namespace)
+	entry = rbacCacheEntry{allowed: allowed, expires: now.Add(a.cacheTtl)}
+	if err != nil {
+		glog.Errorf("Denying %v access to %v in namespace %q: %v", identity.User, kind, namespace, err)
+		metricRbacReviewCount.WithLabelValues("error").Inc()
+		err = errors.Wrapf(err, "could not check access to %v in namespace %q", kind, namespace)
+		entry = rbacCacheEntry{err: err, expires: now.Add(errorCacheTtl)}
+	} else if allowed {
+		metricRbacReviewCount.WithLabelValues("allowed").Inc()
+	} else {
+		metricRbacReviewCount.WithLabelValues("denied").Inc()
+	}
+	a.lock.Lock()
+	a.cache[key] = entry
+	a.pruneCache(now)
+	a.lock.Unlock()
+	return allowed, err
+}
+
+// Removes expired entries, so users, namespaces and kinds which are no longer asked about do not stay in memory.
+// Called with the lock held
+func (a *RbacAuthorizer) pruneCache(now time.Time) {
+	if now.Before(a.nextPrune) {
+		return
+	}
+	a.nextPrune = now.Add(a.cacheTtl)
+	for key, entry := range a.cache {
+		if !now.Before(entry.expires) {
+			delete(a.cache, key)
+		}
+	}
+}
+
+func (a *RbacAuthorizer) review(identity *Identity, kind string, namespace string) (bool, error) {
+	gvr, ok := a.getResource(kind)
+	if !ok {
+		glog.V(2).Infof("Kind %v is not served by the api server, denying access", kind)
+		return false, nil
+	}
+	attributes := &authorizationv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      "get",
+		Group:     gvr.Group,
+		Version:   gvr.Version,
+		Resource:  gvr.Resource,
+	}
+	if kind == namespaceKind {
+		attributes.Name = namespace
+	}
+	sar := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:               identity.User,
+			Groups:             identity.Groups,
+			ResourceAttributes: attributes,
+		},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), reviewTimeout)
+	defer cancel()
+	result, err := a.client.AuthorizationV1().SubjectAccessReviews().Create(ctx, sar, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return result.Status.Allowed && !result.Status.Denied, nil
+}
+
+// Looks up the resource for a kind, running discovery again if it is not known and discovery has not run recently
+func (a *RbacAuthorizer) getResource(kind string) (schema.GroupVersionResource, bool) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	gvr, ok := a.resources[kind]
+	if ok || a.now().Sub(a.resourcesLoadedAt) < discoveryRefreshInterval {
+		return gvr, ok
+	}
+	a.resourcesLoadedAt = a.now()
+	resources, err := discovery.ServerPreferredResources(a.client.Discovery())
+	if err != nil {
+		// Groups which failed are left out, the rest can still be used
+		glog.Errorf("Discovery for rbac checks partly failed: %v", err)
+	}
+	for _, list := range resources {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, resource := range list.APIResources {
+			if strings.Contains(resource.Name, "/") {
+				continue
+			}
+			// Sloop only stores the kind, so when more than one group serves a kind the core group wins
+			if existing, found := a.resources[resource.Kind]; found && existing.Group == "" {
+				continue
+			}
+			a.resources[resource.Kind] = gv.WithResource(resource.Name)
+		}
+	}
+	gvr, ok = a.resources[kind]
+	return gvr, ok
+}
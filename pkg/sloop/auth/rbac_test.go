@@ -0,0 +1,150 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package auth
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// A fake api server which allows the reviews in allowed, keyed by user/namespace/group/resource
+type fakeReviews struct {
+	allowed map[string]bool
+	fail    bool
+	reviews []authorizationv1.ResourceAttributes
+}
+
+func helper_rbacAuthorizer(t *testing.T, reviews *fakeReviews) *RbacAuthorizer {
+	client := fake.NewSimpleClientset()
+	client.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "pods", Kind: "Pod", Namespaced: true, Verbs: []string{"get", "list", "watch"}},
+			{Name: "pods/log", Kind: "Pod", Namespaced: true, Verbs: []string{"get"}},
+			{Name: "namespaces", Kind: "Namespace", Verbs: []string{"get", "list", "watch"}},
+			{Name: "events", Kind: "Event", Namespaced: true, Verbs: []string{"get", "list", "watch"}},
+		}},
+		{GroupVersion: "events.k8s.io/v1", APIResources: []metav1.APIResource{
+			{Name: "events", Kind: "Event", Namespaced: true, Verbs: []string{"get", "list", "watch"}},
+		}},
+		{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{
+			{Name: "deployments", Kind: "Deployment", Namespaced: true, Verbs: []string{"get", "list", "watch"}},
+		}},
+	}
+	client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if reviews.fail {
+			return true, nil, fmt.Errorf("api server is down")
+		}
+		sar := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		attributes := sar.Spec.ResourceAttributes
+		reviews.reviews = append(reviews.reviews, *attributes)
+		key := fmt.Sprintf("%v/%v/%v/%v", sar.Spec.User, attributes.Namespace, attributes.Group, attributes.Resource)
+		sar.Status.Allowed = reviews.allowed[key]
+		return true, sar, nil
+	})
+	return NewRbacAuthorizer(client, time.Minute)
+}
+
+func Test_RbacAuthorizer_CanGet(t *testing.T) {
+	reviews := &fakeReviews{allowed: map[string]bool{
+		"alice/team-a//pods":            true,
+		"alice/team-a/apps/deployments": true,
+		"alice/team-a//namespaces":      true,
+		"alice/team-a//events":          true,
+	}}
+	alice := helper_rbacAuthorizer(t, reviews).ForUser(&Identity{User: "alice", Groups: []string{"dev"}})
+
+	assert.True(t, alice.CanGet("Pod", "team-a"))
+	assert.True(t, alice.CanGet("Deployment", "team-a"))
+	assert.True(t, alice.CanGet("Namespace", "team-a"))
+	assert.True(t, alice.CanGet("Event", "team-a"))
+	assert.False(t, alice.CanGet("Pod", "team-b"))
+	assert.False(t, alice.CanGet("Namespace", "team-b"))
+
+	// Kinds the api server does not serve are denied without a review
+	assert.False(t, alice.CanGet("SomeCrd", "team-a"))
+
+	// The core group wins when more than one group serves a kind, and a namespace is checked by name
+	for _, review := range reviews.reviews {
+		assert.Equal(t, "get", review.Verb)
+		if review.Resource == "events" {
+			assert.Equal(t, "", review.Group)
+		}
+		if review.Resource == "namespaces" {
+			assert.Equal(t, review.Namespace, review.Name)
+		}
+	}
+	assert.Len(t, reviews.reviews, 6)
+}
+
+func Test_RbacAuthorizer_CachesAnswers(t *testing.T) {
+	reviews := &fakeReviews{allowed: map[string]bool{"alice/team-a//pods": true}}
+	authorizer := helper_rbacAuthorizer(t, reviews)
+	now := someNow
+	authorizer.now = func() time.Time { return now }
+	alice := authorizer.ForUser(&Identity{User: "alice"})
+
+	assert.True(t, alice.CanGet("Pod", "team-a"))
+	assert.False(t, alice.CanGet("Pod", "team-b"))
+	assert.True(t, alice.CanGet("Pod", "team-a"))
+	assert.False(t, alice.CanGet("Pod", "team-b"))
+	assert.Len(t, reviews.reviews, 2)
+
+	// Other users get their own answers
+	assert.False(t, authorizer.ForUser(&Identity{User: "bob"}).CanGet("Pod", "team-a"))
+	assert.Len(t, reviews.reviews, 3)
+
+	// Answers expire after the ttl
+	now = now.Add(2 * time.Minute)
+	assert.True(t, alice.CanGet("Pod", "team-a"))
+	assert.Len(t, reviews.reviews, 4)
+}
+
+func Test_RbacAuthorizer_DeniesWhenReviewFails(t *testing.T) {
+	reviews := &fakeReviews{allowed: map[string]bool{"alice/team-a//pods": true, "alice/team-b//pods": true}, fail: true}
+	authorizer := helper_rbacAuthorizer(t, reviews)
+	now := someNow
+	authorizer.now = func() time.Time { return now }
+	alice := authorizer.ForUser(&Identity{User: "alice"})
+	assert.False(t, alice.CanGet("Pod", "team-a"))
+	assert.NotNil(t, alice.Err())
+
+	// The rest of the request is denied without asking again
+	reviews.fail = false
+	assert.False(t, alice.CanGet("Pod", "team-b"))
+
+	// Failures are cached briefly for other requests too
+	assert.False(t, authorizer.ForUser(&Identity{User: "alice"}).CanGet("Pod", "team-a"))
+	now = now.Add(errorCacheTtl)
+	later := authorizer.ForUser(&Identity{User: "alice"})
+	assert.True(t, later.CanGet("Pod", "team-a"))
+	assert.Nil(t, later.Err())
+}
+
+func Test_RbacAuthorizer_PrunesExpiredAnswers(t *testing.T) {
+	reviews := &fakeReviews{allowed: map[string]bool{}}
+	authorizer := helper_rbacAuthorizer(t, reviews)
+	now := someNow
+	authorizer.now = func() time.Time { return now }
+	for _, namespace := range []string{"team-a", "team-b", "team-c"} {
+		authorizer.ForUser(&Identity{User: "alice"}).CanGet("Pod", namespace)
+	}
+	assert.Len(t, authorizer.cache, 3)
+
+	now = now.Add(2 * time.Minute)
+	authorizer.ForUser(&Identity{User: "alice"}).CanGet("Pod", "team-d")
+	assert.Len(t, authorizer.cache, 1)
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package auth
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// A bearer token which is given to a script or service.  The token itself is never logged or shown on the config page
+type StaticToken struct {
+	Token  string   `json:"token"`
+	User   string   `json:"user"`
+	Groups []string `json:"groups"`
+}
+
+// Hides the token when the config is printed
+func (t StaticToken) MarshalJSON() ([]byte, error) {
+	type redacted StaticToken
+	r := redacted(t)
+	if r.Token != "" {
+		r.Token = "<redacted>"
+	}
+	return json.Marshal(r)
+}
+
+type tokenProvider struct {
+	tokens []StaticToken
+}
+
+func newTokenProvider(tokens []StaticToken) (*tokenProvider, error) {
+	for idx, token := range tokens {
+		if token.Token == "" || token.User == "" {
+			return nil, fmt.Errorf("static token %d needs a token and user", idx)
+		}
+	}
+	return &tokenProvider{tokens: tokens}, nil
+}
+
+func (p *tokenProvider) authenticate(request *http.Request) (*Identity, error) {
+	bearer, ok := bearerToken(request)
+	if !ok {
+		return nil, nil
+	}
+	for _, token := range p.tokens {
+		if subtle.ConstantTimeCompare([]byte(bearer), []byte(token.Token)) == 1 {
+			return &Identity{User: token.User, Groups: token.Groups, Method: MethodToken}, nil
+		}
+	}
+	// Could still be an oidc token
+	return nil, nil
+}
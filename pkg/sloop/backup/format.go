@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"io/ioutil"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Frames of a zstd stream start with this
+var zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+// Write writes a zstd compressed backup of the versions in db since the given version to out, envelope encrypted when
+// encryptionKey is set.  It returns the version Backup returned, which is 0 when there was nothing to back up.
+func Write(db badgerwrap.DB, out io.Writer, since uint64, encryptionKey []byte) (uint64, error) {
+	var envelope *common.EnvelopeWriter
+	if encryptionKey != nil {
+		var err error
+		envelope, err = common.NewEnvelopeWriter(out, encryptionKey)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to configure encryption")
+		}
+		out = envelope
+	}
+
+	zw, err := zstd.NewWriter(out)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to configure compression")
+	}
+	version, err := db.Backup(zw, since)
+	if err != nil {
+		zw.Close()
+		return 0, err
+	}
+	err = zw.Close()
+	if err != nil {
+		return 0, err
+	}
+	if envelope != nil {
+		err = envelope.Close()
+		if err != nil {
+			return 0, err
+		}
+	}
+	return version, nil
+}
+
+// OpenReader returns the raw backup in file.  An encrypted backup is decrypted with whichever of keys it was made with,
+// and a compressed backup, like one saved without decoding the Content-Encoding or any encrypted backup, is
+// decompressed.  A backup which is neither is read as is.
+func OpenReader(file io.Reader, keys [][]byte) (io.ReadCloser, error) {
+	reader := bufio.NewReader(file)
+	// Long enough for the magic of an encrypted or a compressed file.  Short files are checked against what is there
+	header, _ := reader.Peek(8)
+	if common.IsEnvelope(header) {
+		if len(keys) == 0 {
+			return nil, errors.New("backup is encrypted and no backup encryption key was given")
+		}
+		envelope, err := common.NewEnvelopeReader(reader, keys)
+		if err != nil {
+			return nil, err
+		}
+		reader = bufio.NewReader(envelope)
+		header, _ = reader.Peek(len(zstdMagic))
+	}
+	if bytes.HasPrefix(header, zstdMagic) {
+		decoder, err := zstd.NewReader(reader)
+		if err != nil {
+			return nil, err
+		}
+		return decoder.IOReadCloser(), nil
+	}
+	return ioutil.NopCloser(reader), nil
+}
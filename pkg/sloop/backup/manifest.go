@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// The manifest is stored next to the backups under this name
+const ManifestName = "manifest.json"
+
+// Manifest records the backups in a target, oldest first.  A full backup starts a chain and each incremental backup
+// after it holds the versions since the one before it, so restoring a chain means loading its backups in order.
+type Manifest struct {
+	Backups []ManifestEntry `json:"backups"`
+}
+
+type ManifestEntry struct {
+	Name string `json:"name"`
+	Full bool   `json:"full"`
+	// The backup holds the versions from Since up to Version
+	Since     uint64    `json:"since"`
+	Version   uint64    `json:"version"`
+	Time      time.Time `json:"time"`
+	SizeBytes int64     `json:"sizeBytes"`
+	Encrypted bool      `json:"encrypted"`
+}
+
+// Chain is a full backup followed by its incremental backups
+type Chain []ManifestEntry
+
+// Validate checks the chain can be restored: it starts with a full backup and every incremental backup starts at the
+// version after the one before it ended, so no writes are missing in between.
+func (c Chain) Validate() error {
+	if len(c) == 0 {
+		return fmt.Errorf("backup chain is empty")
+	}
+	if !c[0].Full {
+		return fmt.Errorf("backup chain starts with incremental backup %v instead of a full backup", c[0].Name)
+	}
+	for i := 1; i < len(c); i++ {
+		if c[i].Full {
+			return fmt.Errorf("backup chain has full backup %v after %v", c[i].Name, c[0].Name)
+		}
+		if c[i].Since != c[i-1].Version+1 {
+			return fmt.Errorf("backup %v starts at version %v but %v ends at version %v", c[i].Name, c[i].Since, c[i-1].Name, c[i-1].Version)
+		}
+	}
+	return nil
+}
+
+// Returns an empty manifest when the target has none yet
+func LoadManifest(target Target) (*Manifest, error) {
+	reader, err := target.Get(ManifestName)
+	if err == ErrNotFound {
+		return &Manifest{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read backup manifest")
+	}
+	defer reader.Close()
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read backup manifest")
+	}
+	manifest := &Manifest{}
+	err = json.Unmarshal(data, manifest)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse backup manifest")
+	}
+	return manifest, nil
+}
+
+func (m *Manifest) Save(target Target) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = target.Put(ManifestName, bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return errors.Wrap(err, "failed to write backup manifest")
+	}
+	return nil
+}
+
+// Chains returns the chains in the manifest, oldest first.  Incremental backups before the first full one can not be
+// restored and are left out.
+func (m *Manifest) Chains() []Chain {
+	var chains []Chain
+	for _, entry := range m.Backups {
+		if entry.Full {
+			chains = append(chains, Chain{entry})
+		} else if len(chains) > 0 {
+			chains[len(chains)-1] = append(chains[len(chains)-1], entry)
+		}
+	}
+	return chains
+}
+
+// ChainAt returns the chain to restore for the store as it was at until: the newest chain whose full backup was taken
+// by then, up to and including the first incremental backup taken at or after it, so nothing written before until is
+// missing.  Partitions after until still need dropping after the restore.  A zero until returns the newest chain.
+func (m *Manifest) ChainAt(until time.Time) (Chain, error) {
+	chains := m.Chains()
+	for i := len(chains) - 1; i >= 0; i-- {
+		chain := chains[i]
+		if until.IsZero() {
+			return chain, nil
+		}
+		if chain[0].Time.After(until) {
+			continue
+		}
+		end := 1
+		for end < len(chain) && chain[end-1].Time.Before(until) {
+			end++
+		}
+		return chain[:end], nil
+	}
+	if until.IsZero() {
+		return nil, fmt.Errorf("there are no backups")
+	}
+	return nil, fmt.Errorf("there are no backups taken by %v", until.Format(time.RFC3339))
+}
+
+func (m *Manifest) Last() (ManifestEntry, bool) {
+	if len(m.Backups) == 0 {
+		return ManifestEntry{}, false
+	}
+	return m.Backups[len(m.Backups)-1], true
+}
+
+// Keeps the newest keepChains chains and returns the backups which were dropped.  Zero keeps every chain
+func (m *Manifest) ApplyRetention(keepChains int) []ManifestEntry {
+	chains := m.Chains()
+	if keepChains <= 0 || len(chains) <= keepChains {
+		return nil
+	}
+	firstKept := chains[len(chains)-keepChains][0].Name
+	for i, entry := range m.Backups {
+		if entry.Name == firstKept {
+			dropped := append([]ManifestEntry{}, m.Backups[:i]...)
+			m.Backups = m.Backups[i:]
+			return dropped
+		}
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package backup
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/dgraph-io/badger/v2/pb"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// RestoreLatest loads the newest chain in the manifest of target into db, the full backup first and then each
+// incremental backup in order.  Encrypted backups are decrypted with whichever of keys they were made with.
+func RestoreLatest(db badgerwrap.DB, target Target, keys [][]byte) error {
+	return RestoreUntil(db, target, time.Time{}, keys)
+}
+
+// RestoreUntil restores the chain in the manifest of target which covers until, then drops the partitions after
+// it.  A zero until restores the newest chain as it is.
+func RestoreUntil(db badgerwrap.DB, target Target, until time.Time, keys [][]byte) error {
+	manifest, err := LoadManifest(target)
+	if err != nil {
+		return err
+	}
+	return restoreManifest(db, manifest, target, until, keys)
+}
+
+// RestoreManifestFile is RestoreUntil for a manifest file, with the backups it lists in the same directory
+func RestoreManifestFile(db badgerwrap.DB, manifestFile string, until time.Time, keys [][]byte) error {
+	manifest, err := readManifestFile(manifestFile)
+	if err != nil {
+		return err
+	}
+	target := &LocalTarget{dir: filepath.Dir(manifestFile)}
+	return restoreManifest(db, manifest, target, until, keys)
+}
+
+func restoreManifest(db badgerwrap.DB, manifest *Manifest, target Target, until time.Time, keys [][]byte) error {
+	chain, err := manifest.ChainAt(until)
+	if err != nil {
+		return errors.Wrapf(err, "can not restore from %v", target)
+	}
+	err = RestoreChain(db, target, chain, keys)
+	if err != nil {
+		return err
+	}
+	if until.IsZero() {
+		return nil
+	}
+	return DropPartitionsAfter(db, until)
+}
+
+// RestoreChain checks the chain has no gaps and loads its backups in order
+func RestoreChain(db badgerwrap.DB, target Target, chain Chain, keys [][]byte) error {
+	err := chain.Validate()
+	if err != nil {
+		return err
+	}
+	for _, entry := range chain {
+		err = restoreEntry(db, target, entry, keys)
+		if err != nil {
+			return errors.Wrapf(err, "failed to restore backup %v", entry.Name)
+		}
+		glog.Infof("Restored backup %v from %v", entry.Name, target)
+	}
+	return nil
+}
+
+func restoreEntry(db badgerwrap.DB, target Target, entry ManifestEntry, keys [][]byte) error {
+	file, err := target.Get(entry.Name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	reader, err := OpenReader(file, keys)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	return db.Load(reader, runtime.NumCPU())
+}
+
+// CheckFiles checks that backup files, in the order they will be loaded, are a full backup followed by its
+// incremental backups.  When the files are next to the manifest they were written with their entries have to be
+// consecutive with no gaps between their versions.  Otherwise every file is read to check its versions all come after
+// the ones in the file before it, which catches files out of order but not a missing incremental backup.
+func CheckFiles(files []string, keys [][]byte) error {
+	chain, err := chainForFiles(files)
+	if err != nil {
+		return err
+	}
+	if chain != nil {
+		return chain.Validate()
+	}
+
+	var lastName string
+	var lastMax uint64
+	for _, file := range files {
+		lowest, highest, err := readVersionRange(file, keys)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read versions of backup %q", file)
+		}
+		if highest == 0 {
+			// Nothing in this one
+			continue
+		}
+		if lastName != "" && lowest <= lastMax {
+			return fmt.Errorf("backup %q has versions from %v, which are not after version %v in %q", file, lowest, lastMax, lastName)
+		}
+		lastName, lastMax = file, highest
+	}
+	return nil
+}
+
+// Returns the manifest entries for the files, or nil when they are not all in one directory with a manifest listing them
+func chainForFiles(files []string) (Chain, error) {
+	dir := filepath.Dir(files[0])
+	manifest, err := readManifestFile(filepath.Join(dir, ManifestName))
+	if os.IsNotExist(errors.Cause(err)) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	entries := map[string]int{}
+	for i, entry := range manifest.Backups {
+		entries[entry.Name] = i
+	}
+	var chain Chain
+	for i, file := range files {
+		index, ok := entries[filepath.Base(file)]
+		if filepath.Dir(file) != dir || !ok {
+			return nil, nil
+		}
+		if i > 0 && index != entries[filepath.Base(files[i-1])]+1 {
+			return nil, fmt.Errorf("backup %q does not follow %q in %v", file, files[i-1], filepath.Join(dir, ManifestName))
+		}
+		chain = append(chain, manifest.Backups[index])
+	}
+	return chain, nil
+}
+
+func readManifestFile(manifestFile string) (*Manifest, error) {
+	data, err := ioutil.ReadFile(manifestFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read backup manifest")
+	}
+	manifest := &Manifest{}
+	err = json.Unmarshal(data, manifest)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse backup manifest %q", manifestFile)
+	}
+	return manifest, nil
+}
+
+// Returns the lowest and highest version in a backup file, which is the lists of key values db.Backup writes, each
+// after its size as a little endian uint64
+func readVersionRange(filename string, keys [][]byte) (uint64, uint64, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer file.Close()
+	reader, err := OpenReader(file, keys)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer reader.Close()
+
+	var lowest, highest uint64
+	br := bufio.NewReaderSize(reader, 16<<10)
+	for {
+		var size uint64
+		err = binary.Read(br, binary.LittleEndian, &size)
+		if err == io.EOF {
+			return lowest, highest, nil
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+		buf := make([]byte, size)
+		_, err = io.ReadFull(br, buf)
+		if err != nil {
+			return 0, 0, err
+		}
+		list := &pb.KVList{}
+		err = list.Unmarshal(buf)
+		if err != nil {
+			return 0, 0, err
+		}
+		for _, kv := range list.Kv {
+			if lowest == 0 || kv.Version < lowest {
+				lowest = kv.Version
+			}
+			if kv.Version > highest {
+				highest = kv.Version
+			}
+		}
+	}
+}
+
+// DropPartitionsAfter drops the partitions of every table which start after the one until falls in, leaving the store
+// as it was at until to within a partition
+func DropPartitionsAfter(db badgerwrap.DB, until time.Time) error {
+	untilPartition := untyped.GetPartitionId(until)
+	partitions, _ := common.GetPartitionsInfo(db)
+	for _, partitionId := range common.GetSortedPartitionIDs(partitions) {
+		if partitionId <= untilPartition {
+			continue
+		}
+		err := common.DropPartition(db, partitionId, partitions[partitionId])
+		if err != nil {
+			return errors.Wrapf(err, "failed to drop partition %v", partitionId)
+		}
+		glog.Infof("Dropped partition %v, which is after %v", partitionId, until.Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package backup
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Takes a backup each hour for the given number of hours, each after writing a watch key in that hour's partition
+func helper_hourlyBackups(t *testing.T, dir string, hours int) (badgerwrap.DB, *LocalTarget) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db := helper_openBadger(t, filepath.Join(dir, "store"))
+	target, err := NewLocalTarget(filepath.Join(dir, "backups"))
+	assert.Nil(t, err)
+	s, now := helper_scheduler(db, target, SchedulerConfig{FullFrequency: 24 * time.Hour})
+	for hour := 0; hour < hours; hour++ {
+		helper_set(t, db, fmt.Sprintf("/watch/%v/Pod/someNamespace/someName/%v", untyped.GetPartitionId(*now), hour))
+		assert.Nil(t, s.RunOnce())
+		*now = now.Add(time.Hour)
+	}
+	return db, target
+}
+
+func helper_backupFiles(t *testing.T, dir string, target Target) []string {
+	manifest, err := LoadManifest(target)
+	assert.Nil(t, err)
+	var files []string
+	for _, entry := range manifest.Backups {
+		files = append(files, filepath.Join(dir, entry.Name))
+	}
+	return files
+}
+
+func Test_Chain_Validate(t *testing.T) {
+	full := ManifestEntry{Name: "full", Full: true, Version: 10}
+	assert.Nil(t, Chain{full, {Name: "incr", Since: 11, Version: 20}}.Validate())
+	assert.NotNil(t, Chain{}.Validate())
+	assert.NotNil(t, Chain{{Name: "incr", Since: 11, Version: 20}}.Validate())
+	assert.NotNil(t, Chain{full, {Name: "full2", Full: true}}.Validate())
+	err := Chain{full, {Name: "incr", Since: 15, Version: 20}}.Validate()
+	assert.Contains(t, err.Error(), "starts at version 15 but full ends at version 10")
+}
+
+func Test_Manifest_ChainAt(t *testing.T) {
+	hour := func(h int) time.Time { return someBackupTime.Add(time.Duration(h) * time.Hour) }
+	manifest := &Manifest{Backups: []ManifestEntry{
+		{Name: "full1", Full: true, Time: hour(0)},
+		{Name: "incr1a", Time: hour(1)},
+		{Name: "incr1b", Time: hour(2)},
+		{Name: "full2", Full: true, Time: hour(10)},
+		{Name: "incr2a", Time: hour(11)},
+	}}
+	names := func(until time.Time) []string {
+		chain, err := manifest.ChainAt(until)
+		if err != nil {
+			return nil
+		}
+		var names []string
+		for _, entry := range chain {
+			names = append(names, entry.Name)
+		}
+		return names
+	}
+	assert.Equal(t, []string{"full2", "incr2a"}, names(time.Time{}))
+	assert.Equal(t, []string{"full1"}, names(hour(0)))
+	assert.Equal(t, []string{"full1", "incr1a", "incr1b"}, names(hour(1).Add(time.Minute)))
+	assert.Equal(t, []string{"full1", "incr1a", "incr1b"}, names(hour(5)))
+	assert.Equal(t, []string{"full2", "incr2a"}, names(hour(10).Add(time.Minute)))
+	assert.Nil(t, names(hour(-1)))
+}
+
+func Test_RestoreUntil_DropsLaterPartitions(t *testing.T) {
+	dir := helper_tempDir(t)
+	_, target := helper_hourlyBackups(t, dir, 4)
+
+	restored := helper_openBadger(t, filepath.Join(dir, "restored"))
+	assert.Nil(t, RestoreUntil(restored, target, someBackupTime.Add(90*time.Minute), nil))
+	keys := helper_keys(t, restored)
+	assert.Len(t, keys, 2)
+	assert.Contains(t, keys[1], untyped.GetPartitionId(someBackupTime.Add(time.Hour)))
+}
+
+func Test_RestoreManifestFile(t *testing.T) {
+	dir := helper_tempDir(t)
+	_, _ = helper_hourlyBackups(t, dir, 3)
+
+	restored := helper_openBadger(t, filepath.Join(dir, "restored"))
+	assert.Nil(t, RestoreManifestFile(restored, filepath.Join(dir, "backups", ManifestName), time.Time{}, nil))
+	assert.Len(t, helper_keys(t, restored), 3)
+}
+
+func Test_CheckFiles_WithManifest(t *testing.T) {
+	dir := helper_tempDir(t)
+	_, target := helper_hourlyBackups(t, dir, 3)
+	files := helper_backupFiles(t, filepath.Join(dir, "backups"), target)
+
+	assert.Nil(t, CheckFiles(files, nil))
+	assert.Nil(t, CheckFiles(files[:2], nil))
+	err := CheckFiles([]string{files[0], files[2]}, nil)
+	assert.Contains(t, err.Error(), "does not follow")
+	assert.NotNil(t, CheckFiles(files[1:], nil))
+}
+
+func Test_CheckFiles_WithoutManifest(t *testing.T) {
+	dir := helper_tempDir(t)
+	_, target := helper_hourlyBackups(t, dir, 3)
+	var files []string
+	for i, file := range helper_backupFiles(t, filepath.Join(dir, "backups"), target) {
+		data, err := ioutil.ReadFile(file)
+		assert.Nil(t, err)
+		copied := filepath.Join(dir, fmt.Sprintf("backup%v", i))
+		assert.Nil(t, ioutil.WriteFile(copied, data, 0600))
+		files = append(files, copied)
+	}
+
+	assert.Nil(t, CheckFiles(files, nil))
+	err := CheckFiles([]string{files[0], files[2], files[1]}, nil)
+	assert.Contains(t, err.Error(), "are not after version")
+}
@@ -0,0 +1,251 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package backup
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	s3DefaultRegion = "us-east-1"
+	s3Service       = "s3"
+	s3TimeFormat    = "20060102T150405Z"
+	s3DateFormat    = "20060102"
+	// Bodies are not hashed so they can be streamed, which S3 and compatible stores allow over https
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3Timeout         = 30 * time.Minute
+)
+
+// S3Config is an S3 compatible bucket to store backups in.  Objects are addressed path style, as
+// <endpoint>/<bucket>/<prefix>/<name>, which works with AWS and with stores like MinIO.  When the keys are not set
+// they are read from AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN.  The session token is only needed
+// for temporary credentials, like those from STS.
+type S3Config struct {
+	Endpoint        string `json:"endpoint"`
+	Bucket          string `json:"bucket"`
+	Prefix          string `json:"prefix"`
+	Region          string `json:"region"`
+	AccessKeyId     string `json:"accessKeyId"`
+	SecretAccessKey string `json:"secretAccessKey"`
+	SessionToken    string `json:"sessionToken"`
+}
+
+// Hides the secret key and session token when the config is printed
+func (c S3Config) MarshalJSON() ([]byte, error) {
+	type redacted S3Config
+	r := redacted(c)
+	if r.SecretAccessKey != "" {
+		r.SecretAccessKey = "<redacted>"
+	}
+	if r.SessionToken != "" {
+		r.SessionToken = "<redacted>"
+	}
+	return json.Marshal(r)
+}
+
+func (c *S3Config) Validate() error {
+	if c.Endpoint == "" || c.Bucket == "" {
+		return fmt.Errorf("backup s3 target needs an endpoint and a bucket")
+	}
+	endpoint, err := url.Parse(c.Endpoint)
+	if err != nil || endpoint.Host == "" || (endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+		return fmt.Errorf("backup s3 endpoint %q must be an http or https url", c.Endpoint)
+	}
+	return nil
+}
+
+// S3Target stores backups in an S3 compatible bucket, signing requests with AWS signature version 4
+type S3Target struct {
+	endpoint        *url.URL
+	bucket          string
+	prefix          string
+	region          string
+	accessKeyId     string
+	secretAccessKey string
+	sessionToken    string
+	client          *http.Client
+	now             func() time.Time
+}
+
+// NewS3Target stores objects under the prefix of the config followed by subPath
+func NewS3Target(config S3Config, subPath string) (*S3Target, error) {
+	err := config.Validate()
+	if err != nil {
+		return nil, err
+	}
+	endpoint, _ := url.Parse(config.Endpoint)
+	t := &S3Target{
+		endpoint:        endpoint,
+		bucket:          config.Bucket,
+		prefix:          strings.Trim(path.Join(config.Prefix, subPath), "/"),
+		region:          config.Region,
+		accessKeyId:     config.AccessKeyId,
+		secretAccessKey: config.SecretAccessKey,
+		sessionToken:    config.SessionToken,
+		client:          &http.Client{Timeout: s3Timeout},
+		now:             time.Now,
+	}
+	if t.region == "" {
+		t.region = s3DefaultRegion
+	}
+	if t.accessKeyId == "" {
+		t.accessKeyId = os.Getenv("AWS_ACCESS_KEY_ID")
+		t.secretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+		t.sessionToken = os.Getenv("AWS_SESSION_TOKEN")
+	}
+	if t.accessKeyId == "" || t.secretAccessKey == "" {
+		return nil, fmt.Errorf("backup s3 target needs an access key")
+	}
+	return t, nil
+}
+
+func (s *S3Target) Put(name string, body io.Reader, size int64) error {
+	resp, err := s.do(http.MethodPut, name, body, size)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (s *S3Target) Get(name string) (io.ReadCloser, error) {
+	resp, err := s.do(http.MethodGet, name, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (s *S3Target) Delete(name string) error {
+	resp, err := s.do(http.MethodDelete, name, nil, 0)
+	if err == ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (s *S3Target) String() string {
+	return fmt.Sprintf("s3 %v/%v/%v", s.endpoint, s.bucket, s.prefix)
+}
+
+func (s *S3Target) objectPath(name string) string {
+	return "/" + strings.TrimPrefix(path.Join(s.endpoint.Path, s.bucket, s.prefix, name), "/")
+}
+
+// Sends a signed request.  The body of a successful response is left for the caller to close
+func (s *S3Target) do(method string, name string, body io.Reader, size int64) (*http.Response, error) {
+	objectUrl := *s.endpoint
+	objectUrl.Path = s.objectPath(name)
+	objectUrl.RawPath = s3EncodePath(objectUrl.Path)
+	req, err := http.NewRequest(method, objectUrl.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		// Without a length the body would be sent chunked, which S3 does not accept
+		req.ContentLength = size
+		if size == 0 {
+			req.Body = http.NoBody
+		}
+	}
+	s.sign(req)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "s3 %v of %v failed", method, name)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, fmt.Errorf("s3 %v of %v failed with %v: %s", method, name, resp.Status, message)
+	}
+	return resp, nil
+}
+
+// Adds an AWS signature version 4 Authorization header, signing the host and the x-amz-* headers.  The session token
+// of temporary credentials is sent in x-amz-security-token, which is signed as well
+func (s *S3Target) sign(req *http.Request) {
+	now := s.now().UTC()
+	amzDate := now.Format(s3TimeFormat)
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", s3UnsignedPayload)
+
+	// Headers are signed in sorted order
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := fmt.Sprintf("host:%v\nx-amz-content-sha256:%v\nx-amz-date:%v\n", req.URL.Host, s3UnsignedPayload, amzDate)
+	if s.sessionToken != "" {
+		req.Header.Set("x-amz-security-token", s.sessionToken)
+		signedHeaders += ";x-amz-security-token"
+		canonicalHeaders += fmt.Sprintf("x-amz-security-token:%v\n", s.sessionToken)
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders,
+		signedHeaders,
+		s3UnsignedPayload,
+	}, "\n")
+
+	scope := strings.Join([]string{now.Format(s3DateFormat), s.region, s3Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := []byte("AWS4" + s.secretAccessKey)
+	for _, part := range []string{now.Format(s3DateFormat), s.region, s3Service, "aws4_request"} {
+		key = hmacSha256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSha256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%v/%v, SignedHeaders=%v, Signature=%v",
+		s.accessKeyId, scope, signedHeaders, signature))
+}
+
+func hmacSha256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Escapes a path the way signature version 4 expects, which is stricter than url.PathEscape
+func s3EncodePath(p string) string {
+	var b strings.Builder
+	for _, c := range []byte(p) {
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || strings.IndexByte("-_.~/", c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// An in memory stand in for an S3 compatible store, which only takes requests signed with someAccessKeyId
+type fakeS3 struct {
+	lock    sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=someAccessKeyId/") || r.Header.Get("x-amz-date") == "" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	switch r.Method {
+	case http.MethodPut:
+		if r.ContentLength < 0 {
+			w.WriteHeader(http.StatusLengthRequired)
+			return
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		f.objects[r.URL.Path] = data
+	case http.MethodGet:
+		data, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(data)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func helper_s3Target(t *testing.T) (*S3Target, *fakeS3) {
+	fake := &fakeS3{objects: map[string][]byte{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	target, err := NewS3Target(S3Config{Endpoint: server.URL, Bucket: "someBucket", Prefix: "sloop/", AccessKeyId: "someAccessKeyId", SecretAccessKey: "someSecret"}, "someContext")
+	assert.Nil(t, err)
+	return target, fake
+}
+
+func Test_S3Target_PutGetDelete(t *testing.T) {
+	target, fake := helper_s3Target(t)
+
+	assert.Nil(t, target.Put("someName", bytes.NewReader([]byte("someData")), 8))
+	assert.Equal(t, []byte("someData"), fake.objects["/someBucket/sloop/someContext/someName"])
+
+	reader, err := target.Get("someName")
+	assert.Nil(t, err)
+	data, err := ioutil.ReadAll(reader)
+	reader.Close()
+	assert.Nil(t, err)
+	assert.Equal(t, "someData", string(data))
+
+	assert.Nil(t, target.Delete("someName"))
+	_, err = target.Get("someName")
+	assert.Equal(t, ErrNotFound, err)
+	assert.Nil(t, target.Delete("someName"))
+
+	target.accessKeyId = "wrongKey"
+	err = target.Put("someName", bytes.NewReader([]byte("someData")), 8)
+	assert.Contains(t, err.Error(), "403")
+}
+
+func Test_S3Target_Sign(t *testing.T) {
+	target, _ := helper_s3Target(t)
+	target.now = func() time.Time { return someBackupTime }
+	req, err := http.NewRequest(http.MethodGet, "http://example.com/someBucket/some%20name", nil)
+	assert.Nil(t, err)
+	target.sign(req)
+	assert.Equal(t, "20190304T050607Z", req.Header.Get("x-amz-date"))
+	assert.Equal(t, "UNSIGNED-PAYLOAD", req.Header.Get("x-amz-content-sha256"))
+	assert.Regexp(t, `^AWS4-HMAC-SHA256 Credential=someAccessKeyId/20190304/us-east-1/s3/aws4_request, `+
+		`SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=[0-9a-f]{64}$`, req.Header.Get("Authorization"))
+}
+
+func Test_S3Target_SignSessionToken(t *testing.T) {
+	// Temporary credentials come from the environment like the keys
+	t.Setenv("AWS_ACCESS_KEY_ID", "someAccessKeyId")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "someSecret")
+	t.Setenv("AWS_SESSION_TOKEN", "someSessionToken")
+	target, err := NewS3Target(S3Config{Endpoint: "http://example.com", Bucket: "someBucket"}, "")
+	assert.Nil(t, err)
+	target.now = func() time.Time { return someBackupTime }
+	req, err := http.NewRequest(http.MethodGet, "http://example.com/someBucket/some%20name", nil)
+	assert.Nil(t, err)
+	target.sign(req)
+	assert.Equal(t, "someSessionToken", req.Header.Get("x-amz-security-token"))
+	// The signature was worked out separately from the signature version 4 spec
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=someAccessKeyId/20190304/us-east-1/s3/aws4_request, "+
+		"SignedHeaders=host;x-amz-content-sha256;x-amz-date;x-amz-security-token, "+
+		"Signature=2ac896a97c6def875630cce69296790723e91d975e7b9f62d46ac33bed37cf01", req.Header.Get("Authorization"))
+}
+
+func Test_S3Target_Scheduler(t *testing.T) {
+	target, fake := helper_s3Target(t)
+	dir := helper_tempDir(t)
+	db := helper_openBadger(t, filepath.Join(dir, "store"))
+	helper_set(t, db, "/a")
+	s, _ := helper_scheduler(db, target, SchedulerConfig{FullFrequency: time.Hour, KeepChains: 1})
+	assert.Nil(t, s.RunOnce())
+
+	var manifest Manifest
+	assert.Nil(t, json.Unmarshal(fake.objects["/someBucket/sloop/someContext/"+ManifestName], &manifest))
+	assert.Len(t, manifest.Backups, 1)
+
+	restored := helper_openBadger(t, filepath.Join(dir, "restored"))
+	assert.Nil(t, RestoreLatest(restored, target, nil))
+	assert.Equal(t, []string{"/a"}, helper_keys(t, restored))
+}
+
+func Test_S3Config_HidesSecret(t *testing.T) {
+	data, err := json.Marshal(S3Config{Endpoint: "https://s3.amazonaws.com", SecretAccessKey: "someSecret", SessionToken: "someSessionToken"})
+	assert.Nil(t, err)
+	assert.NotContains(t, string(data), "someSecret")
+	assert.NotContains(t, string(data), "someSessionToken")
+	assert.NotNil(t, (&S3Config{Endpoint: "s3.amazonaws.com", Bucket: "someBucket"}).Validate())
+}
@@ -0,0 +1,212 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package backup
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/salesforce/sloop/pkg/sloop/storemanager"
+)
+
+const (
+	backupTypeFull        = "full"
+	backupTypeIncremental = "incremental"
+)
+
+var (
+	metricBackupCount       = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_backup_count"}, []string{"type", "result"})
+	metricBackupBytes       = promauto.NewGaugeVec(prometheus.GaugeOpts{Name: "sloop_backup_bytes"}, []string{"type"})
+	metricBackupLatency     = promauto.NewGaugeVec(prometheus.GaugeOpts{Name: "sloop_backup_latency_sec"}, []string{"type"})
+	metricBackupLastSuccess = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_backup_last_success_timestamp"})
+)
+
+type SchedulerConfig struct {
+	// How often to take a backup
+	Frequency time.Duration
+	// A full backup is taken when the last one is this old, and incremental backups in between
+	FullFrequency time.Duration
+	// Number of chains to keep, each a full backup and its incremental backups.  Zero keeps every chain
+	KeepChains int
+	// Backups are envelope encrypted with this key when it is set
+	EncryptionKey []byte
+}
+
+// Scheduler takes backups of a store into a target in the background and records them in the target's manifest
+type Scheduler struct {
+	db       badgerwrap.DB
+	target   Target
+	config   SchedulerConfig
+	sleeper  *storemanager.SleepWithCancel
+	wg       *sync.WaitGroup
+	done     bool
+	donelock *sync.Mutex
+	now      func() time.Time
+}
+
+func NewScheduler(db badgerwrap.DB, target Target, config SchedulerConfig) *Scheduler {
+	return &Scheduler{
+		db:       db,
+		target:   target,
+		config:   config,
+		sleeper:  storemanager.NewSleepWithCancel(),
+		wg:       &sync.WaitGroup{},
+		donelock: &sync.Mutex{},
+		now:      time.Now,
+	}
+}
+
+func (s *Scheduler) isDone() bool {
+	s.donelock.Lock()
+	defer s.donelock.Unlock()
+	return s.done
+}
+
+func (s *Scheduler) Start() {
+	s.wg.Add(1)
+	go s.loop()
+}
+
+func (s *Scheduler) loop() {
+	defer s.wg.Done()
+	for {
+		s.sleeper.Sleep(s.config.Frequency)
+		if s.isDone() {
+			glog.Infof("Backup scheduler loop exiting")
+			return
+		}
+		err := s.RunOnce()
+		if err != nil {
+			glog.Errorf("Backup to %v failed: %v", s.target, err)
+		}
+	}
+}
+
+// Shutdown waits for a backup which is running to finish
+func (s *Scheduler) Shutdown() {
+	glog.Infof("Starting backup scheduler shutdown")
+	s.donelock.Lock()
+	s.done = true
+	s.donelock.Unlock()
+	s.sleeper.Cancel()
+	s.wg.Wait()
+}
+
+// RunOnce takes a full or incremental backup, records it in the manifest and drops the chains past the retention
+func (s *Scheduler) RunOnce() error {
+	manifest, err := LoadManifest(s.target)
+	if err != nil {
+		return err
+	}
+	now := s.now().UTC()
+	entry := ManifestEntry{Full: true, Time: now, Encrypted: s.config.EncryptionKey != nil}
+	chains := manifest.Chains()
+	if len(chains) > 0 && now.Sub(chains[len(chains)-1][0].Time) < s.config.FullFrequency {
+		last, _ := manifest.Last()
+		entry.Full = false
+		entry.Since = last.Version + 1
+	}
+	backupType := setBackupName(&entry)
+
+	before := time.Now()
+	err = s.writeBackup(&entry)
+	if errors.Cause(err) == badgerwrap.ErrIncrementalBackupNotSupported {
+		// Engines without versions, like bolt, only take full backups
+		glog.V(2).Infof("Store can not take incremental backups, taking a full backup instead")
+		entry.Full = true
+		entry.Since = 0
+		backupType = setBackupName(&entry)
+		err = s.writeBackup(&entry)
+	}
+	if err != nil {
+		metricBackupCount.WithLabelValues(backupType, "error").Inc()
+		return err
+	}
+	if entry.Name == "" {
+		glog.V(2).Infof("Nothing changed since backup version %v, skipping incremental backup", entry.Since-1)
+		return nil
+	}
+	metricBackupCount.WithLabelValues(backupType, "success").Inc()
+	metricBackupBytes.WithLabelValues(backupType).Set(float64(entry.SizeBytes))
+	metricBackupLatency.WithLabelValues(backupType).Set(time.Since(before).Seconds())
+	metricBackupLastSuccess.Set(float64(now.Unix()))
+
+	manifest.Backups = append(manifest.Backups, entry)
+	dropped := manifest.ApplyRetention(s.config.KeepChains)
+	// The manifest is saved before old backups are deleted so it never lists a backup which is gone
+	err = manifest.Save(s.target)
+	if err != nil {
+		return err
+	}
+	for _, old := range dropped {
+		err = s.target.Delete(old.Name)
+		if err != nil {
+			glog.Errorf("Failed to delete old backup %v from %v: %v", old.Name, s.target, err)
+		}
+	}
+	glog.Infof("Wrote %v backup %v of %v bytes to %v", backupType, entry.Name, entry.SizeBytes, s.target)
+	return nil
+}
+
+// Names the backup after its type and time, and returns the type
+func setBackupName(entry *ManifestEntry) string {
+	backupType := backupTypeIncremental
+	if entry.Full {
+		backupType = backupTypeFull
+	}
+	entry.Name = fmt.Sprintf("sloop-%v-%v.bak.zst", backupType, entry.Time.Format("20060102T150405.000Z"))
+	if entry.Encrypted {
+		entry.Name += ".enc"
+	}
+	return backupType
+}
+
+// Writes the backup to a temp file first, since the size has to be known to upload it.  Clears entry.Name when an
+// incremental backup has nothing in it.
+func (s *Scheduler) writeBackup(entry *ManifestEntry) error {
+	file, err := ioutil.TempFile("", "sloop-backup")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temp file for backup")
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	version, err := Write(s.db, file, entry.Since, s.config.EncryptionKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to write backup")
+	}
+	if !entry.Full && version == 0 {
+		entry.Name = ""
+		return nil
+	}
+	entry.Version = version
+
+	entry.SizeBytes, err = file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+	err = s.target.Put(entry.Name, file, entry.SizeBytes)
+	if err != nil {
+		return errors.Wrapf(err, "failed to upload backup %v", entry.Name)
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package backup
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	badger "github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+var someBackupTime = time.Date(2019, 3, 4, 5, 6, 7, 0, time.UTC)
+
+func helper_openBadger(t *testing.T, dir string) badgerwrap.DB {
+	db, err := (&badgerwrap.BadgerFactory{}).Open(badger.DefaultOptions(dir).WithLogger(nil))
+	assert.Nil(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func helper_tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "backup")
+	assert.Nil(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func helper_set(t *testing.T, db badgerwrap.DB, key string) {
+	err := db.Update(func(txn badgerwrap.Txn) error { return txn.Set([]byte(key), []byte("someValue")) })
+	assert.Nil(t, err)
+}
+
+func helper_keys(t *testing.T, db badgerwrap.DB) []string {
+	var keys []string
+	err := db.View(func(txn badgerwrap.Txn) error {
+		iterator := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer iterator.Close()
+		for iterator.Rewind(); iterator.Valid(); iterator.Next() {
+			keys = append(keys, string(iterator.Item().Key()))
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	return keys
+}
+
+// A scheduler whose clock only moves when the test moves it
+func helper_scheduler(db badgerwrap.DB, target Target, config SchedulerConfig) (*Scheduler, *time.Time) {
+	now := someBackupTime
+	s := NewScheduler(db, target, config)
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func Test_Scheduler_FullAndIncrementalChain(t *testing.T) {
+	dir := helper_tempDir(t)
+	db := helper_openBadger(t, filepath.Join(dir, "store"))
+	target, err := NewLocalTarget(filepath.Join(dir, "backups"))
+	assert.Nil(t, err)
+	s, now := helper_scheduler(db, target, SchedulerConfig{FullFrequency: 24 * time.Hour, KeepChains: 2})
+
+	helper_set(t, db, "/a")
+	assert.Nil(t, s.RunOnce())
+	helper_set(t, db, "/b")
+	*now = now.Add(time.Hour)
+	assert.Nil(t, s.RunOnce())
+	// Nothing new, so no backup
+	*now = now.Add(time.Hour)
+	assert.Nil(t, s.RunOnce())
+	helper_set(t, db, "/c")
+	*now = now.Add(time.Hour)
+	assert.Nil(t, s.RunOnce())
+
+	manifest, err := LoadManifest(target)
+	assert.Nil(t, err)
+	assert.Len(t, manifest.Backups, 3)
+	assert.Len(t, manifest.Chains(), 1)
+	full, incremental := manifest.Backups[0], manifest.Backups[1]
+	assert.True(t, full.Full)
+	assert.False(t, incremental.Full)
+	assert.Equal(t, full.Version+1, incremental.Since)
+	assert.Equal(t, incremental.Version+1, manifest.Backups[2].Since)
+
+	// Only the full backup and the incremental ones after it are needed to get everything back
+	restored := helper_openBadger(t, filepath.Join(dir, "restored"))
+	assert.Nil(t, RestoreLatest(restored, target, nil))
+	assert.Equal(t, []string{"/a", "/b", "/c"}, helper_keys(t, restored))
+}
+
+func Test_Scheduler_BoltTakesOnlyFullBackups(t *testing.T) {
+	dir := helper_tempDir(t)
+	db, err := (&badgerwrap.BoltFactory{}).Open(badger.DefaultOptions(filepath.Join(dir, "store")))
+	assert.Nil(t, err)
+	defer db.Close()
+	target, err := NewLocalTarget(filepath.Join(dir, "backups"))
+	assert.Nil(t, err)
+	s, now := helper_scheduler(db, target, SchedulerConfig{FullFrequency: 24 * time.Hour})
+
+	helper_set(t, db, "/a")
+	assert.Nil(t, s.RunOnce())
+	helper_set(t, db, "/b")
+	*now = now.Add(time.Hour)
+	assert.Nil(t, s.RunOnce())
+
+	manifest, err := LoadManifest(target)
+	assert.Nil(t, err)
+	assert.Len(t, manifest.Backups, 2)
+	assert.Len(t, manifest.Chains(), 2)
+	for _, entry := range manifest.Backups {
+		assert.True(t, entry.Full)
+		assert.Equal(t, uint64(0), entry.Since)
+		assert.Contains(t, entry.Name, "-full-")
+	}
+
+	restored := helper_openBadger(t, filepath.Join(dir, "restored"))
+	assert.Nil(t, RestoreLatest(restored, target, nil))
+	assert.Equal(t, []string{"/a", "/b"}, helper_keys(t, restored))
+}
+
+func Test_Scheduler_Retention(t *testing.T) {
+	dir := helper_tempDir(t)
+	db := helper_openBadger(t, filepath.Join(dir, "store"))
+	target, err := NewLocalTarget(filepath.Join(dir, "backups"))
+	assert.Nil(t, err)
+	key := []byte("0123456789abcdef")
+	s, now := helper_scheduler(db, target, SchedulerConfig{FullFrequency: 24 * time.Hour, KeepChains: 2, EncryptionKey: key})
+
+	for day := 0; day < 4; day++ {
+		for hour := 0; hour < 2; hour++ {
+			helper_set(t, db, now.Format(time.RFC3339))
+			assert.Nil(t, s.RunOnce())
+			*now = now.Add(time.Hour)
+		}
+		*now = now.Add(22 * time.Hour)
+	}
+
+	manifest, err := LoadManifest(target)
+	assert.Nil(t, err)
+	assert.Len(t, manifest.Chains(), 2)
+	assert.Len(t, manifest.Backups, 4)
+	files, err := ioutil.ReadDir(filepath.Join(dir, "backups"))
+	assert.Nil(t, err)
+	// The backups in the manifest and the manifest itself
+	assert.Len(t, files, 5)
+	for _, entry := range manifest.Backups {
+		assert.True(t, entry.Encrypted)
+		_, err = os.Stat(filepath.Join(dir, "backups", entry.Name))
+		assert.Nil(t, err)
+	}
+
+	restored := helper_openBadger(t, filepath.Join(dir, "restored"))
+	assert.NotNil(t, RestoreLatest(restored, target, nil))
+	assert.Nil(t, RestoreLatest(restored, target, [][]byte{key}))
+	assert.Len(t, helper_keys(t, restored), 8)
+}
+
+func Test_RestoreLatest_NoBackups(t *testing.T) {
+	dir := helper_tempDir(t)
+	target, err := NewLocalTarget(dir)
+	assert.Nil(t, err)
+	db := helper_openBadger(t, filepath.Join(dir, "store"))
+	assert.NotNil(t, RestoreLatest(db, target, nil))
+}
+
+func Test_Manifest_Chains(t *testing.T) {
+	manifest := &Manifest{Backups: []ManifestEntry{
+		{Name: "orphan"},
+		{Name: "full1", Full: true},
+		{Name: "incr1"},
+		{Name: "full2", Full: true},
+		{Name: "full3", Full: true},
+		{Name: "incr3"},
+	}}
+	chains := manifest.Chains()
+	assert.Len(t, chains, 3)
+	assert.Len(t, chains[0], 2)
+
+	assert.Nil(t, manifest.ApplyRetention(0))
+	dropped := manifest.ApplyRetention(2)
+	var names []string
+	for _, entry := range dropped {
+		names = append(names, entry.Name)
+	}
+	assert.Equal(t, []string{"orphan", "full1", "incr1"}, names)
+	assert.Equal(t, "full2", manifest.Backups[0].Name)
+}
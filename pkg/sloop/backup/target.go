@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package backup
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNotFound is returned by Target.Get when there is no object with the name
+var ErrNotFound = errors.New("backup object not found")
+
+// Target is somewhere backups and their manifest are stored, by name
+type Target interface {
+	Put(name string, body io.Reader, size int64) error
+	Get(name string) (io.ReadCloser, error)
+	Delete(name string) error
+	String() string
+}
+
+// LocalTarget stores backups as files in a directory
+type LocalTarget struct {
+	dir string
+}
+
+func NewLocalTarget(dir string) (*LocalTarget, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create backup directory %q", dir)
+	}
+	return &LocalTarget{dir: dir}, nil
+}
+
+// Put writes to a temp file which is renamed into place, so a file with the name is always complete
+func (l *LocalTarget) Put(name string, body io.Reader, size int64) error {
+	tmp, err := ioutil.TempFile(l.dir, "."+name+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = io.Copy(tmp, body)
+	if err != nil {
+		tmp.Close()
+		return errors.Wrapf(err, "failed to write %v", name)
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(l.dir, name))
+}
+
+func (l *LocalTarget) Get(name string) (io.ReadCloser, error) {
+	file, err := os.Open(filepath.Join(l.dir, name))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	return file, err
+}
+
+func (l *LocalTarget) Delete(name string) error {
+	err := os.Remove(filepath.Join(l.dir, name))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (l *LocalTarget) String() string {
+	return l.dir
+}
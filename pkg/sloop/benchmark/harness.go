@@ -0,0 +1,255 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+// Package benchmark loads generated history into a real store and measures ingest, queries and cleanup, so the
+// performance of one commit can be compared to another.
+package benchmark
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+
+	"github.com/salesforce/sloop/pkg/sloop/ingress"
+	"github.com/salesforce/sloop/pkg/sloop/processing"
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/salesforce/sloop/pkg/sloop/storemanager"
+)
+
+// Which way a result gets better, which decides what counts as a regression
+const (
+	BetterHigher = "higher"
+	BetterLower  = "lower"
+)
+
+type Config struct {
+	// A store is made in a new directory under this, and removed after the run.  Defaults to the temp directory
+	StoreRoot   string
+	StoreEngine string
+	// Hours of history to generate from Start
+	Hours int
+	// Fixed rather than relative to now, so every run puts the history in the same partitions
+	Start     time.Time
+	Generator ingress.GeneratorConfig
+	// Processing settings, like the server flags
+	WorkerCount int
+	BatchSize   int
+	// Lookbacks to run the heat map query with.  The ones longer than Hours are skipped
+	Lookbacks []time.Duration
+	// How many times each query is run
+	QueryRepeats int
+}
+
+func DefaultConfig() Config {
+	generator := ingress.DefaultGeneratorConfig()
+	// A fixed seed so every run generates the same history
+	generator.Seed = 1
+	return Config{
+		StoreEngine:  badgerwrap.EngineBadger,
+		Hours:        6,
+		Start:        time.Date(2019, 3, 4, 0, 0, 0, 0, time.UTC),
+		Generator:    generator,
+		WorkerCount:  4,
+		BatchSize:    50,
+		Lookbacks:    []time.Duration{time.Hour, 6 * time.Hour, 24 * time.Hour, 7 * 24 * time.Hour},
+		QueryRepeats: 20,
+	}
+}
+
+type Result struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
+	// BetterHigher or BetterLower.  Empty for results which describe the run rather than measure it
+	Better string `json:"better,omitempty"`
+}
+
+type Report struct {
+	// Set by whoever runs the benchmark, like a commit hash
+	Label     string    `json:"label"`
+	Time      time.Time `json:"time"`
+	GoVersion string    `json:"goVersion"`
+	Os        string    `json:"os"`
+	Arch      string    `json:"arch"`
+	Cpus      int       `json:"cpus"`
+	Hours     int       `json:"hours"`
+	Results   []Result  `json:"results"`
+}
+
+func (r *Report) add(name string, value float64, unit string, better string) {
+	r.Results = append(r.Results, Result{Name: name, Value: value, Unit: unit, Better: better})
+}
+
+// Run generates config.Hours of history, processes it into a new store, and measures the ingest, the size of the
+// store, heat map queries and a cleanup of the older half of the history.  The history is generated up front, so
+// the ingest time is only the time processing takes.
+func Run(config Config) (*Report, error) {
+	if config.Hours < 1 {
+		return nil, fmt.Errorf("benchmark needs at least an hour of history")
+	}
+	root, err := ioutil.TempDir(config.StoreRoot, "sloop-benchmark")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(root)
+	storeRoot := path.Join(root, "store")
+	db, err := openStore(config, storeRoot)
+	if err != nil {
+		return nil, err
+	}
+	defer untyped.CloseStore(db)
+	tables := typed.NewTableList(db)
+
+	report := &Report{
+		Time:      time.Now().UTC(),
+		GoVersion: runtime.Version(),
+		Os:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		Cpus:      runtime.NumCPU(),
+		Hours:     config.Hours,
+	}
+	history, err := GenerateHistory(config.Generator, config.Start, time.Duration(config.Hours)*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+
+	glog.Infof("Ingesting %v generated records", len(history))
+	elapsed := Ingest(tables, history, config.WorkerCount, config.BatchSize)
+	var payloadBytes int
+	for _, record := range history {
+		payloadBytes += len(record.Payload)
+	}
+	report.add("ingest_records", float64(len(history)), "records", "")
+	report.add("ingest_seconds", elapsed.Seconds(), "s", BetterLower)
+	report.add("ingest_records_per_sec", float64(len(history))/elapsed.Seconds(), "records/s", BetterHigher)
+	report.add("ingest_mb_per_sec", float64(payloadBytes)/1024/1024/elapsed.Seconds(), "MB/s", BetterHigher)
+
+	stats := storemanager.GetStoreStats(storeRoot, db, &afero.Afero{Fs: afero.NewOsFs()})
+	report.add("store_disk_bytes", float64(stats.DiskSizeBytes), "bytes", BetterLower)
+	report.add("store_keys", float64(stats.TotalKeyCount), "keys", "")
+	report.add("store_bytes_per_record", float64(stats.DiskSizeBytes)/float64(len(history)), "bytes", BetterLower)
+
+	for _, lookback := range config.Lookbacks {
+		if lookback > time.Duration(config.Hours)*time.Hour {
+			continue
+		}
+		glog.Infof("Running the heat map query with lookback %v", lookback)
+		latencies := make([]time.Duration, 0, config.QueryRepeats)
+		for i := 0; i < config.QueryRepeats; i++ {
+			latency, err := QueryHeatMap(tables, lookback)
+			if err != nil {
+				return nil, err
+			}
+			latencies = append(latencies, latency)
+		}
+		name := "query_eventheatmap_" + lookbackName(lookback)
+		report.add(name+"_p50_ms", percentile(latencies, 0.5).Seconds()*1000, "ms", BetterLower)
+		report.add(name+"_p95_ms", percentile(latencies, 0.95).Seconds()*1000, "ms", BetterLower)
+	}
+
+	// Keep the newer half of the history, and at least an hour so there is a partition left
+	keepHours := config.Hours / 2
+	if keepHours < 1 {
+		keepHours = 1
+	}
+	glog.Infof("Cleaning up all but the last %v hours", keepHours)
+	cleanupConfig := &storemanager.Config{
+		StoreRoot:         storeRoot,
+		TimeLimit:         time.Duration(keepHours) * time.Hour,
+		SizeLimitBytes:    1024 * 1024 * 1024 * 1024,
+		DeletionBatchSize: 1000,
+		GCThreshold:       0.8,
+	}
+	sm := storemanager.NewStoreManager(tables, cleanupConfig, &afero.Afero{Fs: afero.NewOsFs()})
+	before := time.Now()
+	_, deletedKeys, err := sm.Cleanup()
+	if err != nil {
+		return nil, errors.Wrap(err, "cleanup failed")
+	}
+	report.add("cleanup_seconds", time.Since(before).Seconds(), "s", BetterLower)
+	report.add("cleanup_deleted_keys", float64(deletedKeys), "keys", "")
+	return report, nil
+}
+
+func openStore(config Config, storeRoot string) (badgerwrap.DB, error) {
+	factory, err := badgerwrap.NewFactory(config.StoreEngine)
+	if err != nil {
+		return nil, err
+	}
+	// The same badger settings as the server defaults
+	storeConfig := &untyped.Config{
+		RootPath:                storeRoot,
+		ConfigPartitionDuration: time.Hour,
+		BadgerKeepL0InMemory:    true,
+		BadgerVLogMaxEntries:    200000,
+		BadgerUseLSMOnlyOptions: true,
+		BadgerSyncWrites:        true,
+		BadgerVLogTruncate:      true,
+	}
+	return untyped.OpenStore(factory, storeConfig)
+}
+
+// GenerateHistory returns the workload the generator makes over duration from start
+func GenerateHistory(config ingress.GeneratorConfig, start time.Time, duration time.Duration) ([]typed.KubeWatchResult, error) {
+	var history []typed.KubeWatchResult
+	err := ingress.GenerateWorkloadHistory(config, start, duration, func(result typed.KubeWatchResult) bool {
+		history = append(history, result)
+		return true
+	})
+	return history, err
+}
+
+// Ingest processes history into tables like the server does, and returns how long it took
+func Ingest(tables typed.Tables, history []typed.KubeWatchResult, workerCount int, batchSize int) time.Duration {
+	kubeWatchChan := make(chan typed.KubeWatchResult, 1000)
+	runner := processing.NewProcessing(kubeWatchChan, tables, false, 30*24*time.Hour, workerCount, batchSize, nil)
+	before := time.Now()
+	runner.Start()
+	for _, record := range history {
+		kubeWatchChan <- record
+	}
+	close(kubeWatchChan)
+	runner.Wait()
+	return time.Since(before)
+}
+
+// QueryHeatMap runs the heat map query the UI starts with, over lookback back from the newest data
+func QueryHeatMap(tables typed.Tables, lookback time.Duration) (time.Duration, error) {
+	params := url.Values{}
+	params.Set(queries.LookbackParam, lookback.String())
+	before := time.Now()
+	_, err := queries.RunQuery("EventHeatMap", params, tables, 30*24*time.Hour, "benchmark", queries.Unrestricted)
+	return time.Since(before), err
+}
+
+func lookbackName(lookback time.Duration) string {
+	if lookback%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%vd", int(lookback/(24*time.Hour)))
+	}
+	return fmt.Sprintf("%vh", int(lookback/time.Hour))
+}
+
+func percentile(latencies []time.Duration, p float64) time.Duration {
+	if len(latencies) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration{}, latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[int(p*float64(len(sorted)-1))]
+}
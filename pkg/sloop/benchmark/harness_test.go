@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package benchmark
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/storemanager"
+)
+
+// Opens a store in a temp directory which is removed when the test is done
+func helper_openStore(tb testing.TB) (typed.Tables, string) {
+	storeRoot := path.Join(tb.TempDir(), "store")
+	config := DefaultConfig()
+	db, err := openStore(config, storeRoot)
+	assert.Nil(tb, err)
+	tb.Cleanup(func() { untyped.CloseStore(db) })
+	return typed.NewTableList(db), storeRoot
+}
+
+func helper_history(tb testing.TB, hours int) []typed.KubeWatchResult {
+	duration := time.Duration(hours) * time.Hour
+	config := DefaultConfig()
+	history, err := GenerateHistory(config.Generator, config.Start, duration)
+	assert.Nil(tb, err)
+	return history
+}
+
+func helper_resultNames(report *Report) []string {
+	var names []string
+	for _, result := range report.Results {
+		names = append(names, result.Name)
+	}
+	return names
+}
+
+func Test_Run(t *testing.T) {
+	config := DefaultConfig()
+	config.StoreRoot = t.TempDir()
+	config.Hours = 1
+	config.QueryRepeats = 2
+	// A quieter cluster keeps the test quick
+	config.Generator.NodeHeartbeat = "10m"
+	config.Generator.Scenarios = nil
+	config.BatchSize = 500
+	report, err := Run(config)
+	assert.Nil(t, err)
+
+	names := helper_resultNames(report)
+	assert.Contains(t, names, "ingest_records_per_sec")
+	assert.Contains(t, names, "store_disk_bytes")
+	assert.Contains(t, names, "query_eventheatmap_1h_p95_ms")
+	assert.NotContains(t, names, "query_eventheatmap_6h_p95_ms")
+	assert.Contains(t, names, "cleanup_seconds")
+	for _, result := range report.Results {
+		assert.True(t, result.Value >= 0, result.Name)
+	}
+
+	// The scratch store is removed
+	entries, err := afero.ReadDir(afero.NewOsFs(), config.StoreRoot)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 0)
+}
+
+func Test_Compare(t *testing.T) {
+	baseline := &Report{Results: []Result{
+		{Name: "records_per_sec", Value: 1000, Better: BetterHigher},
+		{Name: "query_ms", Value: 10, Better: BetterLower},
+		{Name: "records", Value: 500},
+		{Name: "gone_ms", Value: 1, Better: BetterLower},
+	}}
+	current := &Report{Results: []Result{
+		{Name: "records_per_sec", Value: 850, Better: BetterHigher},
+		{Name: "query_ms", Value: 13, Better: BetterLower},
+		{Name: "records", Value: 5000},
+		{Name: "new_ms", Value: 100, Better: BetterLower},
+	}}
+	assert.Equal(t, []string{"query_ms went from 10 to 13 , 30% worse"}, Compare(baseline, current, 0.2))
+	assert.Len(t, Compare(baseline, current, 0.1), 2)
+	assert.Len(t, Compare(current, baseline, 0.1), 0)
+}
+
+func Test_ReadWriteReport(t *testing.T) {
+	filename := path.Join(t.TempDir(), "report.json")
+	report := &Report{Label: "abc123", Hours: 2, Results: []Result{{Name: "query_ms", Value: 1.5, Unit: "ms", Better: BetterLower}}}
+	assert.Nil(t, WriteReport(filename, report))
+	actual, err := ReadReport(filename)
+	assert.Nil(t, err)
+	assert.Equal(t, report, actual)
+}
+
+func BenchmarkIngest(b *testing.B) {
+	history := helper_history(b, 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		tables, _ := helper_openStore(b)
+		b.StartTimer()
+		Ingest(tables, history, 4, 50)
+	}
+	b.ReportMetric(float64(len(history)*b.N)/b.Elapsed().Seconds(), "records/s")
+}
+
+func BenchmarkEventHeatMap3Query(b *testing.B) {
+	tables, _ := helper_openStore(b)
+	// Loading is not measured, so it uses big batches to be quick
+	Ingest(tables, helper_history(b, 3), 4, 500)
+	for _, lookback := range []time.Duration{time.Hour, 3 * time.Hour} {
+		b.Run(lookbackName(lookback), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, err := QueryHeatMap(tables, lookback)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkCleanup(b *testing.B) {
+	history := helper_history(b, 2)
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		tables, storeRoot := helper_openStore(b)
+		Ingest(tables, history, 4, 500)
+		config := &storemanager.Config{StoreRoot: storeRoot, TimeLimit: time.Hour, SizeLimitBytes: 1 << 40, DeletionBatchSize: 1000, GCThreshold: 0.8}
+		sm := storemanager.NewStoreManager(tables, config, &afero.Afero{Fs: afero.NewOsFs()})
+		b.StartTimer()
+		_, _, err := sm.Cleanup()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
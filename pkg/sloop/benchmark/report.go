@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package benchmark
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+func WriteReport(filename string, report *Report) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, append(data, '\n'), 0644)
+}
+
+func ReadReport(filename string) (*Report, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{}
+	err = json.Unmarshal(data, report)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%v is not a benchmark report", filename)
+	}
+	return report, nil
+}
+
+// Compare returns a line for each result in current which is more than maxRegression (like 0.2 for 20%) worse than
+// the same result in baseline.  Results which are only in one of the reports are not compared.
+func Compare(baseline *Report, current *Report, maxRegression float64) []string {
+	baselineValues := map[string]float64{}
+	for _, result := range baseline.Results {
+		baselineValues[result.Name] = result.Value
+	}
+	var regressions []string
+	for _, result := range current.Results {
+		before, ok := baselineValues[result.Name]
+		if !ok || before == 0 {
+			continue
+		}
+		var change float64
+		switch result.Better {
+		case BetterHigher:
+			change = (before - result.Value) / before
+		case BetterLower:
+			change = (result.Value - before) / before
+		default:
+			continue
+		}
+		if change > maxRegression {
+			regressions = append(regressions, fmt.Sprintf("%v went from %.4g to %.4g %v, %.0f%% worse", result.Name, before, result.Value, result.Unit, change*100))
+		}
+	}
+	return regressions
+}
@@ -0,0 +1,307 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+// Envelope encrypted files start with this, followed by a version byte
+const envelopeMagic = "SLOOPENC"
+
+const (
+	envelopeVersion   = 1
+	envelopeKeyIdSize = 8
+	envelopeChunkSize = 64 * 1024
+	dataKeySize       = 32
+	// The nonce of a chunk is a random prefix from the header and the chunk number
+	noncePrefixSize = 4
+)
+
+// ReadKeyFile reads an AES key from a file.  The file holds the key as hex, as base64 or as raw bytes, tried in that
+// order, and the key must be 16, 24 or 32 bytes long to pick AES-128, AES-192 or AES-256.
+func ReadKeyFile(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read key file %q", path)
+	}
+	text := string(bytes.TrimSpace(data))
+	if key, err := hex.DecodeString(text); err == nil && validKeySize(key) {
+		return key, nil
+	}
+	if key, err := base64.StdEncoding.DecodeString(text); err == nil && validKeySize(key) {
+		return key, nil
+	}
+	if validKeySize(data) {
+		return data, nil
+	}
+	return nil, fmt.Errorf("key file %q must hold a 16, 24 or 32 byte key as hex, base64 or raw bytes", path)
+}
+
+// ReadKeyFiles reads the keys from the files which are set, in order, such as a current key followed by a previous one
+func ReadKeyFiles(paths ...string) ([][]byte, error) {
+	var keys [][]byte
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		key, err := ReadKeyFile(path)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func validKeySize(key []byte) bool {
+	return len(key) == 16 || len(key) == 24 || len(key) == 32
+}
+
+// Identifies a key in an envelope without giving anything away about it, so a restore can pick the right key
+func keyId(key []byte) []byte {
+	sum := sha256.Sum256(key)
+	return sum[:envelopeKeyIdSize]
+}
+
+func newGcm(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// IsEnvelope returns true when data starts like an envelope encrypted file
+func IsEnvelope(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(envelopeMagic))
+}
+
+// EnvelopeWriter encrypts a stream with a random data key, which is stored at the start of the stream encrypted with
+// the master key.  The stream is written in chunks which are each sealed with AES-GCM, and the last chunk is marked
+// so a truncated stream is detected.  Close must be called to write the last chunk.
+type EnvelopeWriter struct {
+	out     io.Writer
+	gcm     cipher.AEAD
+	prefix  []byte
+	counter uint64
+	buf     []byte
+	closed  bool
+}
+
+// NewEnvelopeWriter writes the envelope header to out and returns a writer for the plaintext
+func NewEnvelopeWriter(out io.Writer, masterKey []byte) (*EnvelopeWriter, error) {
+	dataKey := make([]byte, dataKeySize)
+	prefix := make([]byte, noncePrefixSize)
+	for _, random := range [][]byte{dataKey, prefix} {
+		_, err := rand.Read(random)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate data key")
+		}
+	}
+
+	masterGcm, err := newGcm(masterKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "bad master key")
+	}
+	wrapNonce := make([]byte, masterGcm.NonceSize())
+	_, err = rand.Read(wrapNonce)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate nonce")
+	}
+	header := append([]byte(envelopeMagic), envelopeVersion)
+	header = append(header, keyId(masterKey)...)
+	wrappedKey := masterGcm.Seal(nil, wrapNonce, dataKey, header)
+	header = append(header, wrapNonce...)
+	header = append(header, wrappedKey...)
+	header = append(header, prefix...)
+	_, err = out.Write(header)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGcm(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return &EnvelopeWriter{out: out, gcm: gcm, prefix: prefix, buf: make([]byte, 0, envelopeChunkSize)}, nil
+}
+
+func (e *EnvelopeWriter) Write(p []byte) (int, error) {
+	if e.closed {
+		return 0, fmt.Errorf("write to closed envelope writer")
+	}
+	written := 0
+	for len(p) > 0 {
+		n := copy(e.buf[len(e.buf):cap(e.buf)], p)
+		e.buf = e.buf[:len(e.buf)+n]
+		p = p[n:]
+		written += n
+		if len(e.buf) == cap(e.buf) {
+			err := e.writeChunk(false)
+			if err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// Close writes the last chunk.  It does not close the underlying writer
+func (e *EnvelopeWriter) Close() error {
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+	return e.writeChunk(true)
+}
+
+func (e *EnvelopeWriter) writeChunk(last bool) error {
+	sealed := e.gcm.Seal(nil, chunkNonce(e.prefix, e.counter), e.buf, chunkAad(last))
+	e.counter++
+	e.buf = e.buf[:0]
+	length := make([]byte, 4)
+	binary.BigEndian.PutUint32(length, uint32(len(sealed)))
+	_, err := e.out.Write(append(length, sealed...))
+	return err
+}
+
+func chunkNonce(prefix []byte, counter uint64) []byte {
+	nonce := make([]byte, noncePrefixSize+8)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint64(nonce[noncePrefixSize:], counter)
+	return nonce
+}
+
+func chunkAad(last bool) []byte {
+	if last {
+		return []byte{1}
+	}
+	return []byte{0}
+}
+
+// EnvelopeReader decrypts a stream written by EnvelopeWriter
+type EnvelopeReader struct {
+	in      *bufio.Reader
+	gcm     cipher.AEAD
+	prefix  []byte
+	counter uint64
+	buf     []byte
+	done    bool
+}
+
+// NewEnvelopeReader reads the envelope header from in and unwraps the data key with whichever of masterKeys the
+// stream was written with, so backups made before a key rotation can still be read
+func NewEnvelopeReader(in io.Reader, masterKeys [][]byte) (*EnvelopeReader, error) {
+	reader := bufio.NewReader(in)
+	header := make([]byte, len(envelopeMagic)+1+envelopeKeyIdSize)
+	_, err := io.ReadFull(reader, header)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read envelope header")
+	}
+	if !IsEnvelope(header) {
+		return nil, fmt.Errorf("not an encrypted file")
+	}
+	if header[len(envelopeMagic)] != envelopeVersion {
+		return nil, fmt.Errorf("unsupported encryption version %v", header[len(envelopeMagic)])
+	}
+
+	var masterKey []byte
+	for _, key := range masterKeys {
+		if bytes.Equal(keyId(key), header[len(envelopeMagic)+1:]) {
+			masterKey = key
+		}
+	}
+	if masterKey == nil {
+		return nil, fmt.Errorf("file was encrypted with a key which was not given")
+	}
+	masterGcm, err := newGcm(masterKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "bad master key")
+	}
+	wrapped := make([]byte, masterGcm.NonceSize()+dataKeySize+masterGcm.Overhead()+noncePrefixSize)
+	_, err = io.ReadFull(reader, wrapped)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read envelope header")
+	}
+	wrapNonce := wrapped[:masterGcm.NonceSize()]
+	prefix := wrapped[len(wrapped)-noncePrefixSize:]
+	dataKey, err := masterGcm.Open(nil, wrapNonce, wrapped[len(wrapNonce):len(wrapped)-noncePrefixSize], header)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt data key")
+	}
+	gcm, err := newGcm(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return &EnvelopeReader{in: reader, gcm: gcm, prefix: prefix}, nil
+}
+
+func (e *EnvelopeReader) Read(p []byte) (int, error) {
+	for len(e.buf) == 0 {
+		if e.done {
+			return 0, io.EOF
+		}
+		err := e.readChunk()
+		if err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, e.buf)
+	e.buf = e.buf[n:]
+	return n, nil
+}
+
+func (e *EnvelopeReader) readChunk() error {
+	length := make([]byte, 4)
+	_, err := io.ReadFull(e.in, length)
+	if err == io.EOF {
+		return errors.Wrap(io.ErrUnexpectedEOF, "encrypted file is truncated")
+	}
+	if err != nil {
+		return err
+	}
+	size := binary.BigEndian.Uint32(length)
+	if size > envelopeChunkSize+uint32(e.gcm.Overhead()) {
+		return fmt.Errorf("encrypted chunk of %v bytes is too big", size)
+	}
+	sealed := make([]byte, size)
+	_, err = io.ReadFull(e.in, sealed)
+	if err != nil {
+		return errors.Wrap(err, "encrypted file is truncated")
+	}
+	nonce := chunkNonce(e.prefix, e.counter)
+	e.counter++
+	e.buf, err = e.gcm.Open(nil, nonce, sealed, chunkAad(false))
+	if err == nil {
+		return nil
+	}
+	e.buf, err = e.gcm.Open(nil, nonce, sealed, chunkAad(true))
+	if err != nil {
+		return errors.Wrap(err, "failed to decrypt chunk")
+	}
+	e.done = true
+	_, err = e.in.Peek(1)
+	if err != io.EOF {
+		return fmt.Errorf("unexpected data after the last encrypted chunk")
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package common
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var someKey = []byte("0123456789abcdef0123456789abcdef")
+var someOtherKey = []byte("fedcba9876543210")
+
+func helper_encrypt(t *testing.T, key []byte, plaintext []byte) []byte {
+	var buf bytes.Buffer
+	writer, err := NewEnvelopeWriter(&buf, key)
+	assert.Nil(t, err)
+	// Odd sized writes so chunks do not line up with them
+	for len(plaintext) > 0 {
+		n := 1000
+		if n > len(plaintext) {
+			n = len(plaintext)
+		}
+		_, err = writer.Write(plaintext[:n])
+		assert.Nil(t, err)
+		plaintext = plaintext[n:]
+	}
+	assert.Nil(t, writer.Close())
+	return buf.Bytes()
+}
+
+func helper_decrypt(encrypted []byte, keys ...[]byte) ([]byte, error) {
+	reader, err := NewEnvelopeReader(bytes.NewReader(encrypted), keys)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(reader)
+}
+
+func Test_Envelope_RoundTrip(t *testing.T) {
+	for _, size := range []int{0, 10, envelopeChunkSize, 3*envelopeChunkSize + 17} {
+		plaintext := bytes.Repeat([]byte("x"), size)
+		encrypted := helper_encrypt(t, someKey, plaintext)
+		assert.True(t, IsEnvelope(encrypted))
+		assert.False(t, bytes.Contains(encrypted, []byte("xxxxxxxx")))
+
+		// The key can be one of several, like after a rotation
+		decrypted, err := helper_decrypt(encrypted, someOtherKey, someKey)
+		assert.Nil(t, err)
+		assert.Equal(t, plaintext, decrypted)
+	}
+}
+
+func Test_Envelope_Failures(t *testing.T) {
+	encrypted := helper_encrypt(t, someKey, bytes.Repeat([]byte("x"), 2*envelopeChunkSize))
+
+	_, err := helper_decrypt(encrypted, someOtherKey)
+	assert.Contains(t, err.Error(), "key which was not given")
+
+	_, err = helper_decrypt([]byte("plain backup data"), someKey)
+	assert.NotNil(t, err)
+
+	// Losing the last chunk is noticed even though every chunk left is fine
+	_, err = helper_decrypt(encrypted[:len(encrypted)-100], someKey)
+	assert.NotNil(t, err)
+	lastChunk := len(encrypted) - 4 - 16
+	_, err = helper_decrypt(encrypted[:lastChunk], someKey)
+	assert.Contains(t, err.Error(), "truncated")
+
+	tampered := append([]byte{}, encrypted...)
+	tampered[len(tampered)/2] ^= 1
+	_, err = helper_decrypt(tampered, someKey)
+	assert.NotNil(t, err)
+}
+
+func Test_ReadKeyFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keys")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	rawKey := []byte("raw key bytes, 32 bytes long!!!!")
+	contents := map[string][]byte{
+		"hex":    []byte(hex.EncodeToString(someKey) + "\n"),
+		"base64": []byte(base64.StdEncoding.EncodeToString(someKey)),
+		"raw":    rawKey,
+	}
+	for name, data := range contents {
+		file := filepath.Join(dir, name)
+		assert.Nil(t, ioutil.WriteFile(file, data, 0600))
+		key, err := ReadKeyFile(file)
+		assert.Nil(t, err, name)
+		if name == "raw" {
+			assert.Equal(t, rawKey, key)
+		} else {
+			assert.Equal(t, someKey, key, name)
+		}
+	}
+
+	file := filepath.Join(dir, "short")
+	assert.Nil(t, ioutil.WriteFile(file, []byte("too short"), 0600))
+	_, err = ReadKeyFile(file)
+	assert.NotNil(t, err)
+	_, err = ReadKeyFile(filepath.Join(dir, "missing"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,29 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package common
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/diegoholiveira/jsonlogic/v3"
+)
+
+// Applies a JsonLogic rule to a json document and returns true if the rule matched it
+func JsonLogicMatches(logicJson string, dataJson string) (bool, error) {
+	var result bytes.Buffer
+	err := jsonlogic.Apply(
+		strings.NewReader(logicJson),
+		strings.NewReader(dataJson),
+		&result,
+	)
+	if err != nil {
+		return false, err
+	}
+	return strings.Contains(result.String(), "true"), nil
+}
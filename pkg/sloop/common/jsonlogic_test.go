@@ -0,0 +1,31 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const someJsonLogicResource = `{"metadata": {"name": "someName", "namespace": "kube-system"}}`
+
+func Test_JsonLogicMatches(t *testing.T) {
+	matched, err := JsonLogicMatches(`{"==": [{"var": "metadata.namespace"}, "kube-system"]}`, someJsonLogicResource)
+	assert.Nil(t, err)
+	assert.True(t, matched)
+
+	matched, err = JsonLogicMatches(`{"==": [{"var": "metadata.namespace"}, "default"]}`, someJsonLogicResource)
+	assert.Nil(t, err)
+	assert.False(t, matched)
+}
+
+func Test_JsonLogicMatches_BadRule(t *testing.T) {
+	_, err := JsonLogicMatches(`{"==": [`, someJsonLogicResource)
+	assert.NotNil(t, err)
+}
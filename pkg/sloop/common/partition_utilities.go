@@ -1,6 +1,7 @@
 package common
 
 import (
+	"fmt"
 	"sort"
 
 	"github.com/dgraph-io/badger/v2"
@@ -107,3 +108,20 @@ func GetKeysForPrefix(db badgerwrap.DB, keyPrefix string) []string {
 	})
 	return keys
 }
+
+// DropPartition drops the keys of every table in a partition
+func DropPartition(db badgerwrap.DB, partitionID string, partitionInfo *PartitionInfo) error {
+	for tableName := range partitionInfo.TableNameToKeyCountMap {
+		prefix := fmt.Sprintf("/%s/%s", tableName, partitionID)
+		err := db.DropPrefix([]byte(prefix))
+		if err != nil {
+			return err
+		}
+		// !badger!move keys for the given prefix should also be cleaned up. For details: https://github.com/dgraph-io/badger/issues/1288
+		err = db.DropPrefix([]byte("!badger!move" + prefix))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
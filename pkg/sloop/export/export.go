@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+// Package export writes flat rows of history to files for offline analysis, as JSON Lines or Parquet.
+// Rows are written as they come so an export of any size can be streamed to a file or an http response.
+package export
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	FormatJsonLines = "jsonl"
+	FormatParquet   = "parquet"
+)
+
+type ColumnType int
+
+const (
+	String ColumnType = iota
+	Int64
+	Bool
+	Timestamp
+)
+
+// A column of an export.  Every column is nullable, and a nil value in a row is written as null
+type Column struct {
+	Name string
+	Type ColumnType
+}
+
+// Writer writes rows with one value per column, in the order of the columns.  Values are string, int64, bool or
+// time.Time to match the column type, or nil.  Close has to be called to finish the file, and does not close the
+// underlying writer.
+type Writer interface {
+	Write(row []interface{}) error
+	Close() error
+}
+
+// Returns a writer for format, which is FormatJsonLines or FormatParquet
+func NewWriter(format string, out io.Writer, columns []Column) (Writer, error) {
+	switch format {
+	case FormatJsonLines:
+		return NewJsonLinesWriter(out, columns), nil
+	case FormatParquet:
+		return NewParquetWriter(out, columns)
+	default:
+		return nil, fmt.Errorf("unknown export format %q, expected %v or %v", format, FormatJsonLines, FormatParquet)
+	}
+}
+
+// Returns the mime type to serve an export in format with
+func ContentType(format string) string {
+	if format == FormatParquet {
+		return "application/vnd.apache.parquet"
+	}
+	return "application/x-ndjson"
+}
+
+func checkRow(columns []Column, row []interface{}) error {
+	if len(row) != len(columns) {
+		return fmt.Errorf("row has %v values for %v columns", len(row), len(columns))
+	}
+	for i, value := range row {
+		if value == nil {
+			continue
+		}
+		var ok bool
+		switch columns[i].Type {
+		case String:
+			_, ok = value.(string)
+		case Int64:
+			_, ok = value.(int64)
+		case Bool:
+			_, ok = value.(bool)
+		case Timestamp:
+			_, ok = value.(time.Time)
+		}
+		if !ok {
+			return fmt.Errorf("value %v of type %T does not match column %v", value, value, columns[i].Name)
+		}
+	}
+	return nil
+}
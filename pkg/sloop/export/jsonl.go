@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// Writes one json object per row, with the columns as keys in order.  Timestamps are RFC3339 with nanoseconds
+type jsonLinesWriter struct {
+	columns []Column
+	out     *bufio.Writer
+	names   [][]byte
+}
+
+func NewJsonLinesWriter(out io.Writer, columns []Column) Writer {
+	w := &jsonLinesWriter{columns: columns, out: bufio.NewWriter(out)}
+	for _, column := range columns {
+		name, _ := json.Marshal(column.Name)
+		w.names = append(w.names, name)
+	}
+	return w
+}
+
+func (w *jsonLinesWriter) Write(row []interface{}) error {
+	err := checkRow(w.columns, row)
+	if err != nil {
+		return err
+	}
+	// Written by hand rather than from a map so the keys keep the column order
+	w.out.WriteByte('{')
+	for i, value := range row {
+		if i > 0 {
+			w.out.WriteByte(',')
+		}
+		w.out.Write(w.names[i])
+		w.out.WriteByte(':')
+		if t, ok := value.(time.Time); ok {
+			value = t.UTC().Format(time.RFC3339Nano)
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		w.out.Write(data)
+	}
+	w.out.WriteByte('}')
+	return w.out.WriteByte('\n')
+}
+
+func (w *jsonLinesWriter) Close() error {
+	return w.out.Flush()
+}
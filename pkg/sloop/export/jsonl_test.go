@@ -0,0 +1,35 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_JsonLinesWriter(t *testing.T) {
+	columns := []Column{{"name", String}, {"count", Int64}, {"deleted", Bool}, {"seen", Timestamp}}
+	var out bytes.Buffer
+	w, err := NewWriter(FormatJsonLines, &out, columns)
+	assert.Nil(t, err)
+	assert.Nil(t, w.Write([]interface{}{"a\"b", int64(7), true, time.Date(2019, 3, 1, 10, 0, 0, 5, time.UTC)}))
+	assert.Nil(t, w.Write([]interface{}{nil, nil, nil, nil}))
+	assert.Nil(t, w.Close())
+
+	expected := `{"name":"a\"b","count":7,"deleted":true,"seen":"2019-03-01T10:00:00.000000005Z"}` + "\n" +
+		`{"name":null,"count":null,"deleted":null,"seen":null}` + "\n"
+	assert.Equal(t, expected, out.String())
+}
+
+func Test_NewWriter_UnknownFormat(t *testing.T) {
+	_, err := NewWriter("csv", &bytes.Buffer{}, nil)
+	assert.NotNil(t, err)
+}
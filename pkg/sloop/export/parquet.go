@@ -0,0 +1,294 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+)
+
+// The writer keeps a row group in memory, and writes it out when it has this many rows or values of this many bytes.
+// Each column of a row group is a single zstd compressed data page.
+const (
+	parquetRowGroupRows  = 10000
+	parquetRowGroupBytes = 64 << 20
+)
+
+var parquetMagic = []byte("PAR1")
+
+// Values from parquet.thrift
+const (
+	parquetBoolean   = 0
+	parquetInt64     = 2
+	parquetByteArray = 6
+
+	parquetUtf8            = 0
+	parquetTimestampMicros = 10
+
+	parquetOptional  = 1
+	parquetPlain     = 0
+	parquetRle       = 3
+	parquetZstd      = 6
+	parquetDataPage  = 0
+	parquetCreatedBy = "sloop"
+)
+
+type parquetColumnChunk struct {
+	offset           int64
+	uncompressedSize int64
+	compressedSize   int64
+}
+
+type parquetRowGroup struct {
+	numRows       int64
+	totalByteSize int64
+	chunks        []parquetColumnChunk
+}
+
+// Values of one column of the row group being built
+type parquetColumnBuffer struct {
+	present []bool
+	values  bytes.Buffer
+	bools   []bool
+}
+
+type parquetWriter struct {
+	out       *countingWriter
+	columns   []Column
+	buffers   []parquetColumnBuffer
+	rows      int
+	totalRows int64
+	rowGroups []parquetRowGroup
+	encoder   *zstd.Encoder
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// Returns a writer of a parquet file with one optional column per column.  Strings are UTF8 byte arrays and
+// timestamps are int64 microseconds since the epoch in UTC.
+func NewParquetWriter(out io.Writer, columns []Column) (Writer, error) {
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to configure compression")
+	}
+	w := &parquetWriter{
+		out:     &countingWriter{w: out},
+		columns: columns,
+		buffers: make([]parquetColumnBuffer, len(columns)),
+		encoder: encoder,
+	}
+	_, err = w.out.Write(parquetMagic)
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *parquetWriter) Write(row []interface{}) error {
+	err := checkRow(w.columns, row)
+	if err != nil {
+		return err
+	}
+	var scratch [8]byte
+	size := 0
+	for i, value := range row {
+		buffer := &w.buffers[i]
+		buffer.present = append(buffer.present, value != nil)
+		switch v := value.(type) {
+		case string:
+			binary.LittleEndian.PutUint32(scratch[:4], uint32(len(v)))
+			buffer.values.Write(scratch[:4])
+			buffer.values.WriteString(v)
+		case int64:
+			binary.LittleEndian.PutUint64(scratch[:], uint64(v))
+			buffer.values.Write(scratch[:])
+		case time.Time:
+			binary.LittleEndian.PutUint64(scratch[:], uint64(v.UnixNano()/int64(time.Microsecond)))
+			buffer.values.Write(scratch[:])
+		case bool:
+			buffer.bools = append(buffer.bools, v)
+		}
+		size += buffer.values.Len()
+	}
+	w.rows++
+	if w.rows >= parquetRowGroupRows || size >= parquetRowGroupBytes {
+		return w.flushRowGroup()
+	}
+	return nil
+}
+
+func (w *parquetWriter) flushRowGroup() error {
+	if w.rows == 0 {
+		return nil
+	}
+	rowGroup := parquetRowGroup{numRows: int64(w.rows)}
+	for i := range w.columns {
+		buffer := &w.buffers[i]
+		var page bytes.Buffer
+		writeDefinitionLevels(&page, buffer.present)
+		if w.columns[i].Type == Bool {
+			page.Write(packBools(buffer.bools))
+		} else {
+			page.Write(buffer.values.Bytes())
+		}
+		compressed := w.encoder.EncodeAll(page.Bytes(), nil)
+
+		header := newThriftWriter()
+		header.I32(1, parquetDataPage)
+		header.I32(2, int32(page.Len()))
+		header.I32(3, int32(len(compressed)))
+		header.Struct(5)
+		header.I32(1, int32(w.rows))
+		header.I32(2, parquetPlain)
+		header.I32(3, parquetRle)
+		header.I32(4, parquetRle)
+		header.End()
+		header.End()
+
+		chunk := parquetColumnChunk{
+			offset:           w.out.n,
+			uncompressedSize: int64(len(header.Bytes()) + page.Len()),
+			compressedSize:   int64(len(header.Bytes()) + len(compressed)),
+		}
+		_, err := w.out.Write(header.Bytes())
+		if err != nil {
+			return err
+		}
+		_, err = w.out.Write(compressed)
+		if err != nil {
+			return err
+		}
+		rowGroup.chunks = append(rowGroup.chunks, chunk)
+		rowGroup.totalByteSize += chunk.uncompressedSize
+		w.buffers[i] = parquetColumnBuffer{}
+	}
+	w.rowGroups = append(w.rowGroups, rowGroup)
+	w.totalRows += int64(w.rows)
+	w.rows = 0
+	return nil
+}
+
+// Definition levels with a bit width of 1, as runs of the RLE/bit packed hybrid encoding behind a 4 byte length
+func writeDefinitionLevels(page *bytes.Buffer, present []bool) {
+	var runs bytes.Buffer
+	var scratch [binary.MaxVarintLen64]byte
+	for start := 0; start < len(present); {
+		end := start + 1
+		for end < len(present) && present[end] == present[start] {
+			end++
+		}
+		n := binary.PutUvarint(scratch[:], uint64(end-start)<<1)
+		runs.Write(scratch[:n])
+		if present[start] {
+			runs.WriteByte(1)
+		} else {
+			runs.WriteByte(0)
+		}
+		start = end
+	}
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(runs.Len()))
+	page.Write(length[:])
+	page.Write(runs.Bytes())
+}
+
+// Plain encoded booleans are bit packed, least significant bit first
+func packBools(values []bool) []byte {
+	packed := make([]byte, (len(values)+7)/8)
+	for i, v := range values {
+		if v {
+			packed[i/8] |= 1 << uint(i%8)
+		}
+	}
+	return packed
+}
+
+func (c Column) parquetTypes() (physical int32, converted int32, hasConverted bool) {
+	switch c.Type {
+	case Int64:
+		return parquetInt64, 0, false
+	case Bool:
+		return parquetBoolean, 0, false
+	case Timestamp:
+		return parquetInt64, parquetTimestampMicros, true
+	default:
+		return parquetByteArray, parquetUtf8, true
+	}
+}
+
+// Writes the last row group and the footer
+func (w *parquetWriter) Close() error {
+	err := w.flushRowGroup()
+	if err != nil {
+		return err
+	}
+	meta := newThriftWriter()
+	meta.I32(1, 1)
+	meta.StructList(2, len(w.columns)+1, func(i int) {
+		if i == 0 {
+			meta.String(4, "schema")
+			meta.I32(5, int32(len(w.columns)))
+			return
+		}
+		column := w.columns[i-1]
+		physical, converted, hasConverted := column.parquetTypes()
+		meta.I32(1, physical)
+		meta.I32(3, parquetOptional)
+		meta.String(4, column.Name)
+		if hasConverted {
+			meta.I32(6, converted)
+		}
+	})
+	meta.I64(3, w.totalRows)
+	meta.StructList(4, len(w.rowGroups), func(i int) {
+		rowGroup := w.rowGroups[i]
+		meta.StructList(1, len(rowGroup.chunks), func(j int) {
+			chunk := rowGroup.chunks[j]
+			physical, _, _ := w.columns[j].parquetTypes()
+			meta.I64(2, chunk.offset)
+			meta.Struct(3)
+			meta.I32(1, physical)
+			meta.I32List(2, []int32{parquetPlain, parquetRle})
+			meta.StringList(3, []string{w.columns[j].Name})
+			meta.I32(4, parquetZstd)
+			meta.I64(5, rowGroup.numRows)
+			meta.I64(6, chunk.uncompressedSize)
+			meta.I64(7, chunk.compressedSize)
+			meta.I64(9, chunk.offset)
+			meta.End()
+		})
+		meta.I64(2, rowGroup.totalByteSize)
+		meta.I64(3, rowGroup.numRows)
+	})
+	meta.String(6, parquetCreatedBy)
+	meta.End()
+
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(len(meta.Bytes())))
+	for _, data := range [][]byte{meta.Bytes(), length[:], parquetMagic} {
+		_, err = w.out.Write(data)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
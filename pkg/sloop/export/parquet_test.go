@@ -0,0 +1,229 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"flag"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+)
+
+// A generic reader of the thrift compact protocol, so the tests can check the file without a parquet library.
+// Structs are read as maps from field id to value
+type helper_thriftReader struct {
+	t    *testing.T
+	data *bytes.Reader
+}
+
+func (r *helper_thriftReader) varint() uint64 {
+	v, err := binary.ReadUvarint(r.data)
+	assert.Nil(r.t, err)
+	return v
+}
+
+func (r *helper_thriftReader) zigzag() int64 {
+	v := r.varint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *helper_thriftReader) value(fieldType byte) interface{} {
+	switch fieldType {
+	case thriftI32, thriftI64:
+		return r.zigzag()
+	case thriftBinary:
+		b := make([]byte, r.varint())
+		r.data.Read(b)
+		return string(b)
+	case thriftList:
+		header, _ := r.data.ReadByte()
+		size := int(header >> 4)
+		if size == 15 {
+			size = int(r.varint())
+		}
+		list := []interface{}{}
+		for i := 0; i < size; i++ {
+			list = append(list, r.value(header&0x0f))
+		}
+		return list
+	case thriftStruct:
+		return r.readStruct()
+	}
+	r.t.Fatalf("unexpected thrift type %v", fieldType)
+	return nil
+}
+
+func (r *helper_thriftReader) readStruct() map[int16]interface{} {
+	fields := map[int16]interface{}{}
+	var last int16
+	for {
+		header, err := r.data.ReadByte()
+		assert.Nil(r.t, err)
+		if header == 0 {
+			return fields
+		}
+		id := last + int16(header>>4)
+		if header>>4 == 0 {
+			id = int16(r.zigzag())
+		}
+		fields[id] = r.value(header & 0x0f)
+		last = id
+	}
+}
+
+type helper_parquetColumn struct {
+	present []bool
+	values  []byte
+}
+
+// Reads the footer and decompresses the data page of every column chunk, by row group
+func helper_readParquet(t *testing.T, file []byte) (map[int16]interface{}, [][]helper_parquetColumn) {
+	assert.Equal(t, parquetMagic, file[:4])
+	assert.Equal(t, parquetMagic, file[len(file)-4:])
+	footerLength := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+	footer := file[len(file)-8-footerLength : len(file)-8]
+	meta := (&helper_thriftReader{t: t, data: bytes.NewReader(footer)}).readStruct()
+
+	decoder, err := zstd.NewReader(nil)
+	assert.Nil(t, err)
+	var rowGroups [][]helper_parquetColumn
+	for _, rg := range meta[4].([]interface{}) {
+		var columns []helper_parquetColumn
+		for _, cc := range rg.(map[int16]interface{})[1].([]interface{}) {
+			columnMeta := cc.(map[int16]interface{})[3].(map[int16]interface{})
+			offset := columnMeta[9].(int64)
+			reader := bytes.NewReader(file[offset:])
+			header := (&helper_thriftReader{t: t, data: reader}).readStruct()
+			headerSize := int64(len(file[offset:]) - reader.Len())
+			assert.Equal(t, columnMeta[7].(int64), headerSize+header[3].(int64))
+			compressed := file[offset+headerSize : offset+headerSize+header[3].(int64)]
+			page, err := decoder.DecodeAll(compressed, nil)
+			assert.Nil(t, err)
+			assert.Equal(t, header[2].(int64), int64(len(page)))
+
+			// Expand the runs of definition levels
+			levelsLength := int(binary.LittleEndian.Uint32(page))
+			levels := bytes.NewReader(page[4 : 4+levelsLength])
+			column := helper_parquetColumn{values: page[4+levelsLength:]}
+			for levels.Len() > 0 {
+				run, _ := binary.ReadUvarint(levels)
+				value, _ := levels.ReadByte()
+				for i := uint64(0); i < run>>1; i++ {
+					column.present = append(column.present, value == 1)
+				}
+			}
+			columns = append(columns, column)
+		}
+		rowGroups = append(rowGroups, columns)
+	}
+	return meta, rowGroups
+}
+
+func Test_ParquetWriter_RoundTrip(t *testing.T) {
+	columns := []Column{{"name", String}, {"count", Int64}, {"deleted", Bool}, {"seen", Timestamp}}
+	seen := time.Date(2019, 3, 1, 10, 0, 0, 123456000, time.UTC)
+	var out bytes.Buffer
+	w, err := NewParquetWriter(&out, columns)
+	assert.Nil(t, err)
+	assert.Nil(t, w.Write([]interface{}{"a", int64(7), true, seen}))
+	assert.Nil(t, w.Write([]interface{}{nil, nil, nil, nil}))
+	assert.Nil(t, w.Write([]interface{}{"bc", int64(-1), false, seen.Add(time.Second)}))
+	assert.Nil(t, w.Close())
+
+	meta, rowGroups := helper_readParquet(t, out.Bytes())
+	assert.Equal(t, int64(3), meta[3])
+	schema := meta[2].([]interface{})
+	assert.Len(t, schema, 5)
+	assert.Equal(t, int64(4), schema[0].(map[int16]interface{})[5])
+	assert.Equal(t, "seen", schema[4].(map[int16]interface{})[4])
+	assert.Equal(t, int64(parquetTimestampMicros), schema[4].(map[int16]interface{})[6])
+
+	assert.Len(t, rowGroups, 1)
+	present := []bool{true, false, true}
+	name := rowGroups[0][0]
+	assert.Equal(t, present, name.present)
+	assert.Equal(t, []byte{1, 0, 0, 0, 'a', 2, 0, 0, 0, 'b', 'c'}, name.values)
+	count := rowGroups[0][1]
+	assert.Equal(t, int64(7), int64(binary.LittleEndian.Uint64(count.values)))
+	assert.Equal(t, int64(-1), int64(binary.LittleEndian.Uint64(count.values[8:])))
+	assert.Equal(t, []byte{1}, rowGroups[0][2].values)
+	micros := int64(binary.LittleEndian.Uint64(rowGroups[0][3].values))
+	assert.Equal(t, seen, time.Unix(0, micros*int64(time.Microsecond)).UTC())
+}
+
+// Rewrite testdata/golden.parquet with go test ./export -update-golden.  CI reads the file with pyarrow through
+// testdata/verify_golden.py
+var updateGolden = flag.Bool("update-golden", false, "rewrite the golden parquet file")
+
+const goldenParquetFile = "testdata/golden.parquet"
+
+// The rows in the golden file, which verify_golden.py expects
+func helper_writeGoldenParquet(t *testing.T) []byte {
+	columns := []Column{{"name", String}, {"count", Int64}, {"deleted", Bool}, {"seen", Timestamp}}
+	seen := time.Date(2019, 3, 1, 10, 0, 0, 123456000, time.UTC)
+	var out bytes.Buffer
+	w, err := NewParquetWriter(&out, columns)
+	assert.Nil(t, err)
+	assert.Nil(t, w.Write([]interface{}{"a", int64(7), true, seen}))
+	assert.Nil(t, w.Write([]interface{}{nil, nil, nil, nil}))
+	assert.Nil(t, w.Write([]interface{}{"bc", int64(-1), false, seen.Add(time.Second)}))
+	assert.Nil(t, w.Write([]interface{}{"pod-é", int64(0), nil, nil}))
+	assert.Nil(t, w.Close())
+	return out.Bytes()
+}
+
+// The golden file only pins the bytes the writer produces.  It is verify_golden.py, run in CI, which checks a real
+// parquet reader can read them
+func Test_ParquetWriter_Golden(t *testing.T) {
+	file := helper_writeGoldenParquet(t)
+	if *updateGolden {
+		assert.Nil(t, ioutil.WriteFile(goldenParquetFile, file, 0644))
+	}
+	golden, err := ioutil.ReadFile(goldenParquetFile)
+	assert.Nil(t, err)
+	assert.Equal(t, golden, file)
+}
+
+func Test_ParquetWriter_SplitsRowGroups(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewParquetWriter(&out, []Column{{"n", Int64}})
+	assert.Nil(t, err)
+	for i := 0; i < parquetRowGroupRows+5; i++ {
+		assert.Nil(t, w.Write([]interface{}{int64(i)}))
+	}
+	assert.Nil(t, w.Close())
+
+	meta, rowGroups := helper_readParquet(t, out.Bytes())
+	assert.Equal(t, int64(parquetRowGroupRows+5), meta[3])
+	assert.Len(t, rowGroups, 2)
+	assert.Len(t, rowGroups[1][0].present, 5)
+	assert.Equal(t, int64(parquetRowGroupRows), int64(binary.LittleEndian.Uint64(rowGroups[1][0].values)))
+}
+
+func Test_ParquetWriter_Empty(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewParquetWriter(&out, []Column{{"n", Int64}})
+	assert.Nil(t, err)
+	assert.Nil(t, w.Close())
+
+	meta, rowGroups := helper_readParquet(t, out.Bytes())
+	assert.Equal(t, int64(0), meta[3])
+	assert.Len(t, rowGroups, 0)
+}
+
+func Test_ParquetWriter_RejectsWrongType(t *testing.T) {
+	w, err := NewParquetWriter(&bytes.Buffer{}, []Column{{"n", Int64}})
+	assert.Nil(t, err)
+	assert.NotNil(t, w.Write([]interface{}{"7"}))
+	assert.NotNil(t, w.Write([]interface{}{int64(1), int64(2)}))
+}
@@ -0,0 +1,42 @@
+# Copyright (c) 2019, salesforce.com, inc.
+# All rights reserved.
+# SPDX-License-Identifier: BSD-3-Clause
+# For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+
+# Reads golden.parquet with pyarrow and checks it holds the rows written by helper_writeGoldenParquet in
+# parquet_test.go.  CI runs it on every change; run it locally with `pip install pyarrow && python3 verify_golden.py`.
+
+import datetime
+import os
+import sys
+
+import pyarrow as pa
+import pyarrow.parquet as pq
+
+path = os.path.join(os.path.dirname(os.path.abspath(__file__)), "golden.parquet")
+table = pq.read_table(path)
+
+expected_schema = pa.schema([
+    ("name", pa.string()),
+    ("count", pa.int64()),
+    ("deleted", pa.bool_()),
+    ("seen", pa.timestamp("us", tz="UTC")),
+])
+if not table.schema.equals(expected_schema):
+    sys.exit("unexpected schema:\n%s" % table.schema)
+
+seen = datetime.datetime(2019, 3, 1, 10, 0, 0, 123456, tzinfo=datetime.timezone.utc)
+expected_rows = [
+    {"name": "a", "count": 7, "deleted": True, "seen": seen},
+    {"name": None, "count": None, "deleted": None, "seen": None},
+    {"name": "bc", "count": -1, "deleted": False, "seen": seen + datetime.timedelta(seconds=1)},
+    {"name": "pod-é", "count": 0, "deleted": None, "seen": None},
+]
+rows = table.to_pylist()
+if rows != expected_rows:
+    sys.exit("unexpected rows:\n%s" % rows)
+
+metadata = pq.ParquetFile(path).metadata
+if metadata.num_row_groups != 1 or metadata.row_group(0).column(0).compression != "ZSTD":
+    sys.exit("unexpected metadata:\n%s" % metadata)
+print("%s is readable by pyarrow %s and holds the expected rows" % (path, pa.__version__))
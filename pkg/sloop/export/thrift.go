@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Parquet metadata is serialized with the thrift compact protocol.  Sloop only writes a handful of parquet structs,
+// so this is the small subset of the protocol they need rather than a dependency on a thrift library.
+
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+type thriftWriter struct {
+	buf bytes.Buffer
+	// Id of the last field written in each open struct, since field headers hold the delta from it
+	lastField []int16
+}
+
+func newThriftWriter() *thriftWriter {
+	return &thriftWriter{lastField: []int16{0}}
+}
+
+func (w *thriftWriter) Bytes() []byte {
+	return w.buf.Bytes()
+}
+
+func (w *thriftWriter) fieldHeader(id int16, fieldType byte) {
+	last := &w.lastField[len(w.lastField)-1]
+	delta := id - *last
+	if delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | fieldType)
+	} else {
+		w.buf.WriteByte(fieldType)
+		w.varint(zigzag(int64(id)))
+	}
+	*last = id
+}
+
+func (w *thriftWriter) varint(v uint64) {
+	var scratch [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(scratch[:], v)
+	w.buf.Write(scratch[:n])
+}
+
+func zigzag(v int64) uint64 {
+	return uint64(v<<1) ^ uint64(v>>63)
+}
+
+func (w *thriftWriter) I32(id int16, v int32) {
+	w.fieldHeader(id, thriftI32)
+	w.varint(zigzag(int64(v)))
+}
+
+func (w *thriftWriter) I64(id int16, v int64) {
+	w.fieldHeader(id, thriftI64)
+	w.varint(zigzag(v))
+}
+
+func (w *thriftWriter) String(id int16, v string) {
+	w.fieldHeader(id, thriftBinary)
+	w.varint(uint64(len(v)))
+	w.buf.WriteString(v)
+}
+
+func (w *thriftWriter) listHeader(id int16, elemType byte, size int) {
+	w.fieldHeader(id, thriftList)
+	if size < 15 {
+		w.buf.WriteByte(byte(size)<<4 | elemType)
+	} else {
+		w.buf.WriteByte(0xf0 | elemType)
+		w.varint(uint64(size))
+	}
+}
+
+func (w *thriftWriter) I32List(id int16, values []int32) {
+	w.listHeader(id, thriftI32, len(values))
+	for _, v := range values {
+		w.varint(zigzag(int64(v)))
+	}
+}
+
+func (w *thriftWriter) StringList(id int16, values []string) {
+	w.listHeader(id, thriftBinary, len(values))
+	for _, v := range values {
+		w.varint(uint64(len(v)))
+		w.buf.WriteString(v)
+	}
+}
+
+// Writes a list of count structs, with each struct written by writeElem between its begin and end
+func (w *thriftWriter) StructList(id int16, count int, writeElem func(i int)) {
+	w.listHeader(id, thriftStruct, count)
+	for i := 0; i < count; i++ {
+		w.begin()
+		writeElem(i)
+		w.End()
+	}
+}
+
+// Starts a struct field.  It is finished with End
+func (w *thriftWriter) Struct(id int16) {
+	w.fieldHeader(id, thriftStruct)
+	w.begin()
+}
+
+func (w *thriftWriter) begin() {
+	w.lastField = append(w.lastField, 0)
+}
+
+// Ends the innermost open struct, or the top level struct
+func (w *thriftWriter) End() {
+	w.buf.WriteByte(0)
+	if len(w.lastField) > 1 {
+		w.lastField = w.lastField[:len(w.lastField)-1]
+	}
+}
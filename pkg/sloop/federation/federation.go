@@ -0,0 +1,211 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package federation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+)
+
+var (
+	metricPeerQueryCount   = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_federation_peer_query_count"}, []string{"peer"})
+	metricPeerFailureCount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_federation_peer_failure_count"}, []string{"peer"})
+	metricPeerLatency      = promauto.NewHistogramVec(prometheus.HistogramOpts{Name: "sloop_federation_peer_latency_sec", Buckets: prometheus.ExponentialBuckets(0.01, 2, 12)}, []string{"peer"})
+)
+
+var validPeerName = regexp.MustCompile(`^[a-zA-Z0-9\-_.]+$`)
+
+// Another sloop instance.  Url is the base url of one of its clusters, for example http://sloop-east:8080/east.
+// Token is sent as a bearer token when the peer has auth turned on.  Peers can not be federated themselves
+type Peer struct {
+	Name  string `json:"name"`
+	Url   string `json:"url"`
+	Token string `json:"token,omitempty"`
+}
+
+// Hides the token when the config or the peer list is printed
+func (p Peer) MarshalJSON() ([]byte, error) {
+	type redacted Peer
+	r := redacted(p)
+	if r.Token != "" {
+		r.Token = "<redacted>"
+	}
+	return json.Marshal(r)
+}
+
+// Federation answers /data queries by sending them to every peer and merging the results.  Queries about a single
+// resource, which the UI makes after a row is clicked, are sent only to the peer named by the cluster param.
+type Federation struct {
+	peers  []Peer
+	client *http.Client
+}
+
+// The response from one peer
+type peerResult struct {
+	peer Peer
+	body []byte
+	err  error
+}
+
+func NewFederation(peers []Peer, timeout time.Duration) (*Federation, error) {
+	names := map[string]bool{}
+	for _, peer := range peers {
+		if peer.Name == "" || peer.Url == "" {
+			return nil, fmt.Errorf("federation peer %q needs a name and url", peer.Name)
+		}
+		// The name is passed back as the cluster param, which only allows these characters
+		if !validPeerName.MatchString(peer.Name) {
+			return nil, fmt.Errorf("federation peer name %q can only contain letters, digits, '-', '_' and '.'", peer.Name)
+		}
+		if names[peer.Name] {
+			return nil, fmt.Errorf("duplicate federation peer name %v", peer.Name)
+		}
+		names[peer.Name] = true
+		_, err := url.Parse(peer.Url)
+		if err != nil {
+			return nil, errors.Wrapf(err, "federation peer %v has a bad url", peer.Name)
+		}
+	}
+	return &Federation{peers: peers, client: &http.Client{Timeout: timeout}}, nil
+}
+
+func (f *Federation) Peers() []Peer {
+	return f.peers
+}
+
+// RunQuery runs a /data query against the peers and returns the merged json.  Peers which failed are returned
+// separately so the caller can report them.  An error is only returned when the query can not be answered at all.
+func (f *Federation) RunQuery(ctx context.Context, queryName string, params url.Values) ([]byte, []queries.PeerError, error) {
+	cluster := params.Get(queries.ClusterParam)
+	if cluster != "" {
+		peer, ok := f.getPeer(cluster)
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown cluster %q", cluster)
+		}
+		result := f.queryPeer(ctx, peer, queryName, withParam(params, queries.ClusterParam, ""))
+		return result.body, nil, result.err
+	}
+
+	merge, ok := mergeFuncs[queryName]
+	if !ok {
+		return nil, nil, fmt.Errorf("query %v needs the %v param when federated", queryName, queries.ClusterParam)
+	}
+
+	results := f.queryAllPeers(ctx, queryName, params)
+	var peerErrors []queries.PeerError
+	var succeeded []peerResult
+	for _, result := range results {
+		if result.err != nil {
+			peerErrors = append(peerErrors, queries.PeerError{Peer: result.peer.Name, Error: result.err.Error()})
+			continue
+		}
+		succeeded = append(succeeded, result)
+	}
+	if len(succeeded) == 0 && len(peerErrors) > 0 {
+		return nil, peerErrors, fmt.Errorf("all peers failed: %v", describePeerErrors(peerErrors))
+	}
+	ret, err := merge(succeeded, peerErrors)
+	return ret, peerErrors, err
+}
+
+func (f *Federation) getPeer(name string) (Peer, bool) {
+	for _, peer := range f.peers {
+		if peer.Name == name {
+			return peer, true
+		}
+	}
+	return Peer{}, false
+}
+
+// Queries the peers in parallel and returns their results in peer order
+func (f *Federation) queryAllPeers(ctx context.Context, queryName string, params url.Values) []peerResult {
+	results := make([]peerResult, len(f.peers))
+	wg := sync.WaitGroup{}
+	for idx, peer := range f.peers {
+		wg.Add(1)
+		go func(idx int, peer Peer) {
+			defer wg.Done()
+			results[idx] = f.queryPeer(ctx, peer, queryName, params)
+		}(idx, peer)
+	}
+	wg.Wait()
+	return results
+}
+
+func (f *Federation) queryPeer(ctx context.Context, peer Peer, queryName string, params url.Values) peerResult {
+	before := time.Now()
+	metricPeerQueryCount.WithLabelValues(peer.Name).Inc()
+	body, err := f.getData(ctx, peer, queryName, params)
+	metricPeerLatency.WithLabelValues(peer.Name).Observe(time.Since(before).Seconds())
+	if err != nil {
+		glog.Errorf("Federated query %v to peer %v failed: %v", queryName, peer.Name, err)
+		metricPeerFailureCount.WithLabelValues(peer.Name).Inc()
+	}
+	return peerResult{peer: peer, body: body, err: err}
+}
+
+func (f *Federation) getData(ctx context.Context, peer Peer, queryName string, params url.Values) ([]byte, error) {
+	dataUrl := strings.TrimSuffix(peer.Url, "/") + "/data?" + withParam(params, queries.QueryParam, queryName).Encode()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, dataUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	if peer.Token != "" {
+		request.Header.Set("Authorization", "Bearer "+peer.Token)
+	}
+	response, err := f.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response")
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("peer returned %v: %s", response.Status, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+// Returns a copy of params with key set to value, or removed when value is empty
+func withParam(params url.Values, key string, value string) url.Values {
+	ret := url.Values{}
+	for k, values := range params {
+		ret[k] = values
+	}
+	if value == "" {
+		ret.Del(key)
+	} else {
+		ret.Set(key, value)
+	}
+	return ret
+}
+
+func describePeerErrors(peerErrors []queries.PeerError) string {
+	var parts []string
+	for _, peerError := range peerErrors {
+		parts = append(parts, peerError.Peer+": "+peerError.Error)
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,189 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package federation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+)
+
+// An httptest stand-in for a peer which returns a canned response for each query and records the params it got
+type fakePeer struct {
+	responses   map[string]string
+	params      []url.Values
+	authHeaders []string
+}
+
+func (f *fakePeer) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request.URL.Path != "/someContext/data" {
+		http.NotFound(writer, request)
+		return
+	}
+	f.params = append(f.params, request.URL.Query())
+	f.authHeaders = append(f.authHeaders, request.Header.Get("Authorization"))
+	response, ok := f.responses[request.URL.Query().Get(queries.QueryParam)]
+	if !ok {
+		http.Error(writer, "Query not found", http.StatusInternalServerError)
+		return
+	}
+	writer.Write([]byte(response))
+}
+
+func helper_timeline(t *testing.T, names ...string) string {
+	root := queries.TimelineRoot{ViewOpt: queries.ViewOptions{Sort: "starttime"}}
+	for _, name := range names {
+		root.Rows = append(root.Rows, queries.TimelineRow{Text: name, Kind: "Pod", Namespace: "someNamespace", Overlays: []queries.Overlay{}})
+	}
+	bytes, err := json.Marshal(root)
+	assert.Nil(t, err)
+	return string(bytes)
+}
+
+func helper_federation(t *testing.T, peers map[string]*fakePeer) *Federation {
+	var peerList []Peer
+	for _, name := range []string{"east", "west", "north"} {
+		fake, ok := peers[name]
+		if !ok {
+			continue
+		}
+		server := httptest.NewServer(fake)
+		t.Cleanup(server.Close)
+		peerList = append(peerList, Peer{Name: name, Url: server.URL + "/someContext/"})
+	}
+	fed, err := NewFederation(peerList, time.Second)
+	assert.Nil(t, err)
+	return fed
+}
+
+func Test_RunQuery_MergesTimelines(t *testing.T) {
+	east := &fakePeer{responses: map[string]string{"EventHeatMap": helper_timeline(t, "a", "b")}}
+	west := &fakePeer{responses: map[string]string{"EventHeatMap": helper_timeline(t, "c")}}
+	fed := helper_federation(t, map[string]*fakePeer{"east": east, "west": west})
+
+	params := url.Values{queries.LookbackParam: []string{"6h"}, queries.KindParam: []string{"Pod"}}
+	data, peerErrors, err := fed.RunQuery(context.Background(), "EventHeatMap", params)
+	assert.Nil(t, err)
+	assert.Len(t, peerErrors, 0)
+
+	var root queries.TimelineRoot
+	assert.Nil(t, json.Unmarshal(data, &root))
+	assert.Equal(t, "starttime", root.ViewOpt.Sort)
+	var got []string
+	for _, row := range root.Rows {
+		got = append(got, row.Cluster+":"+row.Text)
+	}
+	assert.Equal(t, []string{"east:a", "east:b", "west:c"}, got)
+
+	// Params are passed through to every peer
+	assert.Equal(t, "6h", east.params[0].Get(queries.LookbackParam))
+	assert.Equal(t, "Pod", west.params[0].Get(queries.KindParam))
+}
+
+func Test_RunQuery_ReportsPartialFailures(t *testing.T) {
+	east := &fakePeer{responses: map[string]string{"EventHeatMap": helper_timeline(t, "a")}}
+	west := &fakePeer{responses: map[string]string{}}
+	fed := helper_federation(t, map[string]*fakePeer{"east": east, "west": west})
+
+	data, peerErrors, err := fed.RunQuery(context.Background(), "EventHeatMap", url.Values{})
+	assert.Nil(t, err)
+	assert.Len(t, peerErrors, 1)
+	assert.Equal(t, "west", peerErrors[0].Peer)
+	assert.Contains(t, peerErrors[0].Error, "500")
+
+	var root queries.TimelineRoot
+	assert.Nil(t, json.Unmarshal(data, &root))
+	assert.Len(t, root.Rows, 1)
+	assert.Equal(t, peerErrors, root.PeerErrors)
+}
+
+func Test_RunQuery_AllPeersFail(t *testing.T) {
+	fed := helper_federation(t, map[string]*fakePeer{"east": {}, "west": {}})
+
+	_, peerErrors, err := fed.RunQuery(context.Background(), "Kinds", url.Values{})
+	assert.NotNil(t, err)
+	assert.Len(t, peerErrors, 2)
+}
+
+func Test_RunQuery_MergesNamespacesAndKinds(t *testing.T) {
+	east := &fakePeer{responses: map[string]string{"Namespaces": `["default", "east-ns", "_all"]`, "Kinds": `["Node", "Pod", "_all"]`}}
+	west := &fakePeer{responses: map[string]string{"Namespaces": `["default", "west-ns", "_all"]`, "Kinds": `["Deployment", "Pod", "_all"]`}}
+	fed := helper_federation(t, map[string]*fakePeer{"east": east, "west": west})
+
+	data, _, err := fed.RunQuery(context.Background(), "Namespaces", url.Values{})
+	assert.Nil(t, err)
+	var namespaces []string
+	assert.Nil(t, json.Unmarshal(data, &namespaces))
+	assert.Equal(t, []string{"default", "east-ns", "west-ns", "_all"}, namespaces)
+
+	data, _, err = fed.RunQuery(context.Background(), "Kinds", url.Values{})
+	assert.Nil(t, err)
+	var kinds []string
+	assert.Nil(t, json.Unmarshal(data, &kinds))
+	assert.Equal(t, []string{"Deployment", "Node", "Pod", "_all"}, kinds)
+}
+
+func Test_RunQuery_ClusterParamPicksPeer(t *testing.T) {
+	east := &fakePeer{responses: map[string]string{"GetResPayload": `{"east": true}`}}
+	west := &fakePeer{responses: map[string]string{"GetResPayload": `{"west": true}`}}
+	fed := helper_federation(t, map[string]*fakePeer{"east": east, "west": west})
+
+	params := url.Values{queries.ClusterParam: []string{"west"}, queries.NameParam: []string{"somePod"}}
+	data, _, err := fed.RunQuery(context.Background(), "GetResPayload", params)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"west": true}`, string(data))
+	assert.Len(t, east.params, 0)
+	assert.Equal(t, "somePod", west.params[0].Get(queries.NameParam))
+	assert.Equal(t, "", west.params[0].Get(queries.ClusterParam))
+
+	params.Set(queries.ClusterParam, "south")
+	_, _, err = fed.RunQuery(context.Background(), "GetResPayload", params)
+	assert.NotNil(t, err)
+
+	// Resource queries can not be fanned out
+	_, _, err = fed.RunQuery(context.Background(), "GetResPayload", url.Values{})
+	assert.NotNil(t, err)
+}
+
+func Test_NewFederation_BadPeers(t *testing.T) {
+	badPeers := [][]Peer{
+		{{Url: "http://localhost"}},
+		{{Name: "noUrl"}},
+		{{Name: "bad/name", Url: "http://localhost"}},
+		{{Name: "dup", Url: "http://localhost"}, {Name: "dup", Url: "http://localhost"}},
+	}
+	for _, peers := range badPeers {
+		_, err := NewFederation(peers, time.Second)
+		assert.NotNil(t, err, peers[0].Name)
+	}
+}
+
+func Test_RunQuery_SendsPeerToken(t *testing.T) {
+	east := &fakePeer{responses: map[string]string{"Kinds": `["Pod"]`}}
+	server := httptest.NewServer(east)
+	defer server.Close()
+	fed, err := NewFederation([]Peer{{Name: "east", Url: server.URL + "/someContext", Token: "secret"}}, time.Second)
+	assert.Nil(t, err)
+
+	_, _, err = fed.RunQuery(context.Background(), "Kinds", url.Values{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Bearer secret"}, east.authHeaders)
+
+	// The token is not shown by the peers endpoint
+	bytes, err := json.Marshal(fed.Peers())
+	assert.Nil(t, err)
+	assert.NotContains(t, string(bytes), "secret")
+}
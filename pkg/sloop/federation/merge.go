@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package federation
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+)
+
+// Combines the responses of the peers which succeeded into one response
+type mergeFunc = func(results []peerResult, peerErrors []queries.PeerError) ([]byte, error)
+
+// Queries which can be fanned out to every peer
+var mergeFuncs = map[string]mergeFunc{
+	"EventHeatMap": mergeTimelines,
+	"Namespaces":   mergeNamespaces,
+	"Kinds":        mergeSortedStrings,
+	"Queries":      mergeSortedStrings,
+}
+
+// Concatenates the rows from every peer and tags each with the peer it came from.  The UI does the sorting
+func mergeTimelines(results []peerResult, peerErrors []queries.PeerError) ([]byte, error) {
+	merged := queries.TimelineRoot{Rows: []queries.TimelineRow{}, PeerErrors: peerErrors}
+	for _, result := range results {
+		var root queries.TimelineRoot
+		err := json.Unmarshal(result.body, &root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse response from peer %v: %v", result.peer.Name, err)
+		}
+		merged.ViewOpt = root.ViewOpt
+		for _, row := range root.Rows {
+			row.Cluster = result.peer.Name
+			merged.Rows = append(merged.Rows, row)
+		}
+	}
+	bytes, err := json.MarshalIndent(merged, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
+
+// Namespaces are sorted with AllNamespaces last, like queries.NamespaceQuery
+func mergeNamespaces(results []peerResult, peerErrors []queries.PeerError) ([]byte, error) {
+	namespaces, err := unionOfStrings(results)
+	if err != nil {
+		return nil, err
+	}
+	ret := []string{}
+	for _, namespace := range namespaces {
+		if namespace != queries.AllNamespaces {
+			ret = append(ret, namespace)
+		}
+	}
+	ret = append(ret, queries.AllNamespaces)
+	return marshalStrings(ret)
+}
+
+func mergeSortedStrings(results []peerResult, peerErrors []queries.PeerError) ([]byte, error) {
+	values, err := unionOfStrings(results)
+	if err != nil {
+		return nil, err
+	}
+	return marshalStrings(values)
+}
+
+// Returns the sorted union of the json string lists returned by the peers
+func unionOfStrings(results []peerResult) ([]string, error) {
+	seen := map[string]bool{}
+	ret := []string{}
+	for _, result := range results {
+		var values []string
+		err := json.Unmarshal(result.body, &values)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse response from peer %v: %v", result.peer.Name, err)
+		}
+		for _, value := range values {
+			if !seen[value] {
+				seen[value] = true
+				ret = append(ret, value)
+			}
+		}
+	}
+	sort.Strings(ret)
+	return ret, nil
+}
+
+func marshalStrings(values []string) ([]byte, error) {
+	bytes, err := json.MarshalIndent(values, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal json %v", err)
+	}
+	return bytes, nil
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package ingress
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+// Audit log lines can hold a whole object twice, so they can be much longer than the default scanner buffer
+const maxAuditLogLine = 64 * 1024 * 1024
+
+// The fields of an audit.k8s.io/v1 Event the import needs
+type auditEvent struct {
+	Stage     string `json:"stage"`
+	Verb      string `json:"verb"`
+	ObjectRef *struct {
+		Resource    string `json:"resource"`
+		Subresource string `json:"subresource"`
+	} `json:"objectRef"`
+	ResponseStatus *struct {
+		Code int `json:"code"`
+	} `json:"responseStatus"`
+	RequestObject            json.RawMessage `json:"requestObject"`
+	ResponseObject           json.RawMessage `json:"responseObject"`
+	RequestReceivedTimestamp time.Time       `json:"requestReceivedTimestamp"`
+	StageTimestamp           time.Time       `json:"stageTimestamp"`
+}
+
+var auditVerbToWatchType = map[string]typed.KubeWatchResult_WatchType{
+	"create": typed.KubeWatchResult_ADD,
+	"update": typed.KubeWatchResult_UPDATE,
+	"patch":  typed.KubeWatchResult_UPDATE,
+	"delete": typed.KubeWatchResult_DELETE,
+}
+
+// NewAuditLogSource imports kubernetes api server audit log files, which have one audit event per line.  Each
+// successful create, update, patch or delete logged at the Request or RequestResponse level is imported at the time
+// its response was sent, with the object from the response or else the request.  Reads, other subresources than
+// status and events without an object are skipped.  Files can be gzipped.
+func NewAuditLogSource(files []string, exclusionRules map[string][]any, redactionRules map[string][]RedactionRule, redactionHashKey []byte) (KubeResourceSource, error) {
+	return newFileImportSource(ImportSourceAuditLog, files, readAuditLog, exclusionRules, redactionRules, redactionHashKey)
+}
+
+func readAuditLog(r io.Reader, emit func(typed.KubeWatchResult) bool, skip func(reason string)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxAuditLogLine)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		event := auditEvent{}
+		err := json.Unmarshal(scanner.Bytes(), &event)
+		if err != nil {
+			skip(fmt.Sprintf("line %v does not parse: %v", lineNumber, err))
+			continue
+		}
+		result, err := auditEventToWatchResult(event)
+		if err != nil {
+			skip(fmt.Sprintf("line %v: %v", lineNumber, err))
+			continue
+		}
+		if !emit(result) {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+func auditEventToWatchResult(event auditEvent) (typed.KubeWatchResult, error) {
+	// Events are logged at each stage, and only the last one has the response
+	if event.Stage != "ResponseComplete" {
+		return typed.KubeWatchResult{}, fmt.Errorf("stage %v is not ResponseComplete", event.Stage)
+	}
+	watchType, ok := auditVerbToWatchType[event.Verb]
+	if !ok {
+		return typed.KubeWatchResult{}, fmt.Errorf("verb %v does not change an object", event.Verb)
+	}
+	if event.ObjectRef == nil {
+		return typed.KubeWatchResult{}, errors.New("event has no objectRef")
+	}
+	if event.ObjectRef.Subresource != "" && event.ObjectRef.Subresource != "status" {
+		return typed.KubeWatchResult{}, fmt.Errorf("subresource %v of %v is not imported", event.ObjectRef.Subresource, event.ObjectRef.Resource)
+	}
+	if event.ResponseStatus != nil && (event.ResponseStatus.Code < 200 || event.ResponseStatus.Code > 299) {
+		return typed.KubeWatchResult{}, fmt.Errorf("request failed with code %v", event.ResponseStatus.Code)
+	}
+	timestamp := event.StageTimestamp
+	if timestamp.IsZero() {
+		timestamp = event.RequestReceivedTimestamp
+	}
+
+	// The response is the object as stored.  A delete can respond with a Status instead, and the request is only the
+	// whole object for creates and updates, since a patch request is the patch
+	candidates := []json.RawMessage{event.ResponseObject}
+	if event.Verb == "create" || event.Verb == "update" {
+		candidates = append(candidates, event.RequestObject)
+	}
+	var lastErr error = errors.New("event has no object, it may be logged at the Metadata level")
+	for _, candidate := range candidates {
+		if len(candidate) == 0 || string(candidate) == "null" {
+			continue
+		}
+		result, err := newImportedWatchResult(candidate, watchType, timestamp)
+		if err == nil && result.Kind != "Status" {
+			return result, nil
+		}
+		if err != nil {
+			lastErr = err
+		}
+	}
+	return typed.KubeWatchResult{}, lastErr
+}
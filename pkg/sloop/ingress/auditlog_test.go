@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package ingress
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+const someAuditLog = `{"kind":"Event","apiVersion":"audit.k8s.io/v1","level":"RequestResponse","stage":"ResponseStarted","verb":"create","objectRef":{"resource":"pods","namespace":"ns","name":"p1"},"stageTimestamp":"2019-03-04T05:06:00.000000Z"}
+{"kind":"Event","apiVersion":"audit.k8s.io/v1","level":"RequestResponse","stage":"ResponseComplete","verb":"create","objectRef":{"resource":"pods","namespace":"ns","name":"p1"},"responseStatus":{"code":201},"responseObject":{"kind":"Pod","apiVersion":"v1","metadata":{"name":"p1","namespace":"ns","uid":"u1"}},"requestReceivedTimestamp":"2019-03-04T05:06:00.000000Z","stageTimestamp":"2019-03-04T05:06:01.123456Z"}
+{"kind":"Event","apiVersion":"audit.k8s.io/v1","level":"RequestResponse","stage":"ResponseComplete","verb":"get","objectRef":{"resource":"pods","namespace":"ns","name":"p1"},"responseStatus":{"code":200},"responseObject":{"kind":"Pod","apiVersion":"v1","metadata":{"name":"p1","namespace":"ns"}},"stageTimestamp":"2019-03-04T05:06:02.000000Z"}
+{"kind":"Event","apiVersion":"audit.k8s.io/v1","level":"RequestResponse","stage":"ResponseComplete","verb":"patch","objectRef":{"resource":"pods","namespace":"ns","name":"p1","subresource":"status"},"responseStatus":{"code":200},"requestObject":{"status":{"phase":"Running"}},"responseObject":{"kind":"Pod","apiVersion":"v1","metadata":{"name":"p1","namespace":"ns","uid":"u1"},"status":{"phase":"Running"}},"stageTimestamp":"2019-03-04T05:06:03.000000Z"}
+{"kind":"Event","apiVersion":"audit.k8s.io/v1","level":"Request","stage":"ResponseComplete","verb":"update","objectRef":{"resource":"pods","namespace":"ns","name":"p1"},"responseStatus":{"code":409},"requestObject":{"kind":"Pod","apiVersion":"v1","metadata":{"name":"p1","namespace":"ns"}},"stageTimestamp":"2019-03-04T05:06:04.000000Z"}
+{"kind":"Event","apiVersion":"audit.k8s.io/v1","level":"Metadata","stage":"ResponseComplete","verb":"delete","objectRef":{"resource":"pods","namespace":"ns","name":"p2"},"responseStatus":{"code":200},"stageTimestamp":"2019-03-04T05:06:05.000000Z"}
+not json
+{"kind":"Event","apiVersion":"audit.k8s.io/v1","level":"RequestResponse","stage":"ResponseComplete","verb":"delete","objectRef":{"resource":"pods","namespace":"ns","name":"p1"},"responseStatus":{"code":200},"responseObject":{"kind":"Status","apiVersion":"v1","metadata":{},"status":"Success"},"stageTimestamp":"2019-03-04T05:06:06.000000Z"}
+{"kind":"Event","apiVersion":"audit.k8s.io/v1","level":"RequestResponse","stage":"ResponseComplete","verb":"delete","objectRef":{"resource":"pods","namespace":"ns","name":"p1"},"responseStatus":{"code":200},"responseObject":{"kind":"Pod","apiVersion":"v1","metadata":{"name":"p1","namespace":"ns","uid":"u1","deletionTimestamp":"2019-03-04T05:06:00Z"}},"stageTimestamp":"2019-03-04T05:06:07.000000Z"}
+`
+
+func helper_writeImportFile(t *testing.T, name string, content string, gzipped bool) string {
+	dir, err := ioutil.TempDir("", "import")
+	assert.Nil(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	filename := filepath.Join(dir, name)
+	file, err := os.Create(filename)
+	assert.Nil(t, err)
+	defer file.Close()
+	if gzipped {
+		gz := gzip.NewWriter(file)
+		_, err = gz.Write([]byte(content))
+		assert.Nil(t, err)
+		assert.Nil(t, gz.Close())
+	} else {
+		_, err = file.Write([]byte(content))
+		assert.Nil(t, err)
+	}
+	return filename
+}
+
+// Runs a source to the end and returns what it imported
+func helper_runImportSource(t *testing.T, source KubeResourceSource) []typed.KubeWatchResult {
+	outChan, err := source.Init()
+	assert.Nil(t, err)
+	var results []typed.KubeWatchResult
+	for result := range outChan {
+		results = append(results, result)
+	}
+	source.Stop()
+	return results
+}
+
+func Test_AuditLogSource(t *testing.T) {
+	for _, gzipped := range []bool{false, true} {
+		filename := helper_writeImportFile(t, "audit.log", someAuditLog, gzipped)
+		source, err := NewAuditLogSource([]string{filename}, nil, nil, nil)
+		assert.Nil(t, err)
+		results := helper_runImportSource(t, source)
+
+		assert.Len(t, results, 3)
+		assert.Equal(t, typed.KubeWatchResult_ADD, results[0].WatchType)
+		assert.Equal(t, "Pod", results[0].Kind)
+		assert.Equal(t, `{"kind":"Pod","apiVersion":"v1","metadata":{"name":"p1","namespace":"ns","uid":"u1"}}`, results[0].Payload)
+		ts, err := ptypes.Timestamp(results[0].Timestamp)
+		assert.Nil(t, err)
+		assert.Equal(t, time.Date(2019, 3, 4, 5, 6, 1, 123456000, time.UTC), ts)
+
+		assert.Equal(t, typed.KubeWatchResult_UPDATE, results[1].WatchType)
+		assert.Contains(t, results[1].Payload, `"phase":"Running"`)
+		assert.Equal(t, typed.KubeWatchResult_DELETE, results[2].WatchType)
+	}
+}
+
+func Test_AuditLogSource_ExclusionAndRedaction(t *testing.T) {
+	filename := helper_writeImportFile(t, "audit.log", someAuditLog, false)
+	exclusionRules := map[string][]any{"Pod": {map[string]any{"==": []any{map[string]any{"var": "status.phase"}, "Running"}}}}
+	redactionRules := map[string][]RedactionRule{"Pod": {{Path: "$.apiVersion", Action: RedactDrop}}}
+	source, err := NewAuditLogSource([]string{filename}, exclusionRules, redactionRules, nil)
+	assert.Nil(t, err)
+	results := helper_runImportSource(t, source)
+
+	assert.Len(t, results, 2)
+	for _, result := range results {
+		assert.False(t, strings.Contains(result.Payload, "apiVersion"))
+	}
+}
+
+func Test_AuditLogSource_MissingFile(t *testing.T) {
+	source, err := NewAuditLogSource([]string{"/nonexistent/audit.log"}, nil, nil, nil)
+	assert.Nil(t, err)
+	_, err = source.Init()
+	assert.NotNil(t, err)
+}
+
+func Test_AuditLogSource_Stop(t *testing.T) {
+	filename := helper_writeImportFile(t, "audit.log", strings.Repeat(someAuditLog, 1000), false)
+	source, err := NewAuditLogSource([]string{filename}, nil, nil, nil)
+	assert.Nil(t, err)
+	outChan, err := source.Init()
+	assert.Nil(t, err)
+	<-outChan
+	// Nothing reads the rest, so the import is blocked on a full channel until it is stopped
+	source.Stop()
+	count := 0
+	for range outChan {
+		count++
+	}
+	assert.True(t, count < 3000)
+}
@@ -6,18 +6,41 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/salesforce/sloop/pkg/sloop/backup"
 	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
 )
 
-// DatabaseRestore restores the DB from a backup file created by webserver.backupHandler
-func DatabaseRestore(db badgerwrap.DB, filename string) error {
+// DatabaseRestore restores the DB from backup files created by webserver.backupHandler or the backup scheduler,
+// loaded in the order given: a full backup followed by incremental ones.  The files are checked to form a chain first.
+// An encrypted backup is decrypted with whichever of keys it was made with, and a compressed backup is decompressed.
+func DatabaseRestore(db badgerwrap.DB, filenames []string, keys [][]byte) error {
+	err := backup.CheckFiles(filenames, keys)
+	if err != nil {
+		return errors.Wrap(err, "database restore files are not a backup chain")
+	}
+	for _, filename := range filenames {
+		err = restoreFile(db, filename, keys)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func restoreFile(db badgerwrap.DB, filename string, keys [][]byte) error {
 	file, err := os.Open(filename)
 	if err != nil {
 		return errors.Wrapf(err, "failed to load database restore file: %q", filename)
 	}
 	defer file.Close()
 
-	err = db.Load(file, runtime.NumCPU())
+	reader, err := backup.OpenReader(file, keys)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read database restore file: %q", filename)
+	}
+	defer reader.Close()
+
+	err = db.Load(reader, runtime.NumCPU())
 	if err != nil {
 		return errors.Wrapf(err, "failed to restore database from file: %q", filename)
 	}
@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package ingress
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	badger "github.com/dgraph-io/badger/v2"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+var someBackupKey = []byte("0123456789abcdef")
+
+func helper_openBadger(t *testing.T, dir string) badgerwrap.DB {
+	db, err := (&badgerwrap.BadgerFactory{}).Open(badger.DefaultOptions(dir).WithLogger(nil))
+	assert.Nil(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// Writes a backup of a db with one key, compressed and encrypted like the backup handler does when asked to
+func helper_writeBackup(t *testing.T, dir string, compress bool, key []byte) string {
+	db := helper_openBadger(t, filepath.Join(dir, "source"))
+	err := db.Update(func(txn badgerwrap.Txn) error { return txn.Set([]byte("/someKey"), []byte("someValue")) })
+	assert.Nil(t, err)
+
+	var backup bytes.Buffer
+	var envelope *common.EnvelopeWriter
+	var zw *zstd.Encoder
+	var out io.Writer = &backup
+	if key != nil {
+		envelope, err = common.NewEnvelopeWriter(&backup, key)
+		assert.Nil(t, err)
+		out = envelope
+	}
+	if compress {
+		zw, err = zstd.NewWriter(out)
+		assert.Nil(t, err)
+		out = zw
+	}
+	_, err = db.Backup(out, 0)
+	assert.Nil(t, err)
+	if zw != nil {
+		assert.Nil(t, zw.Close())
+	}
+	if envelope != nil {
+		assert.Nil(t, envelope.Close())
+	}
+
+	file := filepath.Join(dir, "backup")
+	assert.Nil(t, ioutil.WriteFile(file, backup.Bytes(), 0600))
+	return file
+}
+
+func Test_DatabaseRestore_Formats(t *testing.T) {
+	testCases := map[string]struct {
+		compress bool
+		key      []byte
+	}{
+		"plain":     {false, nil},
+		"zstd":      {true, nil},
+		"encrypted": {true, someBackupKey},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "restore")
+			assert.Nil(t, err)
+			defer os.RemoveAll(dir)
+			backupFile := helper_writeBackup(t, dir, tc.compress, tc.key)
+
+			db := helper_openBadger(t, filepath.Join(dir, "restored"))
+			// The current key comes first, the backup was made with the previous one
+			err = DatabaseRestore(db, []string{backupFile}, [][]byte{[]byte("fedcba9876543210"), someBackupKey})
+			assert.Nil(t, err)
+			err = db.View(func(txn badgerwrap.Txn) error {
+				_, err := txn.Get([]byte("/someKey"))
+				return err
+			})
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func Test_DatabaseRestore_EncryptedWithoutKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "restore")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	backupFile := helper_writeBackup(t, dir, true, someBackupKey)
+
+	db := helper_openBadger(t, filepath.Join(dir, "restored"))
+	err = DatabaseRestore(db, []string{backupFile}, nil)
+	assert.Contains(t, err.Error(), "no backup encryption key")
+}
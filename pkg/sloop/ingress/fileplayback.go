@@ -8,29 +8,409 @@
 package ingress
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io"
 	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/ghodss/yaml"
 	"github.com/golang/glog"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+
 	"github.com/salesforce/sloop/pkg/sloop/store/typed"
 )
 
+// Frames of a zstd stream start with this
+var zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+// A single record can hold a whole object, so it can be much longer than the default scanner buffer
+const maxRecordingRecord = 64 * 1024 * 1024
+
+// PlayFile plays a recording and each of its segments in order, as fast as they can be read and with their original
+// timestamps.  The recording is read one record at a time, and can be json lines or protobuf, compressed or not, or a
+// yaml file from older versions which is read whole.  A segment which ends early, like the last one of a recorder
+// which crashed, is played up to where it ends.
 func PlayFile(outChan chan typed.KubeWatchResult, filename string) error {
-	b, err := ioutil.ReadFile(filename)
+	total, err := playRecording(filename, func(record typed.KubeWatchResult) bool {
+		outChan <- record
+		return true
+	})
 	if err != nil {
-		panic(err)
+		return err
 	}
-	var playbackFile KubePlaybackFile
-	err = yaml.Unmarshal(b, &playbackFile)
+	glog.Infof("Done writing %v kubeWatch events to channel", total)
+	return nil
+}
+
+type PlaybackConfig struct {
+	// Records are played with their original gaps divided by this.  Zero plays them as fast as they can be read
+	Speed float64
+	// Moves timestamps, including the ones in payloads, to the time each record is played.  Needs a speed
+	RebaseToNow bool
+	// Plays the recording again from StartOffset each time it ends.  Needs a speed
+	Loop bool
+	// Skips the records from less than this after the first record of the recording
+	StartOffset time.Duration
+	// Ends the playback at the first record from this long or longer after the first record.  Zero plays to the end
+	StopOffset time.Duration
+}
+
+type filePlayback struct {
+	filename string
+	config   PlaybackConfig
+	outChan  chan typed.KubeWatchResult
+	stopChan chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewFilePlaybackSource plays a recording like PlayFile, but paced, shifted and looped as config says
+func NewFilePlaybackSource(filename string, config PlaybackConfig) (KubeResourceSource, error) {
+	if config.Speed < 0 || config.StartOffset < 0 || config.StopOffset < 0 {
+		return nil, errors.New("playback speed and offsets can not be negative")
+	}
+	if config.Speed == 0 && (config.RebaseToNow || config.Loop) {
+		return nil, errors.New("rebasing and looping playback need a speed")
+	}
+	if config.StopOffset > 0 && config.StopOffset <= config.StartOffset {
+		return nil, errors.New("playback stop offset must be after the start offset")
+	}
+	return &filePlayback{filename: filename, config: config, stopChan: make(chan struct{})}, nil
+}
+
+// Init checks the recording can be read and starts playing it.  The channel is closed when the playback ends, which
+// is never for a loop until Stop is called.
+func (p *filePlayback) Init() (chan typed.KubeWatchResult, error) {
+	_, err := os.Stat(p.filename)
 	if err != nil {
-		return err
+		return nil, errors.Wrap(err, "can not play back file")
 	}
+	p.outChan = make(chan typed.KubeWatchResult, 1000)
+	p.wg.Add(1)
+	go p.run()
+	return p.outChan, nil
+}
 
-	glog.Infof("Loaded %v resources from file source %v", len(playbackFile.Data), filename)
+func (p *filePlayback) Stop() {
+	p.stopOnce.Do(func() { close(p.stopChan) })
+	p.wg.Wait()
+}
 
-	for _, watchRecord := range playbackFile.Data {
-		outChan <- watchRecord
+func (p *filePlayback) run() {
+	defer p.wg.Done()
+	defer close(p.outChan)
+	for pass := 1; ; pass++ {
+		clock := &playbackClock{config: p.config}
+		_, err := playRecording(p.filename, func(record typed.KubeWatchResult) bool {
+			return p.play(clock, record)
+		})
+		if err != nil {
+			glog.Errorf("Failed to play back %v: %v", p.filename, err)
+			return
+		}
+		glog.Infof("Finished pass %v of playing back %v", pass, p.filename)
+		select {
+		case <-p.stopChan:
+			return
+		default:
+		}
+		// A loop which plays nothing would only spin
+		if !p.config.Loop || clock.base.IsZero() {
+			return
+		}
 	}
-	glog.Infof("Done writing kubeWatch events to channel")
-	return nil
+}
+
+// Waits until the record is due and sends it.  Returns false to end the pass
+func (p *filePlayback) play(clock *playbackClock, record typed.KubeWatchResult) bool {
+	timestamp, err := ptypes.Timestamp(record.Timestamp)
+	if err != nil {
+		glog.Warningf("Skipping a played back %v with a bad timestamp: %v", record.Kind, err)
+		return true
+	}
+	play, more := clock.schedule(timestamp)
+	if !more {
+		return false
+	}
+	if !play {
+		return true
+	}
+	due := clock.due(timestamp)
+	if wait := time.Until(due); wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-p.stopChan:
+			timer.Stop()
+			return false
+		}
+	}
+	if p.config.RebaseToNow {
+		record.Timestamp, _ = ptypes.TimestampProto(due)
+		payload, err := rebasePayloadTimestamps(record.Payload, clock.due)
+		if err != nil {
+			glog.Warningf("Playing back a %v without rebasing its payload: %v", record.Kind, err)
+		} else {
+			record.Payload = payload
+		}
+	}
+	select {
+	case p.outChan <- record:
+		return true
+	case <-p.stopChan:
+		return false
+	}
+}
+
+// Maps the timestamps of one pass through a recording to when they are played
+type playbackClock struct {
+	config PlaybackConfig
+	// The timestamp of the first record of the recording, which the offsets are from
+	first time.Time
+	// The timestamp of the first record played, and when it was played
+	base      time.Time
+	wallStart time.Time
+}
+
+// Returns whether a record with this timestamp is played, and false for more once the stop offset is reached
+func (c *playbackClock) schedule(timestamp time.Time) (play bool, more bool) {
+	if c.first.IsZero() {
+		c.first = timestamp
+	}
+	offset := timestamp.Sub(c.first)
+	if c.config.StopOffset > 0 && offset >= c.config.StopOffset {
+		return false, false
+	}
+	if offset < c.config.StartOffset {
+		return false, true
+	}
+	if c.base.IsZero() {
+		c.base = timestamp
+		c.wallStart = time.Now()
+	}
+	return true, true
+}
+
+// Returns when a timestamp is played.  Without a speed everything is played right away
+func (c *playbackClock) due(timestamp time.Time) time.Time {
+	if c.config.Speed == 0 {
+		return time.Now()
+	}
+	return c.wallStart.Add(time.Duration(float64(timestamp.Sub(c.base)) / c.config.Speed))
+}
+
+// The timestamps in an object which say when something happened to it, as opposed to config like cron schedules
+var rebasedPayloadTimestamps = [][]string{
+	{"metadata", "creationTimestamp"},
+	{"metadata", "deletionTimestamp"},
+	{"firstTimestamp"},
+	{"lastTimestamp"},
+	{"eventTime"},
+}
+
+// Moves the timestamps of a payload with rebase, keeping the precision they were written with
+func rebasePayloadTimestamps(payload string, rebase func(time.Time) time.Time) (string, error) {
+	// UseNumber keeps large integers like resourceVersions exact
+	decoder := json.NewDecoder(strings.NewReader(payload))
+	decoder.UseNumber()
+	var obj map[string]interface{}
+	err := decoder.Decode(&obj)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse payload")
+	}
+	changed := false
+	for _, path := range rebasedPayloadTimestamps {
+		parent := obj
+		for _, field := range path[:len(path)-1] {
+			parent, _ = parent[field].(map[string]interface{})
+		}
+		value, _ := parent[path[len(path)-1]].(string)
+		if value == "" {
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			continue
+		}
+		layout := time.RFC3339
+		if strings.Contains(value, ".") {
+			layout = "2006-01-02T15:04:05.000000Z07:00"
+		}
+		parent[path[len(path)-1]] = rebase(ts).UTC().Format(layout)
+		changed = true
+	}
+	if !changed {
+		return payload, nil
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	err = encoder.Encode(obj)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal rebased payload")
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// Plays a recording and each of its segments in order until emit returns false, and returns how many records were
+// played
+func playRecording(filename string, emit func(typed.KubeWatchResult) bool) (int, error) {
+	total := 0
+	for segment := 0; ; segment++ {
+		segmentName := recordingSegmentName(filename, segment)
+		if segment > 0 {
+			_, err := os.Stat(segmentName)
+			if os.IsNotExist(err) {
+				return total, nil
+			}
+		}
+		reader, err := openRecording(segmentName)
+		if err != nil {
+			return total, err
+		}
+		count := 0
+		more := true
+		for more {
+			record, err := reader.next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				glog.Warningf("Recording %v ends early after %v records: %v", segmentName, count, err)
+				break
+			}
+			more = emit(record)
+			count++
+		}
+		reader.close()
+		glog.Infof("Loaded %v resources from file source %v", count, segmentName)
+		total += count
+		if !more {
+			return total, nil
+		}
+	}
+}
+
+// Reads the records of one recording segment in order
+type recordingReader struct {
+	file    *os.File
+	decoder *zstd.Decoder
+	// Returns io.EOF after the last record
+	next func() (typed.KubeWatchResult, error)
+}
+
+func openRecording(filename string) (*recordingReader, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	rr := &recordingReader{file: file}
+	reader := bufio.NewReader(file)
+	header, _ := reader.Peek(len(recordingProtobufMagic))
+	if bytes.HasPrefix(header, zstdMagic) {
+		rr.decoder, err = zstd.NewReader(reader)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		reader = bufio.NewReader(rr.decoder)
+		header, err = reader.Peek(len(recordingProtobufMagic))
+		if len(header) == 0 && err != nil && err != io.EOF {
+			// Cut short before the first compressed block, so there is nothing to play
+			rr.next = func() (typed.KubeWatchResult, error) { return typed.KubeWatchResult{}, err }
+			return rr, nil
+		}
+	}
+
+	switch {
+	case bytes.Equal(header, recordingProtobufMagic):
+		reader.Discard(len(recordingProtobufMagic))
+		rr.next = protobufRecordReader(reader)
+	case bytes.HasPrefix(header, []byte("{")):
+		rr.next = jsonLinesRecordReader(reader)
+	default:
+		rr.next, err = yamlRecordReader(reader)
+		if err != nil {
+			rr.close()
+			return nil, errors.Wrapf(err, "%v is not a recording", filename)
+		}
+	}
+	return rr, nil
+}
+
+func (rr *recordingReader) close() {
+	if rr.decoder != nil {
+		rr.decoder.Close()
+	}
+	rr.file.Close()
+}
+
+func protobufRecordReader(reader *bufio.Reader) func() (typed.KubeWatchResult, error) {
+	return func() (typed.KubeWatchResult, error) {
+		length, err := binary.ReadUvarint(reader)
+		if err != nil {
+			return typed.KubeWatchResult{}, err
+		}
+		if length > maxRecordingRecord {
+			return typed.KubeWatchResult{}, errors.Errorf("record of %v bytes is too long", length)
+		}
+		data := make([]byte, length)
+		_, err = io.ReadFull(reader, data)
+		if err != nil {
+			return typed.KubeWatchResult{}, errors.Wrap(err, "record is cut short")
+		}
+		record := typed.KubeWatchResult{}
+		err = proto.Unmarshal(data, &record)
+		return record, err
+	}
+}
+
+func jsonLinesRecordReader(reader *bufio.Reader) func() (typed.KubeWatchResult, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(nil, maxRecordingRecord)
+	return func() (typed.KubeWatchResult, error) {
+		for scanner.Scan() {
+			if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+				continue
+			}
+			record := typed.KubeWatchResult{}
+			err := jsonpb.Unmarshal(bytes.NewReader(scanner.Bytes()), &record)
+			return record, err
+		}
+		if scanner.Err() != nil {
+			return typed.KubeWatchResult{}, scanner.Err()
+		}
+		return typed.KubeWatchResult{}, io.EOF
+	}
+}
+
+// Yaml recordings from older versions are one document, so they can only be read whole
+func yamlRecordReader(reader io.Reader) (func() (typed.KubeWatchResult, error), error) {
+	b, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	var playbackFile KubePlaybackFile
+	err = yaml.Unmarshal(b, &playbackFile)
+	if err != nil {
+		return nil, err
+	}
+	return func() (typed.KubeWatchResult, error) {
+		if len(playbackFile.Data) == 0 {
+			return typed.KubeWatchResult{}, io.EOF
+		}
+		record := playbackFile.Data[0]
+		playbackFile.Data = playbackFile.Data[1:]
+		return record, nil
+	}, nil
 }
@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package ingress
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+var someFirstRecordTime = time.Date(2019, 3, 4, 5, 6, 0, 0, time.UTC)
+
+func Test_PlaybackClock_Offsets(t *testing.T) {
+	clock := &playbackClock{config: PlaybackConfig{Speed: 2, StartOffset: 10 * time.Second, StopOffset: 20 * time.Second}}
+
+	play, more := clock.schedule(someFirstRecordTime)
+	assert.False(t, play)
+	assert.True(t, more)
+	play, more = clock.schedule(someFirstRecordTime.Add(10 * time.Second))
+	assert.True(t, play)
+	assert.True(t, more)
+	play, more = clock.schedule(someFirstRecordTime.Add(20 * time.Second))
+	assert.False(t, play)
+	assert.False(t, more)
+
+	// Gaps from the first record played are halved
+	assert.Equal(t, clock.wallStart, clock.due(someFirstRecordTime.Add(10*time.Second)))
+	assert.Equal(t, clock.wallStart.Add(3*time.Second), clock.due(someFirstRecordTime.Add(16*time.Second)))
+}
+
+func Test_RebasePayloadTimestamps(t *testing.T) {
+	payload := `{"metadata":{"name":"p1.123","creationTimestamp":"2019-03-04T05:06:00Z","resourceVersion":"12345678901234567890"},"lastTimestamp":"2019-03-04T05:06:30Z","eventTime":"2019-03-04T05:06:30.123456Z","reason":"<Pulled>"}`
+	rebase := func(ts time.Time) time.Time { return ts.Add(time.Hour) }
+
+	actual, err := rebasePayloadTimestamps(payload, rebase)
+	assert.Nil(t, err)
+	expected := `{"eventTime":"2019-03-04T06:06:30.123456Z","lastTimestamp":"2019-03-04T06:06:30Z","metadata":{"creationTimestamp":"2019-03-04T06:06:00Z","name":"p1.123","resourceVersion":"12345678901234567890"},"reason":"<Pulled>"}`
+	assert.Equal(t, expected, actual)
+
+	// Objects without timestamps are left as they are
+	actual, err = rebasePayloadTimestamps(`{"metadata": {"name": "p1"}}`, rebase)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"metadata": {"name": "p1"}}`, actual)
+}
+
+func Test_FilePlaybackSource_RebaseAndLoop(t *testing.T) {
+	filename := helper_recordingFilename(t)
+	records := helper_someWatchResults(10, 0)
+	helper_record(t, filename, RecorderConfig{Format: RecordFormatJsonLines}, records)
+
+	// The records are a second apart, so at this speed each pass takes about 9ms
+	config := PlaybackConfig{Speed: 1000, RebaseToNow: true, Loop: true, StartOffset: 2 * time.Second}
+	source, err := NewFilePlaybackSource(filename, config)
+	assert.Nil(t, err)
+	before := time.Now()
+	outChan, err := source.Init()
+	assert.Nil(t, err)
+
+	var previous time.Time
+	for i := 0; i < 20; i++ {
+		record := <-outChan
+		ts, err := ptypes.Timestamp(record.Timestamp)
+		assert.Nil(t, err)
+		assert.False(t, ts.Before(before))
+		assert.False(t, ts.Before(previous))
+		previous = ts
+		// The first two records of each pass are skipped
+		assert.Contains(t, record.Payload, []string{"p2", "p3", "p4", "p5", "p6", "p7", "p8", "p9"}[i%8])
+	}
+	assert.True(t, previous.Sub(before) >= 14*time.Millisecond)
+
+	source.Stop()
+	for range outChan {
+	}
+}
+
+func Test_FilePlaybackSource_StopOffset(t *testing.T) {
+	filename := helper_recordingFilename(t)
+	helper_record(t, filename, RecorderConfig{Format: RecordFormatProtobuf}, helper_someWatchResults(10, 0))
+
+	source, err := NewFilePlaybackSource(filename, PlaybackConfig{StopOffset: 5 * time.Second})
+	assert.Nil(t, err)
+	results := helper_runImportSource(t, source)
+	assert.Len(t, results, 5)
+	// Without rebasing the original timestamps are kept
+	assert.Equal(t, helper_someWatchResults(1, 0)[0].String(), results[0].String())
+}
+
+func Test_NewFilePlaybackSource_BadConfig(t *testing.T) {
+	for _, config := range []PlaybackConfig{
+		{Speed: -1},
+		{Loop: true},
+		{RebaseToNow: true},
+		{StartOffset: time.Minute, StopOffset: time.Minute},
+	} {
+		_, err := NewFilePlaybackSource("record", config)
+		assert.NotNil(t, err)
+	}
+}
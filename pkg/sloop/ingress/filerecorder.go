@@ -8,24 +8,79 @@
 package ingress
 
 import (
-	"io/ioutil"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
 	"sync"
+	"time"
 
-	"github.com/ghodss/yaml"
 	"github.com/golang/glog"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
 	"github.com/salesforce/sloop/pkg/sloop/store/typed"
 )
 
+// Recordings are zstd compressed streams of watch results, written as they arrive so a long recording does not have
+// to fit in memory and a crash only loses what was not flushed yet.  A recording can be split into segments, which
+// are named after the record file with the segment number appended, like record.yaml, record.yaml.1, record.yaml.2.
+const (
+	// One json object per line, in the protobuf json mapping
+	RecordFormatJsonLines = "jsonl"
+	// recordingProtobufMagic followed by each result as a varint length and the marshalled protobuf
+	RecordFormatProtobuf = "protobuf"
+)
+
+// Protobuf recordings start with this, so they can not be mistaken for json lines
+var recordingProtobufMagic = []byte("SLOOPPB1")
+
+var (
+	metricIngressRecordedcount = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_ingress_recordedcount"})
+	metricIngressRecordedbytes = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_ingress_recordedbytes"})
+	metricIngressRecorderrors  = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_ingress_recorderrors"})
+)
+
+type RecorderConfig struct {
+	Format string
+	// A new segment is started once the current one has this many compressed bytes.  Zero disables it
+	RotateSizeBytes int64
+	// A new segment is started once the current one is this old.  Zero disables it
+	RotateInterval time.Duration
+	// Buffered records are written out this often.  Zero only writes them when a compressed block is full
+	FlushInterval time.Duration
+}
+
 type FileRecorder struct {
-	inChan   chan typed.KubeWatchResult
-	data     []typed.KubeWatchResult
 	filename string
-	wg       sync.WaitGroup // Ensure we don't call close at the same time we are taking in events
+	config   RecorderConfig
+	inChan   chan typed.KubeWatchResult
+	segment  int
+	file     *os.File
+	written  *countingWriter
+	encoder  *zstd.Encoder
+	records  int
+	// The first error, which Close returns.  Recording carries on after an error so one bad write does not end it
+	err error
+	wg  sync.WaitGroup // Ensure we don't call close at the same time we are taking in events
 }
 
-func NewFileRecorder(filename string, inChan chan typed.KubeWatchResult) *FileRecorder {
-	fr := &FileRecorder{filename: filename, inChan: inChan}
-	return fr
+// NewFileRecorder opens the first segment of a recording.  Segments which already exist are never overwritten, so
+// a restarted recorder carries on in the next free segment.
+func NewFileRecorder(filename string, inChan chan typed.KubeWatchResult, config RecorderConfig) (*FileRecorder, error) {
+	if config.Format != RecordFormatJsonLines && config.Format != RecordFormatProtobuf {
+		return nil, fmt.Errorf("unknown record format %q", config.Format)
+	}
+	fr := &FileRecorder{filename: filename, inChan: inChan, config: config}
+	err := fr.openSegment()
+	if err != nil {
+		return nil, err
+	}
+	return fr, nil
 }
 
 func (fr *FileRecorder) Start() {
@@ -34,24 +89,169 @@ func (fr *FileRecorder) Start() {
 }
 
 func (fr *FileRecorder) listen(inChan chan typed.KubeWatchResult) {
+	defer fr.wg.Done()
+	var flushChan, rotateChan <-chan time.Time
+	if fr.config.FlushInterval > 0 {
+		ticker := time.NewTicker(fr.config.FlushInterval)
+		defer ticker.Stop()
+		flushChan = ticker.C
+	}
+	if fr.config.RotateInterval > 0 {
+		ticker := time.NewTicker(fr.config.RotateInterval)
+		defer ticker.Stop()
+		rotateChan = ticker.C
+	}
 	for {
-		newRecord, more := <-inChan
-		if !more {
-			fr.wg.Done()
-			return
+		select {
+		case newRecord, more := <-inChan:
+			if !more {
+				fr.setErr(fr.closeSegment())
+				return
+			}
+			fr.setErr(fr.write(&newRecord))
+			if fr.config.RotateSizeBytes > 0 && fr.written.n >= fr.config.RotateSizeBytes {
+				fr.setErr(fr.rotate())
+			}
+		case <-flushChan:
+			fr.setErr(fr.flush())
+		case <-rotateChan:
+			// An idle recording is not split into empty segments
+			if fr.records > 0 {
+				fr.setErr(fr.rotate())
+			}
 		}
-		fr.data = append(fr.data, newRecord)
 	}
 }
 
+// Close waits for the input channel to be closed and everything in it to be written, and returns the first error
+// the recording had
 func (fr *FileRecorder) Close() error {
 	fr.wg.Wait()
-	f := KubePlaybackFile{Data: fr.data}
-	byteData, err := yaml.Marshal(f)
+	return fr.err
+}
+
+func (fr *FileRecorder) setErr(err error) {
+	if err == nil {
+		return
+	}
+	glog.Errorf("Failed to record to %v: %v", recordingSegmentName(fr.filename, fr.segment), err)
+	metricIngressRecorderrors.Inc()
+	if fr.err == nil {
+		fr.err = err
+	}
+}
+
+func (fr *FileRecorder) write(record *typed.KubeWatchResult) error {
+	if fr.encoder == nil {
+		return errors.New("no segment is open")
+	}
+	var data []byte
+	switch fr.config.Format {
+	case RecordFormatJsonLines:
+		line, err := (&jsonpb.Marshaler{}).MarshalToString(record)
+		if err != nil {
+			return err
+		}
+		data = append([]byte(line), '\n')
+	case RecordFormatProtobuf:
+		b, err := proto.Marshal(record)
+		if err != nil {
+			return err
+		}
+		data = binary.AppendUvarint(nil, uint64(len(b)))
+		data = append(data, b...)
+	}
+	_, err := fr.encoder.Write(data)
 	if err != nil {
 		return err
 	}
-	err = ioutil.WriteFile(fr.filename, byteData, 0755)
-	glog.Infof("Wrote %v records to %v. err %v", len(fr.data), fr.filename, err)
+	fr.records++
+	metricIngressRecordedcount.Inc()
+	metricIngressRecordedbytes.Add(float64(len(data)))
+	return nil
+}
+
+func (fr *FileRecorder) flush() error {
+	if fr.encoder == nil {
+		return nil
+	}
+	err := fr.encoder.Flush()
+	if err != nil {
+		return err
+	}
+	return fr.file.Sync()
+}
+
+func (fr *FileRecorder) rotate() error {
+	err := fr.closeSegment()
+	if err != nil {
+		return err
+	}
+	fr.segment++
+	return fr.openSegment()
+}
+
+// Opens the first segment from fr.segment on which does not exist yet
+func (fr *FileRecorder) openSegment() error {
+	for {
+		filename := recordingSegmentName(fr.filename, fr.segment)
+		file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if os.IsExist(err) {
+			fr.segment++
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		fr.file = file
+		fr.written = &countingWriter{w: file}
+		fr.encoder, err = zstd.NewWriter(fr.written)
+		if err != nil {
+			file.Close()
+			return errors.Wrap(err, "failed to configure compression")
+		}
+		fr.records = 0
+		if fr.config.Format == RecordFormatProtobuf {
+			_, err = fr.encoder.Write(recordingProtobufMagic)
+			if err != nil {
+				return err
+			}
+		}
+		glog.Infof("Recording watch data to %v", filename)
+		return nil
+	}
+}
+
+func (fr *FileRecorder) closeSegment() error {
+	if fr.encoder == nil {
+		return nil
+	}
+	filename := recordingSegmentName(fr.filename, fr.segment)
+	err := fr.encoder.Close()
+	closeErr := fr.file.Close()
+	fr.encoder = nil
+	if err == nil {
+		err = closeErr
+	}
+	glog.Infof("Wrote %v records to %v. err %v", fr.records, filename, err)
 	return err
 }
+
+func recordingSegmentName(filename string, segment int) string {
+	if segment == 0 {
+		return filename
+	}
+	return fmt.Sprintf("%v.%v", filename, segment)
+}
+
+// Counts the bytes written to a segment, since the zstd encoder does not say how much it has compressed
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package ingress
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+func helper_recordingFilename(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "recording")
+	assert.Nil(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return filepath.Join(dir, "record")
+}
+
+// Random filler does not compress, so recordings with it write out compressed blocks while recording
+func helper_someWatchResults(count int, fillerBytes int) []typed.KubeWatchResult {
+	random := rand.New(rand.NewSource(1))
+	var results []typed.KubeWatchResult
+	for i := 0; i < count; i++ {
+		ts, _ := ptypes.TimestampProto(time.Date(2019, 3, 4, 5, 6, i, 0, time.UTC))
+		filler := make([]byte, fillerBytes)
+		random.Read(filler)
+		payload := fmt.Sprintf(`{"metadata":{"name":"p%v","namespace":"ns"},"filler":"%x"}`, i, filler)
+		results = append(results, typed.KubeWatchResult{Timestamp: ts, Kind: "Pod", WatchType: typed.KubeWatchResult_UPDATE, Payload: payload})
+	}
+	return results
+}
+
+func helper_record(t *testing.T, filename string, config RecorderConfig, results []typed.KubeWatchResult) {
+	inChan := make(chan typed.KubeWatchResult)
+	recorder, err := NewFileRecorder(filename, inChan, config)
+	assert.Nil(t, err)
+	recorder.Start()
+	for _, result := range results {
+		inChan <- result
+	}
+	close(inChan)
+	assert.Nil(t, recorder.Close())
+}
+
+func helper_play(t *testing.T, filename string) []typed.KubeWatchResult {
+	outChan := make(chan typed.KubeWatchResult, 1000)
+	err := PlayFile(outChan, filename)
+	assert.Nil(t, err)
+	close(outChan)
+	var results []typed.KubeWatchResult
+	for result := range outChan {
+		results = append(results, result)
+	}
+	return results
+}
+
+func Test_FileRecorder_RoundTrip(t *testing.T) {
+	for _, format := range []string{RecordFormatJsonLines, RecordFormatProtobuf} {
+		filename := helper_recordingFilename(t)
+		expected := helper_someWatchResults(10, 0)
+		helper_record(t, filename, RecorderConfig{Format: format}, expected)
+
+		actual := helper_play(t, filename)
+		assert.Len(t, actual, len(expected), format)
+		for i := range expected {
+			assert.Equal(t, expected[i].String(), actual[i].String(), format)
+		}
+	}
+}
+
+func Test_FileRecorder_RotatesBySizeAndNeverOverwrites(t *testing.T) {
+	filename := helper_recordingFilename(t)
+	expected := helper_someWatchResults(1000, 1000)
+	helper_record(t, filename, RecorderConfig{Format: RecordFormatProtobuf, RotateSizeBytes: 100 * 1024}, expected[:500])
+	_, err := os.Stat(recordingSegmentName(filename, 1))
+	assert.Nil(t, err)
+
+	// A second recording carries on in the next segment rather than replacing the first
+	helper_record(t, filename, RecorderConfig{Format: RecordFormatJsonLines}, expected[500:])
+	actual := helper_play(t, filename)
+	assert.Len(t, actual, len(expected))
+	for i := range expected {
+		assert.Equal(t, expected[i].String(), actual[i].String())
+	}
+}
+
+func Test_FileRecorder_TruncatedRecording(t *testing.T) {
+	filename := helper_recordingFilename(t)
+	helper_record(t, filename, RecorderConfig{Format: RecordFormatJsonLines}, helper_someWatchResults(1000, 1000))
+	data, err := ioutil.ReadFile(filename)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(filename, data[:len(data)/2], 0644)
+	assert.Nil(t, err)
+
+	// Like a recorder which crashed, what is there is still played
+	actual := helper_play(t, filename)
+	assert.NotEmpty(t, actual)
+	assert.True(t, len(actual) < 1000)
+}
+
+func Test_PlayFile_Yaml(t *testing.T) {
+	filename := helper_recordingFilename(t)
+	yamlFile := `Data:
+- kind: Pod
+  payload: '{"metadata":{"name":"p1"}}'
+  timestamp:
+    seconds: 1551675960
+  watchType: 1
+`
+	err := ioutil.WriteFile(filename, []byte(yamlFile), 0644)
+	assert.Nil(t, err)
+
+	actual := helper_play(t, filename)
+	assert.Len(t, actual, 1)
+	assert.Equal(t, "Pod", actual[0].Kind)
+	assert.Equal(t, typed.KubeWatchResult_UPDATE, actual[0].WatchType)
+	assert.Equal(t, int64(1551675960), actual[0].Timestamp.Seconds)
+}
+
+func Test_NewFileRecorder_BadFormat(t *testing.T) {
+	_, err := NewFileRecorder(helper_recordingFilename(t), nil, RecorderConfig{Format: "yaml"})
+	assert.NotNil(t, err)
+}
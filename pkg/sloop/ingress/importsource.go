@@ -0,0 +1,233 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package ingress
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+// Import sources read kubernetes objects recorded elsewhere, like api server audit logs and kubectl output, so
+// history from before sloop was deployed can be backfilled.  Objects keep the time they were recorded at rather than
+// the time of the import, and go through the same exclusion and redaction rules as watched objects.
+
+const (
+	ImportSourceAuditLog    = "auditlog"
+	ImportSourceKubectlDump = "kubectldump"
+)
+
+var (
+	metricIngressImportedcount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_ingress_importedcount"}, []string{"source", "kind"})
+	metricIngressImportskipped = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_ingress_importskipped"}, []string{"source"})
+)
+
+// Reads the records in one file and calls emit for each object, or skip for each record which has no object
+// to import.  Reading stops when emit returns false.
+type importReader func(r io.Reader, emit func(typed.KubeWatchResult) bool, skip func(reason string)) error
+
+type fileImportSource struct {
+	source         string
+	files          []string
+	read           importReader
+	exclusionRules map[string][]any
+	redactor       *redactor
+	outChan        chan typed.KubeWatchResult
+	stopChan       chan struct{}
+	stopOnce       sync.Once
+	wg             sync.WaitGroup
+}
+
+func newFileImportSource(source string, files []string, read importReader, exclusionRules map[string][]any, redactionRules map[string][]RedactionRule, redactionHashKey []byte) (*fileImportSource, error) {
+	redactor, err := newRedactor(redactionRules, redactionHashKey)
+	if err != nil {
+		return nil, err
+	}
+	return &fileImportSource{
+		source:         source,
+		files:          files,
+		read:           read,
+		exclusionRules: exclusionRules,
+		redactor:       redactor,
+		stopChan:       make(chan struct{}),
+	}, nil
+}
+
+// Init checks that every file can be read and starts importing them in order.  The channel is closed once every
+// file has been read, or when Stop is called.
+func (s *fileImportSource) Init() (chan typed.KubeWatchResult, error) {
+	for _, file := range s.files {
+		_, err := os.Stat(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "can not import %v", s.source)
+		}
+	}
+	s.outChan = make(chan typed.KubeWatchResult, 1000)
+	s.wg.Add(1)
+	go s.run()
+	return s.outChan, nil
+}
+
+// Stop stops the import and waits for it to finish.  Records which were not read yet are not imported
+func (s *fileImportSource) Stop() {
+	s.stopOnce.Do(func() { close(s.stopChan) })
+	s.wg.Wait()
+}
+
+func (s *fileImportSource) run() {
+	defer s.wg.Done()
+	defer close(s.outChan)
+	for _, file := range s.files {
+		imported, skipped, err := s.importFile(file)
+		if err != nil {
+			glog.Errorf("Failed to import %v file %v after %v objects: %v", s.source, file, imported, err)
+		} else {
+			glog.Infof("Imported %v objects from %v file %v, skipped %v records", imported, s.source, file, skipped)
+		}
+		select {
+		case <-s.stopChan:
+			return
+		default:
+		}
+	}
+}
+
+func (s *fileImportSource) importFile(filename string) (int, int, error) {
+	imported, skipped := 0, 0
+	file, err := os.Open(filename)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer file.Close()
+	reader, err := openMaybeGzip(file)
+	if err != nil {
+		return 0, 0, err
+	}
+	skip := func(reason string) {
+		glog.V(2).Infof("Skipped a record of %v: %v", filename, reason)
+		skipped++
+		metricIngressImportskipped.WithLabelValues(s.source).Inc()
+	}
+	emit := func(result typed.KubeWatchResult) bool {
+		if eventExcluded(s.exclusionRules, result.Kind, result.Payload) {
+			skip("excluded by an exclusion rule")
+			return true
+		}
+		payload, err := s.redactor.redact(result.Kind, result.Payload)
+		if err != nil {
+			// Dropped rather than stored with fields which were meant to be removed
+			glog.Errorf("Dropping imported %v: %v", result.Kind, err)
+			skip("redaction failed")
+			return true
+		}
+		result.Payload = payload
+		select {
+		case s.outChan <- result:
+			imported++
+			metricIngressImportedcount.WithLabelValues(s.source, result.Kind).Inc()
+			return true
+		case <-s.stopChan:
+			return false
+		}
+	}
+	err = s.read(reader, emit, skip)
+	return imported, skipped, err
+}
+
+// Rotated logs are often gzipped, so those are read through gzip based on their magic rather than their name
+func openMaybeGzip(file io.Reader) (io.Reader, error) {
+	reader := bufio.NewReader(file)
+	magic, _ := reader.Peek(2)
+	if bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		return gzip.NewReader(reader)
+	}
+	return reader, nil
+}
+
+// The fields of an object an import needs
+type importedObject struct {
+	Kind     string `json:"kind"`
+	Metadata struct {
+		Name              string    `json:"name"`
+		CreationTimestamp time.Time `json:"creationTimestamp"`
+		DeletionTimestamp time.Time `json:"deletionTimestamp"`
+		ManagedFields     []struct {
+			Time time.Time `json:"time"`
+		} `json:"managedFields"`
+	} `json:"metadata"`
+	// Set for events
+	LastTimestamp  time.Time `json:"lastTimestamp"`
+	EventTime      time.Time `json:"eventTime"`
+	FirstTimestamp time.Time `json:"firstTimestamp"`
+}
+
+// Returns a watch result for a raw object, or an error saying why it can not be imported.  The payload is compacted
+// to match what the watcher stores.  When timestamp is zero the time is taken from the object.
+func newImportedWatchResult(raw json.RawMessage, watchType typed.KubeWatchResult_WatchType, timestamp time.Time) (typed.KubeWatchResult, error) {
+	obj := importedObject{}
+	err := json.Unmarshal(raw, &obj)
+	if err != nil {
+		return typed.KubeWatchResult{}, errors.Wrap(err, "object does not parse")
+	}
+	if obj.Kind == "" || obj.Metadata.Name == "" {
+		return typed.KubeWatchResult{}, errors.New("object has no kind or name")
+	}
+	if timestamp.IsZero() {
+		timestamp = obj.time(watchType)
+		if timestamp.IsZero() {
+			return typed.KubeWatchResult{}, errors.Errorf("%v %v has no timestamp", obj.Kind, obj.Metadata.Name)
+		}
+	}
+	ts, err := ptypes.TimestampProto(timestamp)
+	if err != nil {
+		return typed.KubeWatchResult{}, err
+	}
+	var payload bytes.Buffer
+	err = json.Compact(&payload, raw)
+	if err != nil {
+		return typed.KubeWatchResult{}, err
+	}
+	return typed.KubeWatchResult{Timestamp: ts, Kind: obj.Kind, WatchType: watchType, Payload: payload.String()}, nil
+}
+
+// Returns the best guess at when an object was recorded, for sources which do not say.  Events have their own
+// timestamps.  Other objects use their deletion time when deleted, or else the newest managed fields time, which is
+// when it was last written, or else the creation time.
+func (obj importedObject) time(watchType typed.KubeWatchResult_WatchType) time.Time {
+	for _, ts := range []time.Time{obj.LastTimestamp, obj.EventTime, obj.FirstTimestamp} {
+		if !ts.IsZero() {
+			return ts
+		}
+	}
+	if watchType == typed.KubeWatchResult_DELETE && !obj.Metadata.DeletionTimestamp.IsZero() {
+		return obj.Metadata.DeletionTimestamp
+	}
+	var newest time.Time
+	for _, field := range obj.Metadata.ManagedFields {
+		if field.Time.After(newest) {
+			newest = field.Time
+		}
+	}
+	if !newest.IsZero() {
+		return newest
+	}
+	return obj.Metadata.CreationTimestamp
+}
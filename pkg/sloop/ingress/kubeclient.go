@@ -23,12 +23,13 @@ var BuildConfigFromFlags = clientcmd.BuildConfigFromFlags
 var ClientConfig = clientcmd.ClientConfig.ClientConfig
 var RawConfig = clientcmd.ClientConfig.RawConfig
 
-// GetKubernetesContext takes optional user preferences and returns the Kubernetes context in use
-func GetKubernetesContext(masterURL string, kubeContextPreference string, privilegedAccess bool) (string, error) {
-	glog.Infof("Getting k8s context with user-defined config masterURL=%v, kubeContextPreference=%v.", masterURL, kubeContextPreference)
+// GetKubernetesContext takes optional user preferences and returns the Kubernetes context in use.
+// An empty kubeconfigPath uses the default kubeconfig loading rules
+func GetKubernetesContext(masterURL string, kubeconfigPath string, kubeContextPreference string, privilegedAccess bool) (string, error) {
+	glog.Infof("Getting k8s context with user-defined config masterURL=%v, kubeconfig=%v, kubeContextPreference=%v.", masterURL, kubeconfigPath, kubeContextPreference)
 	contextInUse := kubeContextPreference
 	if privilegedAccess {
-		clientConfig := getConfig(masterURL, kubeContextPreference)
+		clientConfig := getConfig(masterURL, kubeconfigPath, kubeContextPreference)
 		// This tells us the currentContext defined in the kubeConfig which gets used if we dont have an override
 		rawConfig, err := RawConfig(clientConfig)
 		if err != nil {
@@ -45,19 +46,23 @@ func GetKubernetesContext(masterURL string, kubeContextPreference string, privil
 	return contextInUse, nil
 }
 
-// MakeKubernetesClient takes masterURL and kubeContext (user preference should have already been resolved before calling this)
-// and returns a K8s client
-func MakeKubernetesClient(masterURL string, kubeContext string, privilegedAccess bool) (kubernetes.Interface, error) {
-	glog.Infof("Creating k8sclient with user-defined config masterURL=%v, kubeContext=%v.", masterURL, kubeContext)
+// MakeKubernetesClient takes masterURL, kubeconfigPath and kubeContext (user preference should have already been resolved
+// before calling this) and returns a K8s client
+func MakeKubernetesClient(masterURL string, kubeconfigPath string, kubeContext string, privilegedAccess bool) (kubernetes.Interface, error) {
+	glog.Infof("Creating k8sclient with user-defined config masterURL=%v, kubeconfig=%v, kubeContext=%v.", masterURL, kubeconfigPath, kubeContext)
 	var config *rest.Config
 	var err error
 	if privilegedAccess {
-		clientConfig := getConfig(masterURL, kubeContext)
+		clientConfig := getConfig(masterURL, kubeconfigPath, kubeContext)
 		config, err = ClientConfig(clientConfig)
+		if err != nil {
+			glog.Errorf("Cannot create config for context %v", kubeContext)
+			return nil, err
+		}
 		glog.Infof("Building k8sclient with context=%v, masterURL=%v, configFile=%v.", kubeContext, config.Host, clientConfig.ConfigAccess().GetLoadingPrecedence())
 	} else {
 		glog.Infof("Creating Config using BuildConfigFromFlags")
-		config, err = BuildConfigFromFlags(masterURL, "")
+		config, err = BuildConfigFromFlags(masterURL, kubeconfigPath)
 		if err != nil {
 			glog.Errorf("Cannot create config using BuildConfigFromFlags")
 			return nil, err
@@ -75,8 +80,9 @@ func MakeKubernetesClient(masterURL string, kubeContext string, privilegedAccess
 	return clientset, nil
 }
 
-func getConfig(masterURL string, kubeContext string) clientcmd.ClientConfig {
+func getConfig(masterURL string, kubeconfigPath string, kubeContext string) clientcmd.ClientConfig {
 	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfigPath
 	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
 		loadingRules,
 		&clientcmd.ConfigOverrides{CurrentContext: kubeContext, ClusterInfo: api.Cluster{Server: masterURL}})
@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package ingress
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+// A json value in a kubectl dump, which is a list, a watch event or a single object
+type kubectlDumpValue struct {
+	Kind   string            `json:"kind"`
+	Items  []json.RawMessage `json:"items"`
+	Type   string            `json:"type"`
+	Object json.RawMessage   `json:"object"`
+}
+
+var kubectlWatchTypes = map[string]typed.KubeWatchResult_WatchType{
+	"ADDED":    typed.KubeWatchResult_ADD,
+	"MODIFIED": typed.KubeWatchResult_UPDATE,
+	"DELETED":  typed.KubeWatchResult_DELETE,
+}
+
+// NewKubectlDumpSource imports the json output of kubectl, like `kubectl get events -o json` or
+// `kubectl get pods -w -o json`, optionally with --output-watch-events.  A file holds one or more json values, each a
+// list whose items are imported as adds, a watch event or a single object.  kubectl does not record when it saw an
+// object, so events are imported at their last timestamp and other objects at the last time they were written.
+// Files can be gzipped.
+func NewKubectlDumpSource(files []string, exclusionRules map[string][]any, redactionRules map[string][]RedactionRule, redactionHashKey []byte) (KubeResourceSource, error) {
+	return newFileImportSource(ImportSourceKubectlDump, files, readKubectlDump, exclusionRules, redactionRules, redactionHashKey)
+}
+
+func readKubectlDump(r io.Reader, emit func(typed.KubeWatchResult) bool, skip func(reason string)) error {
+	decoder := json.NewDecoder(r)
+	for index := 0; ; index++ {
+		var raw json.RawMessage
+		err := decoder.Decode(&raw)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			// The decoder can not find the start of the next value after a syntax error
+			return err
+		}
+		value := kubectlDumpValue{}
+		err = json.Unmarshal(raw, &value)
+		if err != nil {
+			skip(fmt.Sprintf("value %v is not an object: %v", index, err))
+			continue
+		}
+
+		objects := []json.RawMessage{raw}
+		watchType := typed.KubeWatchResult_ADD
+		switch {
+		case value.Items != nil:
+			objects = value.Items
+		case value.Type != "" && value.Object != nil:
+			var ok bool
+			watchType, ok = kubectlWatchTypes[value.Type]
+			if !ok {
+				skip(fmt.Sprintf("value %v is a %v watch event", index, value.Type))
+				continue
+			}
+			objects = []json.RawMessage{value.Object}
+		}
+		for _, object := range objects {
+			result, err := newImportedWatchResult(object, watchType, time.Time{})
+			if err != nil {
+				skip(fmt.Sprintf("value %v: %v", index, err))
+				continue
+			}
+			if !emit(result) {
+				return nil
+			}
+		}
+	}
+}
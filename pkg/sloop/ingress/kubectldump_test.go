@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package ingress
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+// An event list, a pod from a watch, a watch event with a deletion, a bookmark and an object without a kind
+const someKubectlDump = `{
+    "apiVersion": "v1",
+    "kind": "List",
+    "items": [
+        {
+            "apiVersion": "v1",
+            "kind": "Event",
+            "metadata": {"name": "p1.123", "namespace": "ns", "creationTimestamp": "2019-03-04T05:00:00Z"},
+            "reason": "Pulled",
+            "firstTimestamp": "2019-03-04T05:00:00Z",
+            "lastTimestamp": "2019-03-04T05:10:00Z"
+        }
+    ]
+}
+{
+    "apiVersion": "v1",
+    "kind": "Pod",
+    "metadata": {
+        "name": "p1",
+        "namespace": "ns",
+        "creationTimestamp": "2019-03-04T04:00:00Z",
+        "managedFields": [{"manager": "kubelet", "time": "2019-03-04T05:20:00Z"}, {"manager": "kubectl", "time": "2019-03-04T04:00:00Z"}]
+    }
+}
+{"type": "DELETED", "object": {"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "p1", "namespace": "ns", "creationTimestamp": "2019-03-04T04:00:00Z", "deletionTimestamp": "2019-03-04T05:30:00Z"}}}
+{"type": "BOOKMARK", "object": {"kind": "Pod", "metadata": {"resourceVersion": "12"}}}
+{"metadata": {"name": "nokind"}}
+`
+
+func Test_KubectlDumpSource(t *testing.T) {
+	filename := helper_writeImportFile(t, "dump.json", someKubectlDump, false)
+	source, err := NewKubectlDumpSource([]string{filename}, nil, nil, nil)
+	assert.Nil(t, err)
+	results := helper_runImportSource(t, source)
+
+	assert.Len(t, results, 3)
+	expected := []struct {
+		kind      string
+		watchType typed.KubeWatchResult_WatchType
+		time      time.Time
+	}{
+		{"Event", typed.KubeWatchResult_ADD, time.Date(2019, 3, 4, 5, 10, 0, 0, time.UTC)},
+		{"Pod", typed.KubeWatchResult_ADD, time.Date(2019, 3, 4, 5, 20, 0, 0, time.UTC)},
+		{"Pod", typed.KubeWatchResult_DELETE, time.Date(2019, 3, 4, 5, 30, 0, 0, time.UTC)},
+	}
+	for i, e := range expected {
+		assert.Equal(t, e.kind, results[i].Kind)
+		assert.Equal(t, e.watchType, results[i].WatchType)
+		ts, err := ptypes.Timestamp(results[i].Timestamp)
+		assert.Nil(t, err)
+		assert.Equal(t, e.time, ts)
+	}
+	assert.Contains(t, results[0].Payload, `"reason":"Pulled"`)
+}
+
+func Test_KubectlDumpSource_BadJson(t *testing.T) {
+	filename := helper_writeImportFile(t, "dump.json", `{"kind": "Pod", "metadata": {"name": "p1", "creationTimestamp": "2019-03-04T04:00:00Z"}} {"kind": `, true)
+	source, err := NewKubectlDumpSource([]string{filename}, nil, nil, nil)
+	assert.Nil(t, err)
+	results := helper_runImportSource(t, source)
+
+	// The objects before the error are still imported
+	assert.Len(t, results, 1)
+}
@@ -8,17 +8,13 @@
 package ingress
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"reflect"
-	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
-	"github.com/diegoholiveira/jsonlogic/v3"
 	"github.com/golang/glog"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/pkg/errors"
@@ -32,7 +28,6 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/dynamicinformer"
-	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
@@ -46,189 +41,201 @@ type KubeWatcher interface {
 	Stop()
 }
 
-type crdGroupVersionResourceKind struct {
+type groupVersionResourceKind struct {
 	group    string
 	version  string
 	resource string
 	kind     string
 }
 
-type crdInformerInfo struct {
-	crd      crdGroupVersionResourceKind
+func (r groupVersionResourceKind) gvr() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: r.group, Version: r.version, Resource: r.resource}
+}
+
+type informerInfo struct {
+	gvrk     groupVersionResourceKind
 	stopChan chan struct{}
 }
 
 type kubeWatcherImpl struct {
-	informerFactory informers.SharedInformerFactory
-	stopChan        chan struct{}
+	kubeClient kubernetes.Interface
 
-	crdInformers      map[crdGroupVersionResourceKind]*crdInformerInfo
-	activeCrdInformer int64
+	informers       map[groupVersionResourceKind]*informerInfo
+	activeInformers int64
 
 	outchan        chan typed.KubeWatchResult
 	resync         time.Duration
 	protection     *sync.Mutex
 	stopped        bool
-	refreshCrd     *time.Ticker
+	refresh        *time.Ticker
 	currentContext string
+	kubeconfigPath string
+	includeCrds    bool
+	resourceFilter ResourceFilter
 	exclusionRules map[string][]any
+	redactor       *redactor
 }
 
 var (
 	newCrdClient                        = func(kubeCfg *rest.Config) (clientset.Interface, error) { return clientset.NewForConfig(kubeCfg) }
+	newDynamicClient                    = func(kubeCfg *rest.Config) (dynamic.Interface, error) { return dynamic.NewForConfig(kubeCfg) }
 	metricIngressGranularKubewatchcount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "metric_ingress_event_kubewatchcount"}, []string{"namespace", "name", "kind", "reason", "type"})
 	metricIngressKubewatchcount         = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_ingress_kubewatchcount"}, []string{"kind", "watchtype"})
 	metricIngressKubewatchbytes         = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_ingress_kubewatchbytes"}, []string{"kind", "watchtype"})
-	metricCrdInformerStarted            = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_crd_informer_started"})
-	metricCrdInformerRunning            = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_crd_informer_running"})
+	metricIngressRedactedbytes          = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_ingress_redactedbytes"}, []string{"kind"})
+	metricInformerStarted               = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_informer_started"})
+	metricInformerRunning               = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_informer_running"})
 )
 
-// Todo: Add additional parameters for filtering
-func NewKubeWatcherSource(kubeClient kubernetes.Interface, outChan chan typed.KubeWatchResult, resync time.Duration, includeCrds bool, crdRefreshInterval time.Duration, masterURL string, kubeContext string, enableGranularMetrics bool, exclusionRules map[string][]any) (KubeWatcher, error) {
-	kw := &kubeWatcherImpl{resync: resync, protection: &sync.Mutex{}}
-	kw.stopChan = make(chan struct{})
-	kw.crdInformers = make(map[crdGroupVersionResourceKind]*crdInformerInfo)
+// Built-in resources are found by discovery and filtered with resourceFilter.  Every version of each CRD is also
+// watched when includeCrds is set.  Both are refreshed every refreshInterval, so new api versions and CRDs are
+// picked up without a restart.
+func NewKubeWatcherSource(kubeClient kubernetes.Interface, outChan chan typed.KubeWatchResult, resync time.Duration, includeCrds bool, refreshInterval time.Duration, masterURL string, kubeconfigPath string, kubeContext string, enableGranularMetrics bool, exclusionRules map[string][]any, redactionRules map[string][]RedactionRule, redactionHashKey []byte, resourceFilter ResourceFilter) (KubeWatcher, error) {
+	redactor, err := newRedactor(redactionRules, redactionHashKey)
+	if err != nil {
+		return nil, err
+	}
+	kw := &kubeWatcherImpl{kubeClient: kubeClient, resync: resync, protection: &sync.Mutex{}, kubeconfigPath: kubeconfigPath, includeCrds: includeCrds, resourceFilter: resourceFilter}
+	kw.informers = make(map[groupVersionResourceKind]*informerInfo)
 	kw.outchan = outChan
 	kw.exclusionRules = exclusionRules
+	kw.redactor = redactor
 
-	kw.startWellKnownInformers(kubeClient, enableGranularMetrics)
-	if includeCrds {
-		err := kw.startCustomInformers(masterURL, kubeContext, enableGranularMetrics)
-		if err != nil {
-			return nil, err
-		}
-
-		kw.refreshCrd = time.NewTicker(crdRefreshInterval)
-		go kw.refreshCrdInformers(masterURL, kubeContext, enableGranularMetrics)
+	err = kw.startInformers(masterURL, kubeContext, enableGranularMetrics)
+	if err != nil {
+		return nil, err
 	}
 
-	return kw, nil
-}
+	kw.refresh = time.NewTicker(refreshInterval)
+	go kw.refreshInformers(masterURL, kubeContext, enableGranularMetrics)
 
-func (i *kubeWatcherImpl) startWellKnownInformers(kubeclient kubernetes.Interface, enableGranularMetrics bool) {
-	i.informerFactory = informers.NewSharedInformerFactory(kubeclient, i.resync)
-
-	i.informerFactory.Apps().V1().DaemonSets().Informer().AddEventHandler(i.getEventHandlerForResource("DaemonSet", enableGranularMetrics))
-	i.informerFactory.Apps().V1().Deployments().Informer().AddEventHandler(i.getEventHandlerForResource("Deployment", enableGranularMetrics))
-	i.informerFactory.Apps().V1().ReplicaSets().Informer().AddEventHandler(i.getEventHandlerForResource("ReplicaSet", enableGranularMetrics))
-	i.informerFactory.Apps().V1().StatefulSets().Informer().AddEventHandler(i.getEventHandlerForResource("StatefulSet", enableGranularMetrics))
-	i.informerFactory.Core().V1().ConfigMaps().Informer().AddEventHandler(i.getEventHandlerForResource("ConfigMap", enableGranularMetrics))
-	i.informerFactory.Core().V1().Endpoints().Informer().AddEventHandler(i.getEventHandlerForResource("Endpoint", enableGranularMetrics))
-	i.informerFactory.Core().V1().Events().Informer().AddEventHandler(i.getEventHandlerForResource("Event", enableGranularMetrics))
-	i.informerFactory.Autoscaling().V1().HorizontalPodAutoscalers().Informer().AddEventHandler(i.getEventHandlerForResource("HorizontalPodAutoscaler", enableGranularMetrics))
-	i.informerFactory.Batch().V1().Jobs().Informer().AddEventHandler(i.getEventHandlerForResource("Job", enableGranularMetrics))
-	i.informerFactory.Core().V1().Namespaces().Informer().AddEventHandler(i.getEventHandlerForResource("Namespace", enableGranularMetrics))
-	i.informerFactory.Core().V1().Nodes().Informer().AddEventHandler(i.getEventHandlerForResource("Node", enableGranularMetrics))
-	i.informerFactory.Core().V1().PersistentVolumeClaims().Informer().AddEventHandler(i.getEventHandlerForResource("PersistentVolumeClaim", enableGranularMetrics))
-	i.informerFactory.Core().V1().PersistentVolumes().Informer().AddEventHandler(i.getEventHandlerForResource("PersistentVolume", enableGranularMetrics))
-	i.informerFactory.Core().V1().Pods().Informer().AddEventHandler(i.getEventHandlerForResource("Pod", enableGranularMetrics))
-	i.informerFactory.Policy().V1beta1().PodDisruptionBudgets().Informer().AddEventHandler(i.getEventHandlerForResource("PodDisruptionBudget", enableGranularMetrics))
-	i.informerFactory.Core().V1().Services().Informer().AddEventHandler(i.getEventHandlerForResource("Service", enableGranularMetrics))
-	i.informerFactory.Core().V1().ReplicationControllers().Informer().AddEventHandler(i.getEventHandlerForResource("ReplicationController", enableGranularMetrics))
-	i.informerFactory.Storage().V1().StorageClasses().Informer().AddEventHandler(i.getEventHandlerForResource("StorageClass", enableGranularMetrics))
-	i.informerFactory.Admissionregistration().V1().MutatingWebhookConfigurations().Informer().AddEventHandler(i.getEventHandlerForResource("MutatingWebhookConfiguration", enableGranularMetrics))
-	i.informerFactory.Start(i.stopChan)
+	return kw, nil
 }
 
-func (i *kubeWatcherImpl) startCustomInformers(masterURL string, kubeContext string, enableGranularMetrics bool) error {
-	clientCfg := getConfig(masterURL, kubeContext)
+// Starts an informer for each resource which should be watched and is not already, and stops those which should not
+func (i *kubeWatcherImpl) startInformers(masterURL string, kubeContext string, enableGranularMetrics bool) error {
+	clientCfg := getConfig(masterURL, i.kubeconfigPath, kubeContext)
 	kubeCfg, err := clientCfg.ClientConfig()
 	if err != nil {
-		return errors.Wrap(err, "failed to read config while starting custom informers")
+		return errors.Wrap(err, "failed to read config while starting informers")
 	}
 
-	crdClient, err := newCrdClient(kubeCfg)
+	resources, failedGroups, err := getWatchableResources(i.kubeClient.Discovery(), i.resourceFilter)
 	if err != nil {
-		return errors.Wrap(err, "failed to instantiate client for querying CRDs")
+		return err
 	}
-	crdList, err := getCrdList(crdClient)
-	if err != nil {
-		return errors.Wrap(err, "failed to query list of CRDs")
+	glog.Infof("Found %d resources to watch", len(resources))
+
+	if i.includeCrds {
+		crdClient, err := newCrdClient(kubeCfg)
+		if err != nil {
+			return errors.Wrap(err, "failed to instantiate client for querying CRDs")
+		}
+		crdList, err := getCrdList(crdClient)
+		if err != nil {
+			return errors.Wrap(err, "failed to query list of CRDs")
+		}
+		glog.Infof("Found %d CRD definitions", len(crdList))
+		for _, crd := range crdList {
+			if !matchesAnyResourceEntry(i.resourceFilter.Deny, crd.gvr()) {
+				resources = append(resources, crd)
+			}
+		}
 	}
 
-	glog.Infof("Found %d CRD definitions", len(crdList))
-	dynamicClient, err := dynamic.NewForConfig(kubeCfg)
+	dynamicClient, err := newDynamicClient(kubeCfg)
 	if err != nil {
-		return errors.Wrap(err, "failed to instantiate client for custom informers")
+		return errors.Wrap(err, "failed to instantiate client for informers")
 	}
-	existing := i.pullCrdInformers()
+	existing := i.pullInformers()
 	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicClient, i.resync, "", nil)
-	for _, crd := range crdList {
-		i.existingOrStartNewCrdInformer(crd, existing, factory, enableGranularMetrics)
+	for _, resource := range resources {
+		i.existingOrStartNewInformer(resource, existing, factory, enableGranularMetrics)
+	}
+	// Keep watching resources in groups which could not be discovered this time, rather than losing them until the
+	// next refresh
+	for gvrk, informer := range existing {
+		if failedGroups[gvrk.group] {
+			i.existingOrStartNewInformer(gvrk, map[groupVersionResourceKind]*informerInfo{gvrk: informer}, factory, enableGranularMetrics)
+			delete(existing, gvrk)
+		}
 	}
 
-	glog.Infof("Stopping %d CRD Informers", len(existing))
-	stopUnwantedCrdInformers(existing)
-	metricCrdInformerStarted.Set(float64(len(i.crdInformers)))
+	glog.Infof("Stopping %d Informers", len(existing))
+	stopUnwantedInformers(existing)
+	metricInformerStarted.Set(float64(len(i.informers)))
 	return nil
 }
 
-func (i *kubeWatcherImpl) pullCrdInformers() map[crdGroupVersionResourceKind]*crdInformerInfo {
+func (i *kubeWatcherImpl) pullInformers() map[groupVersionResourceKind]*informerInfo {
 	i.protection.Lock()
 	defer i.protection.Unlock()
 
-	crdInformers := i.crdInformers
-	i.crdInformers = make(map[crdGroupVersionResourceKind]*crdInformerInfo)
-	return crdInformers
+	informers := i.informers
+	i.informers = make(map[groupVersionResourceKind]*informerInfo)
+	return informers
 }
 
-func (i *kubeWatcherImpl) existingOrStartNewCrdInformer(crd crdGroupVersionResourceKind, existing map[crdGroupVersionResourceKind]*crdInformerInfo, factory dynamicinformer.DynamicSharedInformerFactory, enableGranularMetrics bool) {
+func (i *kubeWatcherImpl) existingOrStartNewInformer(gvrk groupVersionResourceKind, existing map[groupVersionResourceKind]*informerInfo, factory dynamicinformer.DynamicSharedInformerFactory, enableGranularMetrics bool) {
 	i.protection.Lock()
 	defer i.protection.Unlock()
 	if i.stopped {
 		return
 	}
-	// if there is an existing informer for this crd, then keep using the existing informer
-	crdInformer, found := existing[crd]
+	// the same resource can be both discovered and listed as a CRD
+	if _, found := i.informers[gvrk]; found {
+		return
+	}
+	// if there is an existing informer for this resource, then keep using the existing informer
+	informer, found := existing[gvrk]
 	if found {
-		i.crdInformers[crd] = crdInformer
-		delete(existing, crd) // remove from existing so it wont get stopped as unwanted
+		i.informers[gvrk] = informer
+		delete(existing, gvrk) // remove from existing so it wont get stopped as unwanted
 		return
 	}
 
-	// need an informer for this crd
-	crdInformer = &crdInformerInfo{crd: crd, stopChan: make(chan struct{})}
-	i.crdInformers[crd] = crdInformer
-	i.startNewCrdInformer(crdInformer, factory, enableGranularMetrics)
+	// need an informer for this resource
+	informer = &informerInfo{gvrk: gvrk, stopChan: make(chan struct{})}
+	i.informers[gvrk] = informer
+	i.startNewInformer(informer, factory, enableGranularMetrics)
 }
 
-func (i *kubeWatcherImpl) startNewCrdInformer(crdInformer *crdInformerInfo, factory dynamicinformer.DynamicSharedInformerFactory, enableGranularMetrics bool) {
-	gvr := schema.GroupVersionResource{Group: crdInformer.crd.group, Version: crdInformer.crd.version, Resource: crdInformer.crd.resource}
-	kind := crdInformer.crd.kind
+func (i *kubeWatcherImpl) startNewInformer(informerInfo *informerInfo, factory dynamicinformer.DynamicSharedInformerFactory, enableGranularMetrics bool) {
+	gvr := informerInfo.gvrk.gvr()
+	kind := informerInfo.gvrk.kind
 	informer := factory.ForResource(gvr)
 	informer.Informer().AddEventHandler(i.getEventHandlerForResource(kind, enableGranularMetrics))
 
 	go func() {
-		glog.V(2).Infof("Starting CRD informer for: %s (%v)", kind, gvr)
-		metricCrdInformerRunning.Set(float64(atomic.AddInt64(&i.activeCrdInformer, 1)))
+		glog.V(2).Infof("Starting informer for: %s (%v)", kind, gvr)
+		metricInformerRunning.Set(float64(atomic.AddInt64(&i.activeInformers, 1)))
 
-		informer.Informer().Run(crdInformer.stopChan)
+		informer.Informer().Run(informerInfo.stopChan)
 
-		glog.V(2).Infof("Exited CRD informer for: %s (%v)", kind, gvr)
-		metricCrdInformerRunning.Set(float64(atomic.AddInt64(&i.activeCrdInformer, -1)))
+		glog.V(2).Infof("Exited informer for: %s (%v)", kind, gvr)
+		metricInformerRunning.Set(float64(atomic.AddInt64(&i.activeInformers, -1)))
 	}()
 }
 
-func stopUnwantedCrdInformers(existing map[crdGroupVersionResourceKind]*crdInformerInfo) {
+func stopUnwantedInformers(existing map[groupVersionResourceKind]*informerInfo) {
 	// no lock is needed - all these informers should be disconnected from kubeWatcherImpl
 	for _, v := range existing {
-		gvr := schema.GroupVersionResource{Group: v.crd.group, Version: v.crd.version, Resource: v.crd.resource}
-		glog.V(2).Infof("Stopping CRD informer for: %s (%v)", v.crd.kind, gvr)
+		glog.V(2).Infof("Stopping informer for: %s (%v)", v.gvrk.kind, v.gvrk.gvr())
 		close(v.stopChan)
 	}
 }
 
-func getCrdList(crdClient clientset.Interface) ([]crdGroupVersionResourceKind, error) {
+func getCrdList(crdClient clientset.Interface) ([]groupVersionResourceKind, error) {
 	crdList, err := crdClient.ApiextensionsV1().CustomResourceDefinitions().List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
 		glog.Errorf("Failed to get CRD list from ApiextensionsV1, falling back to ApiextensionsV1beta1: %v", err)
 		return getCrdListV1beta1(crdClient)
 	}
 
-	var resources []crdGroupVersionResourceKind
+	var resources []groupVersionResourceKind
 	for _, crd := range crdList.Items {
 		for _, version := range crd.Spec.Versions {
-			gvrk := crdGroupVersionResourceKind{group: crd.Spec.Group, version: version.Name, resource: crd.Spec.Names.Plural, kind: crd.Spec.Names.Kind}
+			gvrk := groupVersionResourceKind{group: crd.Spec.Group, version: version.Name, resource: crd.Spec.Names.Plural, kind: crd.Spec.Names.Kind}
 			glog.V(2).Infof("CRD: group: %s, version: %s, kind: %s, plural:%s, singular:%s, short names:%v", crd.Spec.Group, version.Name, crd.Spec.Names.Kind, crd.Spec.Names.Plural, crd.Spec.Names.Singular, crd.Spec.Names.ShortNames)
 			resources = append(resources, gvrk)
 		}
@@ -236,17 +243,17 @@ func getCrdList(crdClient clientset.Interface) ([]crdGroupVersionResourceKind, e
 	return resources, nil
 }
 
-func getCrdListV1beta1(crdClient clientset.Interface) ([]crdGroupVersionResourceKind, error) {
+func getCrdListV1beta1(crdClient clientset.Interface) ([]groupVersionResourceKind, error) {
 	crdList, err := crdClient.ApiextensionsV1beta1().CustomResourceDefinitions().List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to query CRDs")
 	}
 
 	// duplicated code (see getCrdList), the types for crdList are different
-	var resources []crdGroupVersionResourceKind
+	var resources []groupVersionResourceKind
 	for _, crd := range crdList.Items {
 		for _, version := range crd.Spec.Versions {
-			gvrk := crdGroupVersionResourceKind{group: crd.Spec.Group, version: version.Name, resource: crd.Spec.Names.Plural, kind: crd.Spec.Names.Kind}
+			gvrk := groupVersionResourceKind{group: crd.Spec.Group, version: version.Name, resource: crd.Spec.Names.Plural, kind: crd.Spec.Names.Kind}
 			glog.V(2).Infof("CRD: group: %s, version: %s, kind: %s, plural:%s, singular:%s, short names:%v", crd.Spec.Group, version.Name, crd.Spec.Names.Kind, crd.Spec.Names.Plural, crd.Spec.Names.Singular, crd.Spec.Names.ShortNames)
 			resources = append(resources, gvrk)
 		}
@@ -311,18 +318,17 @@ func (i *kubeWatcherImpl) processUpdate(kind string, obj interface{}, watchResul
 	}
 	glog.V(99).Infof("processUpdate: obj json: %v", resourceJson)
 
-	eventExcluded := i.eventExcluded(kind, resourceJson)
-	if eventExcluded {
-		objName := reflect.ValueOf(obj).Elem().FieldByName("ObjectMeta").FieldByName("Name")
-		glog.V(2).Infof("Event for object excluded: %s/%s", kind, objName)
-		return
-	}
-
 	kubeMetadata, err := kubeextractor.ExtractMetadata(resourceJson)
 	if err != nil || kubeMetadata.Namespace == "" {
 		// We are only grabbing namespace here for a prometheus metric, so if metadata extract fails we just log and continue
 		glog.V(2).Infof("No namespace for resource: %v", err)
 	}
+
+	eventExcluded := eventExcluded(i.exclusionRules, kind, resourceJson)
+	if eventExcluded {
+		glog.V(2).Infof("Event for object excluded: %s/%s", kind, kubeMetadata.Name)
+		return
+	}
 	if enableGranularmetrics && kind == "Event" {
 		eventInfo, err1 := kubeextractor.ExtractEventInfo(resourceJson)
 		involvedObject, err2 := kubeextractor.ExtractInvolvedObject(resourceJson)
@@ -339,6 +345,15 @@ func (i *kubeWatcherImpl) processUpdate(kind string, obj interface{}, watchResul
 	metricIngressKubewatchcount.WithLabelValues(kind, watchResult.WatchType.String()).Inc()
 	metricIngressKubewatchbytes.WithLabelValues(kind, watchResult.WatchType.String()).Add(float64(len(resourceJson)))
 
+	redactedJson, err := i.redactor.redact(kind, resourceJson)
+	if err != nil {
+		// Dropped rather than stored with fields which were meant to be removed
+		glog.Errorf("Dropping %v %s/%s: %v", kind, kubeMetadata.Namespace, kubeMetadata.Name, err)
+		return
+	}
+	metricIngressRedactedbytes.WithLabelValues(kind).Add(float64(len(resourceJson) - len(redactedJson)))
+	resourceJson = redactedJson
+
 	glog.V(common.GlogVerbose).Infof("Informer update (%s) - Name: %s, Namespace: %s, ResourceVersion: %s", watchResult.WatchType, kubeMetadata.Name, kubeMetadata.Namespace, kubeMetadata.ResourceVersion)
 	watchResult.Payload = resourceJson
 	i.writeToOutChan(watchResult)
@@ -365,19 +380,19 @@ func (i *kubeWatcherImpl) getResourceAsJsonString(kind string, obj interface{})
 	return string(bytes), nil
 }
 
-func (i *kubeWatcherImpl) refreshCrdInformers(masterURL string, kubeContext string, enableGranularMetrics bool) {
-	for range i.refreshCrd.C {
-		glog.V(common.GlogVerbose).Infof("Starting to refresh CRD informers")
-		err := i.startCustomInformers(masterURL, kubeContext, enableGranularMetrics)
+func (i *kubeWatcherImpl) refreshInformers(masterURL string, kubeContext string, enableGranularMetrics bool) {
+	for range i.refresh.C {
+		glog.V(common.GlogVerbose).Infof("Starting to refresh informers")
+		err := i.startInformers(masterURL, kubeContext, enableGranularMetrics)
 		if err != nil {
-			glog.Errorf("Failed to refresh CRD informers: %v", err)
+			glog.Errorf("Failed to refresh informers: %v", err)
 		}
 	}
 }
 
-func (i *kubeWatcherImpl) getExclusionRules(kind string) []any {
-	kindRules, _ := i.exclusionRules[kind]
-	globalRules, _ := i.exclusionRules["_all"]
+func getExclusionRules(exclusionRules map[string][]any, kind string) []any {
+	kindRules, _ := exclusionRules[kind]
+	globalRules, _ := exclusionRules["_all"]
 	combinedRules := append(
 		kindRules,
 		globalRules...,
@@ -386,25 +401,19 @@ func (i *kubeWatcherImpl) getExclusionRules(kind string) []any {
 	return combinedRules
 }
 
-func (i *kubeWatcherImpl) eventExcluded(kind string, resourceJson string) bool {
-	filters := i.getExclusionRules(kind)
+func eventExcluded(exclusionRules map[string][]any, kind string, resourceJson string) bool {
+	filters := getExclusionRules(exclusionRules, kind)
 	for _, logic := range filters {
 		logicJson, err := json.Marshal(logic)
 		if err != nil {
 			glog.Errorf(`Failed to parse event filtering rule "%s": %s`, string(logicJson), err)
 			return false
 		}
-		var result bytes.Buffer
-		err = jsonlogic.Apply(
-			strings.NewReader(string(logicJson)),
-			strings.NewReader(resourceJson),
-			&result,
-		)
+		resultBool, err := common.JsonLogicMatches(string(logicJson), resourceJson)
 		if err != nil {
 			glog.Errorf(`Failed to apply event filtering rule "%s": %s`, string(logicJson), err)
 			return false
 		}
-		resultBool := strings.Contains(result.String(), "true")
 		if resultBool {
 			truncated, _ := common.Truncate(resourceJson, 40)
 			glog.V(2).Infof(`Event matched logic: logic="%s" resource="%s"`, string(logicJson), truncated)
@@ -424,10 +433,9 @@ func (i *kubeWatcherImpl) Stop() {
 	i.stopped = true
 	i.protection.Unlock()
 
-	if i.refreshCrd != nil {
-		i.refreshCrd.Stop()
+	if i.refresh != nil {
+		i.refresh.Stop()
 	}
 
-	close(i.stopChan)
-	stopUnwantedCrdInformers(i.pullCrdInformers())
+	stopUnwantedInformers(i.pullInformers())
 }
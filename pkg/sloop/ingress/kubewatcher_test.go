@@ -18,13 +18,14 @@ import (
 
 	"github.com/salesforce/sloop/pkg/sloop/store/typed"
 	"github.com/stretchr/testify/assert"
-	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	clientsetFake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/dynamicinformer"
 	dynamicFake "k8s.io/client-go/dynamic/fake"
 	kubernetesFake "k8s.io/client-go/kubernetes/fake"
@@ -66,15 +67,52 @@ func newTestCrdClient(reaction func(_ k8sTesting.Action) (bool, runtime.Object,
 	}
 }
 
+var (
+	namespacesGvr = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+	servicesGvr   = schema.GroupVersionResource{Version: "v1", Resource: "services"}
+)
+
+// newTestClients - provides a fake clientset which discovers namespaces and services, and a fake dynamic client which the kubewatcher
+// will use for its informers
+func newTestClients() (*kubernetesFake.Clientset, *dynamicFake.FakeDynamicClient) {
+	kubeClient := kubernetesFake.NewSimpleClientset()
+	kubeClient.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "namespaces", Kind: "Namespace", Verbs: []string{"list", "watch"}},
+			{Name: "services", Kind: "Service", Namespaced: true, Verbs: []string{"list", "watch"}},
+			{Name: "services/status", Kind: "Service", Namespaced: true, Verbs: []string{"get"}},
+		}},
+	}
+	gvrToListKind := map[schema.GroupVersionResource]string{
+		namespacesGvr: "NamespaceList",
+		servicesGvr:   "ServiceList",
+		{Group: "g", Version: "v1", Resource: "things"}: "kList",
+	}
+	dynamicClient := dynamicFake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), gvrToListKind)
+	newDynamicClient = func(_ *rest.Config) (dynamic.Interface, error) { return dynamicClient, nil }
+	return kubeClient, dynamicClient
+}
+
+func createObject(t *testing.T, dynamicClient dynamic.Interface, gvr schema.GroupVersionResource, kind string, namespace string, name string) {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	_, err := dynamicClient.Resource(gvr).Namespace(namespace).Create(context.TODO(), obj, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Error creating %v: %v\n", kind, err)
+	}
+}
+
 // This test (test-harness) exercises the kubewatcher from the client perspective
 // - start a kubewatcher
 // - force a k8s event in the system
 // - wait for an event
 // - cleanup
 func Test_bigPicture(t *testing.T) {
-	newCrdClient = newTestCrdClient(reactionListOfOne) // force startCustomInformers() to use a fake clientset
-
-	kubeClient := kubernetesFake.NewSimpleClientset()
+	newCrdClient = newTestCrdClient(reactionListOfOne) // force startInformers() to use a fake clientset
+	kubeClient, dynamicClient := newTestClients()
 	outChan := make(chan typed.KubeWatchResult, 5)
 	resync := 30 * time.Minute
 	includeCrds := true
@@ -82,20 +120,13 @@ func Test_bigPicture(t *testing.T) {
 	kubeContext := "" // empty string makes things work
 	enableGranularMetrics := true
 	exclusionRules := map[string][]any{}
-	kw, err := NewKubeWatcherSource(kubeClient, outChan, resync, includeCrds, time.Duration(10*time.Second), masterURL, kubeContext, enableGranularMetrics, exclusionRules)
+	kw, err := NewKubeWatcherSource(kubeClient, outChan, resync, includeCrds, time.Duration(10*time.Second), masterURL, "", kubeContext, enableGranularMetrics, exclusionRules, nil, nil, ResourceFilter{Allow: DefaultWatchResources})
 	assert.NoError(t, err)
 
 	// create service and await corresponding event
 	ns := "ns"
-	_, err = kubeClient.CoreV1().Namespaces().Create(context.TODO(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}}, metav1.CreateOptions{})
-	if err != nil {
-		t.FailNow()
-	}
-	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "s"}}
-	_, err = kubeClient.CoreV1().Services(ns).Create(context.TODO(), svc, metav1.CreateOptions{})
-	if err != nil {
-		t.Fatalf("Error creating service: %v\n", err)
-	}
+	createObject(t, dynamicClient, namespacesGvr, "Namespace", "", ns)
+	createObject(t, dynamicClient, servicesGvr, "Service", ns, "s")
 	_ = <-outChan
 
 	kw.Stop()
@@ -103,9 +134,8 @@ func Test_bigPicture(t *testing.T) {
 
 // As above but specify non-default exclusion rules to exclude events for service named s2
 func Test_bigPictureWithExclusionRules(t *testing.T) {
-	newCrdClient = newTestCrdClient(reactionListOfOne) // force startCustomInformers() to use a fake clientset
-
-	kubeClient := kubernetesFake.NewSimpleClientset()
+	newCrdClient = newTestCrdClient(reactionListOfOne) // force startInformers() to use a fake clientset
+	kubeClient, dynamicClient := newTestClients()
 	outChan := make(chan typed.KubeWatchResult, 5)
 	resync := 30 * time.Minute
 	includeCrds := true
@@ -125,36 +155,21 @@ func Test_bigPictureWithExclusionRules(t *testing.T) {
 		},
 	}
 
-	kw, err := NewKubeWatcherSource(kubeClient, outChan, resync, includeCrds, time.Duration(10*time.Second), masterURL, kubeContext, enableGranularMetrics, exclusionRules)
+	kw, err := NewKubeWatcherSource(kubeClient, outChan, resync, includeCrds, time.Duration(10*time.Second), masterURL, "", kubeContext, enableGranularMetrics, exclusionRules, nil, nil, ResourceFilter{Allow: DefaultWatchResources})
 	assert.NoError(t, err)
 
 	// create namespace
 	ns := "ns"
-	_, err = kubeClient.CoreV1().Namespaces().Create(context.TODO(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}}, metav1.CreateOptions{})
-	if err != nil {
-		t.FailNow()
-	}
+	createObject(t, dynamicClient, namespacesGvr, "Namespace", "", ns)
 
 	// create first service
-	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "s1"}}
-	_, err = kubeClient.CoreV1().Services(ns).Create(context.TODO(), svc, metav1.CreateOptions{})
-	if err != nil {
-		t.Fatalf("Error creating service: %v\n", err)
-	}
+	createObject(t, dynamicClient, servicesGvr, "Service", ns, "s1")
 
 	// create second service, corresponding event should be excluded by exclusion rule
-	svc = &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "s2"}}
-	_, err = kubeClient.CoreV1().Services(ns).Create(context.TODO(), svc, metav1.CreateOptions{})
-	if err != nil {
-		t.Fatalf("Error creating service: %v\n", err)
-	}
+	createObject(t, dynamicClient, servicesGvr, "Service", ns, "s2")
 
 	// create third service
-	svc = &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "s3"}}
-	_, err = kubeClient.CoreV1().Services(ns).Create(context.TODO(), svc, metav1.CreateOptions{})
-	if err != nil {
-		t.Fatalf("Error creating service: %v\n", err)
-	}
+	createObject(t, dynamicClient, servicesGvr, "Service", ns, "s3")
 
 	eventCount := 0
 loop:
@@ -296,9 +311,9 @@ func verifyChannelEmpty(t *testing.T, outChan chan typed.KubeWatchResult) {
 	}
 }
 
-func Test_existingOrStartNewCrdInformer(t *testing.T) {
+func Test_existingOrStartNewInformer(t *testing.T) {
 	kw := &kubeWatcherImpl{protection: &sync.Mutex{}}
-	kw.crdInformers = make(map[crdGroupVersionResourceKind]*crdInformerInfo)
+	kw.informers = make(map[groupVersionResourceKind]*informerInfo)
 
 	gvrToListKind := map[schema.GroupVersionResource]string{
 		{Group: "g", Version: "v", Resource: "r"}: "kList",
@@ -307,30 +322,30 @@ func Test_existingOrStartNewCrdInformer(t *testing.T) {
 	client := dynamicFake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), gvrToListKind)
 	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(client, 30*time.Minute, "", nil)
 
-	crd := crdGroupVersionResourceKind{group: "g", version: "v", resource: "r", kind: "k"}
-	existing := make(map[crdGroupVersionResourceKind]*crdInformerInfo)
+	gvrk := groupVersionResourceKind{group: "g", version: "v", resource: "r", kind: "k"}
+	existing := make(map[groupVersionResourceKind]*informerInfo)
 	enableGranularMetrics := true
 	// start new informer
-	kw.existingOrStartNewCrdInformer(crd, existing, factory, enableGranularMetrics)
-	assert.Len(t, kw.crdInformers, 1)
+	kw.existingOrStartNewInformer(gvrk, existing, factory, enableGranularMetrics)
+	assert.Len(t, kw.informers, 1)
 
-	for atomic.LoadInt64(&kw.activeCrdInformer) == 0 { // wait for the go routine to start
+	for atomic.LoadInt64(&kw.activeInformers) == 0 { // wait for the go routine to start
 		time.Sleep(time.Millisecond)
 	}
 
 	// refresh - start existing informer
-	existing = kw.pullCrdInformers()
-	assert.Len(t, kw.crdInformers, 0)
+	existing = kw.pullInformers()
+	assert.Len(t, kw.informers, 0)
 	assert.Len(t, existing, 1)
 
-	kw.existingOrStartNewCrdInformer(crd, existing, factory, enableGranularMetrics)
-	assert.Len(t, kw.crdInformers, 1)
+	kw.existingOrStartNewInformer(gvrk, existing, factory, enableGranularMetrics)
+	assert.Len(t, kw.informers, 1)
 	assert.Len(t, existing, 0)
-	assert.Equal(t, int64(1), atomic.LoadInt64(&kw.activeCrdInformer))
+	assert.Equal(t, int64(1), atomic.LoadInt64(&kw.activeInformers))
 
 	// cleanup the informer
-	stopUnwantedCrdInformers(kw.pullCrdInformers())
-	for atomic.LoadInt64(&kw.activeCrdInformer) != 0 { // wait for the go routine to exit
+	stopUnwantedInformers(kw.pullInformers())
+	for atomic.LoadInt64(&kw.activeInformers) != 0 { // wait for the go routine to exit
 		time.Sleep(time.Millisecond)
 	}
 }
@@ -0,0 +1,291 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package ingress
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+)
+
+const (
+	RedactDrop = "drop"
+	RedactHash = "hash"
+
+	// Rules under this key apply to every kind, like exclusion rules
+	redactAllKinds = "_all"
+	// Hashed values are replaced with this prefix and the hex hmac-sha256 of the value
+	redactHashPrefix = "hmac-sha256:"
+)
+
+// RedactionRule drops or hashes the fields of an object matched by Path before the object is stored.
+//
+// Path is a JSONPath-style list of fields like "$.metadata.managedFields" or "$.data.*".  A field with dots or
+// slashes in its name is written in brackets, like "$.metadata.annotations['kubectl.kubernetes.io/last-applied-configuration']",
+// and "*" or "[*]" matches every field of an object or every item of a list.  Paths which do not match are ignored.
+//
+// Hashed values are replaced with "hmac-sha256:<hex>", so a change to a secret value still shows up in diffs.  The hmac
+// is keyed with the redaction hash key, so short values like passwords can not be found by hashing guesses without it.
+type RedactionRule struct {
+	Path   string `json:"path"`
+	Action string `json:"action"`
+}
+
+// Fields which processing reads to key and count objects, so no rule may drop or hash them.  The event fields only
+// apply to rules for events
+var protectedRedactionFields = [][]string{
+	{"metadata", "name"},
+	{"metadata", "namespace"},
+	{"metadata", "uid"},
+	{"metadata", "creationTimestamp"},
+}
+var protectedEventRedactionFields = [][]string{
+	{"involvedObject"},
+	{"reason"},
+	{"type"},
+	{"count"},
+	{"firstTimestamp"},
+	{"lastTimestamp"},
+}
+
+// One step of a path.  An empty field is a wildcard
+type pathSegment struct {
+	field string
+}
+
+type compiledRedactionRule struct {
+	path   []pathSegment
+	action string
+}
+
+// Applies the redaction rules for each kind
+type redactor struct {
+	rules   map[string][]compiledRedactionRule
+	hashKey []byte
+}
+
+// Checks that every rule has a known action and a path which parses, and that hash rules have a key file
+func ValidateRedactionRules(rules map[string][]RedactionRule, hashKeyFile string) error {
+	compiled, err := compileRedactionRules(rules)
+	if err != nil {
+		return err
+	}
+	if hashKeyFile == "" && hasHashRule(compiled) {
+		return fmt.Errorf("redaction rules with action %v need RedactionHashKeyFile", RedactHash)
+	}
+	return nil
+}
+
+func newRedactor(rules map[string][]RedactionRule, hashKey []byte) (*redactor, error) {
+	compiled, err := compileRedactionRules(rules)
+	if err != nil {
+		return nil, err
+	}
+	if len(hashKey) == 0 && hasHashRule(compiled) {
+		return nil, fmt.Errorf("redaction rules with action %v need a hash key", RedactHash)
+	}
+	return &redactor{rules: compiled, hashKey: hashKey}, nil
+}
+
+func compileRedactionRules(rules map[string][]RedactionRule) (map[string][]compiledRedactionRule, error) {
+	compiled := map[string][]compiledRedactionRule{}
+	for kind, kindRules := range rules {
+		for _, rule := range kindRules {
+			if rule.Action != RedactDrop && rule.Action != RedactHash {
+				return nil, fmt.Errorf("redaction rule for %v has action %q, it must be %v or %v", kind, rule.Action, RedactDrop, RedactHash)
+			}
+			path, err := parseRedactionPath(rule.Path)
+			if err != nil {
+				return nil, errors.Wrapf(err, "redaction rule for %v has a bad path %q", kind, rule.Path)
+			}
+			if field := protectedField(kind, path); field != "" {
+				return nil, fmt.Errorf("redaction rule for %v has path %q which would change %v, which is needed to store the object", kind, rule.Path, field)
+			}
+			compiled[kind] = append(compiled[kind], compiledRedactionRule{path: path, action: rule.Action})
+		}
+	}
+	return compiled, nil
+}
+
+// Returns the protected field the path matches, an ancestor of or a field under, or "" when there is none
+func protectedField(kind string, path []pathSegment) string {
+	fields := protectedRedactionFields
+	if kind == kubeextractor.EventKind || kind == redactAllKinds {
+		fields = append(append([][]string{}, fields...), protectedEventRedactionFields...)
+	}
+	for _, field := range fields {
+		overlaps := true
+		for i := 0; i < len(path) && i < len(field); i++ {
+			if path[i].field != "" && path[i].field != field[i] {
+				overlaps = false
+				break
+			}
+		}
+		if overlaps {
+			return strings.Join(field, ".")
+		}
+	}
+	return ""
+}
+
+func hasHashRule(rules map[string][]compiledRedactionRule) bool {
+	for _, kindRules := range rules {
+		for _, rule := range kindRules {
+			if rule.action == RedactHash {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func parseRedactionPath(path string) ([]pathSegment, error) {
+	rest := strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	var segments []pathSegment
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, "['"):
+			end := strings.Index(rest, "']")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated bracket")
+			}
+			segments = append(segments, pathSegment{field: rest[2:end]})
+			rest = rest[end+2:]
+		case strings.HasPrefix(rest, "[*]"):
+			segments = append(segments, pathSegment{})
+			rest = rest[3:]
+		case strings.HasPrefix(rest, "["):
+			return nil, fmt.Errorf("only quoted fields and [*] can be used in brackets")
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			field := rest[:end]
+			if field == "" {
+				return nil, fmt.Errorf("empty field")
+			}
+			if field == "*" {
+				field = ""
+			}
+			segments = append(segments, pathSegment{field: field})
+			rest = rest[end:]
+		}
+		rest = strings.TrimPrefix(rest, ".")
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("path is empty")
+	}
+	return segments, nil
+}
+
+func (r *redactor) hasRules(kind string) bool {
+	return r != nil && (len(r.rules[kind]) > 0 || len(r.rules[redactAllKinds]) > 0)
+}
+
+// Returns the object with the rules for its kind applied.  The object is returned unchanged when no rule matches.
+func (r *redactor) redact(kind string, resourceJson string) (string, error) {
+	if !r.hasRules(kind) {
+		return resourceJson, nil
+	}
+	// UseNumber keeps large integers like resourceVersions exact
+	decoder := json.NewDecoder(strings.NewReader(resourceJson))
+	decoder.UseNumber()
+	var obj interface{}
+	err := decoder.Decode(&obj)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse object for redaction")
+	}
+
+	changed := false
+	// A new slice so concurrent informers do not append to the same backing array
+	rules := append(append([]compiledRedactionRule{}, r.rules[kind]...), r.rules[redactAllKinds]...)
+	for _, rule := range rules {
+		var ruleChanged bool
+		obj, ruleChanged = r.applyRedaction(obj, rule.path, rule.action)
+		changed = changed || ruleChanged
+	}
+	if !changed {
+		return resourceJson, nil
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	err = encoder.Encode(obj)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal redacted object")
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// Walks path from node and applies action to what it ends on.  Returns the node, which is only replaced when it is a
+// list whose items are all dropped, and whether anything changed
+func (r *redactor) applyRedaction(node interface{}, path []pathSegment, action string) (interface{}, bool) {
+	segment := path[0]
+	last := len(path) == 1
+	changed := false
+	switch value := node.(type) {
+	case map[string]interface{}:
+		for field, child := range value {
+			if segment.field != "" && segment.field != field {
+				continue
+			}
+			switch {
+			case !last:
+				var childChanged bool
+				value[field], childChanged = r.applyRedaction(child, path[1:], action)
+				changed = changed || childChanged
+			case action == RedactDrop:
+				delete(value, field)
+				changed = true
+			default:
+				value[field] = r.hashValue(child)
+				changed = true
+			}
+		}
+	case []interface{}:
+		// Items of a list can only be reached with a wildcard
+		if segment.field != "" {
+			return node, false
+		}
+		if last && action == RedactDrop {
+			return []interface{}{}, len(value) > 0
+		}
+		for i, child := range value {
+			if last {
+				value[i] = r.hashValue(child)
+				changed = true
+				continue
+			}
+			var childChanged bool
+			value[i], childChanged = r.applyRedaction(child, path[1:], action)
+			changed = changed || childChanged
+		}
+	}
+	return node, changed
+}
+
+func (r *redactor) hashValue(value interface{}) string {
+	var data []byte
+	if s, ok := value.(string); ok {
+		data = []byte(s)
+	} else {
+		data, _ = json.Marshal(value)
+	}
+	mac := hmac.New(sha256.New, r.hashKey)
+	mac.Write(data)
+	return redactHashPrefix + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package ingress
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/test/assertex"
+)
+
+const someConfigMap = `{"kind":"ConfigMap","metadata":{"name":"someName","namespace":"someNamespace","resourceVersion":"123","generation":9007199254740993,` +
+	`"annotations":{"kubectl.kubernetes.io/last-applied-configuration":"{big}","keep":"me"},"managedFields":[{"manager":"kubectl"}]},` +
+	`"data":{"password":"hunter2","user":"admin"}}`
+
+// hmac-sha256 of "hunter2" and "admin" keyed with someHashKey
+const (
+	someHashKey   = "0123456789abcdef0123456789abcdef"
+	hashedHunter2 = "hmac-sha256:3920d54ec70f500ee54e1da041d2364a8e57dd252f7a81ed8acd58ad6cf3b6d0"
+	hashedAdmin   = "hmac-sha256:71e41fdc013ec8ac268f0ff33d59588293330bbe752a90898d1091ccc2bcf755"
+)
+
+func helper_redact(t *testing.T, rules map[string][]RedactionRule, kind string, input string) string {
+	r, err := newRedactor(rules, []byte(someHashKey))
+	assert.Nil(t, err)
+	output, err := r.redact(kind, input)
+	assert.Nil(t, err)
+	return output
+}
+
+func Test_Redact_DropAndHash(t *testing.T) {
+	rules := map[string][]RedactionRule{
+		"_all": {
+			{Path: "$.metadata.managedFields", Action: RedactDrop},
+			{Path: "$.metadata.annotations['kubectl.kubernetes.io/last-applied-configuration']", Action: RedactDrop},
+		},
+		"ConfigMap": {{Path: "$.data.*", Action: RedactHash}},
+	}
+	output := helper_redact(t, rules, "ConfigMap", someConfigMap)
+	expected := `{"kind":"ConfigMap","metadata":{"name":"someName","namespace":"someNamespace","resourceVersion":"123","generation":9007199254740993,` +
+		`"annotations":{"keep":"me"}},"data":{"password":"` + hashedHunter2 + `","user":"` + hashedAdmin + `"}}`
+	assertex.JsonEqual(t, expected, output)
+	// Numbers are written back exactly
+	assert.Contains(t, output, "9007199254740993")
+
+	// Only the _all rules apply to other kinds
+	output = helper_redact(t, rules, "Secret", someConfigMap)
+	assert.NotContains(t, output, "managedFields")
+	assert.Contains(t, output, "hunter2")
+}
+
+func Test_Redact_Lists(t *testing.T) {
+	pod := `{"spec":{"containers":[{"name":"a","env":[{"name":"TOKEN","value":"hunter2"}]},{"name":"b"}]},"status":{"conditions":[{"type":"Ready"}]}}`
+	rules := map[string][]RedactionRule{"Pod": {
+		{Path: "spec.containers[*].env[*].value", Action: RedactHash},
+		{Path: "status.conditions[*]", Action: RedactDrop},
+	}}
+	output := helper_redact(t, rules, "Pod", pod)
+	assertex.JsonEqual(t, `{"spec":{"containers":[{"name":"a","env":[{"name":"TOKEN","value":"`+hashedHunter2+`"}]},{"name":"b"}]},"status":{"conditions":[]}}`, output)
+}
+
+func Test_Redact_NoMatchLeavesPayloadAlone(t *testing.T) {
+	// Not even re-encoded, so the original field order is kept
+	input := `{"z":1,"a":{"b":2}}`
+	rules := map[string][]RedactionRule{"Pod": {{Path: "$.a.c", Action: RedactDrop}, {Path: "$.z.y", Action: RedactHash}}}
+	assert.Equal(t, input, helper_redact(t, rules, "Pod", input))
+	assert.Equal(t, input, helper_redact(t, rules, "Node", input))
+
+	r, err := newRedactor(rules, []byte(someHashKey))
+	assert.Nil(t, err)
+	_, err = r.redact("Pod", "not json")
+	assert.NotNil(t, err)
+}
+
+func Test_ValidateRedactionRules(t *testing.T) {
+	assert.Nil(t, ValidateRedactionRules(nil, ""))
+	badRules := []map[string][]RedactionRule{
+		{"Pod": {{Path: "$.metadata", Action: "encrypt"}}},
+		{"Pod": {{Path: "$", Action: RedactDrop}}},
+		{"Pod": {{Path: "$.metadata..name", Action: RedactDrop}}},
+		{"Pod": {{Path: "$.metadata['name", Action: RedactDrop}}},
+		{"Pod": {{Path: "$.items[0]", Action: RedactDrop}}},
+	}
+	for _, rules := range badRules {
+		assert.NotNil(t, ValidateRedactionRules(rules, "key.txt"), rules["Pod"][0].Path)
+	}
+}
+
+func Test_ValidateRedactionRules_ProtectedFields(t *testing.T) {
+	for kind, paths := range map[string][]string{
+		"_all":  {"$.metadata.*", "$.metadata", "$.*", "$.metadata.name", "$['metadata']['uid']", "$.metadata.creationTimestamp", "$.involvedObject.uid", "$.reason"},
+		"Pod":   {"$.metadata.namespace", "$.*.uid"},
+		"Event": {"$.involvedObject", "$.involvedObject.*", "$.count", "$.lastTimestamp", "$.firstTimestamp", "$.type"},
+	} {
+		for _, path := range paths {
+			rules := map[string][]RedactionRule{kind: {{Path: path, Action: RedactDrop}}}
+			assert.NotNil(t, ValidateRedactionRules(rules, ""), kind+" "+path)
+		}
+	}
+
+	// Fields next to them are fine, and the event fields are only protected for events
+	rules := map[string][]RedactionRule{
+		"_all":      {{Path: "$.metadata.managedFields", Action: RedactDrop}, {Path: "$.metadata.labels.*", Action: RedactDrop}},
+		"ConfigMap": {{Path: "$.count", Action: RedactDrop}, {Path: "$.data.*", Action: RedactDrop}},
+		"Event":     {{Path: "$.message", Action: RedactDrop}},
+	}
+	assert.Nil(t, ValidateRedactionRules(rules, ""))
+}
+
+func Test_Redact_HashNeedsKey(t *testing.T) {
+	rules := map[string][]RedactionRule{"ConfigMap": {{Path: "$.data.*", Action: RedactHash}}}
+	assert.NotNil(t, ValidateRedactionRules(rules, ""))
+	assert.Nil(t, ValidateRedactionRules(rules, "key.txt"))
+	_, err := newRedactor(rules, nil)
+	assert.NotNil(t, err)
+
+	// Drop rules do not need a key
+	dropRules := map[string][]RedactionRule{"ConfigMap": {{Path: "$.data", Action: RedactDrop}}}
+	assert.Nil(t, ValidateRedactionRules(dropRules, ""))
+
+	// Another key gives other hashes, so values can not be guessed without the key
+	output := helper_redact(t, rules, "ConfigMap", someConfigMap)
+	r, err := newRedactor(rules, []byte("another key"))
+	assert.Nil(t, err)
+	otherOutput, err := r.redact("ConfigMap", someConfigMap)
+	assert.Nil(t, err)
+	assert.Contains(t, output, hashedHunter2)
+	assert.NotContains(t, otherOutput, hashedHunter2)
+	assert.Contains(t, otherOutput, "hmac-sha256:")
+}
+
+func Test_processUpdate_Redacts(t *testing.T) {
+	outChan := make(chan typed.KubeWatchResult, 5)
+	r, err := newRedactor(map[string][]RedactionRule{"ConfigMap": {{Path: "$.data", Action: RedactDrop}}}, nil)
+	assert.Nil(t, err)
+	kw := &kubeWatcherImpl{protection: &sync.Mutex{}, outchan: outChan, redactor: r}
+
+	kw.reportAdd("ConfigMap", false)(map[string]interface{}{"metadata": map[string]interface{}{"name": "someName"}, "data": map[string]interface{}{"password": "hunter2"}})
+
+	result := <-outChan
+	assert.Equal(t, `{"metadata":{"name":"someName"}}`, result.Payload)
+	verifyChannelEmpty(t, outChan)
+}
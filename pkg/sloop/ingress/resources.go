@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package ingress
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// The built-in resources watched when no list is configured.  Entries use the kubectl style resource[.version][.group]
+var DefaultWatchResources = []string{
+	"configmaps",
+	"endpoints",
+	"events",
+	"namespaces",
+	"nodes",
+	"persistentvolumeclaims",
+	"persistentvolumes",
+	"pods",
+	"replicationcontrollers",
+	"serviceaccounts",
+	"services",
+	"daemonsets.apps",
+	"deployments.apps",
+	"replicasets.apps",
+	"statefulsets.apps",
+	"horizontalpodautoscalers.autoscaling",
+	"cronjobs.batch",
+	"jobs.batch",
+	"poddisruptionbudgets.policy",
+	"ingresses.networking.k8s.io",
+	"networkpolicies.networking.k8s.io",
+	"endpointslices.discovery.k8s.io",
+	"roles.rbac.authorization.k8s.io",
+	"rolebindings.rbac.authorization.k8s.io",
+	"storageclasses.storage.k8s.io",
+	"priorityclasses.scheduling.k8s.io",
+	"mutatingwebhookconfigurations.admissionregistration.k8s.io",
+}
+
+// Kinds which sloop stored under a different name before resources were found by discovery.  Keeping the old name
+// means existing history and links keep working
+var legacyKindNames = map[string]string{
+	"Endpoints": "Endpoint",
+}
+
+// ResourceFilter picks which discovered resources are watched.  A resource is watched when it matches an entry in
+// the allow list and none in the deny list.  Entries are resource[.version][.group] like kubectl, and "*" can be used
+// for the resource or the whole entry.  An entry without a group only matches the core group.
+type ResourceFilter struct {
+	Allow []string
+	Deny  []string
+}
+
+func matchesResourceEntry(entry string, gvr schema.GroupVersionResource) bool {
+	if entry == "*" {
+		return true
+	}
+	matchResource := func(resource string) bool {
+		return resource == "*" || resource == gvr.Resource
+	}
+	// Like kubectl, resource.version.group and resource.group are both tried as there is no way to tell them apart
+	fullySpecified, groupResource := schema.ParseResourceArg(entry)
+	if fullySpecified != nil && matchResource(fullySpecified.Resource) && fullySpecified.Version == gvr.Version && fullySpecified.Group == gvr.Group {
+		return true
+	}
+	return matchResource(groupResource.Resource) && groupResource.Group == gvr.Group
+}
+
+func matchesAnyResourceEntry(entries []string, gvr schema.GroupVersionResource) bool {
+	for _, entry := range entries {
+		if matchesResourceEntry(entry, gvr) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f ResourceFilter) Matches(gvr schema.GroupVersionResource) bool {
+	return matchesAnyResourceEntry(f.Allow, gvr) && !matchesAnyResourceEntry(f.Deny, gvr)
+}
+
+func ValidateResourceEntries(entries []string) error {
+	for _, entry := range entries {
+		if entry == "" || strings.ContainsAny(entry, "/ ") {
+			return fmt.Errorf("resource %q should look like resource[.version][.group], for example deployments.apps", entry)
+		}
+	}
+	return nil
+}
+
+// Returns the resources which match the filter, each at the version the server prefers.  Only resources which can be
+// listed and watched are returned.  When some api groups could not be discovered the resources from the rest are
+// returned along with the groups which failed, so the caller can keep watching what it already had for those.
+func getWatchableResources(discoveryClient discovery.DiscoveryInterface, filter ResourceFilter) ([]groupVersionResourceKind, map[string]bool, error) {
+	failedGroups := map[string]bool{}
+	resourceLists, err := discovery.ServerPreferredResources(discoveryClient)
+	if err != nil {
+		groupErr, ok := err.(*discovery.ErrGroupDiscoveryFailed)
+		if !ok {
+			return nil, nil, errors.Wrap(err, "failed to discover api resources")
+		}
+		for gv, gvErr := range groupErr.Groups {
+			glog.Errorf("Failed to discover resources in %v: %v", gv, gvErr)
+			failedGroups[gv.Group] = true
+		}
+	}
+
+	var resources []groupVersionResourceKind
+	for _, resourceList := range resourceLists {
+		gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			glog.Errorf("Skipping bad group version %q: %v", resourceList.GroupVersion, err)
+			continue
+		}
+		for _, apiResource := range resourceList.APIResources {
+			if strings.Contains(apiResource.Name, "/") || !hasVerbs(apiResource.Verbs, "list", "watch") {
+				continue
+			}
+			gvr := gv.WithResource(apiResource.Name)
+			if !filter.Matches(gvr) {
+				continue
+			}
+			kind := apiResource.Kind
+			if legacyName, ok := legacyKindNames[kind]; ok && gv.Group == "" {
+				kind = legacyName
+			}
+			resources = append(resources, groupVersionResourceKind{group: gv.Group, version: gv.Version, resource: apiResource.Name, kind: kind})
+		}
+	}
+	return resources, failedGroups, nil
+}
+
+func hasVerbs(verbs []string, wanted ...string) bool {
+	for _, w := range wanted {
+		found := false
+		for _, verb := range verbs {
+			if verb == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
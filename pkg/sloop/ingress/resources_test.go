@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kubernetesFake "k8s.io/client-go/kubernetes/fake"
+)
+
+var listWatch = []string{"get", "list", "watch"}
+
+func Test_ResourceFilter_Matches(t *testing.T) {
+	pods := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	ingresses := schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"}
+	newEvents := schema.GroupVersionResource{Group: "events.k8s.io", Version: "v1", Resource: "events"}
+	oldEvents := schema.GroupVersionResource{Version: "v1", Resource: "events"}
+
+	assert.True(t, ResourceFilter{Allow: []string{"pods"}}.Matches(pods))
+	assert.False(t, ResourceFilter{Allow: []string{"pods"}}.Matches(deployments))
+	assert.True(t, ResourceFilter{Allow: []string{"deployments.apps"}}.Matches(deployments))
+	assert.True(t, ResourceFilter{Allow: []string{"deployments.v1.apps"}}.Matches(deployments))
+	assert.False(t, ResourceFilter{Allow: []string{"deployments.v2.apps"}}.Matches(deployments))
+	assert.True(t, ResourceFilter{Allow: []string{"ingresses.networking.k8s.io"}}.Matches(ingresses))
+	assert.True(t, ResourceFilter{Allow: []string{"*.networking.k8s.io"}}.Matches(ingresses))
+
+	// A name without a group is only the core group
+	assert.True(t, ResourceFilter{Allow: []string{"events"}}.Matches(oldEvents))
+	assert.False(t, ResourceFilter{Allow: []string{"events"}}.Matches(newEvents))
+
+	everythingButEvents := ResourceFilter{Allow: []string{"*"}, Deny: []string{"events", "events.events.k8s.io"}}
+	assert.True(t, everythingButEvents.Matches(pods))
+	assert.True(t, everythingButEvents.Matches(ingresses))
+	assert.False(t, everythingButEvents.Matches(oldEvents))
+	assert.False(t, everythingButEvents.Matches(newEvents))
+}
+
+func Test_ValidateResourceEntries(t *testing.T) {
+	assert.Nil(t, ValidateResourceEntries(DefaultWatchResources))
+	assert.Nil(t, ValidateResourceEntries([]string{"*", "*.apps", "deployments.v1.apps"}))
+	assert.NotNil(t, ValidateResourceEntries([]string{"apps/v1/deployments"}))
+	assert.NotNil(t, ValidateResourceEntries([]string{""}))
+}
+
+func Test_getWatchableResources(t *testing.T) {
+	kubeClient := kubernetesFake.NewSimpleClientset()
+	kubeClient.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "pods", Kind: "Pod", Verbs: listWatch},
+			{Name: "pods/log", Kind: "Pod", Verbs: []string{"get"}},
+			{Name: "endpoints", Kind: "Endpoints", Verbs: listWatch},
+			{Name: "bindings", Kind: "Binding", Verbs: []string{"create"}},
+		}},
+		// v1 is listed first so it is the preferred version
+		{GroupVersion: "policy/v1", APIResources: []metav1.APIResource{
+			{Name: "poddisruptionbudgets", Kind: "PodDisruptionBudget", Verbs: listWatch},
+		}},
+		{GroupVersion: "policy/v1beta1", APIResources: []metav1.APIResource{
+			{Name: "poddisruptionbudgets", Kind: "PodDisruptionBudget", Verbs: listWatch},
+		}},
+		{GroupVersion: "coordination.k8s.io/v1", APIResources: []metav1.APIResource{
+			{Name: "leases", Kind: "Lease", Verbs: listWatch},
+		}},
+	}
+
+	resources, failedGroups, err := getWatchableResources(kubeClient.Discovery(), ResourceFilter{Allow: []string{"*"}, Deny: []string{"leases.coordination.k8s.io"}})
+	assert.Nil(t, err)
+	assert.Len(t, failedGroups, 0)
+	assert.ElementsMatch(t, []groupVersionResourceKind{
+		{version: "v1", resource: "pods", kind: "Pod"},
+		{version: "v1", resource: "endpoints", kind: "Endpoint"},
+		{group: "policy", version: "v1", resource: "poddisruptionbudgets", kind: "PodDisruptionBudget"},
+	}, resources)
+}
@@ -0,0 +1,179 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package ingress
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+// The workload generator makes up a cluster and what happens in it, for load testing and demos without a real
+// cluster.  Deployments own replica sets which own pods, nodes report their status, custom resources change, and
+// failure scenarios like crash loops, node failures and event storms come around on a schedule.
+
+const (
+	// A pod restarts over and over with a growing back off, with a warning event each time
+	ScenarioCrashLoop = "crashloop"
+	// A node stops reporting and goes not ready, and its pods are replaced on other nodes
+	ScenarioNodeFailure = "nodefailure"
+	// Warning events are raised on random pods at Rate a second
+	ScenarioEventStorm = "eventstorm"
+)
+
+var metricIngressGeneratedcount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_ingress_generatedcount"}, []string{"kind", "watchtype"})
+
+type GeneratorConfig struct {
+	Namespaces              int `json:"namespaces"`
+	DeploymentsPerNamespace int `json:"deploymentsPerNamespace"`
+	ReplicasPerDeployment   int `json:"replicasPerDeployment"`
+	Nodes                   int `json:"nodes"`
+	CustomResources         int `json:"customResources"`
+	// How often the workload moves on, like "1s"
+	Interval string `json:"interval"`
+	// How often each ready node reports its status
+	NodeHeartbeat string `json:"nodeHeartbeat"`
+	// Deployments which roll out a new replica set
+	RolloutsPerHour float64 `json:"rolloutsPerHour"`
+	// Pods which are deleted and replaced
+	PodChurnPerHour              float64             `json:"podChurnPerHour"`
+	CustomResourceUpdatesPerHour float64             `json:"customResourceUpdatesPerHour"`
+	Scenarios                    []GeneratorScenario `json:"scenarios"`
+	// The same seed makes the same workload.  Zero seeds from the clock
+	Seed int64 `json:"seed"`
+}
+
+type GeneratorScenario struct {
+	// One of the Scenario constants
+	Type string `json:"type"`
+	// How often the scenario starts, like "30m".  The first time is one period after the generator starts
+	Every string `json:"every"`
+	// How long it lasts each time
+	Duration string `json:"duration"`
+	// Warning events a second, for event storms
+	Rate float64 `json:"rate"`
+}
+
+func DefaultGeneratorConfig() GeneratorConfig {
+	return GeneratorConfig{
+		Namespaces:                   3,
+		DeploymentsPerNamespace:      5,
+		ReplicasPerDeployment:        3,
+		Nodes:                        5,
+		CustomResources:              10,
+		Interval:                     "1s",
+		NodeHeartbeat:                "10s",
+		RolloutsPerHour:              6,
+		PodChurnPerHour:              30,
+		CustomResourceUpdatesPerHour: 60,
+		Scenarios: []GeneratorScenario{
+			{Type: ScenarioCrashLoop, Every: "10m", Duration: "3m"},
+			{Type: ScenarioNodeFailure, Every: "1h", Duration: "5m"},
+			{Type: ScenarioEventStorm, Every: "30m", Duration: "1m", Rate: 20},
+		},
+	}
+}
+
+// Checks the counts and rates are not negative and that the durations and scenario types parse
+func ValidateGeneratorConfig(config GeneratorConfig) error {
+	_, err := newWorkloadSim(config)
+	return err
+}
+
+type workloadGenerator struct {
+	sim      *workloadSim
+	interval time.Duration
+	outChan  chan typed.KubeWatchResult
+	stopChan chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewWorkloadGenerator returns a source which generates a workload in real time, one step every interval
+func NewWorkloadGenerator(config GeneratorConfig) (KubeResourceSource, error) {
+	sim, err := newWorkloadSim(config)
+	if err != nil {
+		return nil, err
+	}
+	return &workloadGenerator{sim: sim, interval: sim.interval, stopChan: make(chan struct{})}, nil
+}
+
+// Init starts generating.  The channel is closed when Stop is called
+func (g *workloadGenerator) Init() (chan typed.KubeWatchResult, error) {
+	g.outChan = make(chan typed.KubeWatchResult, 1000)
+	g.wg.Add(1)
+	go g.run()
+	return g.outChan, nil
+}
+
+func (g *workloadGenerator) Stop() {
+	g.stopOnce.Do(func() { close(g.stopChan) })
+	g.wg.Wait()
+}
+
+func (g *workloadGenerator) run() {
+	defer g.wg.Done()
+	defer close(g.outChan)
+	ticker := time.NewTicker(g.interval)
+	defer ticker.Stop()
+	now := time.Now()
+	for {
+		results := g.sim.step(now)
+		glog.V(2).Infof("Generated %v watch results", len(results))
+		for _, result := range results {
+			select {
+			case g.outChan <- result:
+				metricIngressGeneratedcount.WithLabelValues(result.Kind, result.WatchType.String()).Inc()
+			case <-g.stopChan:
+				return
+			}
+		}
+		select {
+		case now = <-ticker.C:
+		case <-g.stopChan:
+			return
+		}
+	}
+}
+
+// GenerateWorkloadHistory steps a generator through duration from start as fast as it can, one step every interval,
+// and calls emit with what it makes.  It is for benchmarks and tests which need history without waiting for it.
+// Generating stops early when emit returns false.
+func GenerateWorkloadHistory(config GeneratorConfig, start time.Time, duration time.Duration, emit func(typed.KubeWatchResult) bool) error {
+	sim, err := newWorkloadSim(config)
+	if err != nil {
+		return err
+	}
+	for elapsed := time.Duration(0); elapsed <= duration; elapsed += sim.interval {
+		for _, result := range sim.step(start.Add(elapsed)) {
+			if !emit(result) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// Parses a duration from a generator config, which has to be positive
+func parseGeneratorDuration(name string, value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.Wrapf(err, "workload generator %v %q does not parse", name, value)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("workload generator %v must be positive", name)
+	}
+	return d, nil
+}
@@ -0,0 +1,685 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package ingress
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+// Kubernetes drops events an hour after they were last seen
+const simEventTtl = time.Hour
+
+// The longest back off of a crash looping container, like the kubelet
+const simMaxCrashBackoff = 5 * time.Minute
+
+var simAppNames = []string{"frontend", "checkout", "cart", "search", "payments", "inventory", "auth", "catalog", "reviews", "shipping"}
+var simTeams = []string{"web", "commerce", "platform"}
+var simStormReasons = []struct{ reason, message string }{
+	{"FailedScheduling", "0/5 nodes are available: 5 Insufficient cpu."},
+	{"FailedMount", "MountVolume.SetUp failed for volume \"config\" : configmap \"config\" not found"},
+	{"Unhealthy", "Readiness probe failed: HTTP probe failed with statuscode: 503"},
+	{"FailedCreatePodSandBox", "Failed to create pod sandbox: rpc error: code = DeadlineExceeded"},
+}
+
+// What every generated object has
+type simObject struct {
+	apiVersion string
+	kind       string
+	namespace  string
+	name       string
+	uid        string
+	labels     map[string]string
+	created    time.Time
+}
+
+type simNode struct {
+	simObject
+	ready         bool
+	lastHeartbeat time.Time
+	transition    time.Time
+}
+
+type simDeployment struct {
+	simObject
+	revision   int
+	replicaSet *simReplicaSet
+	// The replica set before the last rollout, which is kept scaled down
+	oldReplicaSet *simReplicaSet
+}
+
+type simReplicaSet struct {
+	simObject
+	deployment *simDeployment
+	hash       string
+	pods       []*simPod
+}
+
+type simPod struct {
+	simObject
+	replicaSet   *simReplicaSet
+	node         *simNode
+	restarts     int
+	crashLooping bool
+	deleted      bool
+}
+
+type simEvent struct {
+	simObject
+	involved  *simObject
+	eventType string
+	reason    string
+	message   string
+	count     int
+	first     time.Time
+	last      time.Time
+}
+
+type simScenario struct {
+	GeneratorScenario
+	every    time.Duration
+	duration time.Duration
+	next     time.Time
+	until    time.Time
+	active   bool
+	// What a crash loop or node failure happens to
+	pod       *simPod
+	node      *simNode
+	nextCrash time.Time
+	backoff   time.Duration
+}
+
+// Generates the watch results of a made up cluster.  It is driven by step, so it can run in real time or as fast as
+// it is stepped, and the same seed and steps make the same results.
+type workloadSim struct {
+	config          GeneratorConfig
+	interval        time.Duration
+	heartbeat       time.Duration
+	random          *rand.Rand
+	resourceVersion int64
+	started         bool
+	last            time.Time
+	now             time.Time
+	out             []typed.KubeWatchResult
+	crd             simObject
+	nodes           []*simNode
+	deployments     []*simDeployment
+	customResources []*simObject
+	customSizes     map[*simObject]int
+	// By the uid of the involved object and the reason, which is how kubernetes counts repeats of an event
+	events    map[string]*simEvent
+	scenarios []*simScenario
+}
+
+func newWorkloadSim(config GeneratorConfig) (*workloadSim, error) {
+	if config.Namespaces < 0 || config.DeploymentsPerNamespace < 0 || config.ReplicasPerDeployment < 0 || config.Nodes < 0 || config.CustomResources < 0 {
+		return nil, fmt.Errorf("workload generator counts can not be negative")
+	}
+	if config.RolloutsPerHour < 0 || config.PodChurnPerHour < 0 || config.CustomResourceUpdatesPerHour < 0 {
+		return nil, fmt.Errorf("workload generator rates can not be negative")
+	}
+	s := &workloadSim{config: config, customSizes: map[*simObject]int{}, events: map[string]*simEvent{}}
+	var err error
+	s.interval, err = parseGeneratorDuration("interval", config.Interval)
+	if err != nil {
+		return nil, err
+	}
+	s.heartbeat, err = parseGeneratorDuration("nodeHeartbeat", config.NodeHeartbeat)
+	if err != nil {
+		return nil, err
+	}
+	for _, scenario := range config.Scenarios {
+		if scenario.Type != ScenarioCrashLoop && scenario.Type != ScenarioNodeFailure && scenario.Type != ScenarioEventStorm {
+			return nil, fmt.Errorf("workload generator scenario %q must be %v, %v or %v", scenario.Type, ScenarioCrashLoop, ScenarioNodeFailure, ScenarioEventStorm)
+		}
+		if scenario.Rate < 0 {
+			return nil, fmt.Errorf("workload generator scenario %v has a negative rate", scenario.Type)
+		}
+		compiled := &simScenario{GeneratorScenario: scenario}
+		compiled.every, err = parseGeneratorDuration(scenario.Type+" every", scenario.Every)
+		if err != nil {
+			return nil, err
+		}
+		compiled.duration, err = parseGeneratorDuration(scenario.Type+" duration", scenario.Duration)
+		if err != nil {
+			return nil, err
+		}
+		s.scenarios = append(s.scenarios, compiled)
+	}
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	s.random = rand.New(rand.NewSource(seed))
+	return s, nil
+}
+
+// Moves the workload on to now and returns what changed.  The first step creates the whole cluster
+func (s *workloadSim) step(now time.Time) []typed.KubeWatchResult {
+	s.now = now
+	s.out = nil
+	if !s.started {
+		s.started = true
+		s.last = now
+		s.populate()
+		for _, scenario := range s.scenarios {
+			scenario.next = now.Add(scenario.every)
+		}
+		return s.out
+	}
+	elapsed := now.Sub(s.last)
+	s.last = now
+
+	s.heartbeats()
+	for i := s.occurrences(s.config.RolloutsPerHour * elapsed.Hours()); i > 0; i-- {
+		if d := s.randomDeployment(); d != nil {
+			s.rollout(d)
+		}
+	}
+	for i := s.occurrences(s.config.PodChurnPerHour * elapsed.Hours()); i > 0; i-- {
+		if p := s.randomPod(); p != nil {
+			s.replacePod(p)
+		}
+	}
+	for i := s.occurrences(s.config.CustomResourceUpdatesPerHour * elapsed.Hours()); i > 0; i-- {
+		if len(s.customResources) > 0 {
+			cr := s.customResources[s.random.Intn(len(s.customResources))]
+			s.customSizes[cr] = 1 + s.random.Intn(10)
+			s.emitCustomResource(typed.KubeWatchResult_UPDATE, cr)
+		}
+	}
+	for _, scenario := range s.scenarios {
+		s.runScenario(scenario, elapsed)
+	}
+	s.expireEvents()
+	return s.out
+}
+
+// Rounds an expected number of occurrences up or down at random, so fractions add up over many steps
+func (s *workloadSim) occurrences(expected float64) int {
+	n := int(expected)
+	if s.random.Float64() < expected-float64(n) {
+		n++
+	}
+	return n
+}
+
+func (s *workloadSim) populate() {
+	s.crd = s.newObject("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", "widgets.demo.sloop.dev", nil)
+	s.emitCustomResourceDefinition()
+	for i := 0; i < s.config.Nodes; i++ {
+		name := fmt.Sprintf("node-%v", i)
+		node := &simNode{simObject: s.newObject("v1", "Node", "", name, map[string]string{"kubernetes.io/hostname": name}), ready: true, lastHeartbeat: s.now, transition: s.now}
+		s.nodes = append(s.nodes, node)
+		s.emitNode(typed.KubeWatchResult_ADD, node)
+	}
+	for i := 0; i < s.config.Namespaces; i++ {
+		namespace := s.newObject("v1", "Namespace", "", fmt.Sprintf("workload-%v", i), nil)
+		s.emit(typed.KubeWatchResult_ADD, &namespace, nil, map[string]interface{}{"status": map[string]interface{}{"phase": "Active"}})
+		for j := 0; j < s.config.DeploymentsPerNamespace; j++ {
+			name := simAppNames[j%len(simAppNames)]
+			if j >= len(simAppNames) {
+				name = fmt.Sprintf("%v-%v", name, j/len(simAppNames))
+			}
+			labels := map[string]string{"app": name, "team": simTeams[s.random.Intn(len(simTeams))]}
+			d := &simDeployment{simObject: s.newObject("apps/v1", "Deployment", namespace.name, name, labels), revision: 1}
+			s.deployments = append(s.deployments, d)
+			s.emitDeployment(typed.KubeWatchResult_ADD, d)
+			s.rollout(d)
+		}
+	}
+	for i := 0; i < s.config.CustomResources; i++ {
+		namespace := "default"
+		if s.config.Namespaces > 0 {
+			namespace = fmt.Sprintf("workload-%v", i%s.config.Namespaces)
+		}
+		cr := s.newObject("demo.sloop.dev/v1", "Widget", namespace, fmt.Sprintf("widget-%v", i), nil)
+		s.customResources = append(s.customResources, &cr)
+		s.customSizes[&cr] = 1 + s.random.Intn(10)
+		s.emitCustomResource(typed.KubeWatchResult_ADD, &cr)
+	}
+}
+
+func (s *workloadSim) heartbeats() {
+	for _, node := range s.nodes {
+		if node.ready && s.now.Sub(node.lastHeartbeat) >= s.heartbeat {
+			node.lastHeartbeat = s.now
+			s.emitNode(typed.KubeWatchResult_UPDATE, node)
+		}
+	}
+}
+
+// Replaces the replica set of a deployment with a new one, like a change to its pod template does.  The first
+// rollout of a deployment creates its first replica set.
+func (s *workloadSim) rollout(d *simDeployment) {
+	if d.replicaSet != nil {
+		d.revision++
+	}
+	hash := s.randomName(10)
+	labels := map[string]string{"app": d.labels["app"], "pod-template-hash": hash}
+	rs := &simReplicaSet{simObject: s.newObject("apps/v1", "ReplicaSet", d.namespace, d.name+"-"+hash, labels), deployment: d, hash: hash}
+	old := d.replicaSet
+	d.replicaSet = rs
+	s.emitReplicaSet(typed.KubeWatchResult_ADD, rs)
+	s.recordEvent(&d.simObject, "Normal", "ScalingReplicaSet", fmt.Sprintf("Scaled up replica set %v to %v", rs.name, s.config.ReplicasPerDeployment))
+	for i := 0; i < s.config.ReplicasPerDeployment; i++ {
+		s.createPod(rs)
+	}
+	s.emitReplicaSet(typed.KubeWatchResult_UPDATE, rs)
+
+	if old != nil {
+		s.recordEvent(&d.simObject, "Normal", "ScalingReplicaSet", fmt.Sprintf("Scaled down replica set %v to 0", old.name))
+		for _, p := range old.pods {
+			s.deletePod(p)
+		}
+		old.pods = nil
+		s.emitReplicaSet(typed.KubeWatchResult_UPDATE, old)
+	}
+	// Only one old replica set is kept around
+	if d.oldReplicaSet != nil {
+		s.emitReplicaSet(typed.KubeWatchResult_DELETE, d.oldReplicaSet)
+	}
+	d.oldReplicaSet = old
+	s.emitDeployment(typed.KubeWatchResult_UPDATE, d)
+}
+
+func (s *workloadSim) createPod(rs *simReplicaSet) *simPod {
+	labels := map[string]string{"app": rs.labels["app"], "pod-template-hash": rs.hash}
+	p := &simPod{simObject: s.newObject("v1", "Pod", rs.namespace, rs.name+"-"+s.randomName(5), labels), replicaSet: rs, node: s.randomReadyNode()}
+	rs.pods = append(rs.pods, p)
+	s.emitPod(typed.KubeWatchResult_ADD, p)
+	if p.node == nil {
+		s.recordEvent(&p.simObject, "Warning", "FailedScheduling", "0/0 nodes are available.")
+		return p
+	}
+	s.recordEvent(&p.simObject, "Normal", "Scheduled", fmt.Sprintf("Successfully assigned %v/%v to %v", p.namespace, p.name, p.node.name))
+	s.recordEvent(&p.simObject, "Normal", "Started", "Started container app")
+	s.emitPod(typed.KubeWatchResult_UPDATE, p)
+	return p
+}
+
+func (s *workloadSim) deletePod(p *simPod) {
+	p.deleted = true
+	s.recordEvent(&p.simObject, "Normal", "Killing", "Stopping container app")
+	s.emitPod(typed.KubeWatchResult_DELETE, p)
+}
+
+// Deletes a pod and lets its replica set create a new one
+func (s *workloadSim) replacePod(p *simPod) {
+	rs := p.replicaSet
+	for i, other := range rs.pods {
+		if other == p {
+			rs.pods = append(rs.pods[:i], rs.pods[i+1:]...)
+			break
+		}
+	}
+	s.deletePod(p)
+	s.createPod(rs)
+	s.emitReplicaSet(typed.KubeWatchResult_UPDATE, rs)
+}
+
+func (s *workloadSim) runScenario(scenario *simScenario, elapsed time.Duration) {
+	if scenario.active && !s.now.Before(scenario.until) {
+		s.endScenario(scenario)
+		scenario.active = false
+	}
+	if !scenario.active && !s.now.Before(scenario.next) {
+		scenario.next = s.now.Add(scenario.every)
+		scenario.until = s.now.Add(scenario.duration)
+		scenario.active = s.startScenario(scenario)
+		return
+	}
+	if !scenario.active {
+		return
+	}
+	switch scenario.Type {
+	case ScenarioCrashLoop:
+		if scenario.pod.deleted {
+			scenario.active = false
+			return
+		}
+		if !s.now.Before(scenario.nextCrash) {
+			s.crash(scenario)
+		}
+	case ScenarioEventStorm:
+		for i := s.occurrences(scenario.Rate * elapsed.Seconds()); i > 0; i-- {
+			p := s.randomPod()
+			if p == nil {
+				return
+			}
+			storm := simStormReasons[s.random.Intn(len(simStormReasons))]
+			s.recordEvent(&p.simObject, "Warning", storm.reason, storm.message)
+		}
+	}
+}
+
+// Returns false when there is nothing for the scenario to happen to
+func (s *workloadSim) startScenario(scenario *simScenario) bool {
+	switch scenario.Type {
+	case ScenarioCrashLoop:
+		scenario.pod = s.randomPod()
+		if scenario.pod == nil || scenario.pod.crashLooping {
+			return false
+		}
+		scenario.pod.crashLooping = true
+		scenario.backoff = 10 * time.Second
+		s.crash(scenario)
+	case ScenarioNodeFailure:
+		scenario.node = s.randomReadyNode()
+		if scenario.node == nil {
+			return false
+		}
+		node := scenario.node
+		node.ready = false
+		node.transition = s.now
+		s.emitNode(typed.KubeWatchResult_UPDATE, node)
+		s.recordEvent(&node.simObject, "Normal", "NodeNotReady", fmt.Sprintf("Node %v status is now: NodeNotReady", node.name))
+		for _, d := range s.deployments {
+			for _, p := range append([]*simPod{}, d.replicaSet.pods...) {
+				if p.node == node {
+					s.recordEvent(&p.simObject, "Normal", "TaintManagerEviction", fmt.Sprintf("Marking for deletion Pod %v/%v", p.namespace, p.name))
+					s.replacePod(p)
+				}
+			}
+		}
+	}
+	return true
+}
+
+func (s *workloadSim) endScenario(scenario *simScenario) {
+	switch scenario.Type {
+	case ScenarioCrashLoop:
+		scenario.pod.crashLooping = false
+		if !scenario.pod.deleted {
+			s.emitPod(typed.KubeWatchResult_UPDATE, scenario.pod)
+		}
+	case ScenarioNodeFailure:
+		node := scenario.node
+		node.ready = true
+		node.transition = s.now
+		node.lastHeartbeat = s.now
+		s.emitNode(typed.KubeWatchResult_UPDATE, node)
+		s.recordEvent(&node.simObject, "Normal", "NodeReady", fmt.Sprintf("Node %v status is now: NodeReady", node.name))
+	}
+}
+
+// Restarts the container of a crash looping pod and doubles its back off
+func (s *workloadSim) crash(scenario *simScenario) {
+	p := scenario.pod
+	p.restarts++
+	s.emitPod(typed.KubeWatchResult_UPDATE, p)
+	s.recordEvent(&p.simObject, "Warning", "BackOff", "Back-off restarting failed container app")
+	scenario.nextCrash = s.now.Add(scenario.backoff)
+	scenario.backoff *= 2
+	if scenario.backoff > simMaxCrashBackoff {
+		scenario.backoff = simMaxCrashBackoff
+	}
+}
+
+// Raises an event, or counts another occurrence of one the object already has
+func (s *workloadSim) recordEvent(involved *simObject, eventType string, reason string, message string) {
+	key := involved.uid + "/" + reason
+	e, ok := s.events[key]
+	watchType := typed.KubeWatchResult_UPDATE
+	if !ok {
+		namespace := involved.namespace
+		if namespace == "" {
+			namespace = "default"
+		}
+		name := fmt.Sprintf("%v.%016x", involved.name, s.random.Uint64())
+		e = &simEvent{simObject: s.newObject("v1", "Event", namespace, name, nil), involved: involved, eventType: eventType, reason: reason, first: s.now}
+		s.events[key] = e
+		watchType = typed.KubeWatchResult_ADD
+	}
+	e.count++
+	e.message = message
+	e.last = s.now
+	s.emitEvent(watchType, e)
+}
+
+// Keys are sorted so the deletes come out in the same order for the same seed
+func (s *workloadSim) expireEvents() {
+	var expired []string
+	for key, e := range s.events {
+		if s.now.Sub(e.last) >= simEventTtl {
+			expired = append(expired, key)
+		}
+	}
+	sort.Strings(expired)
+	for _, key := range expired {
+		e := s.events[key]
+		delete(s.events, key)
+		s.emitEvent(typed.KubeWatchResult_DELETE, e)
+	}
+}
+
+func (s *workloadSim) randomDeployment() *simDeployment {
+	if len(s.deployments) == 0 {
+		return nil
+	}
+	return s.deployments[s.random.Intn(len(s.deployments))]
+}
+
+func (s *workloadSim) randomPod() *simPod {
+	d := s.randomDeployment()
+	if d == nil || len(d.replicaSet.pods) == 0 {
+		return nil
+	}
+	return d.replicaSet.pods[s.random.Intn(len(d.replicaSet.pods))]
+}
+
+func (s *workloadSim) randomReadyNode() *simNode {
+	var ready []*simNode
+	for _, node := range s.nodes {
+		if node.ready {
+			ready = append(ready, node)
+		}
+	}
+	if len(ready) == 0 {
+		return nil
+	}
+	return ready[s.random.Intn(len(ready))]
+}
+
+// Lower case letters and digits, like the suffixes kubernetes generates
+func (s *workloadSim) randomName(length int) string {
+	const alphabet = "bcdfghjklmnpqrstvwxz2456789"
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = alphabet[s.random.Intn(len(alphabet))]
+	}
+	return string(b)
+}
+
+func (s *workloadSim) newObject(apiVersion string, kind string, namespace string, name string, labels map[string]string) simObject {
+	r := s.random
+	uid := fmt.Sprintf("%08x-%04x-%04x-%04x-%012x", r.Uint32(), r.Uint32()&0xffff, r.Uint32()&0xffff, r.Uint32()&0xffff, r.Uint64()&0xffffffffffff)
+	return simObject{apiVersion: apiVersion, kind: kind, namespace: namespace, name: name, uid: uid, labels: labels, created: s.now}
+}
+
+func (s *workloadSim) emit(watchType typed.KubeWatchResult_WatchType, o *simObject, owner *simObject, fields map[string]interface{}) {
+	s.resourceVersion++
+	metadata := map[string]interface{}{
+		"name":              o.name,
+		"uid":               o.uid,
+		"resourceVersion":   strconv.FormatInt(s.resourceVersion, 10),
+		"creationTimestamp": formatSimTime(o.created),
+	}
+	if o.namespace != "" {
+		metadata["namespace"] = o.namespace
+	}
+	if len(o.labels) > 0 {
+		metadata["labels"] = o.labels
+	}
+	if owner != nil {
+		metadata["ownerReferences"] = []interface{}{map[string]interface{}{
+			"apiVersion":         owner.apiVersion,
+			"kind":               owner.kind,
+			"name":               owner.name,
+			"uid":                owner.uid,
+			"controller":         true,
+			"blockOwnerDeletion": true,
+		}}
+	}
+	if watchType == typed.KubeWatchResult_DELETE {
+		metadata["deletionTimestamp"] = formatSimTime(s.now)
+	}
+	payload := map[string]interface{}{"apiVersion": o.apiVersion, "kind": o.kind, "metadata": metadata}
+	for field, value := range fields {
+		payload[field] = value
+	}
+	b, _ := json.Marshal(payload)
+	ts, _ := ptypes.TimestampProto(s.now)
+	s.out = append(s.out, typed.KubeWatchResult{Timestamp: ts, Kind: o.kind, WatchType: watchType, Payload: string(b)})
+}
+
+func (s *workloadSim) emitNode(watchType typed.KubeWatchResult_WatchType, node *simNode) {
+	condition := map[string]interface{}{
+		"type":               "Ready",
+		"status":             "True",
+		"reason":             "KubeletReady",
+		"message":            "kubelet is posting ready status",
+		"lastHeartbeatTime":  formatSimTime(node.lastHeartbeat),
+		"lastTransitionTime": formatSimTime(node.transition),
+	}
+	if !node.ready {
+		condition["status"] = "Unknown"
+		condition["reason"] = "NodeStatusUnknown"
+		condition["message"] = "Kubelet stopped posting node status."
+	}
+	s.emit(watchType, &node.simObject, nil, map[string]interface{}{
+		"spec": map[string]interface{}{"podCIDR": "10.244.0.0/24"},
+		"status": map[string]interface{}{
+			"conditions": []interface{}{condition},
+			"capacity":   map[string]interface{}{"cpu": "8", "memory": "32Gi", "pods": "110"},
+			"nodeInfo":   map[string]interface{}{"kubeletVersion": "v1.28.6", "osImage": "Generated Linux"},
+		},
+	})
+}
+
+func (s *workloadSim) podTemplate(app string, revision int) map[string]interface{} {
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{"labels": map[string]string{"app": app}},
+		"spec": map[string]interface{}{"containers": []interface{}{map[string]interface{}{
+			"name":  "app",
+			"image": fmt.Sprintf("registry.example.com/%v:v%v", app, revision),
+		}}},
+	}
+}
+
+func (s *workloadSim) emitDeployment(watchType typed.KubeWatchResult_WatchType, d *simDeployment) {
+	s.emit(watchType, &d.simObject, nil, map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": s.config.ReplicasPerDeployment,
+			"selector": map[string]interface{}{"matchLabels": map[string]string{"app": d.labels["app"]}},
+			"template": s.podTemplate(d.labels["app"], d.revision),
+		},
+		"status": map[string]interface{}{
+			"observedGeneration": d.revision,
+			"replicas":           s.config.ReplicasPerDeployment,
+			"updatedReplicas":    s.config.ReplicasPerDeployment,
+			"readyReplicas":      s.config.ReplicasPerDeployment,
+		},
+	})
+}
+
+func (s *workloadSim) emitReplicaSet(watchType typed.KubeWatchResult_WatchType, rs *simReplicaSet) {
+	replicas := 0
+	if rs.deployment.replicaSet == rs {
+		replicas = s.config.ReplicasPerDeployment
+	}
+	s.emit(watchType, &rs.simObject, &rs.deployment.simObject, map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": replicas,
+			"selector": map[string]interface{}{"matchLabels": rs.labels},
+			"template": s.podTemplate(rs.labels["app"], rs.deployment.revision),
+		},
+		"status": map[string]interface{}{"replicas": len(rs.pods), "readyReplicas": len(rs.pods)},
+	})
+}
+
+func (s *workloadSim) emitPod(watchType typed.KubeWatchResult_WatchType, p *simPod) {
+	spec := map[string]interface{}{"containers": []interface{}{map[string]interface{}{
+		"name":  "app",
+		"image": fmt.Sprintf("registry.example.com/%v:v%v", p.labels["app"], p.replicaSet.deployment.revision),
+	}}}
+	status := map[string]interface{}{"phase": "Pending"}
+	if p.node != nil {
+		spec["nodeName"] = p.node.name
+		state := map[string]interface{}{"running": map[string]interface{}{"startedAt": formatSimTime(s.now)}}
+		if p.crashLooping {
+			state = map[string]interface{}{"waiting": map[string]interface{}{"reason": "CrashLoopBackOff", "message": "back-off restarting failed container"}}
+		}
+		status = map[string]interface{}{
+			"phase":     "Running",
+			"hostIP":    "10.0.0.1",
+			"startTime": formatSimTime(p.created),
+			"containerStatuses": []interface{}{map[string]interface{}{
+				"name":         "app",
+				"ready":        !p.crashLooping,
+				"restartCount": p.restarts,
+				"state":        state,
+			}},
+		}
+	}
+	s.emit(watchType, &p.simObject, &p.replicaSet.simObject, map[string]interface{}{"spec": spec, "status": status})
+}
+
+func (s *workloadSim) emitEvent(watchType typed.KubeWatchResult_WatchType, e *simEvent) {
+	s.emit(watchType, &e.simObject, nil, map[string]interface{}{
+		"involvedObject": map[string]interface{}{
+			"apiVersion": e.involved.apiVersion,
+			"kind":       e.involved.kind,
+			"namespace":  e.involved.namespace,
+			"name":       e.involved.name,
+			"uid":        e.involved.uid,
+		},
+		"reason":         e.reason,
+		"message":        e.message,
+		"type":           e.eventType,
+		"count":          e.count,
+		"firstTimestamp": formatSimTime(e.first),
+		"lastTimestamp":  formatSimTime(e.last),
+		"source":         map[string]interface{}{"component": "workload-generator"},
+	})
+}
+
+func (s *workloadSim) emitCustomResourceDefinition() {
+	s.emit(typed.KubeWatchResult_ADD, &s.crd, nil, map[string]interface{}{
+		"spec": map[string]interface{}{
+			"group": "demo.sloop.dev",
+			"names": map[string]interface{}{"kind": "Widget", "plural": "widgets", "singular": "widget"},
+			"scope": "Namespaced",
+			"versions": []interface{}{map[string]interface{}{
+				"name":    "v1",
+				"served":  true,
+				"storage": true,
+				"schema":  map[string]interface{}{"openAPIV3Schema": map[string]interface{}{"type": "object", "x-kubernetes-preserve-unknown-fields": true}},
+			}},
+		},
+	})
+}
+
+func (s *workloadSim) emitCustomResource(watchType typed.KubeWatchResult_WatchType, cr *simObject) {
+	s.emit(watchType, cr, nil, map[string]interface{}{
+		"spec":   map[string]interface{}{"size": s.customSizes[cr]},
+		"status": map[string]interface{}{"observedSize": s.customSizes[cr]},
+	})
+}
+
+func formatSimTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
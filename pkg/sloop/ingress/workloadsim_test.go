@@ -0,0 +1,171 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package ingress
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+var someSimStart = time.Date(2019, 3, 4, 5, 0, 0, 0, time.UTC)
+
+// Steps a simulation over the given time a second at a time and returns everything it generated
+func helper_runSim(t *testing.T, config GeneratorConfig, duration time.Duration) []typed.KubeWatchResult {
+	sim, err := newWorkloadSim(config)
+	assert.Nil(t, err)
+	var results []typed.KubeWatchResult
+	for elapsed := time.Duration(0); elapsed <= duration; elapsed += time.Second {
+		results = append(results, sim.step(someSimStart.Add(elapsed))...)
+	}
+	return results
+}
+
+func helper_countByKind(results []typed.KubeWatchResult, watchType typed.KubeWatchResult_WatchType) map[string]int {
+	counts := map[string]int{}
+	for _, result := range results {
+		if result.WatchType == watchType {
+			counts[result.Kind]++
+		}
+	}
+	return counts
+}
+
+func Test_WorkloadSim_Populate(t *testing.T) {
+	config := DefaultGeneratorConfig()
+	config.Seed = 1
+	results := helper_runSim(t, config, 0)
+
+	added := helper_countByKind(results, typed.KubeWatchResult_ADD)
+	assert.Equal(t, 1, added["CustomResourceDefinition"])
+	assert.Equal(t, 5, added["Node"])
+	assert.Equal(t, 3, added["Namespace"])
+	assert.Equal(t, 15, added["Deployment"])
+	assert.Equal(t, 15, added["ReplicaSet"])
+	assert.Equal(t, 45, added["Pod"])
+	assert.Equal(t, 10, added["Widget"])
+
+	// Pods are owned by their replica set, which is owned by its deployment
+	uids := map[string]string{}
+	for _, result := range results {
+		metadata, err := kubeextractor.ExtractMetadata(result.Payload)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, metadata.Uid)
+		uids[metadata.Uid] = result.Kind
+		if result.Kind == "Pod" || result.Kind == "ReplicaSet" {
+			assert.Len(t, metadata.OwnerReferences, 1)
+			assert.Contains(t, uids, metadata.OwnerReferences[0].Uid)
+		}
+		if result.Kind == "Event" {
+			involved, err := kubeextractor.ExtractInvolvedObject(result.Payload)
+			assert.Nil(t, err)
+			assert.Contains(t, uids, involved.Uid)
+		}
+	}
+}
+
+func Test_WorkloadSim_SameSeedSameWorkload(t *testing.T) {
+	config := DefaultGeneratorConfig()
+	config.Seed = 42
+	// Long enough for events to expire, which used to come out in map order
+	first := helper_runSim(t, config, simEventTtl+10*time.Minute)
+	second := helper_runSim(t, config, simEventTtl+10*time.Minute)
+	assert.True(t, helper_countByKind(first, typed.KubeWatchResult_DELETE)["Event"] > 1)
+	assert.Equal(t, len(first), len(second))
+	for i := range first {
+		assert.Equal(t, first[i].String(), second[i].String())
+	}
+}
+
+func Test_WorkloadSim_Scenarios(t *testing.T) {
+	config := DefaultGeneratorConfig()
+	config.Seed = 1
+	config.Scenarios = []GeneratorScenario{
+		{Type: ScenarioCrashLoop, Every: "10m", Duration: "5m"},
+		{Type: ScenarioNodeFailure, Every: "20m", Duration: "5m"},
+		{Type: ScenarioEventStorm, Every: "30m", Duration: "1m", Rate: 10},
+	}
+	results := helper_runSim(t, config, 35*time.Minute)
+
+	reasons := map[string]int{}
+	maxCount := map[string]int{}
+	for _, result := range results {
+		if result.Kind != "Event" || result.WatchType == typed.KubeWatchResult_DELETE {
+			continue
+		}
+		info, err := kubeextractor.ExtractEventInfo(result.Payload)
+		assert.Nil(t, err)
+		reasons[info.Reason]++
+		if info.Count > maxCount[info.Reason] {
+			maxCount[info.Reason] = info.Count
+		}
+	}
+	// The back off grows from 10s to 5m, so a 5 minute crash loop restarts 5 times
+	assert.Equal(t, 5, maxCount["BackOff"])
+	assert.Equal(t, 1, reasons["NodeNotReady"])
+	assert.Equal(t, 1, reasons["NodeReady"])
+	assert.True(t, reasons["TaintManagerEviction"] > 0)
+	storm := reasons["FailedScheduling"] + reasons["FailedMount"] + reasons["Unhealthy"] + reasons["FailedCreatePodSandBox"]
+	assert.InDelta(t, 600, storm, 100)
+}
+
+func Test_WorkloadSim_Churn(t *testing.T) {
+	config := DefaultGeneratorConfig()
+	config.Seed = 1
+	config.Scenarios = nil
+	config.RolloutsPerHour = 60
+	config.PodChurnPerHour = 600
+	results := helper_runSim(t, config, time.Hour)
+
+	deleted := helper_countByKind(results, typed.KubeWatchResult_DELETE)
+	// Each rollout deletes 3 pods, so there are about 180 from rollouts and 600 from churn
+	assert.InDelta(t, 780, deleted["Pod"], 150)
+	// Events are dropped an hour after they were last seen, like the ones from creating the cluster at the end
+	assert.True(t, deleted["Event"] > 0)
+
+	// Nodes report every 10 seconds
+	updated := helper_countByKind(results, typed.KubeWatchResult_UPDATE)
+	assert.Equal(t, 5*360, updated["Node"])
+}
+
+func Test_NewWorkloadSim_BadConfig(t *testing.T) {
+	for _, change := range []func(*GeneratorConfig){
+		func(c *GeneratorConfig) { c.Nodes = -1 },
+		func(c *GeneratorConfig) { c.PodChurnPerHour = -1 },
+		func(c *GeneratorConfig) { c.Interval = "soon" },
+		func(c *GeneratorConfig) { c.NodeHeartbeat = "0s" },
+		func(c *GeneratorConfig) {
+			c.Scenarios = []GeneratorScenario{{Type: "meteor", Every: "1h", Duration: "1m"}}
+		},
+		func(c *GeneratorConfig) { c.Scenarios = []GeneratorScenario{{Type: ScenarioCrashLoop, Every: "1h"}} },
+	} {
+		config := DefaultGeneratorConfig()
+		change(&config)
+		assert.NotNil(t, ValidateGeneratorConfig(config))
+	}
+}
+
+func Test_WorkloadGenerator(t *testing.T) {
+	config := DefaultGeneratorConfig()
+	config.Interval = "10ms"
+	source, err := NewWorkloadGenerator(config)
+	assert.Nil(t, err)
+	outChan, err := source.Init()
+	assert.Nil(t, err)
+	// The whole cluster is generated up front
+	for i := 0; i < 100; i++ {
+		<-outChan
+	}
+	source.Stop()
+	for range outChan {
+	}
+}
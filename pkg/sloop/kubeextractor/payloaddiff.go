@@ -0,0 +1,172 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// One operation of an RFC 6902 JSON Patch.  OldValue is not part of the RFC, but it makes the diff readable
+// without having to look up the previous payload.
+type JsonPatchOp struct {
+	Op       string      `json:"op"`
+	Path     string      `json:"path"`
+	Value    interface{} `json:"value"`
+	OldValue interface{} `json:"oldValue"`
+}
+
+const (
+	JsonPatchAdd     = "add"
+	JsonPatchRemove  = "remove"
+	JsonPatchReplace = "replace"
+)
+
+// Add and replace ops always have a value and remove and replace ops always have an old value, even when it is null,
+// which omitempty would drop
+func (o JsonPatchOp) MarshalJSON() ([]byte, error) {
+	type jsonPatchOp struct {
+		Op       string       `json:"op"`
+		Path     string       `json:"path"`
+		Value    *interface{} `json:"value,omitempty"`
+		OldValue *interface{} `json:"oldValue,omitempty"`
+	}
+	out := jsonPatchOp{Op: o.Op, Path: o.Path}
+	if o.Op != JsonPatchRemove {
+		out.Value = &o.Value
+	}
+	if o.Op != JsonPatchAdd {
+		out.OldValue = &o.OldValue
+	}
+	return json.Marshal(out)
+}
+
+// Fields which change on almost every update without saying anything useful about the object.
+// Same idea as removeResVerAndTimestamp, but applied to every kind.
+var noiseMetadataFields = []string{"resourceVersion", "managedFields", "selfLink"}
+var noiseConditionFields = []string{"lastHeartbeatTime", "lastProbeTime"}
+
+// Parses a payload and drops fields that change without a meaningful state change
+func removeNoiseFields(payload string) (interface{}, error) {
+	var parsed interface{}
+	err := json.Unmarshal([]byte(payload), &parsed)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse payload")
+	}
+	obj, ok := parsed.(map[string]interface{})
+	if !ok {
+		return parsed, nil
+	}
+	if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+		for _, field := range noiseMetadataFields {
+			delete(metadata, field)
+		}
+	}
+	if status, ok := obj["status"].(map[string]interface{}); ok {
+		if conditions, ok := status["conditions"].([]interface{}); ok {
+			for _, condition := range conditions {
+				if c, ok := condition.(map[string]interface{}); ok {
+					for _, field := range noiseConditionFields {
+						delete(c, field)
+					}
+				}
+			}
+		}
+	}
+	return obj, nil
+}
+
+// Returns a JSON Patch which turns the before payload into the after payload, ignoring noise fields.
+// An empty result means the payloads only differ in noise.
+func DiffPayloads(before string, after string) ([]JsonPatchOp, error) {
+	beforeObj, err := removeNoiseFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterObj, err := removeNoiseFields(after)
+	if err != nil {
+		return nil, err
+	}
+	ops := []JsonPatchOp{}
+	diffJsonValues("", beforeObj, afterObj, &ops)
+	return ops, nil
+}
+
+func diffJsonValues(path string, before interface{}, after interface{}, ops *[]JsonPatchOp) {
+	switch b := before.(type) {
+	case map[string]interface{}:
+		if a, ok := after.(map[string]interface{}); ok {
+			diffJsonObjects(path, b, a, ops)
+			return
+		}
+	case []interface{}:
+		if a, ok := after.([]interface{}); ok {
+			diffJsonArrays(path, b, a, ops)
+			return
+		}
+	}
+	if !reflect.DeepEqual(before, after) {
+		*ops = append(*ops, JsonPatchOp{Op: JsonPatchReplace, Path: path, Value: after, OldValue: before})
+	}
+}
+
+func diffJsonObjects(path string, before map[string]interface{}, after map[string]interface{}, ops *[]JsonPatchOp) {
+	// Sort the keys so the patch is stable
+	keys := make([]string, 0, len(before)+len(after))
+	for k := range before {
+		keys = append(keys, k)
+	}
+	for k := range after {
+		if _, ok := before[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		childPath := path + "/" + escapeJsonPointer(k)
+		beforeVal, inBefore := before[k]
+		afterVal, inAfter := after[k]
+		switch {
+		case inBefore && !inAfter:
+			*ops = append(*ops, JsonPatchOp{Op: JsonPatchRemove, Path: childPath, OldValue: beforeVal})
+		case !inBefore && inAfter:
+			*ops = append(*ops, JsonPatchOp{Op: JsonPatchAdd, Path: childPath, Value: afterVal})
+		default:
+			diffJsonValues(childPath, beforeVal, afterVal, ops)
+		}
+	}
+}
+
+// Arrays are compared by index.  This does not detect moves, but keeps the patch valid and easy to follow.
+func diffJsonArrays(path string, before []interface{}, after []interface{}, ops *[]JsonPatchOp) {
+	common := len(before)
+	if len(after) < common {
+		common = len(after)
+	}
+	for idx := 0; idx < common; idx++ {
+		diffJsonValues(path+"/"+strconv.Itoa(idx), before[idx], after[idx], ops)
+	}
+	for idx := common; idx < len(after); idx++ {
+		*ops = append(*ops, JsonPatchOp{Op: JsonPatchAdd, Path: path + "/" + strconv.Itoa(idx), Value: after[idx]})
+	}
+	// Remove from the end so the indexes of earlier operations stay valid
+	for idx := len(before) - 1; idx >= common; idx-- {
+		*ops = append(*ops, JsonPatchOp{Op: JsonPatchRemove, Path: path + "/" + strconv.Itoa(idx), OldValue: before[idx]})
+	}
+}
+
+func escapeJsonPointer(s string) string {
+	s = strings.ReplaceAll(s, "~", "~0")
+	return strings.ReplaceAll(s, "/", "~1")
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package kubeextractor
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/test/assertex"
+)
+
+const diffPodBefore = `{
+  "metadata": {
+    "name": "somepod",
+    "resourceVersion": "100",
+    "managedFields": [{"manager": "kubelet"}],
+    "labels": {"app": "web", "app.kubernetes.io/name": "web"}
+  },
+  "spec": {"containers": [{"name": "c1", "image": "web:1"}]},
+  "status": {
+    "phase": "Pending",
+    "conditions": [{"type": "Ready", "status": "False", "lastProbeTime": "2019-07-19T15:35:56Z"}]
+  }
+}`
+
+const diffPodAfter = `{
+  "metadata": {
+    "name": "somepod",
+    "resourceVersion": "200",
+    "managedFields": [{"manager": "kubelet"}, {"manager": "kubectl"}],
+    "labels": {"app": "web", "tier": "front"}
+  },
+  "spec": {"containers": [{"name": "c1", "image": "web:2"}, {"name": "c2", "image": "sidecar:1"}]},
+  "status": {
+    "phase": "Running",
+    "conditions": [{"type": "Ready", "status": "True", "lastProbeTime": "2019-07-19T15:36:56Z"}]
+  }
+}`
+
+func Test_DiffPayloads(t *testing.T) {
+	ops, err := DiffPayloads(diffPodBefore, diffPodAfter)
+	assert.Nil(t, err)
+	expected := []JsonPatchOp{
+		{Op: JsonPatchRemove, Path: "/metadata/labels/app.kubernetes.io~1name", OldValue: "web"},
+		{Op: JsonPatchAdd, Path: "/metadata/labels/tier", Value: "front"},
+		{Op: JsonPatchReplace, Path: "/spec/containers/0/image", Value: "web:2", OldValue: "web:1"},
+		{Op: JsonPatchAdd, Path: "/spec/containers/1", Value: map[string]interface{}{"name": "c2", "image": "sidecar:1"}},
+		{Op: JsonPatchReplace, Path: "/status/conditions/0/status", Value: "True", OldValue: "False"},
+		{Op: JsonPatchReplace, Path: "/status/phase", Value: "Running", OldValue: "Pending"},
+	}
+	assert.Equal(t, expected, ops)
+}
+
+func Test_DiffPayloads_NoiseOnly(t *testing.T) {
+	before := `{"metadata": {"name": "n", "resourceVersion": "1"}, "status": {"conditions": [{"type": "Ready", "lastHeartbeatTime": "a"}]}}`
+	after := `{"metadata": {"name": "n", "resourceVersion": "2"}, "status": {"conditions": [{"type": "Ready", "lastHeartbeatTime": "b"}]}}`
+	ops, err := DiffPayloads(before, after)
+	assert.Nil(t, err)
+	assert.Len(t, ops, 0)
+}
+
+func Test_DiffPayloads_ArrayShrinks(t *testing.T) {
+	ops, err := DiffPayloads(`{"a": [1, 2, 3]}`, `{"a": [1]}`)
+	assert.Nil(t, err)
+	expected := []JsonPatchOp{
+		{Op: JsonPatchRemove, Path: "/a/2", OldValue: float64(3)},
+		{Op: JsonPatchRemove, Path: "/a/1", OldValue: float64(2)},
+	}
+	assert.Equal(t, expected, ops)
+}
+
+func Test_DiffPayloads_NullValues(t *testing.T) {
+	before := `{"a": "x", "b": null, "spec": {"template": {"metadata": {}}}}`
+	after := `{"a": null, "b": "y", "spec": {"template": {"metadata": {"creationTimestamp": null}}}}`
+	ops, err := DiffPayloads(before, after)
+	assert.Nil(t, err)
+	opsJson, err := json.Marshal(ops)
+	assert.Nil(t, err)
+	expected := `[
+  {"op": "replace", "path": "/a", "value": null, "oldValue": "x"},
+  {"op": "replace", "path": "/b", "value": "y", "oldValue": null},
+  {"op": "add", "path": "/spec/template/metadata/creationTimestamp", "value": null}
+]`
+	assertex.JsonEqual(t, expected, string(opsJson))
+
+	removeJson, err := json.Marshal(JsonPatchOp{Op: JsonPatchRemove, Path: "/a", OldValue: nil})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"op":"remove","path":"/a","oldValue":null}`, string(removeJson))
+}
+
+func Test_DiffPayloads_BadJson(t *testing.T) {
+	_, err := DiffPayloads(`{`, `{}`)
+	assert.NotNil(t, err)
+}
@@ -9,16 +9,30 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"os"
 	"runtime/pprof"
 
 	"github.com/golang/glog"
+	"github.com/salesforce/sloop/pkg/sloop/admin"
 	"github.com/salesforce/sloop/pkg/sloop/server"
 )
 
 var cpuprofile = flag.String("cpuprofile", "", "write profile to file")
 
 func main() {
+	// Subcommands which work on a store without running the server
+	if len(os.Args) > 1 && admin.IsCommand(os.Args[1]) {
+		// The subcommands parse their own flags.  This leaves glog logging to its files instead of the output
+		_ = flag.CommandLine.Parse(nil)
+		err := admin.Run(os.Args[1:], os.Stdout)
+		glog.Flush()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
 	if *cpuprofile != "" {
 		f, err := os.Create(*cpuprofile)
 		if err != nil {
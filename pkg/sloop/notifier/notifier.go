@@ -0,0 +1,272 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	metricNotificationSentCount    = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_notification_sent_count"}, []string{"sink"})
+	metricNotificationRetryCount   = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_notification_retry_count"}, []string{"sink"})
+	metricNotificationFailedCount  = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_notification_failed_count"}, []string{"sink"})
+	metricNotificationDroppedCount = promauto.NewCounterVec(prometheus.CounterOpts{Name: "sloop_notification_dropped_count"}, []string{"sink"})
+)
+
+const sinkQueueSize = 1000
+
+type Config struct {
+	Sinks []SinkConfig
+	// Added to notifications which do not have a context so receivers can tell clusters apart
+	Context string
+	// Number of retries after the first attempt fails
+	MaxRetries int
+	// Delay before the first retry.  It doubles after each attempt up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// The same rule firing for the same object is only sent once per RepeatInterval
+	RepeatInterval time.Duration
+	// Notifications which could not be delivered are appended to this file as json lines.  When empty they are
+	// only logged
+	DeadLetterFile string
+	Timeout        time.Duration
+}
+
+// Notifier sends notifications to the configured sinks in the background.  Each sink has its own queue and worker
+// so a sink which is down and being retried does not hold up the others.
+type Notifier struct {
+	config     Config
+	client     *http.Client
+	queues     map[*sink]chan Notification
+	wg         sync.WaitGroup
+	lock       sync.Mutex
+	lastSent   map[string]time.Time
+	nextPrune  time.Time
+	deadLetter sync.Mutex
+	// Closed by Close so workers stop waiting to retry
+	stop chan struct{}
+}
+
+type deadLetterRecord struct {
+	Time         time.Time    `json:"time"`
+	Sink         string       `json:"sink"`
+	Error        string       `json:"error"`
+	Notification Notification `json:"notification"`
+}
+
+func NewNotifier(config Config) (*Notifier, error) {
+	n := &Notifier{
+		config:   config,
+		client:   &http.Client{Timeout: config.Timeout},
+		queues:   map[*sink]chan Notification{},
+		lastSent: map[string]time.Time{},
+		stop:     make(chan struct{}),
+	}
+	names := map[string]bool{}
+	for _, sinkConfig := range config.Sinks {
+		s, err := newSink(sinkConfig)
+		if err != nil {
+			return nil, err
+		}
+		if names[s.Name] {
+			return nil, fmt.Errorf("duplicate notifier sink name %v", s.Name)
+		}
+		names[s.Name] = true
+		n.queues[s] = make(chan Notification, sinkQueueSize)
+	}
+	return n, nil
+}
+
+func (n *Notifier) Start() {
+	for s, queue := range n.queues {
+		n.wg.Add(1)
+		go n.run(s, queue)
+	}
+}
+
+// Notify queues a notification for every sink and returns without waiting for delivery.  It is safe to call on a
+// nil Notifier, which drops everything.
+func (n *Notifier) Notify(notification Notification) {
+	if n == nil || len(n.queues) == 0 {
+		return
+	}
+	if notification.Context == "" {
+		notification.Context = n.config.Context
+	}
+	if notification.FiredAt.IsZero() {
+		notification.FiredAt = time.Now()
+	}
+
+	n.lock.Lock()
+	key := notification.key()
+	last, ok := n.lastSent[key]
+	if ok && notification.FiredAt.Sub(last) < n.config.RepeatInterval {
+		n.lock.Unlock()
+		glog.V(2).Infof("Not repeating notification %v last sent at %v", key, last)
+		return
+	}
+	n.lastSent[key] = notification.FiredAt
+	n.pruneLastSent(notification.FiredAt)
+	n.lock.Unlock()
+
+	for s, queue := range n.queues {
+		select {
+		case queue <- notification:
+		default:
+			glog.Errorf("Notification queue for sink %v is full, dropping %v", s.Name, key)
+			metricNotificationDroppedCount.WithLabelValues(s.Name).Inc()
+			n.writeDeadLetter(s, notification, errors.New("queue full"))
+		}
+	}
+}
+
+// Forgets notifications sent more than RepeatInterval before now, as they no longer hold back a repeat.  Runs at
+// most once per RepeatInterval.  Must be called with the lock held
+func (n *Notifier) pruneLastSent(now time.Time) {
+	if now.Before(n.nextPrune) {
+		return
+	}
+	for key, last := range n.lastSent {
+		if now.Sub(last) >= n.config.RepeatInterval {
+			delete(n.lastSent, key)
+		}
+	}
+	n.nextPrune = now.Add(n.config.RepeatInterval)
+}
+
+// Close stops accepting notifications and waits for the queued ones to be sent.  Failed sends are not retried once
+// Close is called, they go straight to the dead letter file so shutdown does not wait out a backoff
+func (n *Notifier) Close() {
+	if n == nil {
+		return
+	}
+	close(n.stop)
+	for _, queue := range n.queues {
+		close(queue)
+	}
+	n.wg.Wait()
+}
+
+func (n *Notifier) run(s *sink, queue chan Notification) {
+	defer n.wg.Done()
+	for notification := range queue {
+		err := n.sendWithRetry(s, notification)
+		if err != nil {
+			glog.Errorf("Failed to send notification %v to sink %v: %v", notification.key(), s.Name, err)
+			metricNotificationFailedCount.WithLabelValues(s.Name).Inc()
+			n.writeDeadLetter(s, notification, err)
+			continue
+		}
+		metricNotificationSentCount.WithLabelValues(s.Name).Inc()
+	}
+}
+
+type permanentError struct {
+	error
+}
+
+func (n *Notifier) sendWithRetry(s *sink, notification Notification) error {
+	body, err := s.body(notification)
+	if err != nil {
+		return errors.Wrap(err, "failed to render notification body")
+	}
+	backoff := n.config.InitialBackoff
+	for attempt := 0; ; attempt++ {
+		err = n.send(s, body)
+		if err == nil {
+			return nil
+		}
+		if _, ok := err.(permanentError); ok || attempt >= n.config.MaxRetries {
+			return err
+		}
+		glog.V(2).Infof("Notification %v to sink %v failed, retrying in %v: %v", notification.key(), s.Name, backoff, err)
+		metricNotificationRetryCount.WithLabelValues(s.Name).Inc()
+		if !n.wait(backoff) {
+			return errors.Wrap(err, "notifier closed before retry")
+		}
+		backoff *= 2
+		if backoff > n.config.MaxBackoff {
+			backoff = n.config.MaxBackoff
+		}
+	}
+}
+
+// Waits for d and returns true, or returns false as soon as the notifier is closed
+func (n *Notifier) wait(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-n.stop:
+		return false
+	}
+}
+
+// Server errors and throttling are retried, other failure status codes are returned as a permanentError
+func (n *Notifier) send(s *sink, body []byte) error {
+	request, err := http.NewRequest(http.MethodPost, s.Url, bytes.NewReader(body))
+	if err != nil {
+		return permanentError{err}
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for name, value := range s.Headers {
+		request.Header.Set(name, value)
+	}
+	response, err := n.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	responseBody, _ := ioutil.ReadAll(response.Body)
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return nil
+	}
+	err = fmt.Errorf("sink returned %v: %s", response.Status, responseBody)
+	if response.StatusCode >= 500 || response.StatusCode == http.StatusTooManyRequests {
+		return err
+	}
+	return permanentError{err}
+}
+
+func (n *Notifier) writeDeadLetter(s *sink, notification Notification, sendErr error) {
+	if n.config.DeadLetterFile == "" {
+		return
+	}
+	record := deadLetterRecord{Time: time.Now(), Sink: s.Name, Error: sendErr.Error(), Notification: notification}
+	line, err := json.Marshal(record)
+	if err != nil {
+		glog.Errorf("Failed to marshal dead letter for sink %v: %v", s.Name, err)
+		return
+	}
+
+	n.deadLetter.Lock()
+	defer n.deadLetter.Unlock()
+	f, err := os.OpenFile(n.config.DeadLetterFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		glog.Errorf("Failed to open dead letter file %v: %v", n.config.DeadLetterFile, err)
+		return
+	}
+	defer f.Close()
+	_, err = f.Write(append(line, '\n'))
+	if err != nil {
+		glog.Errorf("Failed to write dead letter file %v: %v", n.config.DeadLetterFile, err)
+	}
+}
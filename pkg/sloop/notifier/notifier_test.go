@@ -0,0 +1,281 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package notifier
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var someFiredAt = time.Date(2019, 3, 4, 3, 30, 0, 0, time.UTC)
+
+var someNotification = Notification{
+	Rule:      "PodBackOff",
+	Severity:  "critical",
+	Kind:      "Pod",
+	Namespace: "someNamespace",
+	Name:      "somePod",
+	Message:   "6 BackOff events for Pod someNamespace/somePod",
+	Count:     6,
+	FiredAt:   someFiredAt,
+}
+
+// An httptest stand-in for a sink which records request bodies and fails the first failCount requests with failStatus
+type fakeSink struct {
+	lock       sync.Mutex
+	bodies     []string
+	headers    []http.Header
+	failCount  int
+	failStatus int
+}
+
+func (f *fakeSink) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	body, _ := ioutil.ReadAll(request.Body)
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.bodies = append(f.bodies, string(body))
+	f.headers = append(f.headers, request.Header)
+	if len(f.bodies) <= f.failCount {
+		writer.WriteHeader(f.failStatus)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+func (f *fakeSink) requestCount() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return len(f.bodies)
+}
+
+func helper_notify(t *testing.T, config Config, notifications ...Notification) {
+	helper_notifyAndWait(t, config, nil, 0, notifications...)
+}
+
+// Like helper_notify, but waits for fake to get wantRequests requests before closing the notifier, as retries stop
+// when it is closed
+func helper_notifyAndWait(t *testing.T, config Config, fake *fakeSink, wantRequests int, notifications ...Notification) {
+	config.MaxBackoff = time.Millisecond
+	notifier, err := NewNotifier(config)
+	assert.Nil(t, err)
+	notifier.Start()
+	for _, notification := range notifications {
+		notifier.Notify(notification)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for fake != nil && fake.requestCount() < wantRequests && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	notifier.Close()
+}
+
+func Test_Notifier_Webhook(t *testing.T) {
+	fake := &fakeSink{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	sinkConfig := SinkConfig{Name: "hook", Type: SinkTypeWebhook, Url: server.URL, Headers: map[string]string{"Authorization": "Bearer someToken"}}
+	helper_notify(t, Config{Sinks: []SinkConfig{sinkConfig}, Context: "someCluster"}, someNotification)
+
+	assert.Len(t, fake.bodies, 1)
+	assert.Equal(t, "Bearer someToken", fake.headers[0].Get("Authorization"))
+	var got Notification
+	assert.Nil(t, json.Unmarshal([]byte(fake.bodies[0]), &got))
+	expected := someNotification
+	expected.Context = "someCluster"
+	assert.Equal(t, expected, got)
+}
+
+func Test_Notifier_WebhookTemplate(t *testing.T) {
+	fake := &fakeSink{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	sinkConfig := SinkConfig{Name: "hook", Type: SinkTypeWebhook, Url: server.URL, Template: `{"summary": "{{.Kind | ToLower}} {{.Name}} fired {{.Rule}}"}`}
+	helper_notify(t, Config{Sinks: []SinkConfig{sinkConfig}}, someNotification)
+
+	assert.Equal(t, []string{`{"summary": "pod somePod fired PodBackOff"}`}, fake.bodies)
+}
+
+func Test_Notifier_Slack(t *testing.T) {
+	fake := &fakeSink{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	sinkConfig := SinkConfig{Name: "slack", Type: SinkTypeSlack, Url: server.URL}
+	helper_notify(t, Config{Sinks: []SinkConfig{sinkConfig}}, someNotification)
+
+	assert.Equal(t, []string{`{"text":"[CRITICAL] PodBackOff: 6 BackOff events for Pod someNamespace/somePod"}`}, fake.bodies)
+}
+
+func Test_Notifier_Alertmanager(t *testing.T) {
+	fake := &fakeSink{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	sinkConfig := SinkConfig{Name: "am", Type: SinkTypeAlertmanager, Url: server.URL}
+	helper_notify(t, Config{Sinks: []SinkConfig{sinkConfig}, Context: "someCluster"}, someNotification)
+
+	assert.Len(t, fake.bodies, 1)
+	var alerts []alertmanagerAlert
+	assert.Nil(t, json.Unmarshal([]byte(fake.bodies[0]), &alerts))
+	assert.Len(t, alerts, 1)
+	assert.Equal(t, "PodBackOff", alerts[0].Labels["alertname"])
+	assert.Equal(t, "critical", alerts[0].Labels["severity"])
+	assert.Equal(t, "somePod", alerts[0].Labels["name"])
+	assert.Equal(t, "someCluster", alerts[0].Labels["context"])
+	assert.Equal(t, someNotification.Message, alerts[0].Annotations["summary"])
+	assert.True(t, someFiredAt.Equal(alerts[0].StartsAt))
+}
+
+func Test_Notifier_RetriesServerErrors(t *testing.T) {
+	fake := &fakeSink{failCount: 2, failStatus: http.StatusServiceUnavailable}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	deadLetterFile := path.Join(t.TempDir(), "deadletter.jsonl")
+	sinkConfig := SinkConfig{Name: "hook", Type: SinkTypeWebhook, Url: server.URL}
+	helper_notifyAndWait(t, Config{Sinks: []SinkConfig{sinkConfig}, MaxRetries: 3, InitialBackoff: time.Millisecond, DeadLetterFile: deadLetterFile}, fake, 3, someNotification)
+
+	assert.Len(t, fake.bodies, 3)
+	_, err := ioutil.ReadFile(deadLetterFile)
+	assert.NotNil(t, err)
+}
+
+func Test_Notifier_DeadLetter(t *testing.T) {
+	fake := &fakeSink{failCount: 100, failStatus: http.StatusInternalServerError}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	deadLetterFile := path.Join(t.TempDir(), "deadletter.jsonl")
+	sinkConfig := SinkConfig{Name: "hook", Type: SinkTypeWebhook, Url: server.URL}
+	helper_notifyAndWait(t, Config{Sinks: []SinkConfig{sinkConfig}, MaxRetries: 2, InitialBackoff: time.Millisecond, DeadLetterFile: deadLetterFile}, fake, 3, someNotification)
+
+	assert.Len(t, fake.bodies, 3)
+	data, err := ioutil.ReadFile(deadLetterFile)
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Len(t, lines, 1)
+	var record deadLetterRecord
+	assert.Nil(t, json.Unmarshal([]byte(lines[0]), &record))
+	assert.Equal(t, "hook", record.Sink)
+	assert.Equal(t, "PodBackOff", record.Notification.Rule)
+	assert.Contains(t, record.Error, "500")
+}
+
+func Test_Notifier_DoesNotRetryClientErrors(t *testing.T) {
+	fake := &fakeSink{failCount: 100, failStatus: http.StatusBadRequest}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	deadLetterFile := path.Join(t.TempDir(), "deadletter.jsonl")
+	sinkConfig := SinkConfig{Name: "hook", Type: SinkTypeWebhook, Url: server.URL}
+	helper_notify(t, Config{Sinks: []SinkConfig{sinkConfig}, MaxRetries: 5, InitialBackoff: time.Millisecond, DeadLetterFile: deadLetterFile}, someNotification)
+
+	assert.Len(t, fake.bodies, 1)
+	_, err := ioutil.ReadFile(deadLetterFile)
+	assert.Nil(t, err)
+}
+
+func Test_Notifier_RepeatInterval(t *testing.T) {
+	fake := &fakeSink{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	later := someNotification
+	later.FiredAt = someFiredAt.Add(10 * time.Minute)
+	muchLater := someNotification
+	muchLater.FiredAt = someFiredAt.Add(2 * time.Hour)
+	otherPod := someNotification
+	otherPod.Name = "otherPod"
+
+	sinkConfig := SinkConfig{Name: "hook", Type: SinkTypeWebhook, Url: server.URL, Template: "{{.Name}} {{.FiredAt.Unix}}"}
+	helper_notify(t, Config{Sinks: []SinkConfig{sinkConfig}, RepeatInterval: time.Hour}, someNotification, later, otherPod, muchLater)
+
+	assert.Equal(t, []string{"somePod 1551670200", "otherPod 1551670200", "somePod 1551677400"}, fake.bodies)
+}
+
+func Test_Notifier_PrunesLastSent(t *testing.T) {
+	fake := &fakeSink{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	sinkConfig := SinkConfig{Name: "hook", Type: SinkTypeWebhook, Url: server.URL}
+	notifier, err := NewNotifier(Config{Sinks: []SinkConfig{sinkConfig}, RepeatInterval: time.Hour})
+	assert.Nil(t, err)
+	notifier.Start()
+	defer notifier.Close()
+
+	otherPod := someNotification
+	otherPod.Name = "otherPod"
+	otherPod.FiredAt = someFiredAt.Add(2 * time.Hour)
+	notifier.Notify(someNotification)
+	notifier.Notify(otherPod)
+
+	notifier.lock.Lock()
+	defer notifier.lock.Unlock()
+	assert.Len(t, notifier.lastSent, 1)
+	assert.Contains(t, notifier.lastSent, otherPod.key())
+}
+
+func Test_Notifier_CloseDoesNotWaitForBackoff(t *testing.T) {
+	fake := &fakeSink{failCount: 100, failStatus: http.StatusServiceUnavailable}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	deadLetterFile := path.Join(t.TempDir(), "deadletter.jsonl")
+	sinkConfig := SinkConfig{Name: "hook", Type: SinkTypeWebhook, Url: server.URL}
+	config := Config{Sinks: []SinkConfig{sinkConfig}, MaxRetries: 5, InitialBackoff: time.Hour, MaxBackoff: time.Hour, DeadLetterFile: deadLetterFile}
+	notifier, err := NewNotifier(config)
+	assert.Nil(t, err)
+	notifier.Start()
+	notifier.Notify(someNotification)
+	for fake.requestCount() < 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	before := time.Now()
+	notifier.Close()
+	assert.True(t, time.Since(before) < time.Minute)
+	assert.Equal(t, 1, fake.requestCount())
+	data, err := ioutil.ReadFile(deadLetterFile)
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), "notifier closed before retry")
+}
+
+func Test_NewNotifier_BadSinks(t *testing.T) {
+	badSinks := []SinkConfig{
+		{Type: SinkTypeWebhook, Url: "http://localhost"},
+		{Name: "noUrl", Type: SinkTypeWebhook},
+		{Name: "badType", Type: "email", Url: "http://localhost"},
+		{Name: "badTemplate", Type: SinkTypeSlack, Url: "http://localhost", Template: "{{.Rule"},
+	}
+	for _, sinkConfig := range badSinks {
+		_, err := NewNotifier(Config{Sinks: []SinkConfig{sinkConfig}})
+		assert.NotNil(t, err, sinkConfig.Name)
+	}
+
+	sinkConfig := SinkConfig{Name: "hook", Type: SinkTypeWebhook, Url: "http://localhost"}
+	_, err := NewNotifier(Config{Sinks: []SinkConfig{sinkConfig, sinkConfig}})
+	assert.NotNil(t, err)
+}
+
+func Test_Notifier_NilIsNoop(t *testing.T) {
+	var notifier *Notifier
+	notifier.Notify(someNotification)
+	notifier.Close()
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const (
+	SinkTypeWebhook      = "webhook"
+	SinkTypeSlack        = "slack"
+	SinkTypeAlertmanager = "alertmanager"
+
+	defaultSlackTemplate = "[{{.Severity | ToUpper}}] {{.Rule}}: {{.Message}}"
+)
+
+// A destination for notifications from the config file.
+//
+// Template is a text/template rendered with a Notification.  For webhook sinks it is the whole request body and
+// defaults to the Notification as json.  For slack sinks it is the message text and for alertmanager sinks it is the
+// summary annotation.
+type SinkConfig struct {
+	Name     string            `json:"name"`
+	Type     string            `json:"type"`
+	Url      string            `json:"url"`
+	Headers  map[string]string `json:"headers"`
+	Template string            `json:"template"`
+}
+
+// The message sent to sinks, usually for an alert which fired
+type Notification struct {
+	Context   string    `json:"context"`
+	Rule      string    `json:"rule"`
+	Severity  string    `json:"severity"`
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Message   string    `json:"message"`
+	Count     int       `json:"count"`
+	FiredAt   time.Time `json:"firedAt"`
+}
+
+// Identifies repeats of the same notification
+func (n Notification) key() string {
+	return n.Context + "/" + n.Rule + "/" + n.Kind + "/" + n.Namespace + "/" + n.Name
+}
+
+type alertmanagerAlert struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	StartsAt    time.Time         `json:"startsAt"`
+}
+
+type sink struct {
+	SinkConfig
+	template *template.Template
+}
+
+func newSink(config SinkConfig) (*sink, error) {
+	if config.Name == "" {
+		return nil, fmt.Errorf("notifier sink is missing a name")
+	}
+	if config.Url == "" {
+		return nil, fmt.Errorf("notifier sink %v is missing a url", config.Name)
+	}
+	switch config.Type {
+	case SinkTypeWebhook, SinkTypeSlack, SinkTypeAlertmanager:
+	default:
+		return nil, fmt.Errorf("notifier sink %v has unknown type %q", config.Name, config.Type)
+	}
+
+	s := &sink{SinkConfig: config}
+	templateText := config.Template
+	if templateText == "" && config.Type == SinkTypeSlack {
+		templateText = defaultSlackTemplate
+	}
+	if templateText != "" {
+		funcMap := template.FuncMap{
+			"ToUpper": strings.ToUpper,
+			"ToLower": strings.ToLower,
+		}
+		tmpl, err := template.New(config.Name).Funcs(funcMap).Parse(templateText)
+		if err != nil {
+			return nil, fmt.Errorf("notifier sink %v has bad template: %v", config.Name, err)
+		}
+		s.template = tmpl
+	}
+	return s, nil
+}
+
+func (s *sink) renderTemplate(n Notification) (string, error) {
+	var buf bytes.Buffer
+	err := s.template.Execute(&buf, n)
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Returns the request body for this sink's payload format
+func (s *sink) body(n Notification) ([]byte, error) {
+	switch s.Type {
+	case SinkTypeSlack:
+		text, err := s.renderTemplate(n)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(map[string]string{"text": text})
+	case SinkTypeAlertmanager:
+		summary := n.Message
+		if s.template != nil {
+			var err error
+			summary, err = s.renderTemplate(n)
+			if err != nil {
+				return nil, err
+			}
+		}
+		alert := alertmanagerAlert{
+			Labels: map[string]string{
+				"alertname": n.Rule,
+				"severity":  n.Severity,
+				"kind":      n.Kind,
+				"namespace": n.Namespace,
+				"name":      n.Name,
+				"context":   n.Context,
+			},
+			Annotations: map[string]string{"summary": summary},
+			StartsAt:    n.FiredAt,
+		}
+		return json.Marshal([]alertmanagerAlert{alert})
+	default:
+		if s.template == nil {
+			return json.Marshal(n)
+		}
+		text, err := s.renderTemplate(n)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(text), nil
+	}
+}
@@ -8,33 +8,107 @@
 package processing
 
 import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/dgraph-io/badger/v2"
 	"github.com/golang/glog"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/salesforce/sloop/pkg/sloop/alerting"
 	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/notifier"
 	"github.com/salesforce/sloop/pkg/sloop/store/typed"
 	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
 )
 
+const (
+	// Depth of the channel in front of each worker
+	workerQueueSize = 1000
+	// Number of times a transaction is retried when it conflicts with a transaction from another worker
+	maxConflictRetries = 5
+)
+
 type Runner struct {
 	kubeWatchChan        chan typed.KubeWatchResult
 	tables               typed.Tables
 	inputWg              *sync.WaitGroup
 	keepMinorNodeUpdates bool
 	maxLookback          time.Duration
+	workerCount          int
+	batchSize            int
+	alertEngine          *alerting.Engine
+	// Unix nanoseconds when a batch was last written, for cluster health
+	lastProcessed atomic.Int64
+}
+
+// A watch record along with the metadata used to pick its worker
+type processingItem struct {
+	watchRec         typed.KubeWatchResult
+	resourceMetadata kubeextractor.KubeMetadata
+	involvedObject   kubeextractor.KubeInvolvedObject
+	objectKey        string
+	// Picks the worker.  It is the object key, except for events where it is the key of the involved object
+	shardKey string
+	// Notifications for the alerts this record fired, sent once the alert table update has committed
+	notifications []notifier.Notification
+}
+
+// A processing stage writes one table for a single watch record
+type processingStage struct {
+	name     string
+	updateFn func(r *Runner, txn badgerwrap.Txn, item *processingItem) error
+}
+
+// Processing event count first so it can easily find the previous copy of the event
+// If we update watchTable first then this will see the new event and think it is a dupe
+var processingStages = []processingStage{
+	{name: "updateEventCountTable", updateFn: func(r *Runner, txn badgerwrap.Txn, item *processingItem) error {
+		return updateEventCountTable(r.tables, txn, &item.watchRec, &item.resourceMetadata, &item.involvedObject, r.maxLookback)
+	}},
+	{name: "updateWatchActivityTable", updateFn: func(r *Runner, txn badgerwrap.Txn, item *processingItem) error {
+		return updateWatchActivityTable(r.tables, txn, &item.watchRec, &item.resourceMetadata)
+	}},
+	{name: "updateKubeWatchTable", updateFn: func(r *Runner, txn badgerwrap.Txn, item *processingItem) error {
+		return updateKubeWatchTable(r.tables, txn, &item.watchRec, &item.resourceMetadata, r.keepMinorNodeUpdates)
+	}},
+	{name: "updateResourceSummaryTable", updateFn: func(r *Runner, txn badgerwrap.Txn, item *processingItem) error {
+		return updateResourceSummaryTable(r.tables, txn, &item.watchRec, &item.resourceMetadata)
+	}},
+	{name: "updateResourceIndexTable", updateFn: func(r *Runner, txn badgerwrap.Txn, item *processingItem) error {
+		return updateResourceIndexTable(r.tables, txn, &item.watchRec, &item.resourceMetadata)
+	}},
+	// Runs last so event rules see the counts written for this record
+	{name: "updateAlertTable", updateFn: func(r *Runner, txn badgerwrap.Txn, item *processingItem) error {
+		var err error
+		item.notifications, err = r.alertEngine.Evaluate(r.tables, txn, &item.watchRec, &item.resourceMetadata, &item.involvedObject)
+		return err
+	}},
 }
 
 var (
 	metricProcessingWatchtableUpdatecount = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_processing_watchtable_updatecount"})
 	metricIngestionFailureCount           = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_ingestion_failure_count"})
 	metricIngestionSuccessCount           = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_ingestion_success_count"})
+	metricProcessingQueueDepth            = promauto.NewGaugeVec(prometheus.GaugeOpts{Name: "sloop_processing_queue_depth"}, []string{"queue"})
+	metricProcessingStageLatency          = promauto.NewHistogramVec(prometheus.HistogramOpts{Name: "sloop_processing_stage_latency_sec", Buckets: prometheus.ExponentialBuckets(0.0001, 4, 10)}, []string{"stage"})
+	metricProcessingBatchSize             = promauto.NewHistogram(prometheus.HistogramOpts{Name: "sloop_processing_batch_size", Buckets: prometheus.ExponentialBuckets(1, 2, 10)})
+	metricProcessingConflictCount         = promauto.NewCounter(prometheus.CounterOpts{Name: "sloop_processing_conflict_count"})
 )
 
-func NewProcessing(kubeWatchChan chan typed.KubeWatchResult, tables typed.Tables, keepMinorNodeUpdates bool, maxLookback time.Duration) *Runner {
-	return &Runner{kubeWatchChan: kubeWatchChan, tables: tables, inputWg: &sync.WaitGroup{}, keepMinorNodeUpdates: keepMinorNodeUpdates, maxLookback: maxLookback}
+func NewProcessing(kubeWatchChan chan typed.KubeWatchResult, tables typed.Tables, keepMinorNodeUpdates bool, maxLookback time.Duration, workerCount int, batchSize int, alertEngine *alerting.Engine) *Runner {
+	if workerCount < 1 {
+		workerCount = 1
+	}
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	return &Runner{kubeWatchChan: kubeWatchChan, tables: tables, inputWg: &sync.WaitGroup{}, keepMinorNodeUpdates: keepMinorNodeUpdates,
+		maxLookback: maxLookback, workerCount: workerCount, batchSize: batchSize, alertEngine: alertEngine}
 }
 
 func (r *Runner) processingFailed(name string, err error) {
@@ -42,57 +116,182 @@ func (r *Runner) processingFailed(name string, err error) {
 	metricIngestionFailureCount.Inc()
 }
 
+// Start reads kubeWatchChan and hands each record to one of workerCount workers.  Records are routed by
+// kind/namespace/name so all updates to an object go to the same worker and are processed in order.  Events are
+// routed by their involved object, so the same worker also writes the event counts of that object.
 func (r *Runner) Start() {
+	workerChans := make([]chan *processingItem, r.workerCount)
+	workersWg := &sync.WaitGroup{}
+	for idx := range workerChans {
+		workerChans[idx] = make(chan *processingItem, workerQueueSize)
+		workersWg.Add(1)
+		go r.runWorker(strconv.Itoa(idx), workerChans[idx], workersWg)
+	}
+
 	r.inputWg.Add(1)
 	go func() {
 		for {
+			metricProcessingQueueDepth.WithLabelValues("input").Set(float64(len(r.kubeWatchChan)))
 			watchRec, more := <-r.kubeWatchChan
 			if !more {
+				for _, workerChan := range workerChans {
+					close(workerChan)
+				}
+				workersWg.Wait()
 				r.inputWg.Done()
 				return
 			}
 
-			resourceMetadata, err := kubeextractor.ExtractMetadata(watchRec.Payload)
-			if err != nil {
-				r.processingFailed("cannot extract resource metadata", err)
-			}
-			glog.V(99).Infof("watchRec metadata: %v", resourceMetadata)
-			involvedObject, err := kubeextractor.ExtractInvolvedObject(watchRec.Payload)
-			if err != nil {
-				r.processingFailed("cannot extract involved object", err)
-			}
+			item := r.extractItem(watchRec)
+			workerChans[shardForKey(item.shardKey, r.workerCount)] <- item
+		}
+	}()
+}
 
-			// Processing event count first so it can easily find the previous copy of the event
-			// If we update watchTable first then this will see the new event and think it is a dupe
-			err = r.tables.Db().Update(func(txn badgerwrap.Txn) error {
-				return updateEventCountTable(r.tables, txn, &watchRec, &resourceMetadata, &involvedObject, r.maxLookback)
-			})
-			if err != nil {
-				r.processingFailed("updateEventCountTable", err)
+func (r *Runner) extractItem(watchRec typed.KubeWatchResult) *processingItem {
+	before := time.Now()
+	defer func() { metricProcessingStageLatency.WithLabelValues("extract").Observe(time.Since(before).Seconds()) }()
+
+	resourceMetadata, err := kubeextractor.ExtractMetadata(watchRec.Payload)
+	if err != nil {
+		r.processingFailed("cannot extract resource metadata", err)
+	}
+	glog.V(99).Infof("watchRec metadata: %v", resourceMetadata)
+	involvedObject, err := kubeextractor.ExtractInvolvedObject(watchRec.Payload)
+	if err != nil {
+		r.processingFailed("cannot extract involved object", err)
+	}
+	item := &processingItem{
+		watchRec:         watchRec,
+		resourceMetadata: resourceMetadata,
+		involvedObject:   involvedObject,
+		objectKey:        fmt.Sprintf("%v/%v/%v", watchRec.Kind, resourceMetadata.Namespace, resourceMetadata.Name),
+	}
+	item.shardKey = item.objectKey
+	// Events update the event counts of the object they are about, so all the events for an object go to one worker
+	if watchRec.Kind == kubeextractor.EventKind && involvedObject.Name != "" {
+		item.shardKey = fmt.Sprintf("%v/%v/%v", involvedObject.Kind, involvedObject.Namespace, involvedObject.Name)
+	}
+	return item
+}
+
+func shardForKey(objectKey string, workerCount int) int {
+	h := fnv.New32a()
+	h.Write([]byte(objectKey))
+	return int(h.Sum32() % uint32(workerCount))
+}
+
+// Each worker takes whatever is queued up to batchSize records and writes them in one transaction per stage.
+// A batch never holds two records for the same object, because the stages for a record need to see the
+// previous record of that object in the tables.  Such a record is carried over to the next batch instead.
+func (r *Runner) runWorker(name string, workerChan chan *processingItem, wg *sync.WaitGroup) {
+	defer wg.Done()
+	var carry *processingItem
+	for {
+		batch := make([]*processingItem, 0, r.batchSize)
+		if carry != nil {
+			batch = append(batch, carry)
+			carry = nil
+		} else {
+			item, more := <-workerChan
+			if !more {
+				return
 			}
+			batch = append(batch, item)
+		}
 
-			err = r.tables.Db().Update(func(txn badgerwrap.Txn) error {
-				return updateWatchActivityTable(r.tables, txn, &watchRec, &resourceMetadata)
-			})
-			if err != nil {
-				r.processingFailed("updateWatchActivityTable", err)
+		objectKeys := map[string]bool{batch[0].objectKey: true}
+		closed := false
+	drain:
+		for len(batch) < r.batchSize {
+			select {
+			case item, more := <-workerChan:
+				if !more {
+					closed = true
+					break drain
+				}
+				if objectKeys[item.objectKey] {
+					carry = item
+					break drain
+				}
+				objectKeys[item.objectKey] = true
+				batch = append(batch, item)
+			default:
+				break drain
 			}
+		}
 
-			err = r.tables.Db().Update(func(txn badgerwrap.Txn) error {
-				return updateKubeWatchTable(r.tables, txn, &watchRec, &resourceMetadata, r.keepMinorNodeUpdates)
-			})
-			if err != nil {
-				r.processingFailed("updateKubeWatchTable", err)
+		metricProcessingQueueDepth.WithLabelValues(name).Set(float64(len(workerChan)))
+		metricProcessingBatchSize.Observe(float64(len(batch)))
+		r.processBatch(batch)
+		r.lastProcessed.Store(time.Now().UnixNano())
+		if closed {
+			return
+		}
+	}
+}
+
+func (r *Runner) processBatch(batch []*processingItem) {
+	for _, stage := range processingStages {
+		before := time.Now()
+		err := r.updateWithRetry(func(txn badgerwrap.Txn) error {
+			for _, item := range batch {
+				err := stage.updateFn(r, txn, item)
+				if err != nil {
+					return err
+				}
 			}
+			return nil
+		})
+		metricProcessingStageLatency.WithLabelValues(stage.name).Observe(time.Since(before).Seconds())
+		if err == nil {
+			continue
+		}
+		if len(batch) == 1 {
+			r.processingFailed(stage.name, err)
+			batch[0].notifications = nil
+			continue
+		}
 
-			err = r.tables.Db().Update(func(txn badgerwrap.Txn) error {
-				return updateResourceSummaryTable(r.tables, txn, &watchRec, &resourceMetadata)
+		// Write records one at a time so a bad record (or a transaction that is too big) does not fail the whole batch
+		glog.V(2).Infof("Batch of %v for %v failed with error %v, retrying records individually", len(batch), stage.name, err)
+		for _, item := range batch {
+			err = r.updateWithRetry(func(txn badgerwrap.Txn) error {
+				return stage.updateFn(r, txn, item)
 			})
 			if err != nil {
-				r.processingFailed("updateResourceSummaryTable", err)
+				r.processingFailed(stage.name, err)
+				item.notifications = nil
 			}
 		}
-	}()
+	}
+
+	// Only alerts which were committed are sent
+	for _, item := range batch {
+		r.alertEngine.Notify(item.notifications)
+	}
+}
+
+// Workers can touch the same keys, for example two events for the same pod update its event counts
+func (r *Runner) updateWithRetry(fn func(txn badgerwrap.Txn) error) error {
+	var err error
+	for attempt := 0; attempt <= maxConflictRetries; attempt++ {
+		err = r.tables.Db().Update(fn)
+		if err != badger.ErrConflict {
+			return err
+		}
+		metricProcessingConflictCount.Inc()
+	}
+	return err
+}
+
+// LastProcessed returns when a watch record was last written to the store, or the zero time if none have been
+func (r *Runner) LastProcessed() time.Time {
+	nanos := r.lastProcessed.Load()
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
 }
 
 func (r *Runner) Wait() {
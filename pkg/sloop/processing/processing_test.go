@@ -0,0 +1,173 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/alerting"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/notifier"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+const someRunnerPodTemplate = `{"metadata": {"name": "%v", "namespace": "someNamespace", "uid": "%v-uid", "creationTimestamp": "2019-03-04T03:00:00Z"}, "status": {"phase": "%v"}}`
+
+func helper_runRunner(t *testing.T, workerCount int, batchSize int, recs []typed.KubeWatchResult) typed.Tables {
+	return helper_runRunnerWithAlerts(t, workerCount, batchSize, recs, nil)
+}
+
+func helper_runRunnerWithAlerts(t *testing.T, workerCount int, batchSize int, recs []typed.KubeWatchResult, alertEngine *alerting.Engine) typed.Tables {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	kubeWatchChan := make(chan typed.KubeWatchResult, len(recs))
+	for _, rec := range recs {
+		kubeWatchChan <- rec
+	}
+	close(kubeWatchChan)
+
+	runner := NewProcessing(kubeWatchChan, tables, false, time.Hour, workerCount, batchSize, alertEngine)
+	runner.Start()
+	runner.Wait()
+	return tables
+}
+
+func helper_podWatchResult(t *testing.T, name string, phase string, ts time.Time) typed.KubeWatchResult {
+	pTs, err := ptypes.TimestampProto(ts)
+	assert.Nil(t, err)
+	return typed.KubeWatchResult{Kind: "Pod", WatchType: typed.KubeWatchResult_UPDATE, Timestamp: pTs, Payload: fmt.Sprintf(someRunnerPodTemplate, name, name, phase)}
+}
+
+func Test_Runner_WritesAllRecordsInOrder(t *testing.T) {
+	recs := []typed.KubeWatchResult{}
+	phases := []string{"Pending", "Running", "Failed"}
+	for idx, phase := range phases {
+		for pod := 0; pod < 10; pod++ {
+			recs = append(recs, helper_podWatchResult(t, fmt.Sprintf("pod%v", pod), phase, someWatchTime.Add(time.Duration(idx)*time.Minute)))
+		}
+	}
+
+	tables := helper_runRunner(t, 3, 4, recs)
+
+	err := tables.Db().View(func(txn badgerwrap.Txn) error {
+		watchKeys, err := tables.WatchTable().GetUniquePartitionList(txn)
+		assert.Nil(t, err)
+		assert.Len(t, watchKeys, 1)
+
+		for pod := 0; pod < 10; pod++ {
+			name := fmt.Sprintf("pod%v", pod)
+			for idx, phase := range phases {
+				key := typed.NewWatchTableKey(untyped.GetPartitionId(someWatchTime), "Pod", "someNamespace", name, someWatchTime.Add(time.Duration(idx)*time.Minute))
+				val, err := tables.WatchTable().Get(txn, key.String())
+				assert.Nil(t, err)
+				assert.Equal(t, fmt.Sprintf(someRunnerPodTemplate, name, name, phase), val.Payload)
+			}
+
+			// Last seen only moves forward if the updates for a pod were processed in order
+			resSumKey := typed.NewResourceSummaryKey(someWatchTime, "Pod", "someNamespace", name, name+"-uid")
+			resSum, err := tables.ResourceSummaryTable().Get(txn, resSumKey.String())
+			assert.Nil(t, err)
+			lastSeen, err := ptypes.Timestamp(resSum.LastSeen)
+			assert.Nil(t, err)
+			assert.Equal(t, someWatchTime.Add(2*time.Minute), lastSeen)
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+}
+
+func Test_Runner_SingleWorkerNoBatching(t *testing.T) {
+	recs := []typed.KubeWatchResult{
+		helper_podWatchResult(t, "pod1", "Pending", someWatchTime),
+		helper_podWatchResult(t, "pod1", "Running", someWatchTime.Add(time.Minute)),
+	}
+
+	tables := helper_runRunner(t, 0, 0, recs)
+
+	err := tables.Db().View(func(txn badgerwrap.Txn) error {
+		key := typed.NewWatchTableKey(untyped.GetPartitionId(someWatchTime), "Pod", "someNamespace", "pod1", someWatchTime.Add(time.Minute))
+		val, err := tables.WatchTable().Get(txn, key.String())
+		assert.Nil(t, err)
+		assert.Equal(t, fmt.Sprintf(someRunnerPodTemplate, "pod1", "pod1", "Running"), val.Payload)
+		return nil
+	})
+	assert.Nil(t, err)
+}
+
+func Test_Runner_NotifiesCommittedAlerts(t *testing.T) {
+	lock := sync.Mutex{}
+	names := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var notification notifier.Notification
+		assert.Nil(t, json.NewDecoder(request.Body).Decode(&notification))
+		lock.Lock()
+		defer lock.Unlock()
+		names = append(names, notification.Name)
+	}))
+	defer server.Close()
+	sinks := []notifier.SinkConfig{{Name: "hook", Type: notifier.SinkTypeWebhook, Url: server.URL}}
+	n, err := notifier.NewNotifier(notifier.Config{Sinks: sinks, RepeatInterval: time.Hour})
+	assert.Nil(t, err)
+	n.Start()
+	rule := alerting.Rule{Name: "PodFailed", Kind: "Pod", Logic: map[string]any{"==": []any{map[string]any{"var": "status.phase"}, "Failed"}}}
+	engine, err := alerting.NewEngine([]alerting.Rule{rule}, n, "")
+	assert.Nil(t, err)
+
+	recs := []typed.KubeWatchResult{
+		helper_podWatchResult(t, "pod1", "Running", someWatchTime),
+		helper_podWatchResult(t, "pod2", "Failed", someWatchTime),
+	}
+	tables := helper_runRunnerWithAlerts(t, 1, 2, recs, engine)
+	n.Close()
+
+	assert.Equal(t, []string{"pod2"}, names)
+	err = tables.Db().View(func(txn badgerwrap.Txn) error {
+		alert, err := tables.AlertTable().Get(txn, typed.NewAlertKey(someWatchTime, "Pod", "someNamespace", "pod2", "PodFailed").String())
+		assert.Nil(t, err)
+		assert.Equal(t, int32(1), alert.FireCount)
+		return nil
+	})
+	assert.Nil(t, err)
+}
+
+func Test_Runner_ShardsEventsByInvolvedObject(t *testing.T) {
+	runner := NewProcessing(nil, nil, false, time.Hour, 8, 1, nil)
+	event := `{"metadata": {"name": "%v", "namespace": "someNamespace"}, "involvedObject": {"kind": "Pod", "name": "somePod", "namespace": "someNamespace"}, "reason": "BackOff"}`
+	pod := runner.extractItem(helper_podWatchResult(t, "somePod", "Running", someWatchTime))
+	first := runner.extractItem(typed.KubeWatchResult{Kind: kubeextractor.EventKind, Payload: fmt.Sprintf(event, "somePod.1")})
+	second := runner.extractItem(typed.KubeWatchResult{Kind: kubeextractor.EventKind, Payload: fmt.Sprintf(event, "somePod.2")})
+
+	assert.Equal(t, "Pod/someNamespace/somePod", first.shardKey)
+	assert.Equal(t, pod.shardKey, first.shardKey)
+	assert.Equal(t, first.shardKey, second.shardKey)
+	// Batches still tell the events apart
+	assert.NotEqual(t, first.objectKey, second.objectKey)
+}
+
+func Test_shardForKey(t *testing.T) {
+	assert.Equal(t, shardForKey("Pod/someNamespace/someName", 8), shardForKey("Pod/someNamespace/someName", 8))
+	for idx := 0; idx < 100; idx++ {
+		shard := shardForKey(fmt.Sprintf("Pod/someNamespace/pod%v", idx), 8)
+		assert.True(t, shard >= 0 && shard < 8)
+	}
+	assert.Equal(t, 0, shardForKey("Pod/someNamespace/someName", 1))
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Adds the resource to the index entry of each term it has, once per partition.  Terms are only ever added, so a
+// label which is removed can still be found in the partitions where the resource had it.  Only the annotations in
+// tables.IndexedAnnotations are indexed
+func updateResourceIndexTable(tables typed.Tables, txn badgerwrap.Txn, watchRec *typed.KubeWatchResult, metadata *kubeextractor.KubeMetadata) error {
+	if watchRec.Kind == kubeextractor.EventKind || metadata.Name == "" {
+		return nil
+	}
+	ts, err := ptypes.Timestamp(watchRec.Timestamp)
+	if err != nil {
+		return errors.Wrap(err, "could not convert timestamp")
+	}
+
+	for _, term := range typed.ResourceIndexTerms(metadata.Name, metadata.Labels, metadata.Annotations, tables.IndexedAnnotations()) {
+		key := typed.NewResourceIndexKey(ts, term, watchRec.Kind, metadata.Namespace, metadata.Name).String()
+		value, err := tables.ResourceIndexTable().GetOrDefault(txn, key)
+		if err != nil {
+			return errors.Wrapf(err, "could not get record for key %v", key)
+		}
+		if common.Contains(value.Uids, metadata.Uid) {
+			continue
+		}
+		value.Uids = append(value.Uids, metadata.Uid)
+		err = tables.ResourceIndexTable().Set(txn, key, value)
+		if err != nil {
+			return errors.Wrapf(err, "put for the key %v failed", key)
+		}
+	}
+	return nil
+}
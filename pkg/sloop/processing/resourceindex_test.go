@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package processing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+const someLabeledPodPayload = `{
+  "metadata": {
+    "name": "web-1",
+    "namespace": "someNamespace",
+    "uid": "someuid",
+    "labels": {"tier": "frontend", "app": "web"},
+    "annotations": {"team": "payments", "note": "ignored"}
+  }
+}`
+
+func helper_updateResourceIndexTable(t *testing.T, tables typed.Tables, kind string, payload string) {
+	ts, err := ptypes.TimestampProto(someWatchTime)
+	assert.Nil(t, err)
+	watchRec := &typed.KubeWatchResult{Kind: kind, WatchType: typed.KubeWatchResult_ADD, Timestamp: ts, Payload: payload}
+	metadata, err := kubeextractor.ExtractMetadata(watchRec.Payload)
+	assert.Nil(t, err)
+	err = tables.Db().Update(func(txn badgerwrap.Txn) error {
+		return updateResourceIndexTable(tables, txn, watchRec, &metadata)
+	})
+	assert.Nil(t, err)
+}
+
+func Test_updateResourceIndexTable(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableListWithIndexedAnnotations(db, []string{"team"})
+
+	// The second update of the same resource does not add its uid again
+	helper_updateResourceIndexTable(t, tables, kubeextractor.PodKind, someLabeledPodPayload)
+	helper_updateResourceIndexTable(t, tables, kubeextractor.PodKind, someLabeledPodPayload)
+
+	var keys []string
+	err = tables.Db().View(func(txn badgerwrap.Txn) error {
+		rows, _, err := tables.ResourceIndexTable().RangeRead(txn, nil, nil, nil, someWatchTime, someWatchTime)
+		for key, value := range rows {
+			keys = append(keys, key.Term)
+			assert.Equal(t, []string{"someuid"}, value.Uids)
+			assert.Equal(t, "web-1", key.Name)
+		}
+		return err
+	})
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{
+		"name:web", "name:eb-", "name:b-1",
+		"labelkey:app", "label:app=web", "labelkey:tier", "label:tier=frontend",
+		"annotation:team=payments",
+	}, keys)
+}
+
+func Test_updateResourceIndexTable_SkipsEvents(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+
+	helper_updateResourceIndexTable(t, tables, kubeextractor.EventKind, someLabeledPodPayload)
+
+	err = tables.Db().View(func(txn badgerwrap.Txn) error {
+		rows, _, err := tables.ResourceIndexTable().RangeRead(txn, nil, nil, nil, someWatchTime, someWatchTime)
+		assert.Len(t, rows, 0)
+		return err
+	})
+	assert.Nil(t, err)
+}
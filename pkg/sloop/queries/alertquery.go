@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// An alert fired by one rule against one resource, merged across partitions
+type ApiAlert struct {
+	Key        string    `json:"key"`
+	Kind       string    `json:"kind"`
+	Namespace  string    `json:"namespace"`
+	Name       string    `json:"name"`
+	RuleName   string    `json:"ruleName"`
+	Severity   string    `json:"severity"`
+	Message    string    `json:"message"`
+	FirstFired time.Time `json:"firstFired"`
+	LastFired  time.Time `json:"lastFired"`
+	FireCount  int       `json:"fireCount"`
+}
+
+// Returns the alerts as json for the UI, newest first.  See GetAlertList
+func GetAlerts(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string, scope NamespaceScope) ([]byte, error) {
+	alerts, err := GetAlertList(params, t, startTime, endTime, requestId, scope)
+	if err != nil {
+		return []byte{}, err
+	}
+	sort.SliceStable(alerts, func(i, j int) bool {
+		return alerts[i].LastFired.After(alerts[j].LastFired)
+	})
+	bytes, err := json.MarshalIndent(alerts, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json for alerts %v", err)
+	}
+	return bytes, nil
+}
+
+// Returns one entry per rule and resource which fired in the time range, sorted by key.
+// Alerts have a row in every partition they fired in, so rows are merged here.
+func GetAlertList(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string, scope NamespaceScope) ([]ApiAlert, error) {
+	params = apiDefaultParams(params)
+	var alertRows map[typed.AlertKey]*typed.Alert
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		var err2 error
+		var stats typed.RangeReadStats
+		alertRows, stats, err2 = t.AlertTable().RangeRead(txn, nil, paramFilterAlertFn(params, scope), isAlertValInTimeRange(startTime, endTime), startTime, endTime)
+		if err2 != nil {
+			return err2
+		}
+		stats.Log(requestId)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	merged := map[string]*ApiAlert{}
+	for key, val := range alertRows {
+		firstFired, err := ptypes.Timestamp(val.FirstFired)
+		if err != nil {
+			return nil, err
+		}
+		lastFired, err := ptypes.Timestamp(val.LastFired)
+		if err != nil {
+			return nil, err
+		}
+		id := "/" + key.Kind + "/" + key.Namespace + "/" + key.Name + "/" + key.RuleName
+		existing, ok := merged[id]
+		if !ok {
+			merged[id] = &ApiAlert{
+				Key:        id,
+				Kind:       key.Kind,
+				Namespace:  key.Namespace,
+				Name:       key.Name,
+				RuleName:   key.RuleName,
+				Severity:   val.Severity,
+				Message:    val.Message,
+				FirstFired: firstFired,
+				LastFired:  lastFired,
+				FireCount:  int(val.FireCount),
+			}
+			continue
+		}
+		existing.FireCount += int(val.FireCount)
+		if firstFired.Before(existing.FirstFired) {
+			existing.FirstFired = firstFired
+		}
+		// The message and severity come from the latest firing
+		if lastFired.After(existing.LastFired) {
+			existing.LastFired = lastFired
+			existing.Severity = val.Severity
+			existing.Message = val.Message
+		}
+	}
+
+	ret := make([]ApiAlert, 0, len(merged))
+	for _, alert := range merged {
+		ret = append(ret, *alert)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Key < ret[j].Key
+	})
+	return ret, nil
+}
+
+func isAlertValInTimeRange(startTime time.Time, endTime time.Time) func(*typed.Alert) bool {
+	return func(retVal *typed.Alert) bool {
+		firstFired, err := ptypes.Timestamp(retVal.FirstFired)
+		if err != nil {
+			return false
+		}
+		lastFired, err := ptypes.Timestamp(retVal.LastFired)
+		if err != nil {
+			return false
+		}
+		return !firstFired.After(endTime) && !lastFired.Before(startTime)
+	}
+}
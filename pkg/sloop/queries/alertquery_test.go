@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+var someAlertTs = time.Date(2019, 3, 4, 3, 30, 0, 0, time.UTC)
+
+type alertTestRow struct {
+	name      string
+	rule      string
+	firstSeen time.Duration
+	lastSeen  time.Duration
+	count     int32
+	message   string
+}
+
+func helper_get_alertTables(t *testing.T, rows []alertTestRow) typed.Tables {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for _, row := range rows {
+			firstFired, _ := ptypes.TimestampProto(someAlertTs.Add(row.firstSeen))
+			lastFired, _ := ptypes.TimestampProto(someAlertTs.Add(row.lastSeen))
+			key := typed.NewAlertKey(someAlertTs.Add(row.lastSeen), "Pod", "someNamespace", row.name, row.rule)
+			val := &typed.Alert{RuleName: row.rule, Severity: "warning", Message: row.message, FirstFired: firstFired, LastFired: lastFired, FireCount: row.count}
+			txerr := tables.AlertTable().Set(txn, key.String(), val)
+			if txerr != nil {
+				return txerr
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	return tables
+}
+
+func Test_GetAlertList_MergesPartitions(t *testing.T) {
+	tables := helper_get_alertTables(t, []alertTestRow{
+		{name: "a", rule: "PodBackOff", firstSeen: -90 * time.Minute, lastSeen: -70 * time.Minute, count: 2, message: "old"},
+		{name: "a", rule: "PodBackOff", firstSeen: -20 * time.Minute, lastSeen: -10 * time.Minute, count: 3, message: "new"},
+		{name: "b", rule: "PodBackOff", firstSeen: -5 * time.Minute, lastSeen: -5 * time.Minute, count: 1, message: "b"},
+		{name: "c", rule: "PodBackOff", firstSeen: -5 * time.Hour, lastSeen: -5 * time.Hour, count: 1, message: "too old"},
+	})
+
+	alerts, err := GetAlertList(url.Values{}, tables, someAlertTs.Add(-2*time.Hour), someAlertTs, someRequestId, Unrestricted)
+	assert.Nil(t, err)
+	assert.Len(t, alerts, 2)
+	assert.Equal(t, "/Pod/someNamespace/a/PodBackOff", alerts[0].Key)
+	assert.Equal(t, 5, alerts[0].FireCount)
+	assert.Equal(t, someAlertTs.Add(-90*time.Minute), alerts[0].FirstFired)
+	assert.Equal(t, someAlertTs.Add(-10*time.Minute), alerts[0].LastFired)
+	assert.Equal(t, "new", alerts[0].Message)
+	assert.Equal(t, "b", alerts[1].Name)
+}
+
+func Test_GetAlertList_FilterByRule(t *testing.T) {
+	tables := helper_get_alertTables(t, []alertTestRow{
+		{name: "a", rule: "PodBackOff", firstSeen: -5 * time.Minute, lastSeen: -5 * time.Minute, count: 1},
+		{name: "a", rule: "PodPending", firstSeen: -5 * time.Minute, lastSeen: -5 * time.Minute, count: 1},
+	})
+
+	params := url.Values{}
+	params.Set(RuleParam, "PodPending")
+	alerts, err := GetAlertList(params, tables, someAlertTs.Add(-time.Hour), someAlertTs, someRequestId, Unrestricted)
+	assert.Nil(t, err)
+	assert.Len(t, alerts, 1)
+	assert.Equal(t, "PodPending", alerts[0].RuleName)
+}
@@ -0,0 +1,359 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// The functions in this file back the versioned REST api (/api/v1).  Unlike the ganttJsonQuery functions
+// used by the UI they return typed results, so the webserver can page through them and wrap them in a
+// stable envelope.  Every result carries a Key which is used as the sort order and pagination cursor.
+
+type ApiResource struct {
+	Key           string    `json:"key"`
+	Kind          string    `json:"kind"`
+	Namespace     string    `json:"namespace"`
+	Name          string    `json:"name"`
+	Uid           string    `json:"uid"`
+	FirstSeen     time.Time `json:"firstSeen"`
+	LastSeen      time.Time `json:"lastSeen"`
+	CreateTime    time.Time `json:"createTime"`
+	DeletedAtEnd  bool      `json:"deletedAtEnd"`
+	Relationships []string  `json:"relationships,omitempty"`
+}
+
+type ApiObjectReference struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Uid       string `json:"uid"`
+}
+
+type ApiEvent struct {
+	Key            string             `json:"key"`
+	Namespace      string             `json:"namespace"`
+	Name           string             `json:"name"`
+	WatchTimestamp time.Time          `json:"watchTimestamp"`
+	WatchType      string             `json:"watchType"`
+	InvolvedObject ApiObjectReference `json:"involvedObject"`
+	Reason         string             `json:"reason"`
+	Type           string             `json:"type"`
+	Count          int                `json:"count"`
+	FirstTimestamp time.Time          `json:"firstTimestamp"`
+	LastTimestamp  time.Time          `json:"lastTimestamp"`
+	Payload        json.RawMessage    `json:"payload,omitempty"`
+}
+
+type ApiPayload struct {
+	Key       string          `json:"key"`
+	Timestamp time.Time       `json:"timestamp"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+type ApiCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type ApiSummary struct {
+	ResourceCount int        `json:"resourceCount"`
+	DeletedCount  int        `json:"deletedCount"`
+	Kinds         []ApiCount `json:"kinds"`
+	Namespaces    []ApiCount `json:"namespaces"`
+	MinPartition  string     `json:"minPartition,omitempty"`
+	MaxPartition  string     `json:"maxPartition,omitempty"`
+}
+
+// Exported for callers outside this package which need to resolve the same time range parameters as RunQuery
+func ComputeTimeRange(params url.Values, tables typed.Tables, maxLookBack time.Duration) (time.Time, time.Time, error) {
+	return computeTimeRange(params, tables, maxLookBack)
+}
+
+// The UI always sends kind and namespace, but api clients should be able to leave them out
+func apiDefaultParams(params url.Values) url.Values {
+	ret := url.Values{}
+	for k, v := range params {
+		ret[k] = v
+	}
+	if ret.Get(KindParam) == "" {
+		ret.Set(KindParam, AllKinds)
+	}
+	if ret.Get(NamespaceParam) == "" {
+		ret.Set(NamespaceParam, AllNamespaces)
+	}
+	return ret
+}
+
+// Returns one entry per resource (kind/namespace/name/uid) seen in the time range, sorted by key.
+// A resource has a summary row in every partition it was seen in, so rows are merged here.
+func ApiListResources(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string, scope NamespaceScope) ([]ApiResource, error) {
+	params = apiDefaultParams(params)
+	var resSummaries map[typed.ResourceSummaryKey]*typed.ResourceSummary
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		var err2 error
+		var stats typed.RangeReadStats
+		resSummaries, stats, err2 = readResourceSummaries(t, txn, params, scope, isResSummaryValInTimeRange(startTime, endTime), startTime, endTime)
+		if err2 != nil {
+			return err2
+		}
+		stats.Log(requestId)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	merged := map[string]*ApiResource{}
+	newestPartition := map[string]string{}
+	for key, val := range resSummaries {
+		res, err := resSumToApiResource(key, val)
+		if err != nil {
+			return nil, err
+		}
+		existing, ok := merged[res.Key]
+		if !ok {
+			merged[res.Key] = res
+			newestPartition[res.Key] = key.PartitionId
+			continue
+		}
+		if res.FirstSeen.Before(existing.FirstSeen) {
+			existing.FirstSeen = res.FirstSeen
+		}
+		if res.LastSeen.After(existing.LastSeen) {
+			existing.LastSeen = res.LastSeen
+		}
+		// Deletion and relationships are only meaningful from the newest partition
+		if key.PartitionId > newestPartition[res.Key] {
+			newestPartition[res.Key] = key.PartitionId
+			existing.DeletedAtEnd = res.DeletedAtEnd
+			existing.Relationships = res.Relationships
+		}
+	}
+
+	ret := make([]ApiResource, 0, len(merged))
+	for _, res := range merged {
+		ret = append(ret, *res)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Key < ret[j].Key
+	})
+	return ret, nil
+}
+
+func resSumToApiResource(key typed.ResourceSummaryKey, val *typed.ResourceSummary) (*ApiResource, error) {
+	firstSeen, err := ptypes.Timestamp(val.FirstSeen)
+	if err != nil {
+		return nil, err
+	}
+	lastSeen, err := ptypes.Timestamp(val.LastSeen)
+	if err != nil {
+		return nil, err
+	}
+	// Older rows may not have a create time
+	createTime := time.Time{}
+	if val.CreateTime != nil {
+		createTime, err = ptypes.Timestamp(val.CreateTime)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &ApiResource{
+		Key:           "/" + key.Kind + "/" + key.Namespace + "/" + key.Name + "/" + key.Uid,
+		Kind:          key.Kind,
+		Namespace:     key.Namespace,
+		Name:          key.Name,
+		Uid:           key.Uid,
+		FirstSeen:     firstSeen,
+		LastSeen:      lastSeen,
+		CreateTime:    createTime,
+		DeletedAtEnd:  val.DeletedAtEnd,
+		Relationships: val.Relationships,
+	}, nil
+}
+
+// Returns kubernetes events which were active in the time range, sorted by key.
+// Events get updated in place as their count goes up, so only the newest watch record of each event is returned.
+// When kind and name are set only events for that involved object are returned.
+func ApiListEvents(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string, scope NamespaceScope) ([]ApiEvent, error) {
+	params = apiDefaultParams(params)
+	selectedNamespace := params.Get(NamespaceParam)
+	selectedKind := params.Get(KindParam)
+	selectedName := params.Get(NameParam)
+
+	var keyPrefix *typed.WatchTableKey
+	var keyPredFn func(string) bool
+	if selectedNamespace != AllNamespaces {
+		keyPrefix = typed.NewWatchTableKeyComparator(kubeextractor.EventKind, selectedNamespace, "", time.Time{})
+		if selectedName != "" {
+			// Events are named <involvedObjectName>.<suffix>
+			keyPrefix.Name = selectedName + "."
+		}
+	} else {
+		keyPredFn = func(key string) bool {
+			k := &typed.WatchTableKey{}
+			if err := k.Parse(key); err != nil {
+				return false
+			}
+			return k.Kind == kubeextractor.EventKind
+		}
+	}
+
+	valPredFns := []func(*typed.KubeWatchResult) bool{isEventValInTimeRange(startTime, endTime)}
+	if selectedKind != AllKinds || selectedName != "" {
+		valPredFns = append(valPredFns, matchEventInvolvedObjectKindAndName(selectedKind, selectedName))
+	}
+
+	var watchEvents map[typed.WatchTableKey]*typed.KubeWatchResult
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		var err2 error
+		var stats typed.RangeReadStats
+		watchEvents, stats, err2 = t.WatchTable().RangeRead(txn, keyPrefix, keyPredFn, typed.KubeWatchResult_ValPredicateFns(valPredFns...), startTime, endTime)
+		if err2 != nil {
+			return err2
+		}
+		stats.Log(requestId)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	newest := map[string]typed.WatchTableKey{}
+	for key := range watchEvents {
+		if !scope.AllowsResource(key.Kind, key.Namespace, key.Name) {
+			continue
+		}
+		id := key.Namespace + "/" + key.Name
+		if existing, ok := newest[id]; !ok || key.Timestamp.After(existing.Timestamp) {
+			newest[id] = key
+		}
+	}
+
+	ret := make([]ApiEvent, 0, len(newest))
+	for _, key := range newest {
+		val := watchEvents[key]
+		eventInfo, err := kubeextractor.ExtractEventInfo(val.Payload)
+		if err != nil {
+			return nil, err
+		}
+		involvedObject, err := kubeextractor.ExtractInvolvedObject(val.Payload)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, ApiEvent{
+			Key:            "/" + key.Namespace + "/" + key.Name,
+			Namespace:      key.Namespace,
+			Name:           key.Name,
+			WatchTimestamp: key.Timestamp,
+			WatchType:      val.WatchType.String(),
+			InvolvedObject: ApiObjectReference{
+				Kind:      involvedObject.Kind,
+				Namespace: involvedObject.Namespace,
+				Name:      involvedObject.Name,
+				Uid:       involvedObject.Uid,
+			},
+			Reason:         eventInfo.Reason,
+			Type:           eventInfo.Type,
+			Count:          eventInfo.Count,
+			FirstTimestamp: eventInfo.FirstTimestamp,
+			LastTimestamp:  eventInfo.LastTimestamp,
+			Payload:        json.RawMessage(val.Payload),
+		})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Key < ret[j].Key
+	})
+	return ret, nil
+}
+
+func matchEventInvolvedObjectKindAndName(selectedKind string, selectedName string) func(*typed.KubeWatchResult) bool {
+	return func(retVal *typed.KubeWatchResult) bool {
+		involvedObj, err := kubeextractor.ExtractInvolvedObject(retVal.Payload)
+		if err != nil {
+			return false
+		}
+		if selectedKind != AllKinds && involvedObj.Kind != selectedKind {
+			return false
+		}
+		if selectedName != "" && involvedObj.Name != selectedName {
+			return false
+		}
+		return true
+	}
+}
+
+// Returns every distinct payload of one resource in the time range, oldest first.
+// Requires kind and name, and namespace unless the kind is cluster scoped.
+func ApiPayloadHistory(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string, scope NamespaceScope) ([]ApiPayload, error) {
+	payloads, err := getResPayloadList(params, t, startTime, endTime, requestId, scope)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]ApiPayload, 0, len(payloads))
+	for _, p := range payloads {
+		ret = append(ret, ApiPayload{
+			Key:       p.PayloadKey,
+			Timestamp: time.Unix(0, p.PayLoadTime).UTC(),
+			Payload:   json.RawMessage(p.Payload),
+		})
+	}
+	return ret, nil
+}
+
+// Returns resource counts by kind and namespace for the time range, along with the partitions in the store
+func ApiGetSummary(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string, scope NamespaceScope) (*ApiSummary, error) {
+	resources, err := ApiListResources(params, t, startTime, endTime, requestId, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	kindCounts := map[string]int{}
+	namespaceCounts := map[string]int{}
+	ret := &ApiSummary{ResourceCount: len(resources)}
+	for _, res := range resources {
+		kindCounts[res.Kind] += 1
+		if res.Namespace != "" {
+			namespaceCounts[res.Namespace] += 1
+		}
+		if res.DeletedAtEnd {
+			ret.DeletedCount += 1
+		}
+	}
+	ret.Kinds = sortedApiCounts(kindCounts)
+	ret.Namespaces = sortedApiCounts(namespaceCounts)
+
+	ok, minPartition, maxPartition, err := t.GetMinAndMaxPartition()
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		ret.MinPartition = minPartition
+		ret.MaxPartition = maxPartition
+	}
+	return ret, nil
+}
+
+func sortedApiCounts(counts map[string]int) []ApiCount {
+	ret := make([]ApiCount, 0, len(counts))
+	for name, count := range counts {
+		ret = append(ret, ApiCount{Name: name, Count: count})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Name < ret[j].Name
+	})
+	return ret
+}
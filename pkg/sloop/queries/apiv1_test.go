@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/stretchr/testify/assert"
+)
+
+const someApiEventPayload = `{
+  "involvedObject": {
+    "kind": "Pod",
+    "namespace": "someNamespace",
+    "name": "someName",
+    "uid": "someuuid"
+  },
+  "reason":"someReason",
+  "type":"Warning",
+  "firstTimestamp": "2019-01-01T21:24:55Z",
+  "lastTimestamp": "2019-01-02T21:27:55Z",
+  "count": 10
+}`
+
+func Test_ApiListResources_MergesPartitions(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	keys := make([]*typed.ResourceSummaryKey, 3)
+	keys[0] = typed.NewResourceSummaryKey(someFirstSeenTime, "Pod", "someNamespace", "someName", "someuid")
+	keys[1] = typed.NewResourceSummaryKey(someFirstSeenTime.Add(time.Hour), "Pod", "someNamespace", "someName", "someuid")
+	keys[2] = typed.NewResourceSummaryKey(someFirstSeenTime, "Deployment", "otherNamespace", "otherName", "otheruid")
+	tables := helper_get_resSumtable(keys, t)
+
+	res, err := ApiListResources(url.Values{}, tables, someFirstSeenTime.Add(-1*time.Hour), someLastSeenTime, someRequestId, Unrestricted)
+	assert.Nil(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, "/Deployment/otherNamespace/otherName/otheruid", res[0].Key)
+	assert.Equal(t, "/Pod/someNamespace/someName/someuid", res[1].Key)
+	assert.Equal(t, someFirstSeenTime, res[1].FirstSeen)
+	assert.Equal(t, someLastSeenTime, res[1].LastSeen)
+}
+
+func Test_ApiListResources_Filtered(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	keys := make([]*typed.ResourceSummaryKey, 2)
+	keys[0] = typed.NewResourceSummaryKey(someFirstSeenTime, "Pod", "someNamespace", "someName", "someuid")
+	keys[1] = typed.NewResourceSummaryKey(someFirstSeenTime, "Deployment", "otherNamespace", "otherName", "otheruid")
+	tables := helper_get_resSumtable(keys, t)
+
+	params := url.Values{}
+	params.Set(NamespaceParam, "someNamespace")
+	res, err := ApiListResources(params, tables, someFirstSeenTime.Add(-1*time.Hour), someLastSeenTime, someRequestId, Unrestricted)
+	assert.Nil(t, err)
+	assert.Len(t, res, 1)
+	assert.Equal(t, "someName", res[0].Name)
+}
+
+func Test_ApiListEvents_NewestOnly(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	partitionId := untyped.GetPartitionId(someTs)
+	var keys []string
+	keys = append(keys, typed.NewWatchTableKey(partitionId, "Event", "someNamespace", "someName.xx", someTs).String())
+	keys = append(keys, typed.NewWatchTableKey(partitionId, "Event", "someNamespace", "someName.xx", someTs.Add(time.Minute)).String())
+	keys = append(keys, typed.NewWatchTableKey(partitionId, "Event", "otherNamespace", "someName.yy", someTs).String())
+	tables := helper_get_k8Watchtable(keys, t, someApiEventPayload)
+
+	res, err := ApiListEvents(url.Values{}, tables, someTs.Add(-1*time.Hour), someTs.Add(time.Hour), someRequestId, Unrestricted)
+	assert.Nil(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, "/otherNamespace/someName.yy", res[0].Key)
+	assert.Equal(t, "/someNamespace/someName.xx", res[1].Key)
+	assert.Equal(t, someTs.Add(time.Minute), res[1].WatchTimestamp)
+	assert.Equal(t, "Pod", res[1].InvolvedObject.Kind)
+	assert.Equal(t, "someReason", res[1].Reason)
+	assert.Equal(t, 10, res[1].Count)
+}
+
+func Test_ApiListEvents_InvolvedObject(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	partitionId := untyped.GetPartitionId(someTs)
+	var keys []string
+	keys = append(keys, typed.NewWatchTableKey(partitionId, "Event", "someNamespace", "someName.xx", someTs).String())
+	tables := helper_get_k8Watchtable(keys, t, someApiEventPayload)
+
+	params := url.Values{}
+	params.Set(NamespaceParam, "someNamespace")
+	params.Set(KindParam, "Pod")
+	params.Set(NameParam, "someName")
+	res, err := ApiListEvents(params, tables, someTs.Add(-1*time.Hour), someTs.Add(time.Hour), someRequestId, Unrestricted)
+	assert.Nil(t, err)
+	assert.Len(t, res, 1)
+
+	params.Set(KindParam, "Deployment")
+	res, err = ApiListEvents(params, tables, someTs.Add(-1*time.Hour), someTs.Add(time.Hour), someRequestId, Unrestricted)
+	assert.Nil(t, err)
+	assert.Len(t, res, 0)
+}
+
+func Test_ApiGetSummary(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	keys := make([]*typed.ResourceSummaryKey, 3)
+	keys[0] = typed.NewResourceSummaryKey(someFirstSeenTime, "Pod", "someNamespace", "someName", "someuid")
+	keys[1] = typed.NewResourceSummaryKey(someFirstSeenTime, "Pod", "someNamespace", "someName2", "someuid2")
+	keys[2] = typed.NewResourceSummaryKey(someFirstSeenTime, "Node", "", "someNode", "someuid3")
+	tables := helper_get_resSumtable(keys, t)
+
+	res, err := ApiGetSummary(url.Values{}, tables, someFirstSeenTime.Add(-1*time.Hour), someLastSeenTime, someRequestId, Unrestricted)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, res.ResourceCount)
+	assert.Equal(t, []ApiCount{{Name: "Node", Count: 1}, {Name: "Pod", Count: 2}}, res.Kinds)
+	assert.Equal(t, []ApiCount{{Name: "someNamespace", Count: 2}}, res.Namespaces)
+	assert.Equal(t, untyped.GetPartitionId(someFirstSeenTime), res.MinPartition)
+}
@@ -33,7 +33,7 @@ type EventOutput struct {
 	EventKey       string                          `json:"eventKey"`
 }
 
-func GetEventData(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+func GetEventData(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string, scope NamespaceScope) ([]byte, error) {
 	var watchEvents map[typed.WatchTableKey]*typed.KubeWatchResult
 	err := t.Db().View(func(txn badgerwrap.Txn) error {
 		var err2 error
@@ -74,6 +74,10 @@ func GetEventData(params url.Values, t typed.Tables, startTime time.Time, endTim
 	var res EventsData
 	eventsList := []EventOutput{}
 	for key, val := range watchEvents {
+		// Checked on the keys read rather than the params so no combination of params can reach outside the scope
+		if !scope.AllowsResource(key.Kind, key.Namespace, key.Name) {
+			continue
+		}
 		output := EventOutput{
 			PartitionId:    key.PartitionId,
 			Namespace:      key.Namespace,
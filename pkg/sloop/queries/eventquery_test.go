@@ -62,7 +62,7 @@ func Test_GetEventData_False(t *testing.T) {
 	starTime := someTs.Add(-60 * time.Minute)
 	endTime := someTs.Add(60 * time.Minute)
 	tables := helper_get_k8Watchtable(keys, t, "")
-	res, err := GetEventData(values, tables, starTime, endTime, someRequestId)
+	res, err := GetEventData(values, tables, starTime, endTime, someRequestId, Unrestricted)
 	assert.Equal(t, string(res), "")
 	assert.Nil(t, err)
 }
@@ -81,7 +81,7 @@ func Test_GetEventData_KeyNotInTimeRange(t *testing.T) {
 		keys = append(keys, typed.NewWatchTableKey(partitionId, "someKind"+string(i), "someNamespace", "someName.xx", someTs).String())
 	}
 	tables := helper_get_k8Watchtable(keys, t, "")
-	res, err := GetEventData(values, tables, someTs.Add(-60*time.Minute), someTs.Add(60*time.Minute), someRequestId)
+	res, err := GetEventData(values, tables, someTs.Add(-60*time.Minute), someTs.Add(60*time.Minute), someRequestId, Unrestricted)
 	assert.Nil(t, err)
 	assert.Equal(t, string(res), "")
 }
@@ -113,7 +113,7 @@ func Test_GetEventData_True(t *testing.T) {
     }`
 
 	tables := helper_get_k8Watchtable(keys, t, someEventPayload)
-	res, err := GetEventData(values, tables, someTs.Add(-1*time.Hour), someTs.Add(6*time.Hour), someRequestId)
+	res, err := GetEventData(values, tables, someTs.Add(-1*time.Hour), someTs.Add(6*time.Hour), someRequestId, Unrestricted)
 	assert.Nil(t, err)
 	expectedRes := `[
  {
@@ -156,7 +156,7 @@ func Test_GetEventData_ValPayloadNotInTimeRange(t *testing.T) {
     }`
 
 	tables := helper_get_k8Watchtable(keys, t, someEventPayload)
-	res, err := GetEventData(values, tables, someTs.Add(-1*time.Hour), someTs.Add(6*time.Hour), someRequestId)
+	res, err := GetEventData(values, tables, someTs.Add(-1*time.Hour), someTs.Add(6*time.Hour), someRequestId, Unrestricted)
 	assert.Nil(t, err)
 	assert.Equal(t, string(res), "")
 }
@@ -188,7 +188,7 @@ func Test_GetEventData_ValPayloadKindNotMatch(t *testing.T) {
     }`
 
 	tables := helper_get_k8Watchtable(keys, t, someEventPayload)
-	res, err := GetEventData(values, tables, someTs.Add(-1*time.Hour), someTs.Add(6*time.Hour), someRequestId)
+	res, err := GetEventData(values, tables, someTs.Add(-1*time.Hour), someTs.Add(6*time.Hour), someRequestId, Unrestricted)
 	assert.Nil(t, err)
 	assert.Equal(t, string(res), "")
 }
@@ -227,7 +227,7 @@ func Test_GetEventData_True_HasSamePrefix(t *testing.T) {
     }`
 
 	tables := helper_get_k8Watchtable(keys, t, someEventPayload)
-	res, err := GetEventData(values, tables, someTs.Add(-1*time.Hour), someTs.Add(6*time.Hour), someRequestId)
+	res, err := GetEventData(values, tables, someTs.Add(-1*time.Hour), someTs.Add(6*time.Hour), someRequestId, Unrestricted)
 	assert.Nil(t, err)
 	expectedRes := `[
  {
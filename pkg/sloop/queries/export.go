@@ -0,0 +1,347 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/salesforce/sloop/pkg/sloop/export"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Tables which can be exported, by the table name in their keys
+const (
+	ExportWatchTable      = "watch"
+	ExportResSumTable     = "ressum"
+	ExportEventCountTable = "eventcount"
+)
+
+// One row per watch event, which is one change of an object
+var exportWatchColumns = []export.Column{
+	{Name: "timestamp", Type: export.Timestamp},
+	{Name: "kind", Type: export.String},
+	{Name: "namespace", Type: export.String},
+	{Name: "name", Type: export.String},
+	{Name: "uid", Type: export.String},
+	{Name: "watch_type", Type: export.String},
+	{Name: "resource_version", Type: export.String},
+	{Name: "creation_timestamp", Type: export.Timestamp},
+	{Name: "owner_kind", Type: export.String},
+	{Name: "owner_name", Type: export.String},
+	{Name: "labels", Type: export.String},
+}
+
+var exportPayloadColumn = export.Column{Name: "payload", Type: export.String}
+
+// One row per resource per partition.  Seen times are scoped to the partition
+var exportResSumColumns = []export.Column{
+	{Name: "kind", Type: export.String},
+	{Name: "namespace", Type: export.String},
+	{Name: "name", Type: export.String},
+	{Name: "uid", Type: export.String},
+	{Name: "first_seen", Type: export.Timestamp},
+	{Name: "last_seen", Type: export.Timestamp},
+	{Name: "create_time", Type: export.Timestamp},
+	{Name: "deleted_at_end", Type: export.Bool},
+	{Name: "relationships", Type: export.String},
+}
+
+// One row per resource per minute per event reason
+var exportEventCountColumns = []export.Column{
+	{Name: "minute", Type: export.Timestamp},
+	{Name: "kind", Type: export.String},
+	{Name: "namespace", Type: export.String},
+	{Name: "name", Type: export.String},
+	{Name: "uid", Type: export.String},
+	{Name: "reason", Type: export.String},
+	{Name: "type", Type: export.String},
+	{Name: "count", Type: export.Int64},
+}
+
+// Returns the columns of an export of the table in the table param, which defaults to the watch table.
+// Watch exports only have the payload column when the payload param is true.
+// The label selector and annotation params only apply to resource summary exports, and annotations have to be indexed.
+func ExportColumns(params url.Values, indexedAnnotations []string) ([]export.Column, error) {
+	if (params.Get(LabelSelectorParam) != "" || params.Get(AnnotationParam) != "") && exportTable(params) != ExportResSumTable {
+		return nil, fmt.Errorf("%v and %v are only supported when %v is %v", LabelSelectorParam, AnnotationParam, TableParam, ExportResSumTable)
+	}
+	err := ValidateResourceIndexParams(params, indexedAnnotations)
+	if err != nil {
+		return nil, err
+	}
+	switch exportTable(params) {
+	case ExportWatchTable:
+		if params.Get(PayloadParam) == "true" {
+			return append(append([]export.Column{}, exportWatchColumns...), exportPayloadColumn), nil
+		}
+		return exportWatchColumns, nil
+	case ExportResSumTable:
+		return exportResSumColumns, nil
+	case ExportEventCountTable:
+		return exportEventCountColumns, nil
+	default:
+		return nil, fmt.Errorf("%v must be %v, %v or %v", TableParam, ExportWatchTable, ExportResSumTable, ExportEventCountTable)
+	}
+}
+
+func exportTable(params url.Values) string {
+	table := params.Get(TableParam)
+	if table == "" {
+		return ExportWatchTable
+	}
+	return table
+}
+
+// Export writes the rows of the table picked by params in the time range to w, filtered like the api queries, and
+// returns how many were written.  It reads one partition at a time so memory does not grow with the time range, and
+// rows come out sorted by partition and then key.
+func Export(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string, scope NamespaceScope, w export.Writer) (int, error) {
+	params = apiDefaultParams(params)
+	columns, err := ExportColumns(params, t.IndexedAnnotations())
+	if err != nil {
+		return 0, err
+	}
+	var partitions []string
+	err = t.Db().View(func(txn badgerwrap.Txn) error {
+		var err2 error
+		partitions, err2 = t.WatchTable().GetPartitionsFromTimeRange(txn, startTime, endTime)
+		return err2
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var exportPartition func(txn badgerwrap.Txn, partitionTime time.Time) ([][]interface{}, typed.RangeReadStats, error)
+	switch exportTable(params) {
+	case ExportWatchTable:
+		exportPartition = exportWatchPartition(params, t, startTime, endTime, scope, len(columns) > len(exportWatchColumns))
+	case ExportResSumTable:
+		exportPartition = exportResSumPartition(params, t, startTime, endTime, scope)
+	case ExportEventCountTable:
+		exportPartition = exportEventCountPartition(params, t, startTime, endTime, scope)
+	}
+
+	count := 0
+	for _, partitionId := range partitions {
+		partitionTime, _, err := untyped.GetTimeRangeForPartition(partitionId)
+		if err != nil {
+			return count, err
+		}
+		var rows [][]interface{}
+		err = t.Db().View(func(txn badgerwrap.Txn) error {
+			var stats typed.RangeReadStats
+			var err2 error
+			rows, stats, err2 = exportPartition(txn, partitionTime)
+			if err2 != nil {
+				return err2
+			}
+			stats.Log(requestId)
+			return nil
+		})
+		if err != nil {
+			return count, err
+		}
+		for _, row := range rows {
+			err = w.Write(row)
+			if err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+	return count, nil
+}
+
+func exportWatchPartition(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, scope NamespaceScope, withPayload bool) func(badgerwrap.Txn, time.Time) ([][]interface{}, typed.RangeReadStats, error) {
+	selectedUuid := params.Get(UuidParam)
+	return func(txn badgerwrap.Txn, partitionTime time.Time) ([][]interface{}, typed.RangeReadStats, error) {
+		results, stats, err := t.WatchTable().RangeRead(txn, nil, paramFilterWatchFn(params, scope), isResPayloadInTimeRange(startTime, endTime), partitionTime, partitionTime)
+		if err != nil {
+			return nil, stats, err
+		}
+		keys := make([]typed.WatchTableKey, 0, len(results))
+		for key := range results {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+		var rows [][]interface{}
+		for _, key := range keys {
+			val := results[key]
+			// A payload which does not parse still has a row, with the columns from its key
+			metadata, _ := kubeextractor.ExtractMetadata(val.Payload)
+			if selectedUuid != "" && metadata.Uid != selectedUuid {
+				continue
+			}
+			ts, _ := ptypes.Timestamp(val.Timestamp)
+			var ownerKind, ownerName interface{}
+			if len(metadata.OwnerReferences) > 0 {
+				ownerKind = metadata.OwnerReferences[0].Kind
+				ownerName = metadata.OwnerReferences[0].Name
+			}
+			row := []interface{}{
+				ts,
+				key.Kind,
+				key.Namespace,
+				key.Name,
+				nullIfEmpty(metadata.Uid),
+				val.WatchType.String(),
+				nullIfEmpty(metadata.ResourceVersion),
+				parseExportTime(metadata.CreationTimestamp),
+				ownerKind,
+				ownerName,
+				exportJson(metadata.Labels),
+			}
+			if withPayload {
+				row = append(row, val.Payload)
+			}
+			rows = append(rows, row)
+		}
+		return rows, stats, nil
+	}
+}
+
+func exportResSumPartition(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, scope NamespaceScope) func(badgerwrap.Txn, time.Time) ([][]interface{}, typed.RangeReadStats, error) {
+	return func(txn badgerwrap.Txn, partitionTime time.Time) ([][]interface{}, typed.RangeReadStats, error) {
+		results, stats, err := readResourceSummaries(t, txn, params, scope, isResSummaryValInTimeRange(startTime, endTime), partitionTime, partitionTime)
+		if err != nil {
+			return nil, stats, err
+		}
+		keys := make([]typed.ResourceSummaryKey, 0, len(results))
+		for key := range results {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+		var rows [][]interface{}
+		for _, key := range keys {
+			val := results[key]
+			var relationships interface{}
+			if len(val.Relationships) > 0 {
+				relationships = exportJson(val.Relationships)
+			}
+			rows = append(rows, []interface{}{
+				key.Kind,
+				key.Namespace,
+				key.Name,
+				key.Uid,
+				exportTimestamp(val.FirstSeen),
+				exportTimestamp(val.LastSeen),
+				exportTimestamp(val.CreateTime),
+				val.DeletedAtEnd,
+				relationships,
+			})
+		}
+		return rows, stats, nil
+	}
+}
+
+func exportEventCountPartition(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, scope NamespaceScope) func(badgerwrap.Txn, time.Time) ([][]interface{}, typed.RangeReadStats, error) {
+	return func(txn badgerwrap.Txn, partitionTime time.Time) ([][]interface{}, typed.RangeReadStats, error) {
+		results, stats, err := t.EventCountTable().RangeRead(txn, nil, paramFilterEventCountFn(params, scope), nil, partitionTime, partitionTime)
+		if err != nil {
+			return nil, stats, err
+		}
+		keys := make([]typed.EventCountKey, 0, len(results))
+		for key := range results {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+		var rows [][]interface{}
+		for _, key := range keys {
+			minutes := []int64{}
+			for minute := range results[key].MapMinToEvents {
+				minuteTime := time.Unix(minute, 0)
+				if !minuteTime.Before(startTime.Truncate(time.Minute)) && !minuteTime.After(endTime) {
+					minutes = append(minutes, minute)
+				}
+			}
+			sort.Slice(minutes, func(i, j int) bool { return minutes[i] < minutes[j] })
+			for _, minute := range minutes {
+				counts := results[key].MapMinToEvents[minute]
+				if counts == nil {
+					continue
+				}
+				reasons := []string{}
+				for reason := range counts.MapReasonToCount {
+					reasons = append(reasons, reason)
+				}
+				sort.Strings(reasons)
+				for _, reason := range reasons {
+					// Counts are kept by reason and type joined with a colon
+					var eventType interface{}
+					name := reason
+					if i := strings.LastIndex(reason, ":"); i >= 0 {
+						name = reason[:i]
+						eventType = nullIfEmpty(reason[i+1:])
+					}
+					rows = append(rows, []interface{}{
+						time.Unix(minute, 0).UTC(),
+						key.Kind,
+						key.Namespace,
+						key.Name,
+						key.Uid,
+						name,
+						eventType,
+						int64(counts.MapReasonToCount[reason]),
+					})
+				}
+			}
+		}
+		return rows, stats, nil
+	}
+}
+
+func nullIfEmpty(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+func parseExportTime(value string) interface{} {
+	ts, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return ts.UTC()
+}
+
+func exportTimestamp(value *timestamp.Timestamp) interface{} {
+	if value == nil {
+		return nil
+	}
+	ts, err := ptypes.Timestamp(value)
+	if err != nil {
+		return nil
+	}
+	return ts
+}
+
+// Maps and lists are written as a json string so every row stays flat
+func exportJson(value interface{}) interface{} {
+	if m, ok := value.(map[string]string); ok && len(m) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	return string(data)
+}
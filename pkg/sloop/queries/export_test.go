@@ -0,0 +1,150 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/export"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+var someExportTime = time.Date(2019, 1, 2, 3, 30, 0, 0, time.UTC)
+
+const someExportPodPayload = `{"metadata": {"name": "%v", "namespace": "someNamespace", "uid": "%v-uid", "resourceVersion": "12",
+"creationTimestamp": "2019-01-02T01:00:00Z", "labels": {"app": "web"}, "ownerReferences": [{"kind": "ReplicaSet", "name": "web-123"}]}}`
+
+// Adds a watch row, resource summary and event count for each pod, one hour apart so they are in separate partitions
+func helper_get_exportTables(t *testing.T, names []string) typed.Tables {
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for i, name := range names {
+			ts := someExportTime.Add(time.Duration(i) * time.Hour)
+			pTs, _ := ptypes.TimestampProto(ts)
+			payload := fmt.Sprintf(someExportPodPayload, name, name)
+			watchKey := typed.NewWatchTableKey(untyped.GetPartitionId(ts), "Pod", "someNamespace", name, ts)
+			err := tables.WatchTable().Set(txn, watchKey.String(), &typed.KubeWatchResult{Kind: "Pod", Timestamp: pTs, WatchType: typed.KubeWatchResult_UPDATE, Payload: payload})
+			if err != nil {
+				return err
+			}
+			resSumKey := typed.NewResourceSummaryKey(ts, "Pod", "someNamespace", name, name+"-uid")
+			err = tables.ResourceSummaryTable().Set(txn, resSumKey.String(), &typed.ResourceSummary{FirstSeen: pTs, LastSeen: pTs, DeletedAtEnd: true, Relationships: []string{"/Namespace//someNamespace"}})
+			if err != nil {
+				return err
+			}
+			eventKey := typed.NewEventCountKey(ts, "Pod", "someNamespace", name, name+"-uid")
+			counts := &typed.ResourceEventCounts{MapMinToEvents: map[int64]*typed.EventCounts{
+				ts.Unix(): {MapReasonToCount: map[string]int32{"BackOff:Warning": 3, "Pulled:Normal": 1}},
+			}}
+			err = tables.EventCountTable().Set(txn, eventKey.String(), counts)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	return tables
+}
+
+func helper_export(t *testing.T, tables typed.Tables, params url.Values) []map[string]interface{} {
+	columns, err := ExportColumns(params, nil)
+	assert.Nil(t, err)
+	var out bytes.Buffer
+	w := export.NewJsonLinesWriter(&out, columns)
+	count, err := Export(params, tables, someExportTime.Add(-time.Hour), someExportTime.Add(3*time.Hour), "", Unrestricted, w)
+	assert.Nil(t, err)
+	assert.Nil(t, w.Close())
+
+	var rows []map[string]interface{}
+	decoder := json.NewDecoder(&out)
+	for decoder.More() {
+		row := map[string]interface{}{}
+		assert.Nil(t, decoder.Decode(&row))
+		rows = append(rows, row)
+	}
+	assert.Len(t, rows, count)
+	return rows
+}
+
+func Test_Export_Watch(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	tables := helper_get_exportTables(t, []string{"b", "a"})
+
+	rows := helper_export(t, tables, url.Values{})
+	assert.Len(t, rows, 2)
+	assert.Equal(t, map[string]interface{}{
+		"timestamp":          "2019-01-02T03:30:00Z",
+		"kind":               "Pod",
+		"namespace":          "someNamespace",
+		"name":               "b",
+		"uid":                "b-uid",
+		"watch_type":         "UPDATE",
+		"resource_version":   "12",
+		"creation_timestamp": "2019-01-02T01:00:00Z",
+		"owner_kind":         "ReplicaSet",
+		"owner_name":         "web-123",
+		"labels":             `{"app":"web"}`,
+	}, rows[0])
+	// The second pod is in the next partition
+	assert.Equal(t, "a", rows[1]["name"])
+
+	rows = helper_export(t, tables, url.Values{NameParam: []string{"a"}, PayloadParam: []string{"true"}})
+	assert.Len(t, rows, 1)
+	assert.Contains(t, rows[0]["payload"], `"uid": "a-uid"`)
+
+	rows = helper_export(t, tables, url.Values{UuidParam: []string{"b-uid"}})
+	assert.Len(t, rows, 1)
+	assert.Equal(t, "b", rows[0]["name"])
+
+	rows = helper_export(t, tables, url.Values{NamespaceParam: []string{"otherNamespace"}})
+	assert.Len(t, rows, 0)
+}
+
+func Test_Export_ResSum(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	tables := helper_get_exportTables(t, []string{"a"})
+
+	rows := helper_export(t, tables, url.Values{TableParam: []string{ExportResSumTable}})
+	assert.Len(t, rows, 1)
+	assert.Equal(t, "a-uid", rows[0]["uid"])
+	assert.Equal(t, "2019-01-02T03:30:00Z", rows[0]["first_seen"])
+	assert.Nil(t, rows[0]["create_time"])
+	assert.Equal(t, true, rows[0]["deleted_at_end"])
+	assert.Equal(t, `["/Namespace//someNamespace"]`, rows[0]["relationships"])
+}
+
+func Test_Export_EventCount(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	tables := helper_get_exportTables(t, []string{"a"})
+
+	rows := helper_export(t, tables, url.Values{TableParam: []string{ExportEventCountTable}})
+	assert.Len(t, rows, 2)
+	assert.Equal(t, "BackOff", rows[0]["reason"])
+	assert.Equal(t, "Warning", rows[0]["type"])
+	assert.Equal(t, float64(3), rows[0]["count"])
+	assert.Equal(t, "2019-01-02T03:30:00Z", rows[0]["minute"])
+	assert.Equal(t, "Pulled", rows[1]["reason"])
+}
+
+func Test_ExportColumns_UnknownTable(t *testing.T) {
+	_, err := ExportColumns(url.Values{TableParam: []string{"alert"}}, nil)
+	assert.NotNil(t, err)
+}
@@ -10,17 +10,25 @@ package queries
 // Parameters are shared between webserver and here
 // Keep this in sync with pkg/sloop/webserver/webfiles/filter.js
 const (
-	LookbackParam  = "lookback"
-	NamespaceParam = "namespace"
-	KindParam      = "kind"
-	NameParam      = "name"
-	NameMatchParam = "namematch" // substring match on name
-	UuidParam      = "uuid"
-	StartTimeParam = "start_time"
-	EndTimeParam   = "end_time"
-	ClickTimeParam = "click_time"
-	QueryParam     = "query"
-	SortParam      = "sort"
+	LookbackParam      = "lookback"
+	NamespaceParam     = "namespace"
+	KindParam          = "kind"
+	NameParam          = "name"
+	NameMatchParam     = "namematch" // substring match on name
+	UuidParam          = "uuid"
+	StartTimeParam     = "start_time"
+	EndTimeParam       = "end_time"
+	ClickTimeParam     = "click_time"
+	QueryParam         = "query"
+	SortParam          = "sort"
+	DiffModeParam      = "diff_mode"
+	FormatParam        = "format"
+	RuleParam          = "rule"
+	ClusterParam       = "cluster"    // peer to send a federated query to
+	TableParam         = "table"      // table to export
+	PayloadParam       = "payload"    // include payloads in a watch export
+	LabelSelectorParam = "selector"   // kubernetes label selector, looked up in the resource index
+	AnnotationParam    = "annotation" // key=value of an indexed annotation, can be repeated
 )
 
 const (
@@ -17,7 +17,7 @@ import (
 )
 
 // Takes in arguments from the web page, runs the query, and returns json
-type ganttJsonQuery = func(params url.Values, tables typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error)
+type ganttJsonQuery = func(params url.Values, tables typed.Tables, startTime time.Time, endTime time.Time, requestId string, scope NamespaceScope) ([]byte, error)
 
 var funcMap = map[string]ganttJsonQuery{
 	"EventHeatMap":      EventHeatMap3Query,
@@ -27,6 +27,9 @@ var funcMap = map[string]ganttJsonQuery{
 	"Kinds":             KindQuery,
 	"Queries":           QueryAvailableQueries,
 	"GetResSummaryData": GetResSummaryData,
+	"GetResDiff":        GetResDiff,
+	"Snapshot":          GetSnapshot,
+	"Alerts":            GetAlerts,
 }
 
 func Default() string {
@@ -37,7 +40,7 @@ func GetNamesOfQueries() []string {
 	return []string{"EventHeatMap"}
 }
 
-func RunQuery(queryName string, params url.Values, tables typed.Tables, maxLookBack time.Duration, requestId string) ([]byte, error) {
+func RunQuery(queryName string, params url.Values, tables typed.Tables, maxLookBack time.Duration, requestId string, scope NamespaceScope) ([]byte, error) {
 	startTime, endTime, err := computeTimeRange(params, tables, maxLookBack)
 	if err != nil {
 		glog.Errorf("computeTimeRange failed with error: %v", err)
@@ -48,7 +51,7 @@ func RunQuery(queryName string, params url.Values, tables typed.Tables, maxLookB
 	if !ok {
 		return []byte{}, fmt.Errorf("Query not found: " + queryName)
 	}
-	ret, err := fn(params, tables, startTime, endTime, requestId)
+	ret, err := fn(params, tables, startTime, endTime, requestId, scope)
 	if err != nil {
 		glog.Errorf("Query %v failed with error: %v", queryName, err)
 	}
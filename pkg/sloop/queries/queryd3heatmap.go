@@ -30,9 +30,9 @@ type rawData struct {
 	WatchActivity map[typed.WatchActivityKey]*typed.WatchActivity
 }
 
-func EventHeatMap3Query(params url.Values, t typed.Tables, queryStartTime time.Time, queryEndTime time.Time, requestId string) ([]byte, error) {
+func EventHeatMap3Query(params url.Values, t typed.Tables, queryStartTime time.Time, queryEndTime time.Time, requestId string, scope NamespaceScope) ([]byte, error) {
 	// Simple query of store for all rows in matching partitions (will include extra rows)
-	rawRows, err := getRawDataFromStore(params, t, queryStartTime, queryEndTime, requestId)
+	rawRows, err := getRawDataFromStore(params, t, queryStartTime, queryEndTime, requestId, scope)
 	if err != nil {
 		return nil, err
 	}
@@ -107,7 +107,7 @@ func EventHeatMap3Query(params url.Values, t typed.Tables, queryStartTime time.T
 
 // Grab data from the store.  This will return rows from all partitions that intersect with startTime-endTime
 // which will often include more rows that we need.
-func getRawDataFromStore(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) (rawData, error) {
+func getRawDataFromStore(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string, scope NamespaceScope) (rawData, error) {
 	ret := rawData{}
 	ret.Events = map[typed.EventCountKey]*typed.ResourceEventCounts{}
 	ret.Resources = map[typed.ResourceSummaryKey]*typed.ResourceSummary{}
@@ -116,19 +116,19 @@ func getRawDataFromStore(params url.Values, t typed.Tables, startTime time.Time,
 	err := t.Db().View(func(txn badgerwrap.Txn) error {
 		var err2 error
 		var stats typed.RangeReadStats
-		ret.Events, stats, err2 = t.EventCountTable().RangeRead(txn, nil, paramEventCountSumFn(params), nil, startTime, endTime)
+		ret.Events, stats, err2 = t.EventCountTable().RangeRead(txn, nil, paramEventCountSumFn(params, scope), nil, startTime, endTime)
 		if err2 != nil {
 			return err2
 		}
 		stats.Log(requestId)
 
-		ret.Resources, stats, err2 = t.ResourceSummaryTable().RangeRead(txn, nil, paramFilterResSumFn(params), nil, startTime, endTime)
+		ret.Resources, stats, err2 = readResourceSummaries(t, txn, params, scope, nil, startTime, endTime)
 		if err2 != nil {
 			return err2
 		}
 		stats.Log(requestId)
 
-		ret.WatchActivity, stats, err2 = t.WatchActivityTable().RangeRead(txn, nil, paramFilterWatchActivityFn(params), nil, startTime, endTime)
+		ret.WatchActivity, stats, err2 = t.WatchActivityTable().RangeRead(txn, nil, paramFilterWatchActivityFn(params, scope), nil, startTime, endTime)
 		if err2 != nil {
 			return err2
 		}
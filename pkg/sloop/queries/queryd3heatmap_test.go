@@ -118,7 +118,7 @@ func Test_EventHeatMap3_SimpleTestWithOneDeployment(t *testing.T) {
 
 	helper_AddResSum(t, tables)
 
-	resultJsonBytes, err := EventHeatMap3Query(helper_UrlValues(), tables, someHeatMapQueryStart, someHeatMapQueryEnd, someRequestId)
+	resultJsonBytes, err := EventHeatMap3Query(helper_UrlValues(), tables, someHeatMapQueryStart, someHeatMapQueryEnd, someRequestId, Unrestricted)
 	assert.Nil(t, err)
 	expectedJson := `{
  "view_options": {
@@ -151,7 +151,7 @@ func Test_EventHeatMap3_OneDeploymentAnd3Events(t *testing.T) {
 	helper_AddEventSum(t, tables)
 	helper_AddWatchActivity(t, tables)
 
-	resultJsonBytes, err := EventHeatMap3Query(helper_UrlValues(), tables, someHeatMapQueryStart, someHeatMapQueryEnd, someRequestId)
+	resultJsonBytes, err := EventHeatMap3Query(helper_UrlValues(), tables, someHeatMapQueryStart, someHeatMapQueryEnd, someRequestId, Unrestricted)
 	assert.Nil(t, err)
 	expectedJson := `{
  "view_options": {
@@ -21,7 +21,7 @@ import (
 
 // Consider: Make use of resources to limit what namespaces we return.
 // For example, if kind == ConfigMap, only return namespaces that contain a ConfigMap
-func NamespaceQuery(params url.Values, tables typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+func NamespaceQuery(params url.Values, tables typed.Tables, startTime time.Time, endTime time.Time, requestId string, scope NamespaceScope) ([]byte, error) {
 	var resourcesNs map[typed.ResourceSummaryKey]*typed.ResourceSummary
 	err := tables.Db().View(func(txn badgerwrap.Txn) error {
 		var err2 error
@@ -36,7 +36,12 @@ func NamespaceQuery(params url.Values, tables typed.Tables, startTime time.Time,
 	if err != nil {
 		return []byte{}, err
 	}
-	namespaces := resSumRowsToNamespaceStrings(resourcesNs)
+	namespaces := []string{}
+	for _, namespace := range resSumRowsToNamespaceStrings(resourcesNs) {
+		if scope.AllowsResource(kubeextractor.NamespaceKind, "", namespace) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
 	namespaces = append(namespaces, AllNamespaces)
 	bytes, err := json.MarshalIndent(namespaces, "", " ")
 	if err != nil {
@@ -46,10 +51,10 @@ func NamespaceQuery(params url.Values, tables typed.Tables, startTime time.Time,
 }
 
 // TODO: Only return kinds for the specified namespace
-func KindQuery(params url.Values, tables typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+func KindQuery(params url.Values, tables typed.Tables, startTime time.Time, endTime time.Time, requestId string, scope NamespaceScope) ([]byte, error) {
 	kindExists := make(map[string]bool)
 	err := tables.Db().View(func(txn badgerwrap.Txn) error {
-		_, stats, err2 := tables.ResourceSummaryTable().RangeRead(txn, nil, isKind(kindExists), nil, startTime, endTime)
+		_, stats, err2 := tables.ResourceSummaryTable().RangeRead(txn, nil, isKind(kindExists, scope), nil, startTime, endTime)
 		if err2 != nil {
 			return err2
 		}
@@ -72,7 +77,7 @@ func KindQuery(params url.Values, tables typed.Tables, startTime time.Time, endT
 	return bytes, nil
 }
 
-func QueryAvailableQueries(params url.Values, tables typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+func QueryAvailableQueries(params url.Values, tables typed.Tables, startTime time.Time, endTime time.Time, requestId string, scope NamespaceScope) ([]byte, error) {
 	queries := GetNamesOfQueries()
 	bytes, err := json.MarshalIndent(queries, "", " ")
 	if err != nil {
@@ -104,9 +109,10 @@ func isNamespace(k string) bool {
 	return key.Kind == kubeextractor.NamespaceKind
 }
 
-func isKind(kindExists map[string]bool) func(string) bool {
+// Only kinds with at least one resource in scope are returned
+func isKind(kindExists map[string]bool, scope NamespaceScope) func(string) bool {
 	return func(key string) bool {
-		return keepResourceSummaryKind(key, kindExists)
+		return keepResourceSummaryKind(key, kindExists, scope)
 	}
 }
 
@@ -123,13 +129,16 @@ func resSumRowsToKindStrings(resources map[typed.ResourceSummaryKey]*typed.Resou
 	return kindList
 }
 
-func keepResourceSummaryKind(key string, kindExists map[string]bool) bool {
+func keepResourceSummaryKind(key string, kindExists map[string]bool, scope NamespaceScope) bool {
 	// parse the key and get its kind
 	k := &typed.ResourceSummaryKey{}
 	err := k.Parse(key)
 	if err != nil {
 		return false
 	}
+	if !scope.AllowsResource(k.Kind, k.Namespace, k.Name) {
+		return false
+	}
 	kind := k.Kind
 
 	// if it is the first time to see the kind, return true,
@@ -72,7 +72,7 @@ func Test_GetNamespaces_Success(t *testing.T) {
 	keys[1] = typed.NewResourceSummaryKey(someTs, "Deployment", "namespace-b", "somename-b", "45510937-d4fc-11e9-8e26-14187754567")
 	tables := helper_get_resSumtable(keys, t)
 
-	filterData, err := NamespaceQuery(url.Values{}, tables, someTs, someTs, someRequestId)
+	filterData, err := NamespaceQuery(url.Values{}, tables, someTs, someTs, someRequestId, Unrestricted)
 
 	assert.Nil(t, err)
 	expectedNamespaces := `[
@@ -89,7 +89,7 @@ func Test_GetNamespaces_EmptyNamespace(t *testing.T) {
 	keys[1] = typed.NewResourceSummaryKey(someTs, "SomeKind", "namespace-b", "somename-b", "45510937-d4fc-11e9-8e26-14187754567")
 	tables := helper_get_resSumtable(keys, t)
 
-	filterData, err := NamespaceQuery(url.Values{}, tables, someTs, someTs, someRequestId)
+	filterData, err := NamespaceQuery(url.Values{}, tables, someTs, someTs, someRequestId, Unrestricted)
 
 	assert.Nil(t, err)
 	expectedNamespaces := `[
@@ -105,7 +105,7 @@ func Test_GetKinds_SimpleCase(t *testing.T) {
 	keys[1] = typed.NewResourceSummaryKey(someTs, "Deployment", "namespace-b", "somename-b", "45510937-d4fc-11e9-8e26-14187754567")
 	tables := helper_get_resSumtable(keys, t)
 
-	filterData, err := KindQuery(url.Values{}, tables, someTs, someTs, someRequestId)
+	filterData, err := KindQuery(url.Values{}, tables, someTs, someTs, someRequestId, Unrestricted)
 
 	assert.Nil(t, err)
 	expectedKinds := `[
@@ -192,7 +192,7 @@ func Test_isNamespace_KindWithoutNamespce(t *testing.T) {
 func Test_isKind_Empty(t *testing.T) {
 	kindExists := make(map[string]bool)
 	key := "/ressum/001567105200/StatefulSet/some-namespace/some-name/52071bcf-64cf-11e9-b4c3-1418774b3e9d"
-	flag := isKind(kindExists)(key)
+	flag := isKind(kindExists, Unrestricted)(key)
 	assert.True(t, flag)
 }
 
@@ -200,7 +200,7 @@ func Test_isKind_KindExists(t *testing.T) {
 	kindExists := make(map[string]bool)
 	kindExists["Deployment"] = true
 	key2 := "/ressum/001562961600/Deployment/some-namespace/some-name/f8f372a3-f731-11e8-b3bd-e24c7f08fac6"
-	flag := isKind(kindExists)(key2)
+	flag := isKind(kindExists, Unrestricted)(key2)
 	assert.False(t, flag)
 }
 
@@ -17,7 +17,7 @@ import (
 	"github.com/salesforce/sloop/pkg/sloop/store/typed"
 )
 
-func paramFilterResSumFn(params url.Values) func(string) bool {
+func paramFilterResSumFn(params url.Values, scope NamespaceScope) func(string) bool {
 	selectedNamespace := params.Get(NamespaceParam)
 	selectedKind := params.Get(KindParam)
 	selectedNameSubstring := params.Get(NameMatchParam)
@@ -33,11 +33,12 @@ func paramFilterResSumFn(params url.Values) func(string) bool {
 		namespace := k.Namespace
 		name := k.Name
 		uuid := k.Uid
-		return keepRowHelper(name, kind, namespace, selectedKind, selectedNamespace, selectedNameSubstring, selectedNameExactMatch, selectedUuid, uuid)
+		// Checked last so the authorizer is only asked about rows the params selected
+		return keepRowHelper(name, kind, namespace, selectedKind, selectedNamespace, selectedNameSubstring, selectedNameExactMatch, selectedUuid, uuid) && scope.AllowsResource(kind, namespace, name)
 	}
 }
 
-func paramEventCountSumFn(params url.Values) func(string) bool {
+func paramEventCountSumFn(params url.Values, scope NamespaceScope) func(string) bool {
 	selectedNamespace := params.Get(NamespaceParam)
 	selectedKind := params.Get(KindParam)
 	selectedNameMatchSubstring := params.Get(NameMatchParam)
@@ -50,11 +51,11 @@ func paramEventCountSumFn(params url.Values) func(string) bool {
 		kind := k.Kind
 		namespace := k.Namespace
 		name := k.Name
-		return keepRowHelper(name, kind, namespace, selectedKind, selectedNamespace, selectedNameMatchSubstring, "", "", "")
+		return keepRowHelper(name, kind, namespace, selectedKind, selectedNamespace, selectedNameMatchSubstring, "", "", "") && scope.AllowsResource(kind, namespace, name)
 	}
 }
 
-func paramFilterWatchActivityFn(params url.Values) func(string) bool {
+func paramFilterWatchActivityFn(params url.Values, scope NamespaceScope) func(string) bool {
 	selectedNamespace := params.Get(NamespaceParam)
 	selectedKind := params.Get(KindParam)
 	selectedNameSubstring := params.Get(NameMatchParam)
@@ -70,7 +71,58 @@ func paramFilterWatchActivityFn(params url.Values) func(string) bool {
 		namespace := k.Namespace
 		name := k.Name
 		uuid := k.Uid
-		return keepRowHelper(name, kind, namespace, selectedKind, selectedNamespace, selectedNameSubstring, selectedNameExactMatch, selectedUuid, uuid)
+		return keepRowHelper(name, kind, namespace, selectedKind, selectedNamespace, selectedNameSubstring, selectedNameExactMatch, selectedUuid, uuid) && scope.AllowsResource(kind, namespace, name)
+	}
+}
+
+// Watch keys have no uid, so a uuid param has to be checked against the payload
+func paramFilterWatchFn(params url.Values, scope NamespaceScope) func(string) bool {
+	selectedNamespace := params.Get(NamespaceParam)
+	selectedKind := params.Get(KindParam)
+	selectedNameSubstring := params.Get(NameMatchParam)
+	selectedNameExactMatch := params.Get(NameParam)
+	return func(key string) bool {
+		k := &typed.WatchTableKey{}
+		err := k.Parse(key)
+		if err != nil {
+			return false
+		}
+		return keepRowHelper(k.Name, k.Kind, k.Namespace, selectedKind, selectedNamespace, selectedNameSubstring, selectedNameExactMatch, "", "") && scope.AllowsResource(k.Kind, k.Namespace, k.Name)
+	}
+}
+
+func paramFilterEventCountFn(params url.Values, scope NamespaceScope) func(string) bool {
+	selectedNamespace := params.Get(NamespaceParam)
+	selectedKind := params.Get(KindParam)
+	selectedNameSubstring := params.Get(NameMatchParam)
+	selectedNameExactMatch := params.Get(NameParam)
+	selectedUuid := params.Get(UuidParam)
+	return func(key string) bool {
+		k := &typed.EventCountKey{}
+		err := k.Parse(key)
+		if err != nil {
+			return false
+		}
+		return keepRowHelper(k.Name, k.Kind, k.Namespace, selectedKind, selectedNamespace, selectedNameSubstring, selectedNameExactMatch, selectedUuid, k.Uid) && scope.AllowsResource(k.Kind, k.Namespace, k.Name)
+	}
+}
+
+func paramFilterAlertFn(params url.Values, scope NamespaceScope) func(string) bool {
+	selectedNamespace := params.Get(NamespaceParam)
+	selectedKind := params.Get(KindParam)
+	selectedNameSubstring := params.Get(NameMatchParam)
+	selectedNameExactMatch := params.Get(NameParam)
+	selectedRule := params.Get(RuleParam)
+	return func(key string) bool {
+		k := &typed.AlertKey{}
+		err := k.Parse(key)
+		if err != nil {
+			return false
+		}
+		if selectedRule != "" && selectedRule != k.RuleName {
+			return false
+		}
+		return keepRowHelper(k.Name, k.Kind, k.Namespace, selectedKind, selectedNamespace, selectedNameSubstring, selectedNameExactMatch, "", "") && scope.AllowsResource(k.Kind, k.Namespace, k.Name)
 	}
 }
 
@@ -21,7 +21,7 @@ func Test_isFiltered_NotSelectedNamespace(t *testing.T) {
 
 	// test when namespace is not selected
 	key := "/eventcount/001567105200/StatefulSet/some-user/vrb-mgmt-pd/52071bcf-64cf-11e9-b4c3-1418774b3e9d"
-	flag := paramEventCountSumFn(values)(key)
+	flag := paramEventCountSumFn(values, Unrestricted)(key)
 	assert.False(t, flag)
 }
 
@@ -29,7 +29,7 @@ func Test_isFiltered_SelectedNamespace(t *testing.T) {
 	values := helper_get_params()
 	// test when namespace is selected
 	key := "/eventcount/001567105200/StatefulSet/some-namespace/vrb-mgmt-pd/52071bcf-64cf-11e9-b4c3-1418774b3e9d"
-	flag := paramEventCountSumFn(values)(key)
+	flag := paramEventCountSumFn(values, Unrestricted)(key)
 	assert.True(t, flag)
 }
 
@@ -39,7 +39,7 @@ func Test_isFiltered_WhenKindIsNodeIgnoreNamespace(t *testing.T) {
 	values["namespace"] = []string{"someNamespace"}
 	// test node
 	key := "/eventcount/001567022400/Node//somehost/somehost"
-	flag := paramEventCountSumFn(values)(key)
+	flag := paramEventCountSumFn(values, Unrestricted)(key)
 	assert.True(t, flag)
 }
 
@@ -49,7 +49,7 @@ func Test_isFiltered_NodeReturnedForAllNamespaces(t *testing.T) {
 	values["namespace"] = []string{AllNamespaces}
 	// test node
 	key := "/eventcount/001567022400/Node//somehost/somehost"
-	flag := paramEventCountSumFn(values)(key)
+	flag := paramEventCountSumFn(values, Unrestricted)(key)
 	assert.True(t, flag)
 }
 
@@ -59,7 +59,7 @@ func Test_isFiltered_NodeNotReturnedForAllKindsInSomeNamespace(t *testing.T) {
 	values["namespace"] = []string{"foo"}
 	// test node
 	key := "/eventcount/001567022400/Node//somehost/somehost"
-	flag := paramEventCountSumFn(values)(key)
+	flag := paramEventCountSumFn(values, Unrestricted)(key)
 	assert.False(t, flag)
 }
 
@@ -69,7 +69,7 @@ func Test_isFiltered_NodeReturnedForAllKindsAllNamespace(t *testing.T) {
 	values["namespace"] = []string{AllNamespaces}
 	// test node
 	key := "/eventcount/001567022400/Node//somehost/somehost"
-	flag := paramEventCountSumFn(values)(key)
+	flag := paramEventCountSumFn(values, Unrestricted)(key)
 	assert.True(t, flag)
 }
 
@@ -79,12 +79,12 @@ func Test_isFiltered_KindIsNamespaceMatchNameNotNamespace(t *testing.T) {
 	values[KindParam] = []string{kubeextractor.NamespaceKind}
 	// test when namespace is not selected
 	key := "/ressum/001567094400/Namespace//some-othernamespace/96b0e282-9744-11e8-9d31-1418775557c8"
-	flag := paramFilterResSumFn(values)(key)
+	flag := paramFilterResSumFn(values, Unrestricted)(key)
 	assert.False(t, flag)
 
 	// test when namespace is selected
 	key = "/ressum/001567094400/Namespace//some-namespace/96b0e282-9744-11e8-9d31-1418775557c8"
-	flag = paramFilterResSumFn(values)(key)
+	flag = paramFilterResSumFn(values, Unrestricted)(key)
 	assert.True(t, flag)
 }
 
@@ -0,0 +1,114 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+const (
+	// Diff the state of the resource at start time against its state at end time
+	DiffModeRange = "range"
+	// Diff each change in the time range against the change before it
+	DiffModeConsecutive = "consecutive"
+)
+
+type ResDiffOutput struct {
+	FromKey  string                      `json:"fromKey"`
+	FromTime int64                       `json:"fromTime"`
+	ToKey    string                      `json:"toKey"`
+	ToTime   int64                       `json:"toTime"`
+	Patch    []kubeextractor.JsonPatchOp `json:"patch"`
+}
+
+func GetResDiff(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string, scope NamespaceScope) ([]byte, error) {
+	diffs, err := GetResDiffList(params, t, startTime, endTime, requestId, scope)
+	if err != nil {
+		return []byte{}, err
+	}
+	bytes, err := json.MarshalIndent(diffs, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json for diff list %v", err)
+	}
+	return bytes, nil
+}
+
+// Returns structured diffs of one resource, either a single diff between the state at startTime and endTime
+// or one diff per change depending on DiffModeParam.  Changes which only touch noise fields are left out.
+func GetResDiffList(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string, scope NamespaceScope) ([]ResDiffOutput, error) {
+	mode := params.Get(DiffModeParam)
+	if mode == "" {
+		mode = DiffModeRange
+	}
+	if mode != DiffModeRange && mode != DiffModeConsecutive {
+		return nil, fmt.Errorf("invalid %v %q, must be %v or %v", DiffModeParam, mode, DiffModeRange, DiffModeConsecutive)
+	}
+
+	// Sorted by time and includes the newest payload from before startTime
+	payloads, err := getResPayloadList(params, t, startTime, endTime, requestId, scope)
+	if err != nil {
+		return nil, err
+	}
+	glog.V(common.GlogVerbose).Infof("reqId: %v GetResDiffList: mode: %v found %v payloads", requestId, mode, len(payloads))
+
+	ret := []ResDiffOutput{}
+	if len(payloads) < 2 {
+		return ret, nil
+	}
+
+	if mode == DiffModeRange {
+		from := payloads[0]
+		for _, p := range payloads {
+			if p.PayLoadTime > startTime.UnixNano() {
+				break
+			}
+			from = p
+		}
+		diff, err := diffPayloadOutputs(from, payloads[len(payloads)-1])
+		if err != nil {
+			return nil, err
+		}
+		if len(diff.Patch) > 0 {
+			ret = append(ret, diff)
+		}
+		return ret, nil
+	}
+
+	for idx := 1; idx < len(payloads); idx++ {
+		diff, err := diffPayloadOutputs(payloads[idx-1], payloads[idx])
+		if err != nil {
+			return nil, err
+		}
+		if len(diff.Patch) > 0 {
+			ret = append(ret, diff)
+		}
+	}
+	return ret, nil
+}
+
+func diffPayloadOutputs(from PayloadOuput, to PayloadOuput) (ResDiffOutput, error) {
+	patch, err := kubeextractor.DiffPayloads(from.Payload, to.Payload)
+	if err != nil {
+		return ResDiffOutput{}, err
+	}
+	return ResDiffOutput{
+		FromKey:  from.PayloadKey,
+		FromTime: from.PayLoadTime,
+		ToKey:    to.PayloadKey,
+		ToTime:   to.PayLoadTime,
+		Patch:    patch,
+	}, nil
+}
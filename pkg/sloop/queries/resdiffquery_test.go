@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/salesforce/sloop/pkg/sloop/test/assertex"
+	"github.com/stretchr/testify/assert"
+)
+
+const someDiffPodTemplate = `{"metadata": {"name": "someName", "namespace": "someNamespace", "resourceVersion": "%v"}, "status": {"phase": "%v"}}`
+
+// Writes one payload per minute starting at someTs
+func helper_get_diffTables(t *testing.T, payloads []string) typed.Tables {
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	wt := typed.OpenKubeWatchResultTable()
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for idx, payload := range payloads {
+			ts := someTs.Add(time.Duration(idx) * time.Minute)
+			key := typed.NewWatchTableKey(untyped.GetPartitionId(ts), "Pod", "someNamespace", "someName", ts)
+			pTs, txerr := ptypes.TimestampProto(ts)
+			if txerr != nil {
+				return txerr
+			}
+			txerr = wt.Set(txn, key.String(), &typed.KubeWatchResult{Kind: "Pod", Timestamp: pTs, Payload: payload})
+			if txerr != nil {
+				return txerr
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	return typed.NewTableList(db)
+}
+
+func helper_get_diffParams(mode string) url.Values {
+	values := url.Values{}
+	values.Set(KindParam, "Pod")
+	values.Set(NamespaceParam, "someNamespace")
+	values.Set(NameParam, "someName")
+	values.Set(DiffModeParam, mode)
+	return values
+}
+
+func Test_GetResDiffList_Consecutive(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	tables := helper_get_diffTables(t, []string{
+		fmt.Sprintf(someDiffPodTemplate, 1, "Pending"),
+		fmt.Sprintf(someDiffPodTemplate, 2, "Pending"),
+		fmt.Sprintf(someDiffPodTemplate, 3, "Running"),
+		fmt.Sprintf(someDiffPodTemplate, 4, "Failed"),
+	})
+
+	diffs, err := GetResDiffList(helper_get_diffParams(DiffModeConsecutive), tables, someTs.Add(-time.Minute), someTs.Add(10*time.Minute), someRequestId, Unrestricted)
+	assert.Nil(t, err)
+	// The change from resourceVersion 1 to 2 is noise only and is skipped
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, someTs.Add(time.Minute).UnixNano(), diffs[0].FromTime)
+	assert.Equal(t, "Running", diffs[0].Patch[0].Value)
+	assert.Equal(t, "Failed", diffs[1].Patch[0].Value)
+}
+
+func Test_GetResDiffList_Range(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	tables := helper_get_diffTables(t, []string{
+		fmt.Sprintf(someDiffPodTemplate, 1, "Pending"),
+		fmt.Sprintf(someDiffPodTemplate, 2, "Running"),
+		fmt.Sprintf(someDiffPodTemplate, 3, "Failed"),
+	})
+
+	// The state at start time comes from the payload written before it
+	diffs, err := GetResDiffList(helper_get_diffParams(DiffModeRange), tables, someTs.Add(30*time.Second), someTs.Add(10*time.Minute), someRequestId, Unrestricted)
+	assert.Nil(t, err)
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, someTs.UnixNano(), diffs[0].FromTime)
+	assert.Equal(t, someTs.Add(2*time.Minute).UnixNano(), diffs[0].ToTime)
+	assert.Equal(t, "Pending", diffs[0].Patch[0].OldValue)
+	assert.Equal(t, "Failed", diffs[0].Patch[0].Value)
+}
+
+func Test_GetResDiff_Json(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	tables := helper_get_diffTables(t, []string{
+		fmt.Sprintf(someDiffPodTemplate, 1, "Pending"),
+		fmt.Sprintf(someDiffPodTemplate, 2, "Running"),
+	})
+
+	res, err := GetResDiff(helper_get_diffParams(""), tables, someTs.Add(-time.Minute), someTs.Add(10*time.Minute), someRequestId, Unrestricted)
+	assert.Nil(t, err)
+	expectedRes := `[
+ {
+  "fromKey": "/watch/001546398000/Pod/someNamespace/someName/1546398245000000006",
+  "fromTime": 1546398245000000006,
+  "toKey": "/watch/001546398000/Pod/someNamespace/someName/1546398305000000006",
+  "toTime": 1546398305000000006,
+  "patch": [
+   {
+    "op": "replace",
+    "path": "/status/phase",
+    "value": "Running",
+    "oldValue": "Pending"
+   }
+  ]
+ }
+]`
+	assertex.JsonEqual(t, expectedRes, string(res))
+}
+
+func Test_GetResDiffList_BadMode(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	tables := helper_get_diffTables(t, []string{})
+	_, err := GetResDiffList(helper_get_diffParams("sideways"), tables, someTs, someTs.Add(time.Minute), someRequestId, Unrestricted)
+	assert.NotNil(t, err)
+}
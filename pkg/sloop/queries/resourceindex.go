@@ -0,0 +1,352 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// What a request looks up in the resource index.  The terms narrow down which resources are read, and the checks
+// are made against the index for each resource found.  The index has every term a resource had in a partition, so a
+// resource whose labels changed matches what it had at any time in the partition.
+type resourceIndexLookup struct {
+	// A resource has to have one of the terms of each group, like a label with any of the values of an in
+	termGroups [][]string
+	// Requirements which are met by resources without a term, like app!=payments
+	checks []labels.Requirement
+	// True when the only terms are the trigrams of the name match
+	nameOnly bool
+}
+
+// Returns nil when params have nothing to look up in the index, which is when there is no label selector or
+// annotation and the name match is shorter than a trigram.  Annotations which are not in indexedAnnotations, and
+// labels or annotations too long to be indexed, are an error rather than matching nothing.
+func newResourceIndexLookup(params url.Values, indexedAnnotations []string) (*resourceIndexLookup, error) {
+	lookup := &resourceIndexLookup{}
+	selector := params.Get(LabelSelectorParam)
+	if selector != "" {
+		parsed, err := labels.Parse(selector)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%v %q is not a label selector", LabelSelectorParam, selector)
+		}
+		requirements, _ := parsed.Requirements()
+		for _, requirement := range requirements {
+			key := requirement.Key()
+			switch requirement.Operator() {
+			case selection.Equals, selection.DoubleEquals, selection.In:
+				var group []string
+				for _, value := range requirement.Values().List() {
+					group = append(group, typed.LabelTerm(key, value))
+				}
+				lookup.termGroups = append(lookup.termGroups, group)
+			case selection.Exists:
+				lookup.termGroups = append(lookup.termGroups, []string{typed.LabelKeyTerm(key)})
+			case selection.NotEquals, selection.NotIn, selection.DoesNotExist:
+				lookup.checks = append(lookup.checks, requirement)
+			default:
+				return nil, fmt.Errorf("%v does not support the %v operator", LabelSelectorParam, requirement.Operator())
+			}
+		}
+	}
+	for _, annotation := range params[AnnotationParam] {
+		key, value, found := strings.Cut(annotation, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("%v must be key=value, got %q", AnnotationParam, annotation)
+		}
+		if !common.Contains(indexedAnnotations, key) {
+			return nil, fmt.Errorf("%v %q is not indexed, add it to indexedAnnotations to search by it", AnnotationParam, key)
+		}
+		lookup.termGroups = append(lookup.termGroups, []string{typed.AnnotationTerm(key, value)})
+	}
+	for _, term := range lookup.terms() {
+		if len(term) > typed.MaxResourceIndexTermLength {
+			return nil, fmt.Errorf("%q is longer than the resource index holds, so it can not be searched for", term)
+		}
+	}
+	lookup.nameOnly = selector == "" && len(params[AnnotationParam]) == 0
+	// The name match itself is still checked by the key predicate, the trigrams only find names which could match
+	for _, trigram := range typed.NameTrigrams(params.Get(NameMatchParam)) {
+		lookup.termGroups = append(lookup.termGroups, []string{typed.NameTrigramTerm(trigram)})
+	}
+	if len(lookup.termGroups) == 0 && len(lookup.checks) == 0 {
+		return nil, nil
+	}
+	return lookup, nil
+}
+
+// ValidateResourceIndexParams returns an error when the label selector or annotation params do not parse, the selector
+// uses an operator the resource index cannot answer, or an annotation is not indexed, so callers can reject the request
+// before running a query
+func ValidateResourceIndexParams(params url.Values, indexedAnnotations []string) error {
+	_, err := newResourceIndexLookup(params, indexedAnnotations)
+	return err
+}
+
+// readResourceSummaries reads the resource summaries selected by params in the time range, like a RangeRead of the
+// resource summary table with paramFilterResSumFn.  When params have a label selector, an annotation or a name match
+// of at least a trigram, only the summaries of the resources found in the resource index are read.  Partitions which
+// have no index are scanned instead, with the labels and annotations of each resource read from the watch table.
+// So is the first partition with an index, which the index may have started part way through, as resources only
+// seen before then have no index entries in it.
+func readResourceSummaries(t typed.Tables, txn badgerwrap.Txn, params url.Values, scope NamespaceScope, valPredicateFn func(*typed.ResourceSummary) bool, startTime time.Time, endTime time.Time) (map[typed.ResourceSummaryKey]*typed.ResourceSummary, typed.RangeReadStats, error) {
+	lookup, err := newResourceIndexLookup(params, t.IndexedAnnotations())
+	if err != nil {
+		return nil, typed.RangeReadStats{}, err
+	}
+	keyPredicateFn := paramFilterResSumFn(params, scope)
+	if lookup == nil {
+		return t.ResourceSummaryTable().RangeRead(txn, nil, keyPredicateFn, valPredicateFn, startTime, endTime)
+	}
+
+	resources := map[typed.ResourceSummaryKey]*typed.ResourceSummary{}
+	stats := typed.RangeReadStats{TableName: (&typed.ResourceSummaryKey{}).TableName()}
+	before := time.Now()
+	partitionList, err := t.ResourceSummaryTable().GetPartitionsFromTimeRange(txn, startTime, endTime)
+	stats.PartitionCount = len(partitionList)
+	if err != nil {
+		return nil, stats, errors.Wrapf(err, "failed to get partitions from startTime:%v, to endTime:%v", startTime, endTime)
+	}
+	_, firstIndexedPartition := t.ResourceIndexTable().GetMinPartition(txn)
+	for _, partitionId := range partitionList {
+		indexed := t.ResourceIndexTable().HasPartition(txn, partitionId)
+		fullyIndexed := indexed && partitionId != firstIndexedPartition
+		if fullyIndexed && len(lookup.termGroups) > 0 {
+			err = lookup.readIndexedPartition(t, txn, partitionId, keyPredicateFn, valPredicateFn, resources, &stats)
+		} else {
+			// Only resources without a term are wanted, which the index cannot find, or the index is missing resources
+			err = lookup.scanPartition(t, txn, partitionId, indexed, fullyIndexed, keyPredicateFn, valPredicateFn, resources, &stats)
+		}
+		if err != nil {
+			return nil, stats, err
+		}
+	}
+	stats.Elapsed = time.Since(before)
+	return resources, stats, nil
+}
+
+func (l *resourceIndexLookup) readIndexedPartition(t typed.Tables, txn badgerwrap.Txn, partitionId string, keyPredicateFn func(string) bool, valPredicateFn func(*typed.ResourceSummary) bool, resources map[typed.ResourceSummaryKey]*typed.ResourceSummary, stats *typed.RangeReadStats) error {
+	candidates, err := l.candidates(t, txn, partitionId, stats)
+	if err != nil {
+		return err
+	}
+	for key := range candidates {
+		key := key
+		stats.RowsVisitedCount += 1
+		if !keyPredicateFn(key.String()) {
+			continue
+		}
+		passed, err := l.passesChecks(t, txn, &key)
+		if err != nil {
+			return err
+		}
+		if !passed {
+			continue
+		}
+		stats.RowsPassedKeyPredicateCount += 1
+		value, err := t.ResourceSummaryTable().Get(txn, key.String())
+		if err == badger.ErrKeyNotFound {
+			// An index entry without a summary is skipped, like the table reads skip keys which are gone
+			continue
+		} else if err != nil {
+			return err
+		}
+		if valPredicateFn != nil && !valPredicateFn(value) {
+			continue
+		}
+		stats.RowsPassedValuePredicateCount += 1
+		resources[key] = value
+	}
+	return nil
+}
+
+// Reads every resource summary in the partition which passes the key and value predicates, and keeps the ones which
+// match the lookup.  They are checked against the index when the partition is fully indexed, and otherwise against
+// the terms of the payloads in the watch table along with any terms the index has for them.  A lookup of only a name
+// match is already answered by the key predicate.
+func (l *resourceIndexLookup) scanPartition(t typed.Tables, txn badgerwrap.Txn, partitionId string, indexed bool, fullyIndexed bool, keyPredicateFn func(string) bool, valPredicateFn func(*typed.ResourceSummary) bool, resources map[typed.ResourceSummaryKey]*typed.ResourceSummary, stats *typed.RangeReadStats) error {
+	partitionTime, err := untyped.GetTimeForPartition(partitionId)
+	if err != nil {
+		return err
+	}
+	rows, partitionStats, err := t.ResourceSummaryTable().RangeRead(txn, nil, keyPredicateFn, valPredicateFn, partitionTime, partitionTime)
+	stats.RowsVisitedCount += partitionStats.RowsVisitedCount
+	stats.RowsPassedKeyPredicateCount += partitionStats.RowsPassedKeyPredicateCount
+	if err != nil {
+		return err
+	}
+	for key, value := range rows {
+		key := key
+		var passed bool
+		if fullyIndexed {
+			passed, err = l.passesChecks(t, txn, &key)
+		} else if l.nameOnly {
+			passed = true
+		} else {
+			var terms map[string]bool
+			terms, err = watchTableTerms(t, txn, &key, partitionTime)
+			if err == nil && indexed {
+				err = l.addIndexTerms(t, txn, &key, terms)
+			}
+			passed = l.matchesTerms(terms)
+		}
+		if err != nil {
+			return err
+		}
+		if passed {
+			stats.RowsPassedValuePredicateCount += 1
+			resources[key] = value
+		}
+	}
+	return nil
+}
+
+// Returns the terms of every payload of the resource in the partition, the same as processing indexes them
+func watchTableTerms(t typed.Tables, txn badgerwrap.Txn, key *typed.ResourceSummaryKey, partitionTime time.Time) (map[string]bool, error) {
+	keyPrefix := &typed.WatchTableKey{PartitionId: key.PartitionId, Kind: key.Kind, Namespace: key.Namespace, Name: key.Name}
+	watchRows, _, err := t.WatchTable().RangeRead(txn, keyPrefix, nil, nil, partitionTime, partitionTime)
+	if err != nil {
+		return nil, err
+	}
+	terms := map[string]bool{}
+	for _, watchRow := range watchRows {
+		metadata, err := kubeextractor.ExtractMetadata(watchRow.Payload)
+		if err != nil || metadata.Uid != key.Uid {
+			continue
+		}
+		for _, term := range typed.ResourceIndexTerms(metadata.Name, metadata.Labels, metadata.Annotations, t.IndexedAnnotations()) {
+			terms[term] = true
+		}
+	}
+	return terms, nil
+}
+
+// Adds the terms of the lookup which the index has for the resource, so a node whose updates in the partition were
+// all minor, and so not kept in the watch table, is still found
+func (l *resourceIndexLookup) addIndexTerms(t typed.Tables, txn badgerwrap.Txn, key *typed.ResourceSummaryKey, terms map[string]bool) error {
+	for _, term := range l.terms() {
+		indexKey := &typed.ResourceIndexKey{PartitionId: key.PartitionId, Term: term, Kind: key.Kind, Namespace: key.Namespace, Name: key.Name}
+		value, err := t.ResourceIndexTable().GetOrDefault(txn, indexKey.String())
+		if err != nil {
+			return err
+		}
+		if common.Contains(value.Uids, key.Uid) {
+			terms[term] = true
+		}
+	}
+	return nil
+}
+
+// Returns the resources in the partition which have a term of every group, as resource summary keys
+func (l *resourceIndexLookup) candidates(t typed.Tables, txn badgerwrap.Txn, partitionId string, stats *typed.RangeReadStats) (map[typed.ResourceSummaryKey]bool, error) {
+	partitionTime, err := untyped.GetTimeForPartition(partitionId)
+	if err != nil {
+		return nil, err
+	}
+	var found map[typed.ResourceSummaryKey]bool
+	for _, group := range l.termGroups {
+		inGroup := map[typed.ResourceSummaryKey]bool{}
+		for _, term := range group {
+			rows, termStats, err := t.ResourceIndexTable().RangeRead(txn, &typed.ResourceIndexKey{Term: term}, nil, nil, partitionTime, partitionTime)
+			stats.RowsVisitedCount += termStats.RowsVisitedCount
+			if err != nil {
+				return nil, err
+			}
+			for key, value := range rows {
+				for _, uid := range value.Uids {
+					resSumKey := typed.ResourceSummaryKey{PartitionId: partitionId, Kind: key.Kind, Namespace: key.Namespace, Name: key.Name, Uid: uid}
+					if found == nil || found[resSumKey] {
+						inGroup[resSumKey] = true
+					}
+				}
+			}
+		}
+		found = inGroup
+		if len(found) == 0 {
+			break
+		}
+	}
+	return found, nil
+}
+
+func (l *resourceIndexLookup) passesChecks(t typed.Tables, txn badgerwrap.Txn, key *typed.ResourceSummaryKey) (bool, error) {
+	for _, requirement := range l.checks {
+		for _, term := range checkTerms(requirement) {
+			indexKey := &typed.ResourceIndexKey{PartitionId: key.PartitionId, Term: term, Kind: key.Kind, Namespace: key.Namespace, Name: key.Name}
+			value, err := t.ResourceIndexTable().GetOrDefault(txn, indexKey.String())
+			if err != nil {
+				return false, err
+			}
+			if common.Contains(value.Uids, key.Uid) {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+// Returns true when the terms have one of the terms of each group, and none of the terms of the checks
+func (l *resourceIndexLookup) matchesTerms(terms map[string]bool) bool {
+	for _, group := range l.termGroups {
+		found := false
+		for _, term := range group {
+			if terms[term] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for _, requirement := range l.checks {
+		for _, term := range checkTerms(requirement) {
+			if terms[term] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Returns every term of the groups and the checks
+func (l *resourceIndexLookup) terms() []string {
+	var terms []string
+	for _, group := range l.termGroups {
+		terms = append(terms, group...)
+	}
+	for _, requirement := range l.checks {
+		terms = append(terms, checkTerms(requirement)...)
+	}
+	return terms
+}
+
+// The terms a resource must not have to pass a check
+func checkTerms(requirement labels.Requirement) []string {
+	if requirement.Operator() == selection.DoesNotExist {
+		return []string{typed.LabelKeyTerm(requirement.Key())}
+	}
+	var terms []string
+	for _, value := range requirement.Values().List() {
+		terms = append(terms, typed.LabelTerm(requirement.Key(), value))
+	}
+	return terms
+}
@@ -0,0 +1,265 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+// Makes resource summaries for pods with the labels, and indexes them like processing does
+func helper_get_indexedResSumTable(t *testing.T, podLabels map[string]map[string]string) typed.Tables {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	var keys []*typed.ResourceSummaryKey
+	for name := range podLabels {
+		keys = append(keys, typed.NewResourceSummaryKey(someFirstSeenTime, "Pod", "someNamespace", name, name+"-uid"))
+	}
+	tables := helper_get_resSumtable(keys, t)
+	err := tables.Db().Update(func(txn badgerwrap.Txn) error {
+		for name, labels := range podLabels {
+			var terms []string
+			for _, trigram := range typed.NameTrigrams(name) {
+				terms = append(terms, typed.NameTrigramTerm(trigram))
+			}
+			for key, value := range labels {
+				terms = append(terms, typed.LabelKeyTerm(key), typed.LabelTerm(key, value))
+			}
+			for _, term := range terms {
+				key := typed.NewResourceIndexKey(someFirstSeenTime, term, "Pod", "someNamespace", name)
+				err := tables.ResourceIndexTable().Set(txn, key.String(), &typed.ResourceIndex{Uids: []string{name + "-uid"}})
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	return tables
+}
+
+// Adds resource summaries and watch rows for pods with the labels at ts without indexing them, like a partition
+// written before the index was added
+func helper_addUnindexedPods(t *testing.T, tables typed.Tables, ts time.Time, podLabels map[string]map[string]string) {
+	firstSeen, err := ptypes.TimestampProto(someFirstSeenTime)
+	assert.Nil(t, err)
+	lastSeen, err := ptypes.TimestampProto(someLastSeenTime)
+	assert.Nil(t, err)
+	pTs, err := ptypes.TimestampProto(ts)
+	assert.Nil(t, err)
+	err = tables.Db().Update(func(txn badgerwrap.Txn) error {
+		for name, labels := range podLabels {
+			metadata := map[string]interface{}{"name": name, "namespace": "someNamespace", "uid": name + "-uid", "labels": labels, "annotations": map[string]string{"team": name}}
+			payload, err := json.Marshal(map[string]interface{}{"metadata": metadata})
+			assert.Nil(t, err)
+			watchKey := typed.NewWatchTableKey(untyped.GetPartitionId(ts), "Pod", "someNamespace", name, ts)
+			err = tables.WatchTable().Set(txn, watchKey.String(), &typed.KubeWatchResult{Kind: "Pod", Timestamp: pTs, WatchType: typed.KubeWatchResult_UPDATE, Payload: string(payload)})
+			if err != nil {
+				return err
+			}
+			resSumKey := typed.NewResourceSummaryKey(ts, "Pod", "someNamespace", name, name+"-uid")
+			err = tables.ResourceSummaryTable().Set(txn, resSumKey.String(), &typed.ResourceSummary{FirstSeen: firstSeen, LastSeen: lastSeen})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+}
+
+// Returns the tables of the same store with the annotations indexed
+func helper_withIndexedAnnotations(tables typed.Tables, indexedAnnotations ...string) typed.Tables {
+	return typed.NewTableListWithIndexedAnnotations(tables.Db(), indexedAnnotations)
+}
+
+func helper_listResourceNames(t *testing.T, tables typed.Tables, params url.Values) []string {
+	res, err := ApiListResources(params, tables, someFirstSeenTime.Add(-1*time.Hour), someLastSeenTime, someRequestId, Unrestricted)
+	assert.Nil(t, err)
+	names := []string{}
+	for _, resource := range res {
+		names = append(names, resource.Name)
+	}
+	return names
+}
+
+var someLabeledPods = map[string]map[string]string{
+	"web-1":   {"app": "web", "tier": "frontend"},
+	"web-2":   {"app": "web", "tier": "cache"},
+	"api-1":   {"app": "api"},
+	"batch-1": {},
+}
+
+func Test_ReadResourceSummaries_LabelSelector(t *testing.T) {
+	tables := helper_get_indexedResSumTable(t, someLabeledPods)
+
+	for selector, expected := range map[string][]string{
+		"app=web":                  {"web-1", "web-2"},
+		"app==api":                 {"api-1"},
+		"app=web,tier=frontend":    {"web-1"},
+		"app in (web,api)":         {"api-1", "web-1", "web-2"},
+		"tier":                     {"web-1", "web-2"},
+		"app=web,tier!=cache":      {"web-1"},
+		"app,app notin (web)":      {"api-1"},
+		"!tier":                    {"api-1", "batch-1"},
+		"app!=web":                 {"api-1", "batch-1"},
+		"app=missing":              {},
+		"app in (web),tier=absent": {},
+	} {
+		params := url.Values{}
+		params.Set(LabelSelectorParam, selector)
+		assert.ElementsMatch(t, expected, helper_listResourceNames(t, tables, params), selector)
+	}
+}
+
+func Test_ReadResourceSummaries_NameMatchUsesTrigrams(t *testing.T) {
+	tables := helper_get_indexedResSumTable(t, someLabeledPods)
+
+	params := url.Values{}
+	params.Set(NameMatchParam, "web-")
+	assert.ElementsMatch(t, []string{"web-1", "web-2"}, helper_listResourceNames(t, tables, params))
+
+	// No name has the trigram "b-3"
+	params.Set(NameMatchParam, "web-3")
+	assert.ElementsMatch(t, []string{}, helper_listResourceNames(t, tables, params))
+
+	// Shorter than a trigram is a substring match over every resource
+	params.Set(NameMatchParam, "-1")
+	assert.ElementsMatch(t, []string{"web-1", "api-1", "batch-1"}, helper_listResourceNames(t, tables, params))
+
+	params.Set(NameMatchParam, "web")
+	params.Set(LabelSelectorParam, "tier=cache")
+	assert.ElementsMatch(t, []string{"web-2"}, helper_listResourceNames(t, tables, params))
+}
+
+func Test_ReadResourceSummaries_Annotation(t *testing.T) {
+	tables := helper_withIndexedAnnotations(helper_get_indexedResSumTable(t, someLabeledPods), "team")
+	err := tables.Db().Update(func(txn badgerwrap.Txn) error {
+		key := typed.NewResourceIndexKey(someFirstSeenTime, typed.AnnotationTerm("team", "payments"), "Pod", "someNamespace", "api-1")
+		return tables.ResourceIndexTable().Set(txn, key.String(), &typed.ResourceIndex{Uids: []string{"api-1-uid"}})
+	})
+	assert.Nil(t, err)
+
+	params := url.Values{}
+	params.Set(AnnotationParam, "team=payments")
+	assert.ElementsMatch(t, []string{"api-1"}, helper_listResourceNames(t, tables, params))
+	params.Set(LabelSelectorParam, "app=web")
+	assert.ElementsMatch(t, []string{}, helper_listResourceNames(t, tables, params))
+
+	// An annotation which is not indexed is an error rather than matching nothing
+	params = url.Values{}
+	params.Set(AnnotationParam, "note=ignored")
+	_, err = ApiListResources(params, tables, someFirstSeenTime.Add(-1*time.Hour), someLastSeenTime, someRequestId, Unrestricted)
+	assert.NotNil(t, err)
+}
+
+func Test_ReadResourceSummaries_PartitionWithoutIndex(t *testing.T) {
+	// The indexed pods are in the partition of someFirstSeenTime and the others in the next one
+	tables := helper_withIndexedAnnotations(helper_get_indexedResSumTable(t, someLabeledPods), "team")
+	helper_addUnindexedPods(t, tables, someFirstSeenTime.Add(time.Hour), map[string]map[string]string{
+		"web-old":   {"app": "web", "tier": "cache"},
+		"batch-old": {},
+	})
+
+	for selector, expected := range map[string][]string{
+		"app=web":             {"web-1", "web-2", "web-old"},
+		"app=web,tier!=cache": {"web-1"},
+		"!tier":               {"api-1", "batch-1", "batch-old"},
+	} {
+		params := url.Values{}
+		params.Set(LabelSelectorParam, selector)
+		assert.ElementsMatch(t, expected, helper_listResourceNames(t, tables, params), selector)
+	}
+
+	params := url.Values{}
+	params.Set(NameMatchParam, "-old")
+	assert.ElementsMatch(t, []string{"web-old", "batch-old"}, helper_listResourceNames(t, tables, params))
+	params.Set(NameMatchParam, "web")
+	assert.ElementsMatch(t, []string{"web-1", "web-2", "web-old"}, helper_listResourceNames(t, tables, params))
+
+	params = url.Values{}
+	params.Set(AnnotationParam, "team=batch-old")
+	assert.ElementsMatch(t, []string{"batch-old"}, helper_listResourceNames(t, tables, params))
+}
+
+func Test_ReadResourceSummaries_PartitionIndexStartedIn(t *testing.T) {
+	// The pods added without an index were only seen in the partition before the index started part way through it
+	tables := helper_withIndexedAnnotations(helper_get_indexedResSumTable(t, someLabeledPods), "team")
+	helper_addUnindexedPods(t, tables, someFirstSeenTime, map[string]map[string]string{
+		"web-old":   {"app": "web", "tier": "cache"},
+		"batch-old": {},
+	})
+
+	for selector, expected := range map[string][]string{
+		"app=web":             {"web-1", "web-2", "web-old"},
+		"app=web,tier!=cache": {"web-1"},
+		"!tier":               {"api-1", "batch-1", "batch-old"},
+	} {
+		params := url.Values{}
+		params.Set(LabelSelectorParam, selector)
+		assert.ElementsMatch(t, expected, helper_listResourceNames(t, tables, params), selector)
+	}
+
+	// Later partitions are read from the index, so pods without index entries there are not found
+	helper_addUnindexedPods(t, tables, someFirstSeenTime.Add(time.Hour), map[string]map[string]string{"web-new": {"app": "web"}})
+	err := tables.Db().Update(func(txn badgerwrap.Txn) error {
+		key := typed.NewResourceIndexKey(someFirstSeenTime.Add(time.Hour), typed.LabelTerm("app", "api"), "Pod", "someNamespace", "api-2")
+		return tables.ResourceIndexTable().Set(txn, key.String(), &typed.ResourceIndex{Uids: []string{"api-2-uid"}})
+	})
+	assert.Nil(t, err)
+	params := url.Values{}
+	params.Set(LabelSelectorParam, "app=web")
+	assert.ElementsMatch(t, []string{"web-1", "web-2", "web-old"}, helper_listResourceNames(t, tables, params))
+}
+
+func Test_ValidateResourceIndexParams(t *testing.T) {
+	params := url.Values{}
+	assert.Nil(t, ValidateResourceIndexParams(params, nil))
+	params.Set(LabelSelectorParam, "app=web,!tier")
+	assert.Nil(t, ValidateResourceIndexParams(params, nil))
+	params.Set(LabelSelectorParam, "app=(web")
+	assert.NotNil(t, ValidateResourceIndexParams(params, nil))
+	params.Set(LabelSelectorParam, "replicas>1")
+	assert.NotNil(t, ValidateResourceIndexParams(params, nil))
+	params = url.Values{}
+	params.Set(AnnotationParam, "team")
+	assert.NotNil(t, ValidateResourceIndexParams(params, []string{"team"}))
+
+	// Only indexed annotations can be searched for
+	params.Set(AnnotationParam, "team=payments")
+	assert.Nil(t, ValidateResourceIndexParams(params, []string{"team"}))
+	assert.NotNil(t, ValidateResourceIndexParams(params, nil))
+
+	// Terms too long to be indexed can not be searched for either
+	params.Set(AnnotationParam, "team="+strings.Repeat("x", typed.MaxResourceIndexTermLength))
+	assert.NotNil(t, ValidateResourceIndexParams(params, []string{"team"}))
+	params = url.Values{}
+	params.Set(LabelSelectorParam, "example.com/"+strings.Repeat("x", 63)+"!="+strings.Repeat("y", 63)+",!"+strings.Repeat("a", 63)+"."+strings.Repeat("b", 63)+"."+strings.Repeat("c", 63)+"."+strings.Repeat("d", 61)+"/"+strings.Repeat("e", 63))
+	err := ValidateResourceIndexParams(params, nil)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "longer than the resource index holds")
+}
+
+func Test_ExportColumns_SelectorOnlyForResSum(t *testing.T) {
+	params := url.Values{}
+	params.Set(LabelSelectorParam, "app=web")
+	_, err := ExportColumns(params, nil)
+	assert.NotNil(t, err)
+	params.Set(TableParam, ExportResSumTable)
+	_, err = ExportColumns(params, nil)
+	assert.Nil(t, err)
+}
@@ -33,8 +33,25 @@ type PayloadOuput struct {
 	Payload     string `json:"payload,omitempty"`
 }
 
-func GetResPayload(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+func GetResPayload(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string, scope NamespaceScope) ([]byte, error) {
+	payloadOutputList, err := getResPayloadList(params, t, startTime, endTime, requestId, scope)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	var res ResPayLoadData
+	res.PayloadList = payloadOutputList
+	bytes, err := json.MarshalIndent(res.PayloadList, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json for PayloadList  %v", err)
+	}
+
+	return bytes, nil
+}
 
+// Returns the payloads of a single resource sorted by time with unchanged payloads removed.
+// The newest payload from before startTime is included so callers know the state at the start of the range.
+func getResPayloadList(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string, scope NamespaceScope) ([]PayloadOuput, error) {
 	glog.V(common.GlogVerbose).Infof("GetResPayload: startTime: %v, endTime: %v", startTime.Unix(), endTime.Unix())
 	var watchRes map[typed.WatchTableKey]*typed.KubeWatchResult
 	var previousKey *typed.WatchTableKey
@@ -84,23 +101,21 @@ func GetResPayload(params url.Values, t typed.Tables, startTime time.Time, endTi
 		return nil
 	})
 	if err != nil {
-		return []byte{}, err
+		return nil, err
+	}
+
+	// Checked on the keys read rather than the params so no combination of params can reach outside the scope
+	for key := range watchRes {
+		if !scope.AllowsResource(key.Kind, key.Namespace, key.Name) {
+			delete(watchRes, key)
+		}
 	}
 
 	payloadOutputList := getPayloadOutputList(watchRes)
 	glog.V(5).Infof("get the length of the resPayload is:%v", len(payloadOutputList))
 
 	// Sort by time and remove entries with no payload change
-	payloadOutputList = removeDupePayloads(payloadOutputList)
-
-	var res ResPayLoadData
-	res.PayloadList = payloadOutputList
-	bytes, err := json.MarshalIndent(res.PayloadList, "", " ")
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal json for PayloadList  %v", err)
-	}
-
-	return bytes, nil
+	return removeDupePayloads(payloadOutputList), nil
 }
 
 func GetSeekKey(keyComparator *typed.WatchTableKey, startTime time.Time) *typed.WatchTableKey {
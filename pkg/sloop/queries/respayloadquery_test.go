@@ -63,7 +63,7 @@ func Test_GetResPayload_False(t *testing.T) {
 	starTime := someTs.Add(-60 * time.Minute)
 	endTime := someTs.Add(60 * time.Minute)
 	tables := helper_get_resPayload(keys, t, somePTime)
-	res, err := GetResPayload(values, tables, starTime, endTime, someRequestId)
+	res, err := GetResPayload(values, tables, starTime, endTime, someRequestId, Unrestricted)
 	assert.Equal(t, "[]", string(res))
 	assert.Nil(t, err)
 }
@@ -87,7 +87,7 @@ func Test_GetResPayload_NotInTimeRange(t *testing.T) {
 		keys = append(keys, typed.NewWatchTableKey(partitionId, "someKind"+string(i), "someNamespace", "someName.xx", someTs).String())
 	}
 	tables := helper_get_resPayload(keys, t, somePTime)
-	res, err := GetResPayload(values, tables, someTs.Add(2*time.Hour), someTs.Add(5*time.Hour), someRequestId)
+	res, err := GetResPayload(values, tables, someTs.Add(2*time.Hour), someTs.Add(5*time.Hour), someRequestId, Unrestricted)
 	assert.Nil(t, err)
 	assert.Equal(t, "[]", string(res))
 }
@@ -107,7 +107,7 @@ func Test_GetResPayload_True_NoPreviousKeyFound(t *testing.T) {
 	keys = append(keys, typed.NewWatchTableKey(partitionId, expectedKind, expectedNS, expectedName, someTs).String())
 	keys = append(keys, typed.NewWatchTableKey(partitionId, "someKind", "someNamespaceb", "someName", someTs).String())
 	tables := helper_get_resPayload(keys, t, somePTime)
-	res, err := GetResPayload(values, tables, someTs.Add(-1*time.Hour), someTs.Add(6*time.Hour), someRequestId)
+	res, err := GetResPayload(values, tables, someTs.Add(-1*time.Hour), someTs.Add(6*time.Hour), someRequestId, Unrestricted)
 	assert.Nil(t, err)
 	expectedRes := `[
  {
@@ -139,7 +139,7 @@ func Test_GetResPayload_True_PreviousKeyFound(t *testing.T) {
 	keys = append(keys, typed.NewWatchTableKey(partitionId, "someKind-test", "someNamespace-test", "someName-test", someTs).String())
 	tables := helper_get_resPayload(keys, t, somePTime)
 
-	res, err := GetResPayload(values, tables, someTs, someTs.Add(6*time.Hour), someRequestId)
+	res, err := GetResPayload(values, tables, someTs, someTs.Add(6*time.Hour), someRequestId, Unrestricted)
 
 	assert.Nil(t, err)
 	expectedRes := `[
@@ -202,7 +202,7 @@ func Test_GetResPayload_True_HasSamePrefix(t *testing.T) {
 	keys = append(keys, typed.NewWatchTableKey(partitionId, "someKind", "someNamespace", "someName-15", someTs).String())
 
 	tables := helper_get_resPayload(keys, t, somePTime)
-	res, err := GetResPayload(values, tables, someTs.Add(-1*time.Hour), someTs.Add(6*time.Hour), someRequestId)
+	res, err := GetResPayload(values, tables, someTs.Add(-1*time.Hour), someTs.Add(6*time.Hour), someRequestId, Unrestricted)
 	assert.Nil(t, err)
 	expectedRes := `[
  {
@@ -230,7 +230,7 @@ func Test_GetResPayload_NoDataInTimeRangeStillFindsPayload(t *testing.T) {
 	tables := helper_get_resPayload(keys, t, somePTime)
 
 	queryTs := someTs.Add(5 * time.Hour)
-	res, err := GetResPayload(values, tables, queryTs.Add(-15*time.Minute), queryTs.Add(15*time.Minute), someRequestId)
+	res, err := GetResPayload(values, tables, queryTs.Add(-15*time.Minute), queryTs.Add(15*time.Minute), someRequestId, Unrestricted)
 	assert.Nil(t, err)
 	expectedRes := `[
  {
@@ -27,12 +27,12 @@ func (r ResSummaryOutput) IsEmpty() bool {
 	return reflect.DeepEqual(ResSummaryOutput{}, r)
 }
 
-func GetResSummaryData(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string) ([]byte, error) {
+func GetResSummaryData(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string, scope NamespaceScope) ([]byte, error) {
 	var resSummaries map[typed.ResourceSummaryKey]*typed.ResourceSummary
 	err := t.Db().View(func(txn badgerwrap.Txn) error {
 		var err2 error
 		var stats typed.RangeReadStats
-		resSummaries, stats, err2 = t.ResourceSummaryTable().RangeRead(txn, nil, paramFilterResSumFn(params), isResSummaryValInTimeRange(startTime, endTime), startTime, endTime)
+		resSummaries, stats, err2 = readResourceSummaries(t, txn, params, scope, isResSummaryValInTimeRange(startTime, endTime), startTime, endTime)
 		if err2 != nil {
 			return err2
 		}
@@ -45,7 +45,7 @@ func Test_GetResSummaryData_False(t *testing.T) {
 	keys[0] = typed.NewResourceSummaryKey(someTs, "someKind", "someNs", "mynamespace", "68510937-4ffc-11e9-8e26-1418775557c8")
 	keys[1] = typed.NewResourceSummaryKey(someTs, "SomeKind", "namespace-b", "somename-b", "45510937-d4fc-11e9-8e26-14187754567")
 	tables := helper_get_resSumtable(keys, t)
-	res, err := GetResSummaryData(values, tables, someTs.Add(-60*time.Minute), someTs.Add(60*time.Minute), someRequestId)
+	res, err := GetResSummaryData(values, tables, someTs.Add(-60*time.Minute), someTs.Add(60*time.Minute), someRequestId, Unrestricted)
 	assert.Equal(t, string(res), "")
 	assert.Nil(t, err)
 }
@@ -60,7 +60,7 @@ func Test_GetResSummaryData_NotInTimeRange(t *testing.T) {
 	keys := make([]*typed.ResourceSummaryKey, 1)
 	keys[0] = typed.NewResourceSummaryKey(someTs, "someKind", "someNamespace", "someName", "someuid")
 	tables := helper_get_resSumtable(keys, t)
-	res, err := GetResSummaryData(values, tables, someTs.Add(60*time.Minute), someTs.Add(160*time.Minute), someRequestId)
+	res, err := GetResSummaryData(values, tables, someTs.Add(60*time.Minute), someTs.Add(160*time.Minute), someRequestId, Unrestricted)
 	assert.Nil(t, err)
 	assert.Equal(t, string(res), "")
 }
@@ -75,7 +75,7 @@ func Test_GetResSummaryData_True(t *testing.T) {
 	keys := make([]*typed.ResourceSummaryKey, 1)
 	keys[0] = typed.NewResourceSummaryKey(someFirstSeenTime, "someKind", "someNamespace", "someName", "someuid")
 	tables := helper_get_resSumtable(keys, t)
-	res, err := GetResSummaryData(values, tables, someFirstSeenTime.Add(-1*time.Hour), someLastSeenTime.Add(6*time.Hour), someRequestId)
+	res, err := GetResSummaryData(values, tables, someFirstSeenTime.Add(-1*time.Hour), someLastSeenTime.Add(6*time.Hour), someRequestId, Unrestricted)
 	assert.Nil(t, err)
 	expectedRes := `{
        "PartitionId": "001551668400",
@@ -0,0 +1,164 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"path"
+	"strings"
+
+	"github.com/salesforce/sloop/pkg/sloop/kubeextractor"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+// Namespace pattern which allows cluster scoped resources like nodes and cluster roles
+const ClusterScopePattern = "_cluster"
+
+// Decides whether the caller can see objects of a kind in a namespace.  Namespace is empty for cluster scoped kinds,
+// and is the name of the namespace for Namespace objects.
+type KindAuthorizer interface {
+	CanGet(kind string, namespace string) bool
+	// The error which made the authorizer deny everything, or nil
+	Err() error
+}
+
+// NamespaceScope limits which namespaces a query can return rows from.  The zero value is unrestricted.
+// Every query applies the scope to the keys it reads, so a caller can not get around it with query params.
+type NamespaceScope struct {
+	restricted bool
+	patterns   []string
+	// When set every resource also has to be allowed by the authorizer
+	authorizer KindAuthorizer
+}
+
+var Unrestricted = NamespaceScope{}
+
+// Returns a scope which only allows namespaces matching one of the patterns.  Patterns use path.Match syntax,
+// so "team-*" allows every namespace starting with "team-".  An empty list allows nothing.
+func NewNamespaceScope(patterns []string) NamespaceScope {
+	return NamespaceScope{restricted: true, patterns: append([]string{}, patterns...)}
+}
+
+// Returns a copy of the scope which also checks every resource with authorizer
+func (s NamespaceScope) WithAuthorizer(authorizer KindAuthorizer) NamespaceScope {
+	s.authorizer = authorizer
+	return s
+}
+
+// Returns the error of the authorizer, when it could not check a resource.  A query run with the scope may then have
+// left out rows the caller can see, so it should fail rather than return what it found
+func (s NamespaceScope) Err() error {
+	if s.authorizer == nil {
+		return nil
+	}
+	return s.authorizer.Err()
+}
+
+func (s NamespaceScope) IsRestricted() bool {
+	return s.restricted || s.authorizer != nil
+}
+
+func (s NamespaceScope) AllowsNamespace(namespace string) bool {
+	if !s.restricted {
+		return true
+	}
+	for _, pattern := range s.patterns {
+		if pattern == ClusterScopePattern {
+			continue
+		}
+		if ok, _ := path.Match(pattern, namespace); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// A Namespace object is allowed when the namespace it names is.  Other resources without a namespace are only
+// allowed when the scope includes ClusterScopePattern.
+func (s NamespaceScope) AllowsResource(kind string, namespace string, name string) bool {
+	if kind == kubeextractor.NamespaceKind {
+		namespace = name
+		if !s.AllowsNamespace(namespace) {
+			return false
+		}
+	} else if namespace == "" {
+		if !s.allowsClusterScope() {
+			return false
+		}
+	} else if !s.AllowsNamespace(namespace) {
+		return false
+	}
+	return s.authorizer == nil || s.authorizer.CanGet(kind, namespace)
+}
+
+func (s NamespaceScope) allowsClusterScope() bool {
+	if !s.restricted {
+		return true
+	}
+	for _, pattern := range s.patterns {
+		if pattern == ClusterScopePattern {
+			return true
+		}
+	}
+	return false
+}
+
+// Checks a raw key from any of the typed tables, for pages like the debug key browser which read keys directly.
+// Keys which do not belong to a resource, like badger internal keys, are only allowed when unrestricted.
+func (s NamespaceScope) AllowsKey(key string) bool {
+	if !s.IsRestricted() {
+		return true
+	}
+	var kind, namespace, name string
+	var err error
+	switch {
+	case strings.HasPrefix(key, "/watch/"):
+		k := &typed.WatchTableKey{}
+		err = k.Parse(key)
+		kind, namespace, name = k.Kind, k.Namespace, k.Name
+	case strings.HasPrefix(key, "/ressum/"):
+		k := &typed.ResourceSummaryKey{}
+		err = k.Parse(key)
+		kind, namespace, name = k.Kind, k.Namespace, k.Name
+	case strings.HasPrefix(key, "/eventcount/"):
+		k := &typed.EventCountKey{}
+		err = k.Parse(key)
+		kind, namespace, name = k.Kind, k.Namespace, k.Name
+	case strings.HasPrefix(key, "/watchactivity/"):
+		k := &typed.WatchActivityKey{}
+		err = k.Parse(key)
+		kind, namespace, name = k.Kind, k.Namespace, k.Name
+	case strings.HasPrefix(key, "/alert/"):
+		k := &typed.AlertKey{}
+		err = k.Parse(key)
+		kind, namespace, name = k.Kind, k.Namespace, k.Name
+	case strings.HasPrefix(key, "/resindex/"):
+		k := &typed.ResourceIndexKey{}
+		err = k.Parse(key)
+		kind, namespace, name = k.Kind, k.Namespace, k.Name
+	default:
+		return false
+	}
+	if err != nil {
+		return false
+	}
+	return s.AllowsResource(kind, namespace, name)
+}
+
+// Returns the keys the scope allows
+func (s NamespaceScope) FilterKeys(keys []string) []string {
+	if !s.IsRestricted() {
+		return keys
+	}
+	ret := []string{}
+	for _, key := range keys {
+		if s.AllowsKey(key) {
+			ret = append(ret, key)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/test/assertex"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NamespaceScope_AllowsResource(t *testing.T) {
+	scope := NewNamespaceScope([]string{"team-*", "default"})
+	assert.True(t, scope.AllowsResource("Pod", "team-a", "somePod"))
+	assert.True(t, scope.AllowsResource("Pod", "default", "somePod"))
+	assert.False(t, scope.AllowsResource("Pod", "kube-system", "somePod"))
+	assert.True(t, scope.AllowsResource("Namespace", "", "team-b"))
+	assert.False(t, scope.AllowsResource("Namespace", "", "kube-system"))
+	assert.False(t, scope.AllowsResource("Node", "", "someNode"))
+
+	withCluster := NewNamespaceScope([]string{"default", ClusterScopePattern})
+	assert.True(t, withCluster.AllowsResource("Node", "", "someNode"))
+	assert.False(t, withCluster.AllowsNamespace(ClusterScopePattern))
+
+	assert.False(t, NewNamespaceScope(nil).AllowsResource("Pod", "default", "somePod"))
+	assert.True(t, Unrestricted.AllowsResource("Node", "", "someNode"))
+}
+
+func Test_NamespaceScope_AllowsKey(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	scope := NewNamespaceScope([]string{"team-a"})
+	allowed := []string{
+		typed.NewWatchTableKey("001", "Pod", "team-a", "somePod", someTs).String(),
+		typed.NewResourceSummaryKey(someTs, "Namespace", "", "team-a", "someUid").String(),
+		typed.NewEventCountKey(someTs, "Pod", "team-a", "somePod", "someUid").String(),
+		typed.NewWatchActivityKey("001", "Pod", "team-a", "somePod", "someUid").String(),
+		typed.NewAlertKey(someTs, "Pod", "team-a", "somePod", "someRule").String(),
+	}
+	denied := []string{
+		typed.NewWatchTableKey("001", "Pod", "team-b", "somePod", someTs).String(),
+		typed.NewResourceSummaryKey(someTs, "Node", "", "someNode", "someUid").String(),
+		"/internal/key",
+		"/watch/bad",
+	}
+	for _, key := range allowed {
+		assert.True(t, scope.AllowsKey(key), key)
+	}
+	for _, key := range denied {
+		assert.False(t, scope.AllowsKey(key), key)
+		assert.True(t, Unrestricted.AllowsKey(key), key)
+	}
+	assert.Equal(t, allowed, scope.FilterKeys(append(append([]string{}, allowed...), denied...)))
+}
+
+func Test_NamespaceAndKindQuery_Scoped(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	keys := []*typed.ResourceSummaryKey{
+		typed.NewResourceSummaryKey(someTs, "Namespace", "", "team-a", "68510937-4ffc-11e9-8e26-1418775557c8"),
+		typed.NewResourceSummaryKey(someTs, "Namespace", "", "kube-system", "78510937-4ffc-11e9-8e26-1418775557c8"),
+		typed.NewResourceSummaryKey(someTs, "Deployment", "team-a", "somename-a", "45510937-d4fc-11e9-8e26-14187754567"),
+		typed.NewResourceSummaryKey(someTs, "DaemonSet", "kube-system", "somename-b", "55510937-d4fc-11e9-8e26-14187754567"),
+	}
+	tables := helper_get_resSumtable(keys, t)
+	scope := NewNamespaceScope([]string{"team-*"})
+
+	namespaces, err := NamespaceQuery(url.Values{}, tables, someTs, someTs, someRequestId, scope)
+	assert.Nil(t, err)
+	assertex.JsonEqual(t, `["team-a", "_all"]`, string(namespaces))
+
+	kinds, err := KindQuery(url.Values{}, tables, someTs, someTs, someRequestId, scope)
+	assert.Nil(t, err)
+	assertex.JsonEqual(t, `["Deployment", "Namespace", "_all"]`, string(kinds))
+}
+
+func Test_GetResPayload_Scoped(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	partitionId := untyped.GetPartitionId(someTs)
+	keys := []string{
+		typed.NewWatchTableKey(partitionId, "someKind", "team-a", "someName", someTs).String(),
+		typed.NewWatchTableKey(partitionId, "someKind", "kube-system", "someName", someTs).String(),
+	}
+	tables := helper_get_resPayload(keys, t, somePTime)
+	values := helper_get_params()
+	values[KindParam] = []string{"someKind"}
+	values[NameParam] = []string{"someName"}
+	scope := NewNamespaceScope([]string{"team-a"})
+
+	values[NamespaceParam] = []string{"kube-system"}
+	payloads, err := getResPayloadList(values, tables, someTs.Add(-time.Hour), someTs.Add(time.Hour), someRequestId, scope)
+	assert.Nil(t, err)
+	assert.Len(t, payloads, 0)
+
+	values[NamespaceParam] = []string{"team-a"}
+	payloads, err = getResPayloadList(values, tables, someTs.Add(-time.Hour), someTs.Add(time.Hour), someRequestId, scope)
+	assert.Nil(t, err)
+	assert.Len(t, payloads, 1)
+}
+
+type fakeKindAuthorizer map[string]bool
+
+func (f fakeKindAuthorizer) CanGet(kind string, namespace string) bool {
+	return f[kind+"/"+namespace]
+}
+
+func (f fakeKindAuthorizer) Err() error {
+	return nil
+}
+
+func Test_NamespaceScope_WithAuthorizer(t *testing.T) {
+	authorizer := fakeKindAuthorizer{"Pod/team-a": true, "Namespace/team-a": true, "Node/": true}
+	scope := Unrestricted.WithAuthorizer(authorizer)
+	assert.True(t, scope.IsRestricted())
+	assert.True(t, scope.AllowsResource("Pod", "team-a", "somePod"))
+	assert.False(t, scope.AllowsResource("Deployment", "team-a", "someDeployment"))
+	assert.True(t, scope.AllowsResource("Namespace", "", "team-a"))
+	assert.False(t, scope.AllowsResource("Namespace", "", "team-b"))
+	assert.True(t, scope.AllowsResource("Node", "", "someNode"))
+
+	// Both the namespace patterns and the authorizer have to allow a resource
+	scope = NewNamespaceScope([]string{"team-b"}).WithAuthorizer(authorizer)
+	assert.False(t, scope.AllowsResource("Pod", "team-a", "somePod"))
+	assert.False(t, scope.AllowsResource("Node", "", "someNode"))
+}
+
+func Test_GetResSummaryData_WithAuthorizer(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	keys := []*typed.ResourceSummaryKey{
+		typed.NewResourceSummaryKey(someFirstSeenTime, "Pod", "team-a", "somePod", "68510937-4ffc-11e9-8e26-1418775557c8"),
+		typed.NewResourceSummaryKey(someFirstSeenTime, "Deployment", "team-a", "someDeployment", "45510937-d4fc-11e9-8e26-14187754567"),
+	}
+	tables := helper_get_resSumtable(keys, t)
+	scope := Unrestricted.WithAuthorizer(fakeKindAuthorizer{"Pod/team-a": true})
+
+	values := helper_get_params()
+	values[NamespaceParam] = []string{"team-a"}
+	values[NameParam] = []string{"someDeployment"}
+	res, err := GetResSummaryData(values, tables, someFirstSeenTime.Add(-time.Hour), someLastSeenTime.Add(time.Hour), someRequestId, scope)
+	assert.Nil(t, err)
+	assert.Equal(t, "", string(res))
+
+	values[NameParam] = []string{"somePod"}
+	res, err = GetResSummaryData(values, tables, someFirstSeenTime.Add(-time.Hour), someLastSeenTime.Add(time.Hour), someRequestId, scope)
+	assert.Nil(t, err)
+	assert.Contains(t, string(res), "somePod")
+}
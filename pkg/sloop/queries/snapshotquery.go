@@ -0,0 +1,180 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/ghodss/yaml"
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// An object as it was at the time of a snapshot
+type SnapshotObject struct {
+	Kind      string          `json:"kind"`
+	Namespace string          `json:"namespace"`
+	Name      string          `json:"name"`
+	Uid       string          `json:"uid"`
+	Timestamp time.Time       `json:"timestamp"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// Returns the objects which were live at endTime as json.  See GetSnapshotList
+func GetSnapshot(params url.Values, t typed.Tables, startTime time.Time, endTime time.Time, requestId string, scope NamespaceScope) ([]byte, error) {
+	objects, err := GetSnapshotList(params, t, startTime, endTime, requestId, scope)
+	if err != nil {
+		return []byte{}, err
+	}
+	bytes, err := json.MarshalIndent(objects, "", " ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json for snapshot %v", err)
+	}
+	return bytes, nil
+}
+
+// Reconstructs the set of objects which were live at snapshotTime, filtered by kind/namespace/name like other queries.
+//
+// Candidates come from the resource summary table between startTime and snapshotTime.  Objects are only written
+// when they change or on resync, so startTime should be at least one resync period before snapshotTime.
+// An object is skipped if it was first seen after snapshotTime or its deletion was recorded at or before it.
+// The payload of each object is the newest one in the watch table at or before snapshotTime.
+func GetSnapshotList(params url.Values, t typed.Tables, startTime time.Time, snapshotTime time.Time, requestId string, scope NamespaceScope) ([]SnapshotObject, error) {
+	params = apiDefaultParams(params)
+	objects := []SnapshotObject{}
+	err := t.Db().View(func(txn badgerwrap.Txn) error {
+		resSummaries, stats, err := readResourceSummaries(t, txn, params, scope, isResSummaryFirstSeenBefore(snapshotTime), startTime, snapshotTime)
+		if err != nil {
+			return err
+		}
+		stats.Log(requestId)
+
+		for key, val := range newestResSumPerResource(resSummaries) {
+			if val.DeletedAtEnd {
+				lastSeen, err := ptypes.Timestamp(val.LastSeen)
+				if err != nil {
+					return err
+				}
+				if !lastSeen.After(snapshotTime) {
+					continue
+				}
+			}
+
+			watchKey, watchVal, err := getWatchResultAtTime(t, txn, key.Kind, key.Namespace, key.Name, snapshotTime)
+			if err != nil {
+				return err
+			}
+			if watchVal == nil || watchVal.WatchType == typed.KubeWatchResult_DELETE {
+				glog.V(common.GlogVerbose).Infof("reqId: %v GetSnapshotList: no live payload for %v", requestId, key.String())
+				continue
+			}
+			objects = append(objects, SnapshotObject{
+				Kind:      key.Kind,
+				Namespace: key.Namespace,
+				Name:      key.Name,
+				Uid:       key.Uid,
+				Timestamp: watchKey.Timestamp,
+				Payload:   json.RawMessage(watchVal.Payload),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(objects, func(i, j int) bool {
+		if objects[i].Kind != objects[j].Kind {
+			return objects[i].Kind < objects[j].Kind
+		}
+		if objects[i].Namespace != objects[j].Namespace {
+			return objects[i].Namespace < objects[j].Namespace
+		}
+		if objects[i].Name != objects[j].Name {
+			return objects[i].Name < objects[j].Name
+		}
+		return objects[i].Uid < objects[j].Uid
+	})
+	glog.Infof("reqId: %v GetSnapshotList found %v objects live at %v", requestId, len(objects), snapshotTime)
+	return objects, nil
+}
+
+// Converts a snapshot to a multi-document yaml dump similar to `kubectl get -o yaml` for each object
+func SnapshotToYaml(objects []SnapshotObject) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, obj := range objects {
+		y, err := yaml.JSONToYAML(obj.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert %v/%v/%v to yaml: %v", obj.Kind, obj.Namespace, obj.Name, err)
+		}
+		buf.WriteString("---\n")
+		buf.Write(y)
+	}
+	return buf.Bytes(), nil
+}
+
+func isResSummaryFirstSeenBefore(snapshotTime time.Time) func(*typed.ResourceSummary) bool {
+	return func(retVal *typed.ResourceSummary) bool {
+		firstSeen, err := ptypes.Timestamp(retVal.FirstSeen)
+		if err != nil {
+			return false
+		}
+		return !firstSeen.After(snapshotTime)
+	}
+}
+
+// A resource has a summary row in each partition it was seen in.  Keep the one from the newest partition
+func newestResSumPerResource(resSummaries map[typed.ResourceSummaryKey]*typed.ResourceSummary) map[typed.ResourceSummaryKey]*typed.ResourceSummary {
+	newestKeys := map[typed.ResourceSummaryKey]typed.ResourceSummaryKey{}
+	for key := range resSummaries {
+		id := key
+		id.PartitionId = ""
+		if existing, ok := newestKeys[id]; !ok || key.PartitionId > existing.PartitionId {
+			newestKeys[id] = key
+		}
+	}
+	ret := map[typed.ResourceSummaryKey]*typed.ResourceSummary{}
+	for _, key := range newestKeys {
+		ret[key] = resSummaries[key]
+	}
+	return ret
+}
+
+// Returns the newest watch table entry for the resource at or before ts, or nil if there is none
+func getWatchResultAtTime(t typed.Tables, txn badgerwrap.Txn, kind string, namespace string, name string, ts time.Time) (*typed.WatchTableKey, *typed.KubeWatchResult, error) {
+	// GetPreviousKey skips an exact match, so seek from just after ts
+	seekTime := ts.Add(time.Nanosecond)
+	seekKey := typed.NewWatchTableKey(untyped.GetPartitionId(seekTime), kind, namespace, name, seekTime)
+	keyComparator := typed.NewWatchTableKeyComparator(kind, namespace, name, time.Time{})
+	prevKey, err := t.WatchTable().GetPreviousKey(txn, seekKey, keyComparator)
+	if err != nil {
+		if err == badger.ErrKeyNotFound {
+			// Expected when the payload was cleaned up or never recorded
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	val, err := t.WatchTable().Get(txn, prevKey.String())
+	if err != nil {
+		if err == badger.ErrKeyNotFound {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	return prevKey, val, nil
+}
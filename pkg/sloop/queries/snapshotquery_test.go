@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package queries
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+var someSnapshotTime = time.Date(2019, 1, 2, 3, 40, 0, 0, time.UTC)
+
+const someSnapshotPodTemplate = `{"metadata": {"name": "%v", "namespace": "someNamespace"}, "status": {"phase": "%v"}}`
+
+type snapshotTestPod struct {
+	name      string
+	firstSeen time.Duration
+	lastSeen  time.Duration
+	deleted   bool
+	// offset from someSnapshotTime to phase
+	changes map[time.Duration]string
+}
+
+func helper_get_snapshotTables(t *testing.T, pods []snapshotTestPod) typed.Tables {
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	tables := typed.NewTableList(db)
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for _, pod := range pods {
+			firstSeen, _ := ptypes.TimestampProto(someSnapshotTime.Add(pod.firstSeen))
+			lastSeen, _ := ptypes.TimestampProto(someSnapshotTime.Add(pod.lastSeen))
+			resSum := &typed.ResourceSummary{FirstSeen: firstSeen, LastSeen: lastSeen, CreateTime: firstSeen, DeletedAtEnd: pod.deleted}
+			key := typed.NewResourceSummaryKey(someSnapshotTime.Add(pod.lastSeen), "Pod", "someNamespace", pod.name, pod.name+"-uid")
+			txerr := tables.ResourceSummaryTable().Set(txn, key.String(), resSum)
+			if txerr != nil {
+				return txerr
+			}
+			for offset, phase := range pod.changes {
+				ts := someSnapshotTime.Add(offset)
+				pTs, _ := ptypes.TimestampProto(ts)
+				watchKey := typed.NewWatchTableKey(untyped.GetPartitionId(ts), "Pod", "someNamespace", pod.name, ts)
+				watchVal := &typed.KubeWatchResult{Kind: "Pod", Timestamp: pTs, Payload: fmt.Sprintf(someSnapshotPodTemplate, pod.name, phase)}
+				txerr = tables.WatchTable().Set(txn, watchKey.String(), watchVal)
+				if txerr != nil {
+					return txerr
+				}
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	return tables
+}
+
+func Test_GetSnapshotList(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	tables := helper_get_snapshotTables(t, []snapshotTestPod{
+		{name: "a-running", firstSeen: -30 * time.Minute, lastSeen: 5 * time.Minute,
+			changes: map[time.Duration]string{-30 * time.Minute: "Pending", -10 * time.Minute: "Running", 5 * time.Minute: "Failed"}},
+		{name: "b-deleted-before", firstSeen: -30 * time.Minute, lastSeen: -5 * time.Minute, deleted: true,
+			changes: map[time.Duration]string{-30 * time.Minute: "Running"}},
+		{name: "c-deleted-after", firstSeen: -20 * time.Minute, lastSeen: 5 * time.Minute, deleted: true,
+			changes: map[time.Duration]string{-20 * time.Minute: "Running"}},
+		{name: "d-created-after", firstSeen: 2 * time.Minute, lastSeen: 2 * time.Minute,
+			changes: map[time.Duration]string{2 * time.Minute: "Pending"}},
+		{name: "e-changed-at", firstSeen: -20 * time.Minute, lastSeen: 0,
+			changes: map[time.Duration]string{-20 * time.Minute: "Pending", 0: "Running"}},
+	})
+
+	objects, err := GetSnapshotList(url.Values{}, tables, someSnapshotTime.Add(-time.Hour), someSnapshotTime, someRequestId, Unrestricted)
+	assert.Nil(t, err)
+	assert.Len(t, objects, 3)
+	assert.Equal(t, "a-running", objects[0].Name)
+	assert.Equal(t, someSnapshotTime.Add(-10*time.Minute), objects[0].Timestamp)
+	assert.Equal(t, fmt.Sprintf(someSnapshotPodTemplate, "a-running", "Running"), string(objects[0].Payload))
+	assert.Equal(t, "c-deleted-after", objects[1].Name)
+	assert.Equal(t, "e-changed-at", objects[2].Name)
+	assert.Equal(t, someSnapshotTime, objects[2].Timestamp)
+}
+
+func Test_GetSnapshotList_Filtered(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	tables := helper_get_snapshotTables(t, []snapshotTestPod{
+		{name: "a-running", firstSeen: -30 * time.Minute, lastSeen: -10 * time.Minute,
+			changes: map[time.Duration]string{-10 * time.Minute: "Running"}},
+	})
+
+	params := url.Values{}
+	params.Set(KindParam, "Deployment")
+	objects, err := GetSnapshotList(params, tables, someSnapshotTime.Add(-time.Hour), someSnapshotTime, someRequestId, Unrestricted)
+	assert.Nil(t, err)
+	assert.Len(t, objects, 0)
+}
+
+func Test_SnapshotToYaml(t *testing.T) {
+	objects := []SnapshotObject{
+		{Kind: "Pod", Name: "a", Payload: []byte(`{"kind": "Pod", "metadata": {"name": "a"}}`)},
+		{Kind: "Pod", Name: "b", Payload: []byte(`{"kind": "Pod", "metadata": {"name": "b"}}`)},
+	}
+	res, err := SnapshotToYaml(objects)
+	assert.Nil(t, err)
+	expected := `---
+kind: Pod
+metadata:
+  name: a
+---
+kind: Pod
+metadata:
+  name: b
+`
+	assert.Equal(t, expected, string(res))
+}
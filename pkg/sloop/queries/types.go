@@ -10,6 +10,8 @@ package queries
 type TimelineRoot struct {
 	ViewOpt ViewOptions   `json:"view_options"`
 	Rows    []TimelineRow `json:"rows"`
+	// Peers which failed, only set for federated queries
+	PeerErrors []PeerError `json:"peer_errors,omitempty"`
 }
 
 type TimelineRow struct {
@@ -22,6 +24,8 @@ type TimelineRow struct {
 	NoChangeAt []int64   `json:"nochangeat"`
 	StartDate  int64     `json:"start_date"`
 	EndDate    int64     `json:"end_date"`
+	// The peer the row came from, only set for federated queries
+	Cluster string `json:"cluster,omitempty"`
 }
 
 type ViewOptions struct {
@@ -34,3 +38,8 @@ type Overlay struct {
 	Duration  int64  `json:"duration"`
 	EndDate   int64  `json:"end_date"`
 }
+
+type PeerError struct {
+	Peer  string `json:"peer"`
+	Error string `json:"error"`
+}
@@ -0,0 +1,411 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package server
+
+import (
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+
+	"github.com/salesforce/sloop/pkg/sloop/alerting"
+	"github.com/salesforce/sloop/pkg/sloop/auth"
+	"github.com/salesforce/sloop/pkg/sloop/backup"
+	"github.com/salesforce/sloop/pkg/sloop/ingress"
+	"github.com/salesforce/sloop/pkg/sloop/notifier"
+	"github.com/salesforce/sloop/pkg/sloop/processing"
+	"github.com/salesforce/sloop/pkg/sloop/server/internal/config"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/salesforce/sloop/pkg/sloop/storemanager"
+	"github.com/salesforce/sloop/pkg/sloop/webserver"
+)
+
+// Everything sloop runs for one kubernetes context
+type cluster struct {
+	clusterConfig  config.ClusterConfig
+	kubeContext    string
+	displayContext string
+	db             badgerwrap.DB
+	tables         typed.Tables
+	// The channel is owned by the cluster, and no external code should close this!
+	kubeWatchChan chan typed.KubeWatchResult
+	processor     *processing.Runner
+	watcher       ingress.KubeWatcher
+	recorder      *ingress.FileRecorder
+	imports       []ingress.KubeResourceSource
+	importWg      sync.WaitGroup
+	storemgr      *storemanager.StoreManager
+	backups       *backup.Scheduler
+	// Nil when rbac authorization is off
+	authorizer *auth.RbacAuthorizer
+	// Set when the kubernetes watch could not be started.  The cluster still serves its stored history
+	watchErr error
+	// Health reports the cluster as stale when nothing has been written for this long.  Zero disables the check
+	staleAfter time.Duration
+}
+
+// Returns the clusters from the config, or a single cluster from the context flags when none are configured
+func getClusterConfigs(conf *config.SloopConfig) []config.ClusterConfig {
+	if len(conf.Clusters) > 0 {
+		return conf.Clusters
+	}
+	return []config.ClusterConfig{{Context: conf.UseKubeContext, ApiServerHost: conf.ApiServerHost, DisplayContext: conf.DisplayContext}}
+}
+
+// Resolves the kube context of every cluster and makes sure their urls and store directories will not collide
+func newClusters(conf *config.SloopConfig) ([]*cluster, error) {
+	var clusters []*cluster
+	seenDisplayContexts := map[string]bool{}
+	seenKubeContexts := map[string]bool{}
+	for _, clusterConfig := range getClusterConfigs(conf) {
+		kubeContext, err := ingress.GetKubernetesContext(clusterConfig.ApiServerHost, clusterConfig.Kubeconfig, clusterConfig.Context, conf.PrivilegedAccess)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get kubernetes context for %q", clusterConfig.Context)
+		}
+		c := &cluster{clusterConfig: clusterConfig, kubeContext: kubeContext, displayContext: kubeContext}
+		if clusterConfig.DisplayContext != "" {
+			c.displayContext = clusterConfig.DisplayContext
+		}
+		// The display context is in the url and the kube context names the store directory
+		if seenDisplayContexts[c.displayContext] {
+			return nil, fmt.Errorf("more than one cluster has display context %q", c.displayContext)
+		}
+		if seenKubeContexts[c.kubeContext] {
+			return nil, fmt.Errorf("more than one cluster has kube context %q", c.kubeContext)
+		}
+		seenDisplayContexts[c.displayContext] = true
+		seenKubeContexts[c.kubeContext] = true
+		clusters = append(clusters, c)
+	}
+	return clusters, nil
+}
+
+// start opens the store for the cluster and starts processing, the kubernetes watch and store management.  When
+// there is more than one cluster a watch which can not be started is recorded in watchErr instead of failing,
+// so one unreachable cluster does not take down the others.
+func (c *cluster) start(conf *config.SloopConfig, factory badgerwrap.Factory, alertNotifier *notifier.Notifier, backupKeys [][]byte, onlyCluster bool) error {
+	err := c.openStore(conf, factory)
+	if err != nil {
+		return err
+	}
+	err = c.restore(conf, backupKeys)
+	if err != nil {
+		return err
+	}
+	db := c.db
+	storeRootWithKubeContext := c.storeRoot(conf)
+
+	backupTarget, err := newBackupTarget(conf, c.kubeContext)
+	if err != nil {
+		return err
+	}
+
+	alertEngine, err := alerting.NewEngine(conf.AlertRules, alertNotifier, c.displayContext)
+	if err != nil {
+		return errors.Wrap(err, "failed to load alert rules")
+	}
+
+	// Without an authorizer every user would see everything, so unlike the watch this always fails the start
+	if conf.RbacAuthorization {
+		kubeClient, err := ingress.MakeKubernetesClient(c.clusterConfig.ApiServerHost, c.clusterConfig.Kubeconfig, c.kubeContext, conf.PrivilegedAccess)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create kubernetes client for rbac authorization of context %q", c.kubeContext)
+		}
+		c.authorizer = auth.NewRbacAuthorizer(kubeClient, conf.RbacCacheTtl)
+	}
+
+	redactionHashKey, err := readRedactionHashKey(conf)
+	if err != nil {
+		return err
+	}
+
+	c.kubeWatchChan = make(chan typed.KubeWatchResult, 1000)
+	c.tables = typed.NewTableListWithIndexedAnnotations(db, conf.IndexedAnnotations)
+	c.processor = processing.NewProcessing(c.kubeWatchChan, c.tables, conf.KeepMinorNodeUpdates, conf.MaxLookback, conf.ProcessingWorkerCount, conf.ProcessingBatchSize, alertEngine)
+	c.processor.Start()
+
+	// Real kubernetes watcher
+	if !conf.DisableKubeWatcher {
+		c.watchErr = c.startWatcher(conf, redactionHashKey)
+		if c.watchErr != nil {
+			if onlyCluster {
+				return c.watchErr
+			}
+			glog.Errorf("Cluster %q will only serve stored history: %v", c.displayContext, c.watchErr)
+		}
+		c.staleAfter = 2 * conf.KubeWatchResyncInterval
+	}
+
+	err = c.startImports(conf, redactionHashKey)
+	if err != nil {
+		return err
+	}
+
+	if conf.DebugRecordFile != "" {
+		recorderConfig := ingress.RecorderConfig{
+			Format:          conf.RecordFormat,
+			RotateSizeBytes: int64(conf.RecordRotateSizeMb) * 1024 * 1024,
+			RotateInterval:  conf.RecordRotateInterval,
+			FlushInterval:   conf.RecordFlushInterval,
+		}
+		c.recorder, err = ingress.NewFileRecorder(conf.DebugRecordFile, c.kubeWatchChan, recorderConfig)
+		if err != nil {
+			return errors.Wrap(err, "failed to open record file")
+		}
+		c.recorder.Start()
+	}
+
+	if !conf.DisableStoreManager {
+		fs := &afero.Afero{Fs: afero.NewOsFs()}
+		storeCfg := &storemanager.Config{
+			StoreRoot:          storeRootWithKubeContext,
+			Freq:               conf.CleanupFrequency,
+			TimeLimit:          conf.MaxLookback,
+			SizeLimitBytes:     conf.MaxDiskMb * 1024 * 1024,
+			BadgerDiscardRatio: conf.BadgerDiscardRatio,
+			BadgerVLogGCFreq:   conf.BadgerVLogGCFreq,
+			DeletionBatchSize:  conf.DeletionBatchSize,
+			GCThreshold:        conf.ThresholdForGC,
+			EnableDeleteKeys:   conf.EnableDeleteKeys,
+		}
+		c.storemgr = storemanager.NewStoreManager(c.tables, storeCfg, fs)
+		c.storemgr.Start()
+	}
+
+	if conf.BackupFrequency > 0 {
+		backupCfg := backup.SchedulerConfig{
+			Frequency:     conf.BackupFrequency,
+			FullFrequency: conf.BackupFullFrequency,
+			KeepChains:    conf.BackupKeepChains,
+		}
+		if len(backupKeys) > 0 {
+			backupCfg.EncryptionKey = backupKeys[0]
+		}
+		c.backups = backup.NewScheduler(db, backupTarget, backupCfg)
+		c.backups.Start()
+	}
+	return nil
+}
+
+func (c *cluster) storeRoot(conf *config.SloopConfig) string {
+	return path.Join(conf.StoreRoot, c.kubeContext)
+}
+
+func (c *cluster) openStore(conf *config.SloopConfig, factory badgerwrap.Factory) error {
+	storeConfig := &untyped.Config{
+		RootPath:                 c.storeRoot(conf),
+		ConfigPartitionDuration:  time.Duration(1) * time.Hour,
+		BadgerMaxTableSize:       conf.BadgerMaxTableSize,
+		BadgerKeepL0InMemory:     conf.BadgerKeepL0InMemory,
+		BadgerVLogFileSize:       conf.BadgerVLogFileSize,
+		BadgerVLogMaxEntries:     conf.BadgerVLogMaxEntries,
+		BadgerUseLSMOnlyOptions:  conf.BadgerUseLSMOnlyOptions,
+		BadgerEnableEventLogging: conf.BadgerEnableEventLogging,
+		BadgerNumOfCompactors:    conf.BadgerNumOfCompactors,
+		BadgerNumL0Tables:        conf.BadgerNumL0Tables,
+		BadgerNumL0TablesStall:   conf.BadgerNumL0TablesStall,
+		BadgerSyncWrites:         conf.BadgerSyncWrites,
+		BadgerLevelOneSize:       conf.BadgerLevelOneSize,
+		BadgerLevSizeMultiplier:  conf.BadgerLevSizeMultiplier,
+		BadgerVLogFileIOMapping:  conf.BadgerVLogFileIOMapping,
+		BadgerVLogTruncate:       conf.BadgerVLogTruncate,
+		BadgerDetailLogEnabled:   conf.BadgerDetailLogEnabled,
+		EncryptionKeyFile:        conf.StoreEncryptionKeyFile,
+		PreviousKeyFile:          conf.StorePreviousKeyFile,
+		KeyRotation:              conf.StoreKeyRotation,
+	}
+	db, err := untyped.OpenStore(factory, storeConfig)
+	if err != nil {
+		return errors.Wrapf(err, "failed to init untyped store for context %q", c.kubeContext)
+	}
+	c.db = db
+	return nil
+}
+
+// Loads the backups the config asks for into the store of the cluster
+func (c *cluster) restore(conf *config.SloopConfig, backupKeys [][]byte) error {
+	until := conf.GetRestoreUntil()
+	switch {
+	case conf.RestoreDatabaseFile != "":
+		files := conf.GetRestoreFiles()
+		glog.Infof("Restoring from backup files %q into context %q", files, c.kubeContext)
+		err := ingress.DatabaseRestore(c.db, files, backupKeys)
+		if err != nil {
+			return errors.Wrap(err, "failed to restore database")
+		}
+		if !until.IsZero() {
+			err = backup.DropPartitionsAfter(c.db, until)
+			if err != nil {
+				return errors.Wrap(err, "failed to restore database")
+			}
+		}
+		glog.Infof("Restored from backup files %q into context %q", files, c.kubeContext)
+	case conf.RestoreManifest != "":
+		glog.Infof("Restoring the backups in %q into context %q", conf.RestoreManifest, c.kubeContext)
+		err := backup.RestoreManifestFile(c.db, conf.RestoreManifest, until, backupKeys)
+		if err != nil {
+			return errors.Wrap(err, "failed to restore database from backup manifest")
+		}
+	case conf.RestoreFromBackups:
+		backupTarget, err := newBackupTarget(conf, c.kubeContext)
+		if err != nil {
+			return err
+		}
+		glog.Infof("Restoring the backups from %v into context %q", backupTarget, c.kubeContext)
+		err = backup.RestoreUntil(c.db, backupTarget, until, backupKeys)
+		if err != nil {
+			return errors.Wrap(err, "failed to restore database from backups")
+		}
+	}
+	return nil
+}
+
+// Each context gets its own directory or prefix in the backup target.  Returns nil when no target is configured
+func newBackupTarget(conf *config.SloopConfig, kubeContext string) (backup.Target, error) {
+	switch {
+	case conf.BackupS3 != nil:
+		return backup.NewS3Target(*conf.BackupS3, kubeContext)
+	case conf.BackupDir != "":
+		return backup.NewLocalTarget(path.Join(conf.BackupDir, kubeContext))
+	}
+	return nil, nil
+}
+
+func (c *cluster) startWatcher(conf *config.SloopConfig, redactionHashKey []byte) error {
+	kubeClient, err := ingress.MakeKubernetesClient(c.clusterConfig.ApiServerHost, c.clusterConfig.Kubeconfig, c.kubeContext, conf.PrivilegedAccess)
+	if err != nil {
+		return errors.Wrap(err, "failed to create kubernetes client")
+	}
+
+	c.watcher, err = ingress.NewKubeWatcherSource(kubeClient, c.kubeWatchChan, conf.KubeWatchResyncInterval, conf.WatchCrds, conf.CrdRefreshInterval, c.clusterConfig.ApiServerHost, c.clusterConfig.Kubeconfig, c.kubeContext, conf.EnableGranularMetrics, conf.ExclusionRules, conf.RedactionRules, redactionHashKey, ingress.ResourceFilter{Allow: conf.WatchResources, Deny: conf.ExcludeResources})
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize kubeWatcher")
+	}
+	return nil
+}
+
+// Starts the file playback, the configured import sources and the workload generator, which feed the objects they
+// read or make up into processing like the watch does
+func (c *cluster) startImports(conf *config.SloopConfig, redactionHashKey []byte) error {
+	var sources []ingress.KubeResourceSource
+	if conf.DebugPlaybackFile != "" {
+		playbackConfig := ingress.PlaybackConfig{
+			Speed:       conf.PlaybackSpeed,
+			RebaseToNow: conf.PlaybackRebaseToNow,
+			Loop:        conf.PlaybackLoop,
+			StartOffset: conf.PlaybackStartOffset,
+			StopOffset:  conf.PlaybackStopOffset,
+		}
+		source, err := ingress.NewFilePlaybackSource(conf.DebugPlaybackFile, playbackConfig)
+		if err != nil {
+			return errors.Wrap(err, "failed to configure file playback")
+		}
+		sources = append(sources, source)
+	}
+	if files := conf.GetImportAuditLogs(); len(files) > 0 {
+		source, err := ingress.NewAuditLogSource(files, conf.ExclusionRules, conf.RedactionRules, redactionHashKey)
+		if err != nil {
+			return errors.Wrap(err, "failed to configure audit log import")
+		}
+		sources = append(sources, source)
+	}
+	if files := conf.GetImportKubectlDumps(); len(files) > 0 {
+		source, err := ingress.NewKubectlDumpSource(files, conf.ExclusionRules, conf.RedactionRules, redactionHashKey)
+		if err != nil {
+			return errors.Wrap(err, "failed to configure kubectl dump import")
+		}
+		sources = append(sources, source)
+	}
+	if conf.GenerateWorkload {
+		source, err := ingress.NewWorkloadGenerator(conf.WorkloadGenerator)
+		if err != nil {
+			return errors.Wrap(err, "failed to configure workload generator")
+		}
+		sources = append(sources, source)
+	}
+	for _, source := range sources {
+		sourceChan, err := source.Init()
+		if err != nil {
+			return errors.Wrap(err, "failed to start import")
+		}
+		c.imports = append(c.imports, source)
+		c.importWg.Add(1)
+		go func() {
+			defer c.importWg.Done()
+			for result := range sourceChan {
+				c.kubeWatchChan <- result
+			}
+		}()
+	}
+	return nil
+}
+
+// Shuts the cluster down in the following order:
+// 1. Shut down ingress so that it stops emitting events
+// 2. Close the input channel which signals processing to finish work
+// 3. Wait on processor to tell us all work is complete.  Store will not change after that
+// It is safe to call on a cluster which only partly started.
+func (c *cluster) stop() {
+	if c.watcher != nil {
+		c.watcher.Stop()
+	}
+	for _, source := range c.imports {
+		source.Stop()
+	}
+	c.importWg.Wait()
+	if c.processor != nil {
+		close(c.kubeWatchChan)
+		c.processor.Wait()
+	}
+
+	if c.recorder != nil {
+		err := c.recorder.Close()
+		if err != nil {
+			glog.Errorf("Record file is incomplete: %v", err)
+		}
+	}
+
+	if c.storemgr != nil {
+		c.storemgr.Shutdown()
+	}
+
+	if c.backups != nil {
+		c.backups.Shutdown()
+	}
+}
+
+// Closes the store.  Call after every cluster has stopped and nothing can write to the store any more
+func (c *cluster) close() {
+	if c.db != nil {
+		untyped.CloseStore(c.db)
+	}
+}
+
+func (c *cluster) health() webserver.ClusterHealth {
+	h := webserver.ClusterHealth{LastUpdate: c.processor.LastProcessed()}
+	switch {
+	case c.watchErr != nil:
+		h.Status = webserver.ClusterStatusError
+		h.Message = c.watchErr.Error()
+	case h.LastUpdate.IsZero():
+		h.Status = webserver.ClusterStatusStarting
+		h.Message = "nothing has been recorded yet"
+	case c.staleAfter > 0 && time.Since(h.LastUpdate) > c.staleAfter:
+		h.Status = webserver.ClusterStatusStale
+		h.Message = fmt.Sprintf("nothing has been recorded for %v", time.Since(h.LastUpdate).Round(time.Second))
+	default:
+		h.Status = webserver.ClusterStatusOk
+	}
+	return h
+}
@@ -20,13 +20,30 @@ import (
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
 
+	"github.com/salesforce/sloop/pkg/sloop/alerting"
+	"github.com/salesforce/sloop/pkg/sloop/auth"
+	"github.com/salesforce/sloop/pkg/sloop/backup"
 	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/federation"
+	"github.com/salesforce/sloop/pkg/sloop/ingress"
+	"github.com/salesforce/sloop/pkg/sloop/notifier"
 	"github.com/salesforce/sloop/pkg/sloop/server/server_metrics"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
 	"github.com/salesforce/sloop/pkg/sloop/webserver"
 )
 
 const sloopConfigEnvVar = "SLOOP_CONFIG"
 
+// One kubernetes context to watch.  Each cluster gets its own watcher, processing and store under
+// StoreRoot/<context>.  An empty Context uses the current context of the kubeconfig, and an empty Kubeconfig uses
+// the default kubeconfig loading rules
+type ClusterConfig struct {
+	Context        string `json:"context"`
+	Kubeconfig     string `json:"kubeconfig"`
+	ApiServerHost  string `json:"apiServerHost"`
+	DisplayContext string `json:"displayContext"`
+}
+
 type SloopConfig struct {
 	// These fields can only come from command line
 	ConfigFile string
@@ -34,7 +51,18 @@ type SloopConfig struct {
 	LeftBarLinks       []webserver.LinkTemplate           `json:"leftBarLinks"`
 	ResourceLinks      []webserver.ResourceLinkTemplate   `json:"resourceLinks"`
 	ExclusionRules     map[string][]any                   `json:"exclusionRules"`
+	RedactionRules     map[string][]ingress.RedactionRule `json:"redactionRules"`
+	AlertRules         []alerting.Rule                    `json:"alertRules"`
+	NotifierSinks      []notifier.SinkConfig              `json:"notifierSinks"`
+	Clusters           []ClusterConfig                    `json:"clusters"`
+	FederationPeers    []federation.Peer                  `json:"federationPeers"`
+	WatchResources     []string                           `json:"watchResources"`
+	ExcludeResources   []string                           `json:"excludeResources"`
+	Auth               auth.Config                        `json:"auth"`
 	UserMetricsHeaders []server_metrics.UserMetricsConfig `json:"userMetricsHeaders"`
+	BackupS3           *backup.S3Config                   `json:"backupS3"`
+	WorkloadGenerator  ingress.GeneratorConfig            `json:"workloadGenerator"`
+	IndexedAnnotations []string                           `json:"indexedAnnotations"`
 	// Normal fields that can come from file or cmd line
 	DisableKubeWatcher       bool          `json:"disableKubeWatch"`
 	KubeWatchResyncInterval  time.Duration `json:"kubeWatchResyncInterval"`
@@ -42,15 +70,45 @@ type SloopConfig struct {
 	BindAddress              string        `json:"bindAddress"`
 	Port                     int           `json:"port"`
 	StoreRoot                string        `json:"storeRoot"`
+	StoreEngine              string        `json:"storeEngine"`
+	StoreEncryptionKeyFile   string        `json:"storeEncryptionKeyFile"`
+	StorePreviousKeyFile     string        `json:"storePreviousKeyFile"`
+	StoreKeyRotation         time.Duration `json:"storeKeyRotation"`
+	BackupEncryptionKeyFile  string        `json:"backupEncryptionKeyFile"`
+	BackupPreviousKeyFile    string        `json:"backupPreviousKeyFile"`
+	RedactionHashKeyFile     string        `json:"redactionHashKeyFile"`
 	MaxLookback              time.Duration `json:"maxLookBack"`
 	MaxDiskMb                int           `json:"maxDiskMb"`
 	DebugPlaybackFile        string        `json:"debugPlaybackFile"`
+	PlaybackSpeed            float64       `json:"playbackSpeed"`
+	PlaybackRebaseToNow      bool          `json:"playbackRebaseToNow"`
+	PlaybackLoop             bool          `json:"playbackLoop"`
+	PlaybackStartOffset      time.Duration `json:"playbackStartOffset"`
+	PlaybackStopOffset       time.Duration `json:"playbackStopOffset"`
 	DebugRecordFile          string        `json:"debugRecordFile"`
+	RecordFormat             string        `json:"recordFormat"`
+	RecordRotateSizeMb       int           `json:"recordRotateSizeMb"`
+	RecordRotateInterval     time.Duration `json:"recordRotateInterval"`
+	RecordFlushInterval      time.Duration `json:"recordFlushInterval"`
+	ImportAuditLogs          string        `json:"importAuditLogs"`
+	ImportKubectlDumps       string        `json:"importKubectlDumps"`
+	GenerateWorkload         bool          `json:"generateWorkload"`
 	DeletionBatchSize        int           `json:"deletionBatchSize"`
 	UseMockBadger            bool          `json:"mockBadger"`
 	DisableStoreManager      bool          `json:"disableStoreManager"`
 	CleanupFrequency         time.Duration `json:"cleanupFrequency" validate:"min=1h,max=120h"`
 	KeepMinorNodeUpdates     bool          `json:"keepMinorNodeUpdates"`
+	ProcessingWorkerCount    int           `json:"processingWorkerCount"`
+	ProcessingBatchSize      int           `json:"processingBatchSize"`
+	NotifierMaxRetries       int           `json:"notifierMaxRetries"`
+	NotifierRetryBackoff     time.Duration `json:"notifierRetryBackoff"`
+	NotifierMaxRetryBackoff  time.Duration `json:"notifierMaxRetryBackoff"`
+	NotifierRepeatInterval   time.Duration `json:"notifierRepeatInterval"`
+	NotifierTimeout          time.Duration `json:"notifierTimeout"`
+	NotifierDeadLetterFile   string        `json:"notifierDeadLetterFile"`
+	FederationTimeout        time.Duration `json:"federationTimeout"`
+	RbacAuthorization        bool          `json:"rbacAuthorization"`
+	RbacCacheTtl             time.Duration `json:"rbacCacheTtl"`
 	DefaultNamespace         string        `json:"defaultNamespace"`
 	DefaultKind              string        `json:"defaultKind"`
 	DefaultLookback          string        `json:"defaultLookback"`
@@ -61,6 +119,14 @@ type SloopConfig struct {
 	CrdRefreshInterval       time.Duration `json:"crdRefreshInterval"`
 	ThresholdForGC           float64       `json:"threshold for GC"`
 	RestoreDatabaseFile      string        `json:"restoreDatabaseFile"`
+	RestoreFromBackups       bool          `json:"restoreFromBackups"`
+	RestoreManifest          string        `json:"restoreManifest"`
+	RestoreUntil             string        `json:"restoreUntil"`
+	RestoreOnly              bool          `json:"restoreOnly"`
+	BackupFrequency          time.Duration `json:"backupFrequency"`
+	BackupFullFrequency      time.Duration `json:"backupFullFrequency"`
+	BackupKeepChains         int           `json:"backupKeepChains"`
+	BackupDir                string        `json:"backupDir"`
 	BadgerDiscardRatio       float64       `json:"badgerDiscardRatio"`
 	BadgerVLogGCFreq         time.Duration `json:"badgerVLogGCFreq"`
 	BadgerMaxTableSize       int64         `json:"badgerMaxTableSize"`
@@ -93,14 +159,44 @@ func registerFlags(fs *flag.FlagSet, config *SloopConfig) {
 	fs.StringVar(&config.BindAddress, "bind-address", config.BindAddress, "Web server bind ip address.")
 	fs.IntVar(&config.Port, "port", config.Port, "Web server port")
 	fs.StringVar(&config.StoreRoot, "store-root", config.StoreRoot, "Path to store history data")
+	fs.StringVar(&config.StoreEngine, "store-engine", config.StoreEngine, "Storage engine for history data: badger or bolt.  The badger-* flags only apply to badger")
+	fs.StringVar(&config.StoreEncryptionKeyFile, "store-encryption-key-file", config.StoreEncryptionKeyFile, "File with a 16, 24 or 32 byte AES key to encrypt the store with.  Only badger supports encryption")
+	fs.StringVar(&config.StorePreviousKeyFile, "store-previous-key-file", config.StorePreviousKeyFile, "File with the key the store was encrypted with before, to rotate to the key in store-encryption-key-file")
+	fs.DurationVar(&config.StoreKeyRotation, "store-key-rotation", config.StoreKeyRotation, "How often badger makes a new data key for an encrypted store")
+	fs.StringVar(&config.BackupEncryptionKeyFile, "backup-encryption-key-file", config.BackupEncryptionKeyFile, "File with a 16, 24 or 32 byte AES key to encrypt backups with, and to decrypt them on restore")
+	fs.StringVar(&config.BackupPreviousKeyFile, "backup-previous-key-file", config.BackupPreviousKeyFile, "File with an older backup key, so backups made before a key rotation can still be restored")
+	fs.StringVar(&config.RedactionHashKeyFile, "redaction-hash-key-file", config.RedactionHashKeyFile, "File with a 16, 24 or 32 byte key for the hmac of values redacted with the hash action.  Needed by hash redaction rules")
 	fs.DurationVar(&config.MaxLookback, "max-look-back", config.MaxLookback, "Max history data to keep")
 	fs.IntVar(&config.MaxDiskMb, "max-disk-mb", config.MaxDiskMb, "Max disk storage in MB")
 	fs.StringVar(&config.DebugPlaybackFile, "playback-file", config.DebugPlaybackFile, "Read watch data from a playback file")
+	fs.Float64Var(&config.PlaybackSpeed, "playback-speed", config.PlaybackSpeed, "Play back records with their original gaps divided by this.  0 plays them as fast as they can be read")
+	fs.BoolVar(&config.PlaybackRebaseToNow, "playback-rebase-to-now", config.PlaybackRebaseToNow, "Move the timestamps of played back records to when they are played.  Needs playback-speed")
+	fs.BoolVar(&config.PlaybackLoop, "playback-loop", config.PlaybackLoop, "Play the playback file again each time it ends.  Needs playback-speed")
+	fs.DurationVar(&config.PlaybackStartOffset, "playback-start-offset", config.PlaybackStartOffset, "Skip the records from less than this after the first record of the playback file")
+	fs.DurationVar(&config.PlaybackStopOffset, "playback-stop-offset", config.PlaybackStopOffset, "End playback at the first record from this long or longer after the first record.  0 plays to the end")
 	fs.StringVar(&config.DebugRecordFile, "record-file", config.DebugRecordFile, "Record watch data to a playback file")
+	fs.StringVar(&config.RecordFormat, "record-format", config.RecordFormat, "Format of the record file, jsonl or protobuf.  Either is zstd compressed")
+	fs.IntVar(&config.RecordRotateSizeMb, "record-rotate-size-mb", config.RecordRotateSizeMb, "Start a new record file segment once the current one has this many compressed MB.  0 disables it")
+	fs.DurationVar(&config.RecordRotateInterval, "record-rotate-interval", config.RecordRotateInterval, "Start a new record file segment once the current one is this old.  0 disables it")
+	fs.DurationVar(&config.RecordFlushInterval, "record-flush-interval", config.RecordFlushInterval, "How often buffered records are written to the record file")
+	fs.StringVar(&config.ImportAuditLogs, "import-audit-logs", config.ImportAuditLogs, "Comma separated kubernetes api server audit log files to import history from at startup")
+	fs.StringVar(&config.ImportKubectlDumps, "import-kubectl-dumps", config.ImportKubectlDumps, "Comma separated files of kubectl get -o json output to import history from at startup")
+	fs.BoolVar(&config.GenerateWorkload, "generate-workload", config.GenerateWorkload, "Generate a synthetic workload, set by workloadGenerator in the config file, for load testing and demos")
 	fs.BoolVar(&config.UseMockBadger, "use-mock-badger", config.UseMockBadger, "Use a fake in-memory mock of badger")
 	fs.BoolVar(&config.DisableStoreManager, "disable-store-manager", config.DisableStoreManager, "Turn off store manager which is to clean up database")
 	fs.DurationVar(&config.CleanupFrequency, "cleanup-frequency", config.CleanupFrequency, "Frequency between subsequent runs for the database cleanup")
 	fs.BoolVar(&config.KeepMinorNodeUpdates, "keep-minor-node-updates", config.KeepMinorNodeUpdates, "Keep all node updates even if change is only condition timestamps")
+	fs.IntVar(&config.ProcessingWorkerCount, "processing-worker-count", config.ProcessingWorkerCount, "Number of workers writing watch data to the store.  Updates to the same object are always handled by the same worker")
+	fs.IntVar(&config.ProcessingBatchSize, "processing-batch-size", config.ProcessingBatchSize, "Max number of queued watch records a worker writes in one transaction")
+	fs.IntVar(&config.NotifierMaxRetries, "notifier-max-retries", config.NotifierMaxRetries, "Number of times a failed notification is retried before it goes to the dead letter file")
+	fs.DurationVar(&config.NotifierRetryBackoff, "notifier-retry-backoff", config.NotifierRetryBackoff, "Delay before the first notification retry.  It doubles for each retry")
+	fs.DurationVar(&config.NotifierMaxRetryBackoff, "notifier-max-retry-backoff", config.NotifierMaxRetryBackoff, "Max delay between notification retries")
+	fs.DurationVar(&config.NotifierRepeatInterval, "notifier-repeat-interval", config.NotifierRepeatInterval, "Min time between notifications for the same alert rule and object")
+	fs.DurationVar(&config.NotifierTimeout, "notifier-timeout", config.NotifierTimeout, "Timeout for each request to a notifier sink")
+	fs.StringVar(&config.NotifierDeadLetterFile, "notifier-dead-letter-file", config.NotifierDeadLetterFile, "Append notifications which could not be delivered to this file as json lines")
+	fs.DurationVar(&config.FederationTimeout, "federation-timeout", config.FederationTimeout, "Timeout for each query to a federation peer")
+	fs.BoolVar(&config.RbacAuthorization, "rbac-authorization", config.RbacAuthorization, "Only show users the kinds and namespaces the api server lets them get, checked with a SubjectAccessReview")
+	fs.DurationVar(&config.RbacCacheTtl, "rbac-cache-ttl", config.RbacCacheTtl, "How long the result of each SubjectAccessReview is cached")
 	fs.StringVar(&config.DefaultLookback, "default-lookback", config.DefaultLookback, "Default UX filter lookback")
 	fs.StringVar(&config.DefaultKind, "default-kind", config.DefaultKind, "Default UX filter kind")
 	fs.StringVar(&config.DefaultNamespace, "default-namespace", config.DefaultNamespace, "Default UX filter namespace")
@@ -109,8 +205,16 @@ func registerFlags(fs *flag.FlagSet, config *SloopConfig) {
 	fs.StringVar(&config.DisplayContext, "display-context", config.DisplayContext, "Use this to override the display context.  When running in k8s the context is empty string.  This lets you override that (mainly useful if you are running many copies of sloop on different clusters) ")
 	fs.StringVar(&config.ApiServerHost, "apiserver-host", config.ApiServerHost, "Kubernetes API server endpoint")
 	fs.BoolVar(&config.WatchCrds, "watch-crds", config.WatchCrds, "Watch for activity for CRDs")
-	fs.DurationVar(&config.CrdRefreshInterval, "crd-refresh-interval", config.CrdRefreshInterval, "Frequency between CRD Informer refresh")
-	fs.StringVar(&config.RestoreDatabaseFile, "restore-database-file", config.RestoreDatabaseFile, "Restore database from backup file into current context.")
+	fs.DurationVar(&config.CrdRefreshInterval, "crd-refresh-interval", config.CrdRefreshInterval, "Frequency between refreshes of the watched resources and CRDs")
+	fs.StringVar(&config.RestoreDatabaseFile, "restore-database-file", config.RestoreDatabaseFile, "Restore database from backup file into current context.  A comma separated list is loaded in order, a full backup followed by its incremental backups")
+	fs.BoolVar(&config.RestoreFromBackups, "restore-from-backups", config.RestoreFromBackups, "Restore the latest backup chain from backup-dir or the s3 backup target into each context")
+	fs.StringVar(&config.RestoreManifest, "restore-manifest", config.RestoreManifest, "Restore the latest backup chain listed in this backup manifest file into current context")
+	fs.StringVar(&config.RestoreUntil, "restore-until", config.RestoreUntil, "RFC3339 time to restore to.  Picks the backup chain covering it and drops the partitions after it")
+	fs.BoolVar(&config.RestoreOnly, "restore-only", config.RestoreOnly, "Restore into an empty store and exit, without watching kubernetes or serving the UI")
+	fs.DurationVar(&config.BackupFrequency, "backup-frequency", config.BackupFrequency, "How often to take scheduled backups.  0 turns them off")
+	fs.DurationVar(&config.BackupFullFrequency, "backup-full-frequency", config.BackupFullFrequency, "How often scheduled backups are full backups.  The ones in between are incremental")
+	fs.IntVar(&config.BackupKeepChains, "backup-keep-chains", config.BackupKeepChains, "Number of full backups to keep, with their incremental backups.  0 keeps all of them")
+	fs.StringVar(&config.BackupDir, "backup-dir", config.BackupDir, "Directory to write scheduled backups to, unless backupS3 is set in the config file")
 	fs.Float64Var(&config.BadgerDiscardRatio, "badger-discard-ratio", config.BadgerDiscardRatio, "Badger value log GC uses this value to decide if it wants to compact a vlog file. The lower the value of discardRatio the higher the number of !badger!move keys. And thus more the number of !badger!move keys, the size on disk keeps on increasing over time.")
 	fs.Float64Var(&config.ThresholdForGC, "gc-threshold", config.ThresholdForGC, "Threshold for GC to start garbage collecting")
 	fs.DurationVar(&config.BadgerVLogGCFreq, "badger-vlog-gc-freq", config.BadgerVLogGCFreq, "Frequency of running badger's ValueLogGC")
@@ -144,15 +248,40 @@ func getDefaultConfig() *SloopConfig {
 		BindAddress:              "",
 		Port:                     8080,
 		StoreRoot:                "./data",
+		StoreEngine:              badgerwrap.EngineBadger,
+		StoreKeyRotation:         10 * 24 * time.Hour,
 		MaxLookback:              time.Duration(14*24) * time.Hour,
 		MaxDiskMb:                32 * 1024,
 		DebugPlaybackFile:        "",
+		PlaybackSpeed:            0,
+		PlaybackRebaseToNow:      false,
+		PlaybackLoop:             false,
+		PlaybackStartOffset:      0,
+		PlaybackStopOffset:       0,
 		DebugRecordFile:          "",
+		RecordFormat:             ingress.RecordFormatJsonLines,
+		RecordRotateSizeMb:       0,
+		RecordRotateInterval:     0,
+		RecordFlushInterval:      10 * time.Second,
+		ImportAuditLogs:          "",
+		ImportKubectlDumps:       "",
+		GenerateWorkload:         false,
+		WorkloadGenerator:        ingress.DefaultGeneratorConfig(),
 		DeletionBatchSize:        1000,
 		UseMockBadger:            false,
 		DisableStoreManager:      false,
 		CleanupFrequency:         time.Minute * 30,
 		KeepMinorNodeUpdates:     false,
+		ProcessingWorkerCount:    4,
+		ProcessingBatchSize:      50,
+		NotifierMaxRetries:       5,
+		NotifierRetryBackoff:     time.Second,
+		NotifierMaxRetryBackoff:  time.Minute,
+		NotifierRepeatInterval:   time.Hour,
+		NotifierTimeout:          10 * time.Second,
+		FederationTimeout:        30 * time.Second,
+		RbacAuthorization:        false,
+		RbacCacheTtl:             time.Minute,
 		DefaultNamespace:         "default",
 		DefaultKind:              "_all",
 		DefaultLookback:          "1h",
@@ -163,6 +292,8 @@ func getDefaultConfig() *SloopConfig {
 		CrdRefreshInterval:       time.Duration(5 * time.Minute),
 		ThresholdForGC:           0.8,
 		RestoreDatabaseFile:      "",
+		BackupFullFrequency:      24 * time.Hour,
+		BackupKeepChains:         7,
 		BadgerDiscardRatio:       0.99,
 		BadgerVLogGCFreq:         time.Minute * 1,
 		BadgerMaxTableSize:       0,
@@ -185,6 +316,7 @@ func getDefaultConfig() *SloopConfig {
 		PrivilegedAccess:         true,
 		BadgerDetailLogEnabled:   false,
 		ExclusionRules:           map[string][]any{},
+		WatchResources:           append([]string{}, ingress.DefaultWatchResources...),
 	}
 	return &defaultConfig
 }
@@ -216,6 +348,88 @@ func (c *SloopConfig) ToYaml() string {
 	return string(b)
 }
 
+// Returns the files to restore from, in the order to load them
+func (c *SloopConfig) GetRestoreFiles() []string {
+	return splitFileList(c.RestoreDatabaseFile)
+}
+
+// Returns the audit log files to import, in the order to import them
+func (c *SloopConfig) GetImportAuditLogs() []string {
+	return splitFileList(c.ImportAuditLogs)
+}
+
+// Returns the kubectl dump files to import, in the order to import them
+func (c *SloopConfig) GetImportKubectlDumps() []string {
+	return splitFileList(c.ImportKubectlDumps)
+}
+
+func splitFileList(list string) []string {
+	var files []string
+	for _, file := range strings.Split(list, ",") {
+		file = strings.TrimSpace(file)
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// Returns the time to restore to, or zero to restore everything.  Validate checks RestoreUntil parses
+func (c *SloopConfig) GetRestoreUntil() time.Time {
+	until, _ := time.Parse(time.RFC3339, c.RestoreUntil)
+	return until
+}
+
+func (c *SloopConfig) validateBackups() error {
+	if c.BackupDir != "" && c.BackupS3 != nil {
+		return fmt.Errorf("backups can go to BackupDir or BackupS3, not both")
+	}
+	if c.BackupS3 != nil {
+		err := c.BackupS3.Validate()
+		if err != nil {
+			return err
+		}
+	}
+	hasTarget := c.BackupDir != "" || c.BackupS3 != nil
+	if c.BackupFrequency < 0 {
+		return fmt.Errorf("BackupFrequency can not be < 0")
+	}
+	if c.BackupFrequency > 0 && !hasTarget {
+		return fmt.Errorf("scheduled backups need BackupDir or BackupS3")
+	}
+	if c.RestoreFromBackups && !hasTarget {
+		return fmt.Errorf("RestoreFromBackups needs BackupDir or BackupS3")
+	}
+	restoreSources := 0
+	for _, used := range []bool{c.RestoreDatabaseFile != "", c.RestoreManifest != "", c.RestoreFromBackups} {
+		if used {
+			restoreSources++
+		}
+	}
+	if restoreSources > 1 {
+		return fmt.Errorf("only one of RestoreDatabaseFile, RestoreManifest and RestoreFromBackups can be used")
+	}
+	if c.RestoreUntil != "" {
+		if restoreSources == 0 {
+			return fmt.Errorf("RestoreUntil needs something to restore from")
+		}
+		_, err := time.Parse(time.RFC3339, c.RestoreUntil)
+		if err != nil {
+			return errors.Wrapf(err, "RestoreUntil is not an RFC3339 time: %v", c.RestoreUntil)
+		}
+	}
+	if c.RestoreOnly && restoreSources == 0 {
+		return fmt.Errorf("RestoreOnly needs something to restore from")
+	}
+	if c.BackupFullFrequency <= 0 {
+		return fmt.Errorf("BackupFullFrequency must be > 0")
+	}
+	if c.BackupKeepChains < 0 {
+		return fmt.Errorf("BackupKeepChains can not be < 0")
+	}
+	return nil
+}
+
 func (c *SloopConfig) Validate() error {
 	if c.MaxLookback <= 0 {
 		return fmt.Errorf("SloopConfig value MaxLookback can not be <= 0")
@@ -227,6 +441,88 @@ func (c *SloopConfig) Validate() error {
 	if err != nil {
 		return errors.Wrapf(err, "DefaultLookback is an invalid duration: %v", c.DefaultLookback)
 	}
+	if c.StoreEngine != badgerwrap.EngineBadger && c.StoreEngine != badgerwrap.EngineBolt {
+		return fmt.Errorf("StoreEngine must be %v or %v", badgerwrap.EngineBadger, badgerwrap.EngineBolt)
+	}
+	if c.StoreEncryptionKeyFile != "" && c.StoreEngine != badgerwrap.EngineBadger {
+		return fmt.Errorf("store encryption is only supported by the %v store engine", badgerwrap.EngineBadger)
+	}
+	if c.StorePreviousKeyFile != "" && c.StoreEncryptionKeyFile == "" {
+		return fmt.Errorf("StorePreviousKeyFile needs StoreEncryptionKeyFile to rotate to")
+	}
+	if c.StoreKeyRotation <= 0 {
+		return fmt.Errorf("StoreKeyRotation must be > 0")
+	}
+	if c.BackupPreviousKeyFile != "" && c.BackupEncryptionKeyFile == "" {
+		return fmt.Errorf("BackupPreviousKeyFile needs BackupEncryptionKeyFile")
+	}
+	err = c.validateBackups()
+	if err != nil {
+		return err
+	}
+	if len(c.Clusters) > 1 && (c.DebugPlaybackFile != "" || c.DebugRecordFile != "" || c.RestoreDatabaseFile != "" || c.RestoreManifest != "") {
+		return fmt.Errorf("playback, record and restore files can only be used with a single cluster")
+	}
+	if c.PlaybackSpeed < 0 || c.PlaybackStartOffset < 0 || c.PlaybackStopOffset < 0 {
+		return fmt.Errorf("PlaybackSpeed, PlaybackStartOffset and PlaybackStopOffset can not be negative")
+	}
+	if c.PlaybackSpeed == 0 && (c.PlaybackRebaseToNow || c.PlaybackLoop) {
+		return fmt.Errorf("PlaybackRebaseToNow and PlaybackLoop need PlaybackSpeed")
+	}
+	if c.PlaybackStopOffset > 0 && c.PlaybackStopOffset <= c.PlaybackStartOffset {
+		return fmt.Errorf("PlaybackStopOffset must be after PlaybackStartOffset")
+	}
+	if c.RecordFormat != ingress.RecordFormatJsonLines && c.RecordFormat != ingress.RecordFormatProtobuf {
+		return fmt.Errorf("RecordFormat must be %v or %v", ingress.RecordFormatJsonLines, ingress.RecordFormatProtobuf)
+	}
+	if c.RecordRotateSizeMb < 0 || c.RecordRotateInterval < 0 || c.RecordFlushInterval < 0 {
+		return fmt.Errorf("RecordRotateSizeMb, RecordRotateInterval and RecordFlushInterval can not be negative")
+	}
+	if len(c.Clusters) > 1 && (c.ImportAuditLogs != "" || c.ImportKubectlDumps != "") {
+		return fmt.Errorf("imported files can only be used with a single cluster")
+	}
+	if len(c.FederationPeers) > 0 && (c.ImportAuditLogs != "" || c.ImportKubectlDumps != "") {
+		return fmt.Errorf("there is no store to import into in federation mode")
+	}
+	if c.GenerateWorkload {
+		if len(c.Clusters) > 1 || len(c.FederationPeers) > 0 {
+			return fmt.Errorf("a workload can only be generated for a single cluster")
+		}
+		err = ingress.ValidateGeneratorConfig(c.WorkloadGenerator)
+		if err != nil {
+			return err
+		}
+	}
+	if len(c.FederationPeers) > 0 && len(c.Clusters) > 0 {
+		return fmt.Errorf("clusters can not be watched in federation mode")
+	}
+	if len(c.FederationPeers) > 0 && c.RestoreOnly {
+		return fmt.Errorf("there is no store to restore into in federation mode")
+	}
+	if c.RbacAuthorization {
+		if !c.Auth.Enabled() {
+			return fmt.Errorf("rbac authorization needs auth to be configured so users can be identified")
+		}
+		if len(c.FederationPeers) > 0 {
+			return fmt.Errorf("rbac authorization can not be used in federation mode")
+		}
+		if c.RbacCacheTtl <= 0 {
+			return fmt.Errorf("RbacCacheTtl must be > 0")
+		}
+	}
+	err = ingress.ValidateRedactionRules(c.RedactionRules, c.RedactionHashKeyFile)
+	if err != nil {
+		return err
+	}
+	for _, resources := range [][]string{c.WatchResources, c.ExcludeResources} {
+		err = ingress.ValidateResourceEntries(resources)
+		if err != nil {
+			return err
+		}
+	}
+	if c.NotifierMaxRetries < 0 {
+		return fmt.Errorf("NotifierMaxRetries can not be < 0")
+	}
 	if c.CleanupFrequency < time.Minute*15 {
 		return fmt.Errorf("CleanupFrequency can not be less than 15 minutes.  Badger is lazy about freeing space " +
 			"on disk so we need to give it time to avoid over-correction")
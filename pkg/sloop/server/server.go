@@ -9,30 +9,30 @@ package server
 
 import (
 	"flag"
+	"fmt"
 	"os"
-	"path"
 	"strings"
-	"time"
 
 	"github.com/pkg/errors"
 
-	"github.com/salesforce/sloop/pkg/sloop/ingress"
+	"github.com/salesforce/sloop/pkg/sloop/auth"
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/federation"
+	"github.com/salesforce/sloop/pkg/sloop/notifier"
 	"github.com/salesforce/sloop/pkg/sloop/server/internal/config"
 	"github.com/salesforce/sloop/pkg/sloop/server/server_metrics"
-	"github.com/salesforce/sloop/pkg/sloop/store/typed"
-	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
 
 	"github.com/golang/glog"
 
-	"github.com/spf13/afero"
-
-	"github.com/salesforce/sloop/pkg/sloop/processing"
 	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
-	"github.com/salesforce/sloop/pkg/sloop/storemanager"
 	"github.com/salesforce/sloop/pkg/sloop/webserver"
 )
 
-const logtostderr = "logtostderr"
+const (
+	logtostderr = "logtostderr"
+	// The url prefix in federation mode unless a display context is set
+	defaultFederationContext = "federation"
+)
 
 func RealMain() error {
 	defer glog.Flush()
@@ -46,105 +46,71 @@ func RealMain() error {
 		return errors.Wrap(err, "config validation failed")
 	}
 
-	kubeContext, err := ingress.GetKubernetesContext(conf.ApiServerHost, conf.UseKubeContext, conf.PrivilegedAccess)
-	if err != nil {
-		return errors.Wrap(err, "failed to get kubernetes context")
-	}
-
-	// Channel used for updates from ingress to store
-	// The channel is owned by this function, and no external code should close this!
-	kubeWatchChan := make(chan typed.KubeWatchResult, 1000)
-
-	factory := &badgerwrap.BadgerFactory{}
-
-	storeRootWithKubeContext := path.Join(conf.StoreRoot, kubeContext)
-	storeConfig := &untyped.Config{
-		RootPath:                 storeRootWithKubeContext,
-		ConfigPartitionDuration:  time.Duration(1) * time.Hour,
-		BadgerMaxTableSize:       conf.BadgerMaxTableSize,
-		BadgerKeepL0InMemory:     conf.BadgerKeepL0InMemory,
-		BadgerVLogFileSize:       conf.BadgerVLogFileSize,
-		BadgerVLogMaxEntries:     conf.BadgerVLogMaxEntries,
-		BadgerUseLSMOnlyOptions:  conf.BadgerUseLSMOnlyOptions,
-		BadgerEnableEventLogging: conf.BadgerEnableEventLogging,
-		BadgerNumOfCompactors:    conf.BadgerNumOfCompactors,
-		BadgerNumL0Tables:        conf.BadgerNumL0Tables,
-		BadgerNumL0TablesStall:   conf.BadgerNumL0TablesStall,
-		BadgerSyncWrites:         conf.BadgerSyncWrites,
-		BadgerLevelOneSize:       conf.BadgerLevelOneSize,
-		BadgerLevSizeMultiplier:  conf.BadgerLevSizeMultiplier,
-		BadgerVLogFileIOMapping:  conf.BadgerVLogFileIOMapping,
-		BadgerVLogTruncate:       conf.BadgerVLogTruncate,
-		BadgerDetailLogEnabled:   conf.BadgerDetailLogEnabled,
-	}
-	db, err := untyped.OpenStore(factory, storeConfig)
+	authenticator, err := auth.NewAuthenticator(conf.Auth)
 	if err != nil {
-		return errors.Wrap(err, "failed to init untyped store")
+		return errors.Wrap(err, "failed to set up auth")
 	}
-	defer untyped.CloseStore(db)
 
-	if conf.RestoreDatabaseFile != "" {
-		glog.Infof("Restoring from backup file %q into context %q", conf.RestoreDatabaseFile, kubeContext)
-		err := ingress.DatabaseRestore(db, conf.RestoreDatabaseFile)
-		if err != nil {
-			return errors.Wrap(err, "failed to restore database")
-		}
-		glog.Infof("Restored from backup file %q into context %q", conf.RestoreDatabaseFile, kubeContext)
+	if len(conf.FederationPeers) > 0 {
+		return runFederation(conf, authenticator)
 	}
 
-	tables := typed.NewTableList(db)
-	processor := processing.NewProcessing(kubeWatchChan, tables, conf.KeepMinorNodeUpdates, conf.MaxLookback)
-	processor.Start()
+	clusters, err := newClusters(conf)
+	if err != nil {
+		return err
+	}
 
-	// Real kubernetes watcher
-	var kubeWatcherSource ingress.KubeWatcher
-	if !conf.DisableKubeWatcher {
-		kubeClient, err := ingress.MakeKubernetesClient(conf.ApiServerHost, kubeContext, conf.PrivilegedAccess)
-		if err != nil {
-			return errors.Wrap(err, "failed to create kubernetes client")
-		}
+	factory, err := badgerwrap.NewFactory(conf.StoreEngine)
+	if err != nil {
+		return errors.Wrap(err, "failed to create store factory")
+	}
 
-		kubeWatcherSource, err = ingress.NewKubeWatcherSource(kubeClient, kubeWatchChan, conf.KubeWatchResyncInterval, conf.WatchCrds, conf.CrdRefreshInterval, conf.ApiServerHost, kubeContext, conf.EnableGranularMetrics, conf.ExclusionRules)
-		if err != nil {
-			return errors.Wrap(err, "failed to initialize kubeWatcher")
-		}
+	if conf.RestoreOnly {
+		return runRestoreOnly(conf, clusters, factory)
 	}
 
-	// File playback
-	if conf.DebugPlaybackFile != "" {
-		err = ingress.PlayFile(kubeWatchChan, conf.DebugPlaybackFile)
-		if err != nil {
-			return errors.Wrap(err, "failed to play back file")
-		}
+	notifierConfig := notifier.Config{
+		Sinks:          conf.NotifierSinks,
+		MaxRetries:     conf.NotifierMaxRetries,
+		InitialBackoff: conf.NotifierRetryBackoff,
+		MaxBackoff:     conf.NotifierMaxRetryBackoff,
+		RepeatInterval: conf.NotifierRepeatInterval,
+		DeadLetterFile: conf.NotifierDeadLetterFile,
+		Timeout:        conf.NotifierTimeout,
+	}
+	alertNotifier, err := notifier.NewNotifier(notifierConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to load notifier sinks")
 	}
+	alertNotifier.Start()
 
-	var recorder *ingress.FileRecorder
-	if conf.DebugRecordFile != "" {
-		recorder = ingress.NewFileRecorder(conf.DebugRecordFile, kubeWatchChan)
-		recorder.Start()
-	}
-
-	var storemgr *storemanager.StoreManager
-	if !conf.DisableStoreManager {
-		fs := &afero.Afero{Fs: afero.NewOsFs()}
-		storeCfg := &storemanager.Config{
-			StoreRoot:          conf.StoreRoot,
-			Freq:               conf.CleanupFrequency,
-			TimeLimit:          conf.MaxLookback,
-			SizeLimitBytes:     conf.MaxDiskMb * 1024 * 1024,
-			BadgerDiscardRatio: conf.BadgerDiscardRatio,
-			BadgerVLogGCFreq:   conf.BadgerVLogGCFreq,
-			DeletionBatchSize:  conf.DeletionBatchSize,
-			GCThreshold:        conf.ThresholdForGC,
-			EnableDeleteKeys:   conf.EnableDeleteKeys,
+	backupKeys, err := readBackupKeys(conf)
+	if err != nil {
+		return err
+	}
+
+	// Stores are closed last, once nothing can write to them
+	defer func() {
+		for _, c := range clusters {
+			c.close()
+		}
+	}()
+	stopClusters := func() {
+		for _, c := range clusters {
+			c.stop()
 		}
-		storemgr = storemanager.NewStoreManager(tables, storeCfg, fs)
-		storemgr.Start()
+		alertNotifier.Close()
 	}
 
-	displayContext := kubeContext
-	if conf.DisplayContext != "" {
-		displayContext = conf.DisplayContext
+	var webClusters []webserver.Cluster
+	for _, c := range clusters {
+		glog.Infof("Starting cluster with context %q", c.kubeContext)
+		err = c.start(conf, factory, alertNotifier, backupKeys, len(clusters) == 1)
+		if err != nil {
+			stopClusters()
+			return err
+		}
+		webClusters = append(webClusters, webserver.Cluster{Context: c.displayContext, Tables: c.tables, Health: c.health, Authorizer: c.authorizer})
 	}
 
 	// Initialize user metrics if enabled
@@ -163,36 +129,110 @@ func RealMain() error {
 		DefaultResources:  conf.DefaultKind,
 		ResourceLinks:     conf.ResourceLinks,
 		LeftBarLinks:      conf.LeftBarLinks,
-		CurrentContext:    displayContext,
 		EnableUserMetrics: conf.EnableUserMetrics,
+		Auth:              authenticator,
+	}
+	if len(backupKeys) > 0 {
+		webConfig.BackupEncryptionKey = backupKeys[0]
 	}
-	err = webserver.Run(webConfig, tables)
+	err = webserver.Run(webConfig, webClusters)
 	if err != nil {
 		return errors.Wrap(err, "failed to run webserver")
 	}
 
-	// Initiate shutdown with the following order:
-	// 1. Shut down ingress so that it stops emitting events
-	// 2. Close the input channel which signals processing to finish work
-	// 3. Wait on processor to tell us all work is complete.  Store will not change after that
-	if kubeWatcherSource != nil {
-		kubeWatcherSource.Stop()
-	}
-	close(kubeWatchChan)
-	processor.Wait()
+	stopClusters()
+	glog.Infof("RunWithConfig finished")
+	return nil
+}
 
-	if recorder != nil {
-		recorder.Close()
+// Restores into the store of every cluster and closes them again, without starting anything which writes to the store.
+// The stores have to be empty so the restore is all they hold.
+func runRestoreOnly(conf *config.SloopConfig, clusters []*cluster, factory badgerwrap.Factory) error {
+	backupKeys, err := readBackupKeys(conf)
+	if err != nil {
+		return err
 	}
+	defer func() {
+		for _, c := range clusters {
+			c.close()
+		}
+	}()
+	for _, c := range clusters {
+		err = c.openStore(conf, factory)
+		if err != nil {
+			return err
+		}
+		_, keyCount := common.GetPartitionsInfo(c.db)
+		if keyCount > 0 {
+			return fmt.Errorf("store %q for context %q is not empty, restore-only needs a fresh store root", c.storeRoot(conf), c.kubeContext)
+		}
+		err = c.restore(conf, backupKeys)
+		if err != nil {
+			return err
+		}
+		_, keyCount = common.GetPartitionsInfo(c.db)
+		glog.Infof("Restored %v keys into %q for context %q", keyCount, c.storeRoot(conf), c.kubeContext)
+	}
+	return nil
+}
 
-	if storemgr != nil {
-		storemgr.Shutdown()
+// Serves queries from the federation peers without a watcher or store of our own
+func runFederation(conf *config.SloopConfig, authenticator *auth.Authenticator) error {
+	fed, err := federation.NewFederation(conf.FederationPeers, conf.FederationTimeout)
+	if err != nil {
+		return errors.Wrap(err, "failed to load federation peers")
 	}
 
+	displayContext := conf.DisplayContext
+	if displayContext == "" {
+		displayContext = defaultFederationContext
+	}
+	if conf.EnableUserMetrics {
+		server_metrics.InitUserMetrics(conf.UserMetricsHeaders)
+	}
+	webConfig := webserver.WebConfig{
+		BindAddress:       conf.BindAddress,
+		Port:              conf.Port,
+		WebFilesPath:      conf.WebFilesPath,
+		MaxLookback:       conf.MaxLookback,
+		DefaultNamespace:  conf.DefaultNamespace,
+		DefaultLookback:   conf.DefaultLookback,
+		DefaultResources:  conf.DefaultKind,
+		ResourceLinks:     conf.ResourceLinks,
+		LeftBarLinks:      conf.LeftBarLinks,
+		CurrentContext:    displayContext,
+		EnableUserMetrics: conf.EnableUserMetrics,
+		Auth:              authenticator,
+	}
+	err = webserver.RunFederation(webConfig, fed)
+	if err != nil {
+		return errors.Wrap(err, "failed to run webserver")
+	}
 	glog.Infof("RunWithConfig finished")
 	return nil
 }
 
+// Returns the backup encryption key followed by the previous one, or nothing when backups are not encrypted
+func readBackupKeys(conf *config.SloopConfig) ([][]byte, error) {
+	keys, err := common.ReadKeyFiles(conf.BackupEncryptionKeyFile, conf.BackupPreviousKeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read backup encryption key")
+	}
+	return keys, nil
+}
+
+// Returns nil when no key file is configured, which validation only allows without hash redaction rules
+func readRedactionHashKey(conf *config.SloopConfig) ([]byte, error) {
+	if conf.RedactionHashKeyFile == "" {
+		return nil, nil
+	}
+	key, err := common.ReadKeyFile(conf.RedactionHashKeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read redaction hash key")
+	}
+	return key, nil
+}
+
 // By default glog will not print anything to console, which can confuse users
 // This will turn it on unless user sets it explicitly (with --logtostderr=false)
 func setupStdErrLogging() {
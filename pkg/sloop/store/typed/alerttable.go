@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Key is /<partition>/<kind>/<namespace>/<name>/<ruleName>
+//
+// Partition is UnixSeconds rounded down to partition duration
+// Kind is kubernetes kind, starts with upper case
+// Namespace is kubernetes namespace, all lower
+// Name is kubernetes name, all lower
+// RuleName is the name of the alert rule that fired
+
+type AlertKey struct {
+	PartitionId string
+	Kind        string
+	Namespace   string
+	Name        string
+	RuleName    string
+}
+
+func NewAlertKey(timestamp time.Time, kind string, namespace string, name string, ruleName string) *AlertKey {
+	partitionId := untyped.GetPartitionId(timestamp)
+	return &AlertKey{PartitionId: partitionId, Kind: kind, Namespace: namespace, Name: name, RuleName: ruleName}
+}
+
+func NewAlertKeyComparator(kind string, namespace string, name string, ruleName string) *AlertKey {
+	return &AlertKey{Kind: kind, Namespace: namespace, Name: name, RuleName: ruleName}
+}
+
+func (*AlertKey) TableName() string {
+	return "alert"
+}
+
+func (k *AlertKey) Parse(key string) error {
+	err, parts := common.ParseKey(key)
+	if err != nil {
+		return err
+	}
+
+	if parts[1] != k.TableName() {
+		return fmt.Errorf("Second part of key (%v) should be %v", key, k.TableName())
+	}
+	k.PartitionId = parts[2]
+	k.Kind = parts[3]
+	k.Namespace = parts[4]
+	k.Name = parts[5]
+	k.RuleName = parts[6]
+	return nil
+}
+
+func (k *AlertKey) String() string {
+	if k.RuleName == "" {
+		return fmt.Sprintf("/%v/%v/%v/%v/%v", k.TableName(), k.PartitionId, k.Kind, k.Namespace, k.Name)
+	} else {
+		return fmt.Sprintf("/%v/%v/%v/%v/%v/%v", k.TableName(), k.PartitionId, k.Kind, k.Namespace, k.Name, k.RuleName)
+	}
+}
+
+func (*AlertKey) ValidateKey(key string) error {
+	newKey := AlertKey{}
+	return newKey.Parse(key)
+}
+
+func (k *AlertKey) SetPartitionId(newPartitionId string) {
+	k.PartitionId = newPartitionId
+}
+
+func (t *AlertTable) GetOrDefault(txn badgerwrap.Txn, key string) (*Alert, error) {
+	rec, err := t.Get(txn, key)
+	if err != nil {
+		if err != badger.ErrKeyNotFound {
+			return nil, err
+		} else {
+			return &Alert{}, nil
+		}
+	}
+	return rec, nil
+}
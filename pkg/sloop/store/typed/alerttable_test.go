@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	someRuleName = "someRule"
+	someAlertKey = "/alert/001546398000/somekind/somenamespace/somename/someRule"
+)
+
+func Test_AlertKey_OutputCorrect(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	k := NewAlertKey(someTs, someKind, someNamespace, someName, someRuleName)
+	assert.Equal(t, someAlertKey, k.String())
+}
+
+func Test_AlertKey_ParseCorrect(t *testing.T) {
+	k := &AlertKey{}
+	err := k.Parse(someAlertKey)
+	assert.Nil(t, err)
+	assert.Equal(t, someMinPartition, k.PartitionId)
+	assert.Equal(t, someKind, k.Kind)
+	assert.Equal(t, someNamespace, k.Namespace)
+	assert.Equal(t, someName, k.Name)
+	assert.Equal(t, someRuleName, k.RuleName)
+}
+
+func Test_AlertKey_ValidateWorks(t *testing.T) {
+	assert.Nil(t, (&AlertKey{}).ValidateKey(someAlertKey))
+	assert.NotNil(t, (&AlertKey{}).ValidateKey("/watchactivity/001546398000/somekind/somenamespace/somename/someRule"))
+}
+
+func Test_Alert_GetOrDefault(t *testing.T) {
+	db, at := helper_update_AlertTable(t, (&AlertKey{}).SetTestKeys(), &Alert{RuleName: someRuleName, FireCount: 3})
+	var found *Alert
+	var missing *Alert
+	err := db.View(func(txn badgerwrap.Txn) error {
+		var txerr error
+		found, txerr = at.GetOrDefault(txn, someAlertKey)
+		if txerr != nil {
+			return txerr
+		}
+		missing, txerr = at.GetOrDefault(txn, "/alert/001546398000/somekind/somenamespace/othername/someRule")
+		return txerr
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3), found.FireCount)
+	assert.Equal(t, int32(0), missing.FireCount)
+}
+
+func (*AlertKey) GetTestKey() string {
+	k := NewAlertKey(someTs, someKind, someNamespace, someName, someRuleName)
+	return k.String()
+}
+
+func (*AlertKey) GetTestValue() *Alert {
+	return &Alert{}
+}
+
+func (*AlertKey) SetTestKeys() []string {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	var keys []string
+	gap := 0
+	for i := 'a'; i < 'd'; i++ {
+		// add keys in ascending order
+		ts := someTs.Add(time.Hour * time.Duration(gap))
+		keys = append(keys, NewAlertKey(ts, someKind, someNamespace, someName, someRuleName).String())
+		keys = append(keys, NewAlertKey(ts, someKind, someNamespace, someName, someRuleName+string(i)).String())
+		gap++
+	}
+	return keys
+}
+
+func (*AlertKey) SetTestValue() *Alert {
+	return &Alert{}
+}
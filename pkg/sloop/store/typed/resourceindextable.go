@@ -0,0 +1,191 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+// Key is /<partition>/<term>/<kind>/<namespace>/<name>
+//
+// Partition is UnixSeconds rounded down to partition duration
+// Term is something the resource had in the partition, like a label.  It is path escaped as it can contain a slash
+// Kind is kubernetes kind, starts with upper case
+// Namespace is kubernetes namespace, all lower
+// Name is kubernetes name, all lower
+//
+// All the keys for a term in a partition share a prefix, so looking up a term reads only the resources which had it
+
+const (
+	nameTermPrefix       = "name:"
+	labelTermPrefix      = "label:"
+	labelKeyTermPrefix   = "labelkey:"
+	annotationTermPrefix = "annotation:"
+	// Name terms are every run of this many characters in the name
+	NameTrigramLength = 3
+	// Terms longer than this are not indexed, so a big annotation value does not make a big key
+	MaxResourceIndexTermLength = 256
+)
+
+type ResourceIndexKey struct {
+	PartitionId string
+	Term        string
+	Kind        string
+	Namespace   string
+	Name        string
+}
+
+func NewResourceIndexKey(timestamp time.Time, term string, kind string, namespace string, name string) *ResourceIndexKey {
+	partitionId := untyped.GetPartitionId(timestamp)
+	return &ResourceIndexKey{PartitionId: partitionId, Term: term, Kind: kind, Namespace: namespace, Name: name}
+}
+
+// The term for a run of NameTrigramLength characters in a resource name
+func NameTrigramTerm(trigram string) string {
+	return nameTermPrefix + trigram
+}
+
+func LabelTerm(key string, value string) string {
+	return labelTermPrefix + key + "=" + value
+}
+
+// The term for having a label with any value
+func LabelKeyTerm(key string) string {
+	return labelKeyTermPrefix + key
+}
+
+func AnnotationTerm(key string, value string) string {
+	return annotationTermPrefix + key + "=" + value
+}
+
+// ResourceIndexTerms returns the terms a resource with the name, labels and annotations is indexed by: the trigrams
+// of its name, each label as a key and as key=value, and each of the indexed annotations as key=value.  Terms longer
+// than MaxResourceIndexTermLength are left out.  Labels and annotations are sorted by key so the terms come out the
+// same every time
+func ResourceIndexTerms(name string, labels map[string]string, annotations map[string]string, indexedAnnotations []string) []string {
+	var terms []string
+	for _, trigram := range NameTrigrams(name) {
+		terms = append(terms, NameTrigramTerm(trigram))
+	}
+	for _, key := range sortedKeys(labels) {
+		terms = append(terms, LabelKeyTerm(key), LabelTerm(key, labels[key]))
+	}
+	for _, key := range sortedKeys(annotations) {
+		if common.Contains(indexedAnnotations, key) {
+			terms = append(terms, AnnotationTerm(key, annotations[key]))
+		}
+	}
+	var ret []string
+	for _, term := range terms {
+		if len(term) <= MaxResourceIndexTermLength {
+			ret = append(ret, term)
+		}
+	}
+	return ret
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// NameTrigrams returns each distinct run of NameTrigramLength characters in name, in the order they first appear.
+// A name shorter than that has none
+func NameTrigrams(name string) []string {
+	runes := []rune(name)
+	trigrams := []string{}
+	seen := map[string]bool{}
+	for i := 0; i+NameTrigramLength <= len(runes); i++ {
+		trigram := string(runes[i : i+NameTrigramLength])
+		if !seen[trigram] {
+			seen[trigram] = true
+			trigrams = append(trigrams, trigram)
+		}
+	}
+	return trigrams
+}
+
+func (*ResourceIndexKey) TableName() string {
+	return "resindex"
+}
+
+func (k *ResourceIndexKey) Parse(key string) error {
+	err, parts := common.ParseKey(key)
+	if err != nil {
+		return err
+	}
+
+	if parts[1] != k.TableName() {
+		return fmt.Errorf("Second part of key (%v) should be %v", key, k.TableName())
+	}
+	term, err := url.PathUnescape(parts[3])
+	if err != nil {
+		return fmt.Errorf("Third part of key (%v) is not an escaped term: %v", key, err)
+	}
+	k.PartitionId = parts[2]
+	k.Term = term
+	k.Kind = parts[4]
+	k.Namespace = parts[5]
+	k.Name = parts[6]
+	return nil
+}
+
+// Without a kind this is the prefix of every key for the term in the partition
+func (k *ResourceIndexKey) String() string {
+	if k.Kind == "" {
+		return fmt.Sprintf("/%v/%v/%v/", k.TableName(), k.PartitionId, url.PathEscape(k.Term))
+	} else {
+		return fmt.Sprintf("/%v/%v/%v/%v/%v/%v", k.TableName(), k.PartitionId, url.PathEscape(k.Term), k.Kind, k.Namespace, k.Name)
+	}
+}
+
+func (*ResourceIndexKey) ValidateKey(key string) error {
+	newKey := ResourceIndexKey{}
+	return newKey.Parse(key)
+}
+
+func (k *ResourceIndexKey) SetPartitionId(newPartitionId string) {
+	k.PartitionId = newPartitionId
+}
+
+func (t *ResourceIndexTable) GetOrDefault(txn badgerwrap.Txn, key string) (*ResourceIndex, error) {
+	rec, err := t.Get(txn, key)
+	if err != nil {
+		if err != badger.ErrKeyNotFound {
+			return nil, err
+		} else {
+			return &ResourceIndex{}, nil
+		}
+	}
+	return rec, nil
+}
+
+// HasPartition returns true when the partition has any index keys.  Partitions written before the index was added,
+// or restored from an older backup, have none
+func (t *ResourceIndexTable) HasPartition(txn badgerwrap.Txn, partitionId string) bool {
+	keyPrefix := []byte("/" + t.tableName + "/" + partitionId + "/")
+	iterOpt := badger.DefaultIteratorOptions
+	iterOpt.Prefix = keyPrefix
+	iterOpt.PrefetchValues = false
+	iterator := txn.NewIterator(iterOpt)
+	defer iterator.Close()
+	iterator.Seek(keyPrefix)
+	return iterator.ValidForPrefix(keyPrefix)
+}
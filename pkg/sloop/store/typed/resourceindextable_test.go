@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package typed
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	someResourceIndexKey = "/resindex/001546398000/label:app.kubernetes.io%2Fname=payments/somekind/somenamespace/somename"
+)
+
+var someIndexTerm = LabelTerm("app.kubernetes.io/name", "payments")
+
+func Test_ResourceIndexKey_OutputCorrect(t *testing.T) {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	k := NewResourceIndexKey(someTs, someIndexTerm, someKind, someNamespace, someName)
+	assert.Equal(t, someResourceIndexKey, k.String())
+
+	// Without a kind it is the prefix of the keys of the term
+	k = &ResourceIndexKey{PartitionId: someMinPartition, Term: someIndexTerm}
+	assert.Equal(t, "/resindex/001546398000/label:app.kubernetes.io%2Fname=payments/", k.String())
+}
+
+func Test_ResourceIndexKey_ParseCorrect(t *testing.T) {
+	k := &ResourceIndexKey{}
+	err := k.Parse(someResourceIndexKey)
+	assert.Nil(t, err)
+	assert.Equal(t, someMinPartition, k.PartitionId)
+	assert.Equal(t, someIndexTerm, k.Term)
+	assert.Equal(t, someKind, k.Kind)
+	assert.Equal(t, someNamespace, k.Namespace)
+	assert.Equal(t, someName, k.Name)
+}
+
+func Test_ResourceIndexKey_ValidateWorks(t *testing.T) {
+	assert.Nil(t, (&ResourceIndexKey{}).ValidateKey(someResourceIndexKey))
+	assert.NotNil(t, (&ResourceIndexKey{}).ValidateKey("/alert/001546398000/somekind/somenamespace/somename/someRule"))
+	assert.NotNil(t, (&ResourceIndexKey{}).ValidateKey("/resindex/001546398000/label:bad%zz/somekind/somenamespace/somename"))
+}
+
+func Test_NameTrigrams(t *testing.T) {
+	assert.Equal(t, []string{"pay", "aym", "yme", "men", "ent", "nts"}, NameTrigrams("payments"))
+	assert.Equal(t, []string{"aaa"}, NameTrigrams("aaaaa"))
+	assert.Equal(t, []string{}, NameTrigrams("ab"))
+}
+
+func Test_ResourceIndexTerms(t *testing.T) {
+	labels := map[string]string{"app": "web"}
+	annotations := map[string]string{"team": "payments", "note": "ignored", "big": strings.Repeat("x", MaxResourceIndexTermLength)}
+	terms := ResourceIndexTerms("ab", labels, annotations, []string{"team", "big", "missing"})
+	// Only indexed annotations, and not the one which is too long
+	assert.Equal(t, []string{"labelkey:app", "label:app=web", "annotation:team=payments"}, terms)
+}
+
+func Test_ResourceIndex_GetOrDefault(t *testing.T) {
+	db, it := helper_update_ResourceIndexTable(t, (&ResourceIndexKey{}).SetTestKeys(), &ResourceIndex{Uids: []string{someUid}})
+	var found *ResourceIndex
+	var missing *ResourceIndex
+	err := db.View(func(txn badgerwrap.Txn) error {
+		var txerr error
+		found, txerr = it.GetOrDefault(txn, someResourceIndexKey)
+		if txerr != nil {
+			return txerr
+		}
+		missing, txerr = it.GetOrDefault(txn, "/resindex/001546398000/label:app=other/somekind/somenamespace/somename")
+		return txerr
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{someUid}, found.Uids)
+	assert.Len(t, missing.Uids, 0)
+}
+
+func (*ResourceIndexKey) GetTestKey() string {
+	k := NewResourceIndexKey(someTs, someIndexTerm, someKind, someNamespace, someName)
+	return k.String()
+}
+
+func (*ResourceIndexKey) GetTestValue() *ResourceIndex {
+	return &ResourceIndex{}
+}
+
+func (*ResourceIndexKey) SetTestKeys() []string {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	var keys []string
+	gap := 0
+	for i := 'a'; i < 'd'; i++ {
+		// add keys in ascending order
+		ts := someTs.Add(time.Hour * time.Duration(gap))
+		keys = append(keys, NewResourceIndexKey(ts, someIndexTerm, someKind, someNamespace, someName).String())
+		keys = append(keys, NewResourceIndexKey(ts, someIndexTerm, someKind, someNamespace, someName+string(i)).String())
+		gap++
+	}
+	return keys
+}
+
+func (*ResourceIndexKey) SetTestValue() *ResourceIndex {
+	return &ResourceIndex{}
+}
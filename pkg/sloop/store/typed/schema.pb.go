@@ -320,6 +320,126 @@ func (m *WatchActivity) GetChangedAt() []int64 {
 	return nil
 }
 
+type Alert struct {
+	RuleName             string               `protobuf:"bytes,1,opt,name=ruleName,proto3" json:"ruleName,omitempty"`
+	Severity             string               `protobuf:"bytes,2,opt,name=severity,proto3" json:"severity,omitempty"`
+	Message              string               `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	FirstFired           *timestamp.Timestamp `protobuf:"bytes,4,opt,name=firstFired,proto3" json:"firstFired,omitempty"`
+	LastFired            *timestamp.Timestamp `protobuf:"bytes,5,opt,name=lastFired,proto3" json:"lastFired,omitempty"`
+	FireCount            int32                `protobuf:"varint,6,opt,name=fireCount,proto3" json:"fireCount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Alert) Reset()         { *m = Alert{} }
+func (m *Alert) String() string { return proto.CompactTextString(m) }
+func (*Alert) ProtoMessage()    {}
+func (*Alert) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{5}
+}
+
+func (m *Alert) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Alert.Unmarshal(m, b)
+}
+func (m *Alert) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Alert.Marshal(b, m, deterministic)
+}
+func (m *Alert) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Alert.Merge(m, src)
+}
+func (m *Alert) XXX_Size() int {
+	return xxx_messageInfo_Alert.Size(m)
+}
+func (m *Alert) XXX_DiscardUnknown() {
+	xxx_messageInfo_Alert.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Alert proto.InternalMessageInfo
+
+func (m *Alert) GetRuleName() string {
+	if m != nil {
+		return m.RuleName
+	}
+	return ""
+}
+
+func (m *Alert) GetSeverity() string {
+	if m != nil {
+		return m.Severity
+	}
+	return ""
+}
+
+func (m *Alert) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *Alert) GetFirstFired() *timestamp.Timestamp {
+	if m != nil {
+		return m.FirstFired
+	}
+	return nil
+}
+
+func (m *Alert) GetLastFired() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastFired
+	}
+	return nil
+}
+
+func (m *Alert) GetFireCount() int32 {
+	if m != nil {
+		return m.FireCount
+	}
+	return 0
+}
+
+// The uids of the resources of a name which had a term in a partition, like a label or part of the name
+// Key: /<partition>/<term>/<kind>/<namespace>/<name>
+type ResourceIndex struct {
+	Uids                 []string `protobuf:"bytes,1,rep,name=uids,proto3" json:"uids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResourceIndex) Reset()         { *m = ResourceIndex{} }
+func (m *ResourceIndex) String() string { return proto.CompactTextString(m) }
+func (*ResourceIndex) ProtoMessage()    {}
+func (*ResourceIndex) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1c5fb4d8cc22d66a, []int{6}
+}
+
+func (m *ResourceIndex) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResourceIndex.Unmarshal(m, b)
+}
+func (m *ResourceIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResourceIndex.Marshal(b, m, deterministic)
+}
+func (m *ResourceIndex) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceIndex.Merge(m, src)
+}
+func (m *ResourceIndex) XXX_Size() int {
+	return xxx_messageInfo_ResourceIndex.Size(m)
+}
+func (m *ResourceIndex) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceIndex.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResourceIndex proto.InternalMessageInfo
+
+func (m *ResourceIndex) GetUids() []string {
+	if m != nil {
+		return m.Uids
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("typed.KubeWatchResult_WatchType", KubeWatchResult_WatchType_name, KubeWatchResult_WatchType_value)
 	proto.RegisterType((*KubeWatchResult)(nil), "typed.KubeWatchResult")
@@ -329,42 +449,50 @@ func init() {
 	proto.RegisterType((*ResourceEventCounts)(nil), "typed.ResourceEventCounts")
 	proto.RegisterMapType((map[int64]*EventCounts)(nil), "typed.ResourceEventCounts.MapMinToEventsEntry")
 	proto.RegisterType((*WatchActivity)(nil), "typed.WatchActivity")
+	proto.RegisterType((*Alert)(nil), "typed.Alert")
+	proto.RegisterType((*ResourceIndex)(nil), "typed.ResourceIndex")
 }
 
 func init() { proto.RegisterFile("schema.proto", fileDescriptor_1c5fb4d8cc22d66a) }
 
 var fileDescriptor_1c5fb4d8cc22d66a = []byte{
-	// 505 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0xcf, 0x6e, 0x9b, 0x40,
-	0x10, 0xc6, 0x0b, 0xc4, 0x49, 0x18, 0xe7, 0x8f, 0xb5, 0x69, 0x25, 0x64, 0x55, 0x2d, 0x42, 0x3d,
-	0x70, 0xa8, 0x88, 0xe4, 0x4a, 0x55, 0x94, 0x43, 0x25, 0x64, 0x73, 0x6a, 0x5d, 0x55, 0x1b, 0xd2,
-	0x9c, 0xd7, 0x66, 0x62, 0xa3, 0x00, 0x8b, 0xd8, 0xc5, 0x15, 0x8f, 0xd0, 0x37, 0xe9, 0x83, 0xf4,
-	0x5d, 0xfa, 0x1a, 0x15, 0x8b, 0xb1, 0x89, 0x63, 0xb5, 0xb9, 0x0d, 0xdf, 0xfe, 0xbe, 0xe1, 0x9b,
-	0xd9, 0x85, 0x13, 0x31, 0x5f, 0x62, 0xca, 0xbc, 0xbc, 0xe0, 0x92, 0x93, 0x9e, 0xac, 0x72, 0x8c,
-	0x86, 0x6f, 0x17, 0x9c, 0x2f, 0x12, 0xbc, 0x54, 0xe2, 0xac, 0xbc, 0xbf, 0x94, 0x71, 0x8a, 0x42,
-	0xb2, 0x34, 0x6f, 0x38, 0xe7, 0x8f, 0x06, 0xe7, 0x9f, 0xcb, 0x19, 0xde, 0x31, 0x39, 0x5f, 0x52,
-	0x14, 0x65, 0x22, 0xc9, 0x15, 0x98, 0x1b, 0xcc, 0xd2, 0x6c, 0xcd, 0xed, 0x8f, 0x86, 0x5e, 0xd3,
-	0xc8, 0x6b, 0x1b, 0x79, 0x61, 0x4b, 0xd0, 0x2d, 0x4c, 0x08, 0x1c, 0x3c, 0xc4, 0x59, 0x64, 0xe9,
-	0xb6, 0xe6, 0x9a, 0x54, 0xd5, 0xe4, 0x13, 0x98, 0x3f, 0xea, 0xe6, 0x61, 0x95, 0xa3, 0x65, 0xd8,
-	0x9a, 0x7b, 0x36, 0xb2, 0x3d, 0x95, 0xce, 0xdb, 0xf9, 0xb1, 0x77, 0xd7, 0x72, 0x74, 0x6b, 0x21,
-	0x16, 0x1c, 0xe5, 0xac, 0x4a, 0x38, 0x8b, 0xac, 0x03, 0xd5, 0xb6, 0xfd, 0x74, 0xde, 0x83, 0xb9,
-	0x71, 0x90, 0x23, 0x30, 0xfc, 0xc9, 0x64, 0xf0, 0x82, 0x00, 0x1c, 0xde, 0x7e, 0x9b, 0xf8, 0x61,
-	0x30, 0xd0, 0xea, 0x7a, 0x12, 0x7c, 0x09, 0xc2, 0x60, 0xa0, 0x3b, 0x3f, 0x75, 0x38, 0xa7, 0x28,
-	0x78, 0x59, 0xcc, 0xf1, 0xa6, 0x4c, 0x53, 0x56, 0x54, 0xf5, 0xa4, 0xf7, 0x71, 0x21, 0xe4, 0x0d,
-	0x62, 0xf6, 0x9c, 0x49, 0x37, 0x30, 0xf9, 0x08, 0xc7, 0x09, 0x5b, 0x1b, 0xf5, 0xff, 0x1a, 0x37,
-	0x2c, 0xb9, 0x06, 0x98, 0x17, 0xc8, 0x24, 0xd6, 0x87, 0x6a, 0x1d, 0xff, 0x76, 0x76, 0x68, 0xe2,
-	0xc0, 0x49, 0x84, 0x09, 0x4a, 0x8c, 0x7c, 0x19, 0x64, 0xcd, 0x3a, 0x8e, 0xe9, 0x23, 0x8d, 0xbc,
-	0x83, 0xd3, 0x02, 0x13, 0x26, 0x63, 0x9e, 0x89, 0x65, 0x9c, 0x0b, 0xab, 0x67, 0x1b, 0xae, 0x49,
-	0x1f, 0x8b, 0xce, 0x2f, 0x0d, 0xfa, 0xc1, 0x0a, 0x33, 0x39, 0xe6, 0x65, 0x26, 0x05, 0x09, 0x61,
-	0x90, 0xb2, 0x9c, 0x22, 0x13, 0x3c, 0x0b, 0xb9, 0x12, 0x2d, 0xcd, 0x36, 0xdc, 0xfe, 0xc8, 0x5d,
-	0x5f, 0x55, 0x87, 0xf6, 0xa6, 0x3b, 0x68, 0x90, 0xc9, 0xa2, 0xa2, 0x4f, 0x3a, 0x0c, 0xc7, 0xf0,
-	0x6a, 0x2f, 0x4a, 0x06, 0x60, 0x3c, 0x60, 0xa5, 0x16, 0x6e, 0xd2, 0xba, 0x24, 0x2f, 0xa1, 0xb7,
-	0x62, 0x49, 0x89, 0x6a, 0x97, 0x3d, 0xda, 0x7c, 0x5c, 0xeb, 0x57, 0x9a, 0xf3, 0x5b, 0x83, 0x8b,
-	0xf6, 0xda, 0xba, 0x91, 0xbf, 0xc3, 0x59, 0xca, 0xf2, 0x69, 0x9c, 0x85, 0x5c, 0xc9, 0x62, 0x1d,
-	0xd8, 0x5b, 0x07, 0xde, 0xe3, 0xa9, 0x83, 0x77, 0x0c, 0x4d, 0xec, 0x9d, 0x2e, 0xc3, 0x5b, 0xb8,
-	0xd8, 0x83, 0x75, 0x23, 0x1b, 0x4d, 0x64, 0xb7, 0x1b, 0xb9, 0x3f, 0x22, 0x4f, 0x17, 0xd5, 0x1d,
-	0x63, 0x0a, 0xa7, 0xea, 0xad, 0xfa, 0x73, 0x19, 0xaf, 0x62, 0x59, 0x91, 0x37, 0x00, 0x5f, 0xf9,
-	0x78, 0xc9, 0xb2, 0x05, 0xfa, 0xcd, 0xb2, 0x0d, 0xda, 0x51, 0xc8, 0x6b, 0x30, 0x9b, 0x3a, 0xf2,
-	0xa5, 0xa5, 0xab, 0xe3, 0xad, 0x30, 0x3b, 0x54, 0x4f, 0xe5, 0xc3, 0xdf, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0x7e, 0x49, 0x70, 0xa9, 0xf5, 0x03, 0x00, 0x00,
+	// 605 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xdd, 0x6a, 0xdb, 0x4c,
+	0x10, 0xfd, 0x64, 0xc5, 0x49, 0x34, 0xce, 0x8f, 0xd9, 0x7c, 0x05, 0x61, 0x4a, 0x6b, 0xd4, 0x5e,
+	0xe8, 0xa2, 0x28, 0xe0, 0x42, 0x09, 0xb9, 0x28, 0x88, 0x58, 0x85, 0xd2, 0x3a, 0x94, 0x8d, 0xd2,
+	0x5c, 0x6f, 0xac, 0x89, 0x2d, 0xa2, 0x3f, 0xb4, 0x2b, 0xb7, 0x7a, 0x84, 0xbe, 0x49, 0x1f, 0xa4,
+	0xef, 0xd2, 0xab, 0xbe, 0x43, 0xd1, 0xae, 0x24, 0x2b, 0x89, 0xc1, 0xbd, 0x9b, 0x39, 0x7b, 0x66,
+	0x74, 0x76, 0xf6, 0x8c, 0xe0, 0x80, 0xcf, 0x97, 0x18, 0x33, 0x27, 0xcb, 0x53, 0x91, 0x92, 0xbe,
+	0x28, 0x33, 0x0c, 0x46, 0x2f, 0x17, 0x69, 0xba, 0x88, 0xf0, 0x54, 0x82, 0xb7, 0xc5, 0xdd, 0xa9,
+	0x08, 0x63, 0xe4, 0x82, 0xc5, 0x99, 0xe2, 0x59, 0xbf, 0x35, 0x38, 0xfe, 0x54, 0xdc, 0xe2, 0x0d,
+	0x13, 0xf3, 0x25, 0x45, 0x5e, 0x44, 0x82, 0x9c, 0x81, 0xd1, 0xd2, 0x4c, 0x6d, 0xac, 0xd9, 0x83,
+	0xc9, 0xc8, 0x51, 0x8d, 0x9c, 0xa6, 0x91, 0xe3, 0x37, 0x0c, 0xba, 0x26, 0x13, 0x02, 0x3b, 0xf7,
+	0x61, 0x12, 0x98, 0xbd, 0xb1, 0x66, 0x1b, 0x54, 0xc6, 0xe4, 0x3d, 0x18, 0xdf, 0xaa, 0xe6, 0x7e,
+	0x99, 0xa1, 0xa9, 0x8f, 0x35, 0xfb, 0x68, 0x32, 0x76, 0xa4, 0x3a, 0xe7, 0xd1, 0x87, 0x9d, 0x9b,
+	0x86, 0x47, 0xd7, 0x25, 0xc4, 0x84, 0xbd, 0x8c, 0x95, 0x51, 0xca, 0x02, 0x73, 0x47, 0xb6, 0x6d,
+	0x52, 0xeb, 0x0d, 0x18, 0x6d, 0x05, 0xd9, 0x03, 0xdd, 0x9d, 0x4e, 0x87, 0xff, 0x11, 0x80, 0xdd,
+	0xeb, 0x2f, 0x53, 0xd7, 0xf7, 0x86, 0x5a, 0x15, 0x4f, 0xbd, 0xcf, 0x9e, 0xef, 0x0d, 0x7b, 0xd6,
+	0x8f, 0x1e, 0x1c, 0x53, 0xe4, 0x69, 0x91, 0xcf, 0xf1, 0xaa, 0x88, 0x63, 0x96, 0x97, 0xd5, 0x4d,
+	0xef, 0xc2, 0x9c, 0x8b, 0x2b, 0xc4, 0xe4, 0x5f, 0x6e, 0xda, 0x92, 0xc9, 0x3b, 0xd8, 0x8f, 0x58,
+	0x5d, 0xd8, 0xdb, 0x5a, 0xd8, 0x72, 0xc9, 0x39, 0xc0, 0x3c, 0x47, 0x26, 0xb0, 0x3a, 0x34, 0xf5,
+	0xad, 0x95, 0x1d, 0x36, 0xb1, 0xe0, 0x20, 0xc0, 0x08, 0x05, 0x06, 0xae, 0xf0, 0x12, 0x35, 0x8e,
+	0x7d, 0xfa, 0x00, 0x23, 0xaf, 0xe1, 0x30, 0xc7, 0x88, 0x89, 0x30, 0x4d, 0xf8, 0x32, 0xcc, 0xb8,
+	0xd9, 0x1f, 0xeb, 0xb6, 0x41, 0x1f, 0x82, 0xd6, 0x4f, 0x0d, 0x06, 0xde, 0x0a, 0x13, 0x71, 0x91,
+	0x16, 0x89, 0xe0, 0xc4, 0x87, 0x61, 0xcc, 0x32, 0x8a, 0x8c, 0xa7, 0x89, 0x9f, 0x4a, 0xd0, 0xd4,
+	0xc6, 0xba, 0x3d, 0x98, 0xd8, 0xf5, 0x53, 0x75, 0xd8, 0xce, 0xec, 0x11, 0xd5, 0x4b, 0x44, 0x5e,
+	0xd2, 0x27, 0x1d, 0x46, 0x17, 0xf0, 0x6c, 0x23, 0x95, 0x0c, 0x41, 0xbf, 0xc7, 0x52, 0x0e, 0xdc,
+	0xa0, 0x55, 0x48, 0xfe, 0x87, 0xfe, 0x8a, 0x45, 0x05, 0xca, 0x59, 0xf6, 0xa9, 0x4a, 0xce, 0x7b,
+	0x67, 0x9a, 0xf5, 0x4b, 0x83, 0x93, 0xe6, 0xd9, 0xba, 0x92, 0xbf, 0xc2, 0x51, 0xcc, 0xb2, 0x59,
+	0x98, 0xf8, 0xa9, 0x84, 0x79, 0x2d, 0xd8, 0xa9, 0x05, 0x6f, 0xa8, 0x71, 0x66, 0x0f, 0x0a, 0x94,
+	0xec, 0x47, 0x5d, 0x46, 0xd7, 0x70, 0xb2, 0x81, 0xd6, 0x95, 0xac, 0x2b, 0xc9, 0x76, 0x57, 0xf2,
+	0x60, 0x42, 0x9e, 0x0e, 0xaa, 0x7b, 0x8d, 0x19, 0x1c, 0x4a, 0xaf, 0xba, 0x73, 0x11, 0xae, 0x42,
+	0x51, 0x92, 0x17, 0x00, 0x97, 0xe9, 0xc5, 0x92, 0x25, 0x0b, 0x74, 0xd5, 0xb0, 0x75, 0xda, 0x41,
+	0xc8, 0x73, 0x30, 0x54, 0x1c, 0xb8, 0xc2, 0xec, 0xc9, 0xe3, 0x35, 0x60, 0xfd, 0xd1, 0xa0, 0xef,
+	0x46, 0x98, 0x0b, 0x32, 0x82, 0xfd, 0xbc, 0x88, 0xf0, 0x92, 0xc5, 0x58, 0x0f, 0xb4, 0xcd, 0xab,
+	0x33, 0x8e, 0x2b, 0xcc, 0x43, 0x51, 0xd6, 0x2b, 0xd9, 0xe6, 0xd5, 0x5a, 0xc5, 0xc8, 0x39, 0x5b,
+	0x28, 0x17, 0x1a, 0xb4, 0x49, 0x2b, 0x8b, 0x4a, 0x9f, 0x7f, 0x08, 0x73, 0x54, 0x26, 0xdb, 0x62,
+	0xd1, 0x35, 0xbb, 0x5a, 0xa8, 0x88, 0xd5, 0x89, 0xd9, 0xdf, 0xbe, 0x50, 0x2d, 0xb9, 0xba, 0xef,
+	0x5d, 0x98, 0xa3, 0xf2, 0xde, 0xae, 0x74, 0xc1, 0x1a, 0xb0, 0x5e, 0xc1, 0x61, 0xf3, 0xa0, 0x1f,
+	0x93, 0x00, 0xbf, 0x57, 0x7f, 0x9a, 0x22, 0x0c, 0xd4, 0xa3, 0x1b, 0x54, 0xc6, 0xb7, 0xbb, 0xf2,
+	0x0b, 0x6f, 0xff, 0x0e, 0x00, 0x9d, 0xdb, 0x99, 0x76, 0x0a, 0x05, 0x00, 0x00,
 }
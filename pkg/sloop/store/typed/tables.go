@@ -8,6 +8,7 @@
 package typed
 
 import (
+	"fmt"
 	"sort"
 
 	"github.com/golang/glog"
@@ -19,11 +20,15 @@ type Tables interface {
 	EventCountTable() *ResourceEventCountsTable
 	WatchTable() *KubeWatchResultTable
 	WatchActivityTable() *WatchActivityTable
+	AlertTable() *AlertTable
+	ResourceIndexTable() *ResourceIndexTable
 	Db() badgerwrap.DB
 	GetMinAndMaxPartition() (bool, string, string, error)
 	GetTableNames() []string
 	GetTables() []interface{}
 	GetMinAndMaxPartitionWithTxn(badgerwrap.Txn) (bool, string, string)
+	// The annotation keys which processing adds to the resource index, and which queries can look up
+	IndexedAnnotations() []string
 }
 
 type MinMaxPartitionsGetter interface {
@@ -35,15 +40,25 @@ type tablesImpl struct {
 	eventCountTable      *ResourceEventCountsTable
 	watchTable           *KubeWatchResultTable
 	watchActivityTable   *WatchActivityTable
+	alertTable           *AlertTable
+	resourceIndexTable   *ResourceIndexTable
 	db                   badgerwrap.DB
+	indexedAnnotations   []string
 }
 
 func NewTableList(db badgerwrap.DB) Tables {
-	t := &tablesImpl{}
+	return NewTableListWithIndexedAnnotations(db, nil)
+}
+
+// Returns the tables of a store whose resource index has the given annotations as well as the names and labels
+func NewTableListWithIndexedAnnotations(db badgerwrap.DB, indexedAnnotations []string) Tables {
+	t := &tablesImpl{indexedAnnotations: indexedAnnotations}
 	t.resourceSummaryTable = OpenResourceSummaryTable()
 	t.eventCountTable = OpenResourceEventCountsTable()
 	t.watchTable = OpenKubeWatchResultTable()
 	t.watchActivityTable = OpenWatchActivityTable()
+	t.alertTable = OpenAlertTable()
+	t.resourceIndexTable = OpenResourceIndexTable()
 	t.db = db
 	return t
 }
@@ -64,6 +79,18 @@ func (t *tablesImpl) WatchActivityTable() *WatchActivityTable {
 	return t.watchActivityTable
 }
 
+func (t *tablesImpl) AlertTable() *AlertTable {
+	return t.alertTable
+}
+
+func (t *tablesImpl) ResourceIndexTable() *ResourceIndexTable {
+	return t.resourceIndexTable
+}
+
+func (t *tablesImpl) IndexedAnnotations() []string {
+	return t.indexedAnnotations
+}
+
 func (t *tablesImpl) Db() badgerwrap.DB {
 	return t.db
 }
@@ -106,11 +133,30 @@ func (t *tablesImpl) GetMinAndMaxPartitionWithTxn(txn badgerwrap.Txn) (bool, str
 }
 
 func (t *tablesImpl) GetTableNames() []string {
-	return []string{t.watchTable.tableName, t.resourceSummaryTable.tableName, t.eventCountTable.tableName, t.watchActivityTable.tableName}
+	return []string{t.watchTable.tableName, t.resourceSummaryTable.tableName, t.eventCountTable.tableName, t.watchActivityTable.tableName, t.alertTable.tableName, t.resourceIndexTable.tableName}
 }
 
 func (t *tablesImpl) GetTables() []interface{} {
 	intfs := new([]interface{})
-	*intfs = append(*intfs, t.eventCountTable, t.resourceSummaryTable, t.watchTable, t.watchActivityTable)
+	*intfs = append(*intfs, t.eventCountTable, t.resourceSummaryTable, t.watchTable, t.watchActivityTable, t.alertTable, t.resourceIndexTable)
 	return *intfs
 }
+
+// GetValue reads the value of a key from whichever table the key belongs to
+func GetValue(tables Tables, txn badgerwrap.Txn, key string) (interface{}, error) {
+	switch {
+	case (&WatchTableKey{}).ValidateKey(key) == nil:
+		return tables.WatchTable().Get(txn, key)
+	case (&ResourceSummaryKey{}).ValidateKey(key) == nil:
+		return tables.ResourceSummaryTable().Get(txn, key)
+	case (&EventCountKey{}).ValidateKey(key) == nil:
+		return tables.EventCountTable().Get(txn, key)
+	case (&WatchActivityKey{}).ValidateKey(key) == nil:
+		return tables.WatchActivityTable().Get(txn, key)
+	case (&AlertKey{}).ValidateKey(key) == nil:
+		return tables.AlertTable().Get(txn, key)
+	case (&ResourceIndexKey{}).ValidateKey(key) == nil:
+		return tables.ResourceIndexTable().Get(txn, key)
+	}
+	return nil, fmt.Errorf("Invalid key: %v", key)
+}
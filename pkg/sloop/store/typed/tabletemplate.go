@@ -23,6 +23,8 @@ import (
 //go:generate genny -in=$GOFILE -out=resourcesummarytablegen.go gen "ValueType=ResourceSummary KeyType=ResourceSummaryKey"
 //go:generate genny -in=$GOFILE -out=eventcounttablegen.go gen "ValueType=ResourceEventCounts KeyType=EventCountKey"
 //go:generate genny -in=$GOFILE -out=watchactivitytablegen.go gen "ValueType=WatchActivity KeyType=WatchActivityKey"
+//go:generate genny -in=$GOFILE -out=alerttablegen.go gen "ValueType=Alert KeyType=AlertKey"
+//go:generate genny -in=$GOFILE -out=resourceindextablegen.go gen "ValueType=ResourceIndex KeyType=ResourceIndexKey"
 
 type ValueTypeTable struct {
 	tableName string
@@ -172,7 +174,8 @@ func (t *ValueTypeTable) GetPreviousKey(txn badgerwrap.Txn, key *KeyType, keyCom
 			}
 		}
 	}
-	return &KeyType{}, fmt.Errorf("failed to get any previous key in table:%v, for key:%v, keyComparator:%v", t.tableName, key.String(), keyComparator)
+	// Dont wrap. Need to preserve error type
+	return &KeyType{}, badger.ErrKeyNotFound
 }
 
 func (t *ValueTypeTable) getLastMatchingKeyInPartition(txn badgerwrap.Txn, curPartition string, curKey *KeyType, keyComparator *KeyType) (bool, *KeyType, error) {
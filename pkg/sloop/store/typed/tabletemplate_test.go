@@ -23,6 +23,8 @@ import (
 //go:generate genny -in=$GOFILE -out=resourcesummarytablegen_test.go gen "ValueType=ResourceSummary KeyType=ResourceSummaryKey"
 //go:generate genny -in=$GOFILE -out=eventcounttablegen_test.go gen "ValueType=ResourceEventCounts KeyType=EventCountKey"
 //go:generate genny -in=$GOFILE -out=watchactivitytablegen_test.go gen "ValueType=WatchActivity KeyType=WatchActivityKey"
+//go:generate genny -in=$GOFILE -out=alerttablegen_test.go gen "ValueType=Alert KeyType=AlertKey"
+//go:generate genny -in=$GOFILE -out=resourceindextablegen_test.go gen "ValueType=ResourceIndex KeyType=ResourceIndexKey"
 
 func helper_ValueType_ShouldSkip() bool {
 	// Tests will not work on the fake types in the template, but we want to run tests on real objects
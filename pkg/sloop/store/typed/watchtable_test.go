@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dgraph-io/badger/v2"
 	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
 	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
 	"github.com/stretchr/testify/assert"
@@ -192,7 +193,7 @@ func Test_GetPreviousKey_Fail(t *testing.T) {
 		partRes, err1 = wt.GetPreviousKey(txn, curKey, keyComparator)
 		return err1
 	})
-	assert.NotNil(t, err)
+	assert.Equal(t, badger.ErrKeyNotFound, err)
 	assert.Equal(t, &WatchTableKey{}, partRes)
 }
 
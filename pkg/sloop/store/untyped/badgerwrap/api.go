@@ -8,17 +8,34 @@
 package badgerwrap
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/dgraph-io/badger/v2"
 )
 
+// Storage engines that can be picked with --store-engine
+const (
+	EngineBadger = "badger"
+	EngineBolt   = "bolt"
+)
+
 // Need a factory we can pass into untyped store so it can open and close databases
 // with the proper impl
 type Factory interface {
 	Open(opt badger.Options) (DB, error)
 }
 
+func NewFactory(engine string) (Factory, error) {
+	switch engine {
+	case EngineBadger:
+		return &BadgerFactory{}, nil
+	case EngineBolt:
+		return &BoltFactory{}, nil
+	}
+	return nil, fmt.Errorf("unknown store engine %q, expected %v or %v", engine, EngineBadger, EngineBolt)
+}
+
 type DB interface {
 	Close() error
 	Sync() error
@@ -47,7 +64,7 @@ type DB interface {
 	RunValueLogGC(discardRatio float64) error
 	//	SetDiscardTs(ts uint64)
 	//	Subscribe(ctx context.Context, cb func(kv *KVList), prefixes ...[]byte) error
-	//	VerifyChecksum() error
+	VerifyChecksum() error
 }
 
 type Txn interface {
@@ -92,6 +92,10 @@ func (b *BadgerDb) RunValueLogGC(discardRatio float64) error {
 	return b.db.RunValueLogGC(discardRatio)
 }
 
+func (b *BadgerDb) VerifyChecksum() error {
+	return b.db.VerifyChecksum()
+}
+
 // Transaction
 
 func (t *BadgerTxn) Get(key []byte) (Item, error) {
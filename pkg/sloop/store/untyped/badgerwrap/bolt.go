@@ -0,0 +1,532 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package badgerwrap
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/dgraph-io/badger/v2/pb"
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+)
+
+// This implements the badgerwrap interfaces on top of bbolt, a single file B+tree store.
+// There is no value log, so there is no value log GC and no !badger!move keys.  Deleted pages are
+// reused by later writes and are given back to the file system by compacting the file in RunValueLogGC.
+//
+// Bolt allows one writer at a time, so Update calls never conflict.  Backup and Load use the same stream
+// format as badger, so backups can be restored into either engine.
+
+const (
+	boltFileName = "sloop.bolt"
+	// Compact the file when at least this fraction of it is free pages, and it is at least this many bytes
+	boltCompactFreeRatio    = 0.3
+	boltCompactMinFreeBytes = 4 << 20
+	// Max size of each transaction used to copy data when compacting
+	boltCompactTxMaxSize = 64 << 20
+	// Number of keys deleted per transaction in DropPrefix
+	boltDropPrefixBatchSize = 10000
+	// Number of keys written per list in Backup
+	boltBackupListSize = 1000
+)
+
+var boltBucket = []byte("sloop")
+
+// Returned by Backup for an incremental backup, as bolt keeps no versions to tell what changed
+var ErrIncrementalBackupNotSupported = errors.New("the bolt store engine can only take full backups")
+
+type BoltFactory struct {
+}
+
+type BoltDb struct {
+	// Update and View hold a read lock.  Compaction swaps the underlying file so it holds a write lock
+	lock     *sync.RWMutex
+	db       *bolt.DB
+	filePath string
+	opts     *bolt.Options
+}
+
+type BoltTxn struct {
+	tx     *bolt.Tx
+	bucket *bolt.Bucket
+	// Counts writes so iterators know their cursor needs to be repositioned
+	mutations int
+}
+
+type BoltItem struct {
+	key   []byte
+	value []byte
+}
+
+type BoltIterator struct {
+	opt       badger.IteratorOptions
+	txn       *BoltTxn
+	cursor    *bolt.Cursor
+	mutations int
+	key       []byte
+	value     []byte
+}
+
+// Open uses opt.Dir for the location of the file and opt.SyncWrites.  Other badger options do not apply.
+func (f *BoltFactory) Open(opt badger.Options) (DB, error) {
+	err := os.MkdirAll(opt.Dir, 0755)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create bolt directory")
+	}
+	opts := &bolt.Options{
+		Timeout:        time.Second,
+		NoSync:         !opt.SyncWrites,
+		NoFreelistSync: true,
+		FreelistType:   bolt.FreelistMapType,
+	}
+	b := &BoltDb{lock: &sync.RWMutex{}, filePath: path.Join(opt.Dir, boltFileName), opts: opts}
+	err = b.open()
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (b *BoltDb) open() error {
+	db, err := bolt.Open(b.filePath, 0644, b.opts)
+	if err != nil {
+		return errors.Wrap(err, "Failed to open bolt")
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(boltBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return errors.Wrap(err, "failed to create bolt bucket")
+	}
+	b.db = db
+	return nil
+}
+
+// Database
+
+func (b *BoltDb) Close() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.db.Close()
+}
+
+func (b *BoltDb) Sync() error {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.db.Sync()
+}
+
+func (b *BoltDb) Update(fn func(txn Txn) error) error {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return fn(&BoltTxn{tx: tx, bucket: tx.Bucket(boltBucket)})
+	})
+}
+
+func (b *BoltDb) View(fn func(txn Txn) error) error {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.db.View(func(tx *bolt.Tx) error {
+		return fn(&BoltTxn{tx: tx, bucket: tx.Bucket(boltBucket)})
+	})
+}
+
+func (b *BoltDb) DropPrefix(prefix []byte) error {
+	for {
+		deleted := 0
+		err := b.Update(func(txn Txn) error {
+			boltTxn := txn.(*BoltTxn)
+			keys := [][]byte{}
+			c := boltTxn.bucket.Cursor()
+			for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix) && len(keys) < boltDropPrefixBatchSize; k, _ = c.Next() {
+				keys = append(keys, append([]byte{}, k...))
+			}
+			for _, k := range keys {
+				err := boltTxn.bucket.Delete(k)
+				if err != nil {
+					return err
+				}
+			}
+			deleted = len(keys)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if deleted < boltDropPrefixBatchSize {
+			return nil
+		}
+	}
+}
+
+// Size returns the size of the file as lsm and 0 for vlog
+func (b *BoltDb) Size() (lsm, vlog int64) {
+	_ = b.View(func(txn Txn) error {
+		lsm = txn.(*BoltTxn).tx.Size()
+		return nil
+	})
+	return lsm, 0
+}
+
+// Bolt has no LSM tables
+func (b *BoltDb) Tables(withKeysCount bool) []badger.TableInfo {
+	return []badger.TableInfo{}
+}
+
+// Backup writes all keys in the same format as badger's Backup.  Every key is written as version 1 and the returned
+// version is always 1.  There is nothing to compare since against, so it has to be 0 for a full backup, and anything
+// else returns ErrIncrementalBackupNotSupported
+func (b *BoltDb) Backup(w io.Writer, since uint64) (uint64, error) {
+	if since > 0 {
+		return 0, ErrIncrementalBackupNotSupported
+	}
+	err := b.View(func(txn Txn) error {
+		list := &pb.KVList{}
+		c := txn.(*BoltTxn).bucket.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			list.Kv = append(list.Kv, &pb.KV{
+				Key:      append([]byte{}, k...),
+				Value:    append([]byte{}, v...),
+				UserMeta: []byte{0},
+				Version:  1,
+				Meta:     []byte{0},
+			})
+			if len(list.Kv) >= boltBackupListSize {
+				err := writeKVList(w, list)
+				if err != nil {
+					return err
+				}
+				list = &pb.KVList{}
+			}
+		}
+		if len(list.Kv) > 0 {
+			return writeKVList(w, list)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return 1, nil
+}
+
+func writeKVList(w io.Writer, list *pb.KVList) error {
+	err := binary.Write(w, binary.LittleEndian, uint64(proto.Size(list)))
+	if err != nil {
+		return err
+	}
+	buf, err := proto.Marshal(list)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(buf)
+	return err
+}
+
+// Load reads a backup written by badger or BoltDb.Backup.  Badger writes every version of a key newest first
+// in one list, so only the first entry of each key is used, and keys whose newest entry is a delete are skipped.
+func (b *BoltDb) Load(r io.Reader, maxPendingWrites int) error {
+	if maxPendingWrites < 1 {
+		maxPendingWrites = 1
+	}
+	br := bufio.NewReaderSize(r, 16<<10)
+	pending := []*pb.KV{}
+	flush := func() error {
+		err := b.Update(func(txn Txn) error {
+			for _, kv := range pending {
+				err := txn.Set(kv.Key, kv.Value)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		pending = pending[:0]
+		return err
+	}
+
+	for {
+		var sz uint64
+		err := binary.Read(br, binary.LittleEndian, &sz)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return errors.Wrap(err, "failed to read backup list size")
+		}
+		buf := make([]byte, sz)
+		_, err = io.ReadFull(br, buf)
+		if err != nil {
+			return errors.Wrap(err, "failed to read backup list")
+		}
+		list := &pb.KVList{}
+		err = proto.Unmarshal(buf, list)
+		if err != nil {
+			return errors.Wrap(err, "failed to unmarshal backup list")
+		}
+
+		var lastKey []byte
+		for _, kv := range list.Kv {
+			if lastKey != nil && bytes.Equal(lastKey, kv.Key) {
+				continue
+			}
+			lastKey = kv.Key
+			// Same bits as badger's bitDelete and bitExpired
+			if len(kv.Meta) > 0 && kv.Meta[0]&(1<<0|1<<1) != 0 {
+				continue
+			}
+			if kv.ExpiresAt != 0 && kv.ExpiresAt <= uint64(time.Now().Unix()) {
+				continue
+			}
+			pending = append(pending, kv)
+			if len(pending) >= maxPendingWrites {
+				err = flush()
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if len(pending) > 0 {
+		return flush()
+	}
+	return nil
+}
+
+func (b *BoltDb) Flatten(workers int) error {
+	return nil
+}
+
+// RunValueLogGC compacts the file when enough of it is free pages.  Like badger it returns ErrNoRewrite
+// when there was nothing to do.  The discard ratio is a badger setting and is ignored.
+func (b *BoltDb) RunValueLogGC(discardRatio float64) error {
+	b.lock.RLock()
+	freeBytes := b.db.Stats().FreeAlloc
+	info, err := os.Stat(b.filePath)
+	b.lock.RUnlock()
+	if err != nil {
+		return err
+	}
+	if freeBytes < boltCompactMinFreeBytes || float64(freeBytes)/float64(info.Size()) < boltCompactFreeRatio {
+		return badger.ErrNoRewrite
+	}
+	return b.compact()
+}
+
+// VerifyChecksum checks the consistency of the pages in the file.  Bolt has no checksums, so this is the closest there is
+func (b *BoltDb) VerifyChecksum() error {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.db.View(func(tx *bolt.Tx) error {
+		var messages []string
+		for err := range tx.Check() {
+			messages = append(messages, err.Error())
+		}
+		if len(messages) > 0 {
+			return fmt.Errorf("bolt file %v is inconsistent: %v", b.filePath, strings.Join(messages, "; "))
+		}
+		return nil
+	})
+}
+
+// Copies all keys to a new file and swaps it in.  Blocks all transactions while it runs.
+func (b *BoltDb) compact() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	before := time.Now()
+	tmpPath := b.filePath + ".compact"
+	_ = os.Remove(tmpPath)
+	dst, err := bolt.Open(tmpPath, 0644, b.opts)
+	if err != nil {
+		return errors.Wrap(err, "failed to open bolt compaction file")
+	}
+	err = bolt.Compact(dst, b.db, boltCompactTxMaxSize)
+	closeErr := dst.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return errors.Wrap(err, "failed to compact bolt file")
+	}
+
+	err = b.db.Close()
+	if err != nil {
+		return errors.Wrap(err, "failed to close bolt before swapping compacted file")
+	}
+	err = os.Rename(tmpPath, b.filePath)
+	if err != nil {
+		glog.Errorf("Failed to swap in compacted bolt file: %v", err)
+	}
+	openErr := b.open()
+	if openErr != nil {
+		return openErr
+	}
+	glog.Infof("Compacted bolt file %v in %v", b.filePath, time.Since(before))
+	return err
+}
+
+// Transaction
+
+func (t *BoltTxn) Get(key []byte) (Item, error) {
+	value := t.bucket.Get(key)
+	if value == nil {
+		return nil, badger.ErrKeyNotFound
+	}
+	return &BoltItem{key: key, value: value}, nil
+}
+
+// Bolt requires keys and values to stay valid until the transaction ends so they are copied
+func (t *BoltTxn) Set(key, val []byte) error {
+	if !t.tx.Writable() {
+		return badger.ErrReadOnlyTxn
+	}
+	t.mutations += 1
+	return t.bucket.Put(append([]byte{}, key...), append([]byte{}, val...))
+}
+
+func (t *BoltTxn) Delete(key []byte) error {
+	if !t.tx.Writable() {
+		return badger.ErrReadOnlyTxn
+	}
+	t.mutations += 1
+	return t.bucket.Delete(key)
+}
+
+func (t *BoltTxn) NewIterator(opt badger.IteratorOptions) Iterator {
+	return &BoltIterator{opt: opt, txn: t, cursor: t.bucket.Cursor(), mutations: t.mutations}
+}
+
+// Item
+
+func (i *BoltItem) Key() []byte {
+	return i.key
+}
+
+func (i *BoltItem) Value(fn func(val []byte) error) error {
+	return fn(i.value)
+}
+
+func (i *BoltItem) ValueCopy(dst []byte) ([]byte, error) {
+	return append(dst[:0], i.value...), nil
+}
+
+func (i *BoltItem) EstimatedSize() int64 {
+	return int64(len(i.key) + len(i.value))
+}
+
+func (i *BoltItem) IsDeletedOrExpired() bool {
+	return false
+}
+
+func (i *BoltItem) KeyCopy(dst []byte) []byte {
+	return append(dst[:0], i.key...)
+}
+
+// Iterator
+
+func (i *BoltIterator) Close() {
+}
+
+func (i *BoltIterator) Item() Item {
+	if i.key == nil {
+		return nil
+	}
+	return &BoltItem{key: i.key, value: i.value}
+}
+
+// Bolt cursors are invalidated by writes, so after a write in the same transaction the cursor is
+// moved back to the current key before stepping
+func (i *BoltIterator) Next() {
+	if i.key == nil {
+		return
+	}
+	var k, v []byte
+	if i.mutations != i.txn.mutations {
+		i.mutations = i.txn.mutations
+		k, v = i.cursor.Seek(i.key)
+		if i.opt.Reverse {
+			if k == nil {
+				k, v = i.cursor.Last()
+			}
+			for k != nil && bytes.Compare(k, i.key) >= 0 {
+				k, v = i.cursor.Prev()
+			}
+		} else if k != nil && bytes.Equal(k, i.key) {
+			k, v = i.cursor.Next()
+		}
+	} else if i.opt.Reverse {
+		k, v = i.cursor.Prev()
+	} else {
+		k, v = i.cursor.Next()
+	}
+	i.setCurrent(k, v)
+}
+
+// Seek finds the first key >= key, or the last key <= key when iterating in reverse, same as badger.
+// An empty key seeks to the iterator prefix.
+func (i *BoltIterator) Seek(key []byte) {
+	i.mutations = i.txn.mutations
+	if len(key) == 0 {
+		key = i.opt.Prefix
+	}
+	var k, v []byte
+	switch {
+	case len(key) == 0 && i.opt.Reverse:
+		k, v = i.cursor.Last()
+	case len(key) == 0:
+		k, v = i.cursor.First()
+	case i.opt.Reverse:
+		k, v = i.cursor.Seek(key)
+		if k == nil {
+			k, v = i.cursor.Last()
+		} else if !bytes.Equal(k, key) {
+			k, v = i.cursor.Prev()
+		}
+	default:
+		k, v = i.cursor.Seek(key)
+	}
+	i.setCurrent(k, v)
+}
+
+func (i *BoltIterator) setCurrent(k, v []byte) {
+	if k == nil {
+		i.key = nil
+		i.value = nil
+		return
+	}
+	i.key = append([]byte{}, k...)
+	i.value = v
+}
+
+func (i *BoltIterator) Valid() bool {
+	return i.key != nil && bytes.HasPrefix(i.key, i.opt.Prefix)
+}
+
+func (i *BoltIterator) ValidForPrefix(prefix []byte) bool {
+	return i.Valid() && bytes.HasPrefix(i.key, prefix)
+}
+
+func (i *BoltIterator) Rewind() {
+	i.Seek(nil)
+}
@@ -0,0 +1,224 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package badgerwrap
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_OpenBoltDb(t *testing.T, dataDir string) DB {
+	db, err := (&BoltFactory{}).Open(badger.DefaultOptions(dataDir))
+	assert.Nil(t, err)
+	return db
+}
+
+func helper_TempDir(t *testing.T) string {
+	dataDir, err := ioutil.TempDir("", "bolt")
+	assert.Nil(t, err)
+	t.Cleanup(func() { os.RemoveAll(dataDir) })
+	return dataDir
+}
+
+func helper_SetKeys(t *testing.T, db DB, keys []string) {
+	for _, key := range keys {
+		helper_Set(t, db, []byte(key), []byte("value"+key))
+	}
+}
+
+var someBoltKeys = []string{"/a/1", "/a/2", "/b/1", "/b/4", "/c/1", "/c/2"}
+
+func Test_Bolt_PutGetDelete(t *testing.T) {
+	db := helper_OpenBoltDb(t, helper_TempDir(t))
+	defer db.Close()
+
+	_, err := helper_Get(t, db, testKey)
+	assert.Equal(t, badger.ErrKeyNotFound, err)
+
+	helper_Set(t, db, testKey, testValue1)
+	helper_Set(t, db, testKey, testValue2)
+	assert.Equal(t, testValue2, helper_GetNoError(t, db, testKey))
+
+	err = db.Update(func(txn Txn) error {
+		return txn.Delete(testKey)
+	})
+	assert.Nil(t, err)
+	_, err = helper_Get(t, db, testKey)
+	assert.Equal(t, badger.ErrKeyNotFound, err)
+}
+
+func Test_Bolt_SetInView_ReturnsReadOnlyError(t *testing.T) {
+	db := helper_OpenBoltDb(t, helper_TempDir(t))
+	defer db.Close()
+
+	err := db.View(func(txn Txn) error {
+		return txn.Set(testKey, testValue1)
+	})
+	assert.Equal(t, badger.ErrReadOnlyTxn, err)
+}
+
+func Test_Bolt_Iterate(t *testing.T) {
+	db := helper_OpenBoltDb(t, helper_TempDir(t))
+	defer db.Close()
+	helper_SetKeys(t, db, someBoltKeys)
+
+	assert.Equal(t, someBoltKeys, helper_iterateKeys(db, badger.DefaultIteratorOptions))
+
+	opt := badger.DefaultIteratorOptions
+	opt.Reverse = true
+	assert.Equal(t, []string{"/c/2", "/c/1", "/b/4", "/b/1", "/a/2", "/a/1"}, helper_iterateKeys(db, opt))
+
+	assert.Equal(t, []string{"/b/1", "/b/4"}, helper_iterateKeysPrefix(db, badger.DefaultIteratorOptions, "/b/", "/b/"))
+	// Same as badger, seeking in reverse starts at the last key <= the seek key
+	assert.Equal(t, []string{"/b/4", "/b/1"}, helper_iterateKeysPrefix(db, opt, "/b0", "/b/"))
+	assert.Equal(t, []string{"/b/4", "/b/1"}, helper_iterateKeysPrefix(db, opt, "/b/4", "/b/"))
+
+	opt = badger.DefaultIteratorOptions
+	opt.Prefix = []byte("/c/")
+	assert.Equal(t, []string{"/c/1", "/c/2"}, helper_iterateKeys(db, opt))
+}
+
+func Test_Bolt_DeleteWhileIterating(t *testing.T) {
+	for _, reverse := range []bool{false, true} {
+		db := helper_OpenBoltDb(t, helper_TempDir(t))
+		helper_SetKeys(t, db, someBoltKeys)
+
+		visited := []string{}
+		err := db.Update(func(txn Txn) error {
+			opt := badger.DefaultIteratorOptions
+			opt.Reverse = reverse
+			itr := txn.NewIterator(opt)
+			defer itr.Close()
+			for itr.Rewind(); itr.Valid(); itr.Next() {
+				key := itr.Item().KeyCopy(nil)
+				visited = append(visited, string(key))
+				if bytes.HasPrefix(key, []byte("/b/")) {
+					err := txn.Delete(key)
+					if err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Len(t, visited, len(someBoltKeys))
+		assert.Equal(t, []string{"/a/1", "/a/2", "/c/1", "/c/2"}, helper_iterateKeys(db, badger.DefaultIteratorOptions))
+		db.Close()
+	}
+}
+
+func Test_Bolt_DropPrefix(t *testing.T) {
+	db := helper_OpenBoltDb(t, helper_TempDir(t))
+	defer db.Close()
+	helper_SetKeys(t, db, someBoltKeys)
+
+	err := db.DropPrefix([]byte("/b"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/a/1", "/a/2", "/c/1", "/c/2"}, helper_iterateKeys(db, badger.DefaultIteratorOptions))
+}
+
+func Test_Bolt_BackupAndLoad(t *testing.T) {
+	db := helper_OpenBoltDb(t, helper_TempDir(t))
+	defer db.Close()
+	helper_SetKeys(t, db, someBoltKeys)
+
+	var buf bytes.Buffer
+	_, err := db.Backup(&buf, 0)
+	assert.Nil(t, err)
+
+	restored := helper_OpenBoltDb(t, helper_TempDir(t))
+	defer restored.Close()
+	err = restored.Load(&buf, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, someBoltKeys, helper_iterateKeys(restored, badger.DefaultIteratorOptions))
+	assert.Equal(t, []byte("value/b/4"), helper_GetNoError(t, restored, []byte("/b/4")))
+}
+
+func Test_Bolt_IncrementalBackup_ReturnsError(t *testing.T) {
+	db := helper_OpenBoltDb(t, helper_TempDir(t))
+	defer db.Close()
+	helper_SetKeys(t, db, someBoltKeys)
+
+	var buf bytes.Buffer
+	_, err := db.Backup(&buf, 2)
+	assert.Equal(t, ErrIncrementalBackupNotSupported, err)
+	assert.Equal(t, 0, buf.Len())
+}
+
+func Test_Bolt_LoadBadgerBackup(t *testing.T) {
+	badgerDb, err := (&BadgerFactory{}).Open(badger.DefaultOptions(helper_TempDir(t)).WithLogger(nil))
+	assert.Nil(t, err)
+	defer badgerDb.Close()
+	helper_SetKeys(t, badgerDb, someBoltKeys)
+	// Deleted keys are in the backup as tombstones and should not be restored
+	err = badgerDb.Update(func(txn Txn) error {
+		return txn.Delete([]byte("/a/2"))
+	})
+	assert.Nil(t, err)
+
+	var buf bytes.Buffer
+	_, err = badgerDb.Backup(&buf, 0)
+	assert.Nil(t, err)
+
+	db := helper_OpenBoltDb(t, helper_TempDir(t))
+	defer db.Close()
+	err = db.Load(&buf, 100)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/a/1", "/b/1", "/b/4", "/c/1", "/c/2"}, helper_iterateKeys(db, badger.DefaultIteratorOptions))
+}
+
+func Test_Bolt_RunValueLogGC_CompactsFile(t *testing.T) {
+	dataDir := helper_TempDir(t)
+	db := helper_OpenBoltDb(t, dataDir)
+	defer db.Close()
+
+	value := bytes.Repeat([]byte("x"), 1024)
+	err := db.Update(func(txn Txn) error {
+		for idx := 0; idx < 5000; idx++ {
+			err := txn.Set([]byte(fmt.Sprintf("/drop/%05d", idx)), value)
+			if err != nil {
+				return err
+			}
+		}
+		return txn.Set([]byte("/keep/1"), value)
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, badger.ErrNoRewrite, db.RunValueLogGC(0.5))
+
+	err = db.DropPrefix([]byte("/drop/"))
+	assert.Nil(t, err)
+	before, err := os.Stat(path.Join(dataDir, boltFileName))
+	assert.Nil(t, err)
+
+	err = db.RunValueLogGC(0.5)
+	assert.Nil(t, err)
+	after, err := os.Stat(path.Join(dataDir, boltFileName))
+	assert.Nil(t, err)
+	assert.True(t, after.Size() < before.Size()/2)
+	assert.Equal(t, value, helper_GetNoError(t, db, []byte("/keep/1")))
+	assert.Equal(t, badger.ErrNoRewrite, db.RunValueLogGC(0.5))
+}
+
+func Test_Bolt_ReopenKeepsData(t *testing.T) {
+	dataDir := helper_TempDir(t)
+	db := helper_OpenBoltDb(t, dataDir)
+	helper_Set(t, db, testKey, testValue1)
+	assert.Nil(t, db.Close())
+
+	db = helper_OpenBoltDb(t, dataDir)
+	defer db.Close()
+	assert.Equal(t, testValue1, helper_GetNoError(t, db, testKey))
+}
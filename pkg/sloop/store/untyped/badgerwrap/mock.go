@@ -121,6 +121,10 @@ func (b *MockDb) RunValueLogGC(discardRatio float64) error {
 	return nil
 }
 
+func (b *MockDb) VerifyChecksum() error {
+	return nil
+}
+
 // Transaction
 
 func (t *MockTxn) Get(key []byte) (Item, error) {
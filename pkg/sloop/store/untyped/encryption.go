@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package untyped
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+
+	"github.com/salesforce/sloop/pkg/sloop/common"
+)
+
+func withEncryption(opts badger.Options, config *Config) (badger.Options, error) {
+	key, err := common.ReadKeyFile(config.EncryptionKeyFile)
+	if err != nil {
+		return opts, errors.Wrap(err, "failed to read store encryption key")
+	}
+	if config.PreviousKeyFile != "" {
+		previousKey, err := common.ReadKeyFile(config.PreviousKeyFile)
+		if err != nil {
+			return opts, errors.Wrap(err, "failed to read previous store encryption key")
+		}
+		err = rotateEncryptionKey(config.RootPath, previousKey, key)
+		if err != nil {
+			return opts, err
+		}
+	}
+	opts = opts.WithEncryptionKey(key)
+	if config.KeyRotation != 0 {
+		opts = opts.WithEncryptionKeyRotationDuration(config.KeyRotation)
+	}
+	return opts, nil
+}
+
+// Badger encrypts its data with data keys which are kept in a key registry, and only the registry is encrypted with
+// the master key.  Rotating the master key re-encrypts the registry, the same as the badger rotate command.  Nothing
+// is done when there is no store yet or the registry already uses the new key, so the previous key can be left
+// configured across restarts.
+func rotateEncryptionKey(dir string, previousKey []byte, key []byte) error {
+	_, err := os.Stat(filepath.Join(dir, badger.KeyRegistryFileName))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	opt := badger.KeyRegistryOptions{Dir: dir, ReadOnly: true, EncryptionKey: key}
+	_, err = badger.OpenKeyRegistry(opt)
+	if err == nil {
+		return nil
+	}
+	if err != badger.ErrEncryptionKeyMismatch {
+		return errors.Wrap(err, "failed to open store key registry")
+	}
+
+	opt.EncryptionKey = previousKey
+	registry, err := badger.OpenKeyRegistry(opt)
+	if err != nil {
+		return errors.Wrap(err, "failed to open store key registry with the previous encryption key")
+	}
+	opt.EncryptionKey = key
+	err = badger.WriteKeyRegistry(registry, opt)
+	if err != nil {
+		return errors.Wrap(err, "failed to rotate store encryption key")
+	}
+	glog.Infof("Rotated the encryption key of the store in %v", dir)
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package untyped
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+func helper_writeKey(t *testing.T, dir string, name string, key string) string {
+	file := filepath.Join(dir, name)
+	assert.Nil(t, ioutil.WriteFile(file, []byte(hex.EncodeToString([]byte(key))), 0600))
+	return file
+}
+
+func Test_OpenStore_EncryptionKeyRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "encrypted")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	oldKey := helper_writeKey(t, dir, "old.key", "0123456789abcdef")
+	newKey := helper_writeKey(t, dir, "new.key", "fedcba9876543210")
+	factory := &badgerwrap.BadgerFactory{}
+	config := &Config{RootPath: filepath.Join(dir, "store"), ConfigPartitionDuration: time.Hour, EncryptionKeyFile: oldKey}
+
+	db, err := OpenStore(factory, config)
+	assert.Nil(t, err)
+	err = db.Update(func(txn badgerwrap.Txn) error { return txn.Set([]byte("/someKey"), []byte("someValue")) })
+	assert.Nil(t, err)
+	assert.Nil(t, db.Close())
+
+	// The new key does not open the store until the old one is given to rotate from
+	config.EncryptionKeyFile = newKey
+	_, err = OpenStore(factory, config)
+	assert.NotNil(t, err)
+
+	config.PreviousKeyFile = oldKey
+	for i := 0; i < 2; i++ {
+		// Opening again with the previous key still set does nothing more
+		db, err = OpenStore(factory, config)
+		assert.Nil(t, err)
+		err = db.View(func(txn badgerwrap.Txn) error {
+			item, err := txn.Get([]byte("/someKey"))
+			if err != nil {
+				return err
+			}
+			value, err := item.ValueCopy(nil)
+			assert.Equal(t, "someValue", string(value))
+			return err
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, db.Close())
+	}
+
+	config.EncryptionKeyFile = oldKey
+	config.PreviousKeyFile = ""
+	_, err = OpenStore(factory, config)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package untyped
+
+import (
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+type kvPair struct {
+	key   []byte
+	value []byte
+}
+
+// Copies every key in src to dst, batchSize keys per write transaction.  This is meant to be run offline
+// to move a store to a different engine, so neither store should be open anywhere else.
+// Returns the number of keys copied.
+func CopyStore(src badgerwrap.DB, dst badgerwrap.DB, batchSize int) (uint64, error) {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	var copied uint64
+	batch := make([]kvPair, 0, batchSize)
+	flush := func() error {
+		err := dst.Update(func(txn badgerwrap.Txn) error {
+			for _, kv := range batch {
+				err := txn.Set(kv.key, kv.value)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to write batch starting at key %q", string(batch[0].key))
+		}
+		copied += uint64(len(batch))
+		batch = batch[:0]
+		if copied%(uint64(batchSize)*100) == 0 {
+			glog.Infof("Copied %v keys", copied)
+		}
+		return nil
+	}
+
+	err := src.View(func(txn badgerwrap.Txn) error {
+		iterator := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer iterator.Close()
+		for iterator.Rewind(); iterator.Valid(); iterator.Next() {
+			item := iterator.Item()
+			value, err := item.ValueCopy(nil)
+			if err != nil {
+				return errors.Wrapf(err, "failed to read value of key %q", string(item.Key()))
+			}
+			batch = append(batch, kvPair{key: item.KeyCopy(nil), value: value})
+			if len(batch) >= batchSize {
+				err = flush()
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return copied, err
+	}
+	if len(batch) > 0 {
+		err = flush()
+		if err != nil {
+			return copied, err
+		}
+	}
+	return copied, dst.Sync()
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package untyped
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CopyStore_BadgerToBolt(t *testing.T) {
+	srcDir, err := ioutil.TempDir("", "badger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(srcDir)
+	dstDir, err := ioutil.TempDir("", "bolt")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dstDir)
+
+	src, err := (&badgerwrap.BadgerFactory{}).Open(badger.DefaultOptions(srcDir).WithLogger(nil))
+	assert.Nil(t, err)
+	defer src.Close()
+	err = src.Update(func(txn badgerwrap.Txn) error {
+		for idx := 0; idx < 25; idx++ {
+			txerr := txn.Set([]byte(fmt.Sprintf("/watch/001546398000/Pod/ns/name%02d/1", idx)), []byte(fmt.Sprintf("value%v", idx)))
+			if txerr != nil {
+				return txerr
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+
+	dst, err := (&badgerwrap.BoltFactory{}).Open(badger.DefaultOptions(dstDir))
+	assert.Nil(t, err)
+	defer dst.Close()
+
+	copied, err := CopyStore(src, dst, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(25), copied)
+
+	err = dst.View(func(txn badgerwrap.Txn) error {
+		item, txerr := txn.Get([]byte("/watch/001546398000/Pod/ns/name24/1"))
+		assert.Nil(t, txerr)
+		value, txerr := item.ValueCopy(nil)
+		assert.Nil(t, txerr)
+		assert.Equal(t, "value24", string(value))
+		return nil
+	})
+	assert.Nil(t, err)
+}
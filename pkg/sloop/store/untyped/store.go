@@ -36,6 +36,12 @@ type Config struct {
 	BadgerVLogFileIOMapping  bool
 	BadgerDetailLogEnabled   bool
 	BadgerVLogTruncate       bool
+	// Badger encrypts the store with the key in this file when it is set
+	EncryptionKeyFile string
+	// When set, the store is moved from this key to the one in EncryptionKeyFile before it is opened
+	PreviousKeyFile string
+	// How often badger makes a new data key.  Zero uses the badger default
+	KeyRotation time.Duration
 }
 
 func OpenStore(factory badgerwrap.Factory, config *Config) (badgerwrap.DB, error) {
@@ -105,13 +111,24 @@ func OpenStore(factory badgerwrap.Factory, config *Config) (badgerwrap.DB, error
 
 	opts = opts.WithSyncWrites(config.BadgerSyncWrites)
 
+	if config.EncryptionKeyFile != "" {
+		opts, err = withEncryption(opts, config)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	db, err := factory.Open(opts)
 	if err != nil {
 		return nil, fmt.Errorf("badger.OpenStore failed with: %v", err)
 	}
 
 	db.Flatten(5)
-	glog.Infof("BadgerDB Options: %+v", opts)
+	loggedOpts := opts
+	if len(loggedOpts.EncryptionKey) > 0 {
+		loggedOpts.EncryptionKey = []byte("redacted")
+	}
+	glog.Infof("BadgerDB Options: %+v", loggedOpts)
 
 	partitionDuration = config.ConfigPartitionDuration
 	return db, nil
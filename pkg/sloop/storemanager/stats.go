@@ -34,7 +34,8 @@ var (
 	metricTotalKeysCount             = promauto.NewGauge(prometheus.GaugeOpts{Name: "sloop_total_key_count"})
 )
 
-type storeStats struct {
+// StoreStats is the size of a store on disk and its key counts
+type StoreStats struct {
 	timestamp         time.Time
 	DiskSizeBytes     int64
 	DiskLsmBytes      int64
@@ -46,8 +47,13 @@ type storeStats struct {
 	TotalKeyCount     uint64
 }
 
-func generateStats(storeRoot string, db badgerwrap.DB, fs *afero.Afero) *storeStats {
-	ret := &storeStats{}
+// GetStoreStats reads the stats of a store without emitting metrics for them
+func GetStoreStats(storeRoot string, db badgerwrap.DB, fs *afero.Afero) *StoreStats {
+	return generateStats(storeRoot, db, fs)
+}
+
+func generateStats(storeRoot string, db badgerwrap.DB, fs *afero.Afero) *StoreStats {
+	ret := &StoreStats{}
 	ret.LevelToKeyCount = make(map[int]uint64)
 	ret.LevelToTableCount = make(map[int]int)
 	ret.timestamp = time.Now()
@@ -99,7 +105,7 @@ func getDirSizeRecursive(root string, fs *afero.Afero) (uint64, map[string]int,
 	return totalSize, extFileCount, extByteCount, nil
 }
 
-func emitMetrics(stats *storeStats) {
+func emitMetrics(stats *StoreStats) {
 	metricStoreSizeOnDiskMb.Set(float64(stats.DiskSizeBytes / 1024 / 1024))
 	for k, v := range stats.LevelToKeyCount {
 		metricBadgerKeys.WithLabelValues(fmt.Sprintf("%v", k)).Set(float64(v))
@@ -114,7 +120,7 @@ func emitMetrics(stats *storeStats) {
 	metricTotalKeysCount.Set(float64(stats.TotalKeyCount))
 }
 
-func emitGCMetrics(stats *storeStats) {
+func emitGCMetrics(stats *StoreStats) {
 	metricCleanedStoreSizeOnDiskMb.Set(float64(stats.DiskSizeBytes / 1024 / 1024))
 	for k, v := range stats.LevelToKeyCount {
 		metricCleanedBadgerKeys.WithLabelValues(fmt.Sprintf("%v", k)).Set(float64(v))
@@ -125,8 +131,8 @@ func emitGCMetrics(stats *storeStats) {
 	metricCleanedBadgerVLogSizeMb.Set(float64(stats.DiskVlogBytes / 1024 / 1024))
 }
 
-func getDeltaStats(beforeStats *storeStats, afterStats *storeStats) *storeStats {
-	ret := &storeStats{}
+func getDeltaStats(beforeStats *StoreStats, afterStats *StoreStats) *StoreStats {
+	ret := &StoreStats{}
 
 	for k, v := range beforeStats.LevelToKeyCount {
 		metricCleanedBadgerKeys.WithLabelValues(fmt.Sprintf("%v", k)).Set(float64(v) - float64(afterStats.LevelToKeyCount[k]))
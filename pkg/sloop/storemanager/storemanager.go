@@ -62,7 +62,7 @@ type StoreManager struct {
 	done     bool
 	donelock *sync.Mutex
 	config   *Config
-	stats    *storeStats
+	stats    *StoreStats
 }
 
 func NewStoreManager(tables typed.Tables, config *Config, fs *afero.Afero) *StoreManager {
@@ -74,7 +74,7 @@ func NewStoreManager(tables typed.Tables, config *Config, fs *afero.Afero) *Stor
 		done:     false,
 		donelock: &sync.Mutex{},
 		config:   config,
-		stats:    &storeStats{},
+		stats:    &StoreStats{},
 	}
 }
 
@@ -99,31 +99,39 @@ func (sm *StoreManager) gcLoop() {
 			return
 		}
 
-		beforeGCStats := sm.refreshStats()
-
-		metricGcRunCount.Inc()
 		before := time.Now()
-		metricGcRunning.Set(1)
-		cleanUpPerformed, numOfDeletedKeys, numOfKeysToDelete, err := doCleanup(sm.tables, sm.config.TimeLimit, sm.config.SizeLimitBytes, sm.stats, sm.config.DeletionBatchSize, sm.config.GCThreshold, sm.config.EnableDeleteKeys)
-		metricGcCleanUpPerformed.Set(common.BoolToFloat(cleanUpPerformed))
-		metricGcDeletedNumberOfKeys.Set(float64(numOfDeletedKeys))
-		metricGcNumberOfKeysToDelete.Set(float64(numOfKeysToDelete))
-		metricGcRunning.Set(0)
-		if err == nil {
-			metricGcSuccessCount.Inc()
-		} else {
-			metricGcFailedCount.Inc()
-		}
-		metricGcLatency.Set(time.Since(before).Seconds())
+		_, _, err := sm.Cleanup()
 		glog.V(common.GlogVerbose).Infof("GC finished in %v with error '%v'.  Next run in %v", time.Since(before), err, sm.config.Freq)
-
-		afterGCEnds := sm.refreshStats()
-		deltaStats := getDeltaStats(beforeGCStats, afterGCEnds)
-		emitGCMetrics(deltaStats)
 		sm.sleeper.Sleep(sm.config.Freq)
 	}
 }
 
+// Cleanup runs the cleanup the store manager runs every Freq once, and returns whether it deleted anything and how
+// many keys it deleted
+func (sm *StoreManager) Cleanup() (bool, int64, error) {
+	beforeGCStats := sm.refreshStats()
+
+	metricGcRunCount.Inc()
+	before := time.Now()
+	metricGcRunning.Set(1)
+	cleanUpPerformed, numOfDeletedKeys, numOfKeysToDelete, err := doCleanup(sm.tables, sm.config.TimeLimit, sm.config.SizeLimitBytes, sm.stats, sm.config.DeletionBatchSize, sm.config.GCThreshold, sm.config.EnableDeleteKeys)
+	metricGcCleanUpPerformed.Set(common.BoolToFloat(cleanUpPerformed))
+	metricGcDeletedNumberOfKeys.Set(float64(numOfDeletedKeys))
+	metricGcNumberOfKeysToDelete.Set(float64(numOfKeysToDelete))
+	metricGcRunning.Set(0)
+	if err == nil {
+		metricGcSuccessCount.Inc()
+	} else {
+		metricGcFailedCount.Inc()
+	}
+	metricGcLatency.Set(time.Since(before).Seconds())
+
+	afterGCEnds := sm.refreshStats()
+	deltaStats := getDeltaStats(beforeGCStats, afterGCEnds)
+	emitGCMetrics(deltaStats)
+	return cleanUpPerformed, numOfDeletedKeys, err
+}
+
 func (sm *StoreManager) vlogGcLoop() {
 	// Its up to us to trigger the Badger value log GC.
 	// See https://github.com/dgraph-io/badger#garbage-collection
@@ -165,7 +173,7 @@ func (sm *StoreManager) Shutdown() {
 	sm.wg.Wait()
 }
 
-func (sm *StoreManager) refreshStats() *storeStats {
+func (sm *StoreManager) refreshStats() *StoreStats {
 	// If we have fresh results its good enough
 	if time.Since(sm.stats.timestamp) < time.Second {
 		return sm.stats
@@ -175,7 +183,7 @@ func (sm *StoreManager) refreshStats() *storeStats {
 	return sm.stats
 }
 
-func doCleanup(tables typed.Tables, timeLimit time.Duration, sizeLimitBytes int, stats *storeStats, deletionBatchSize int, gcThreshold float64, enableDeletePrefix bool) (bool, int64, int64, error) {
+func doCleanup(tables typed.Tables, timeLimit time.Duration, sizeLimitBytes int, stats *StoreStats, deletionBatchSize int, gcThreshold float64, enableDeletePrefix bool) (bool, int64, int64, error) {
 	anyCleanupPerformed := false
 	var totalNumOfDeletedKeys int64 = 0
 	var totalNumOfKeysToDelete int64 = 0
@@ -286,9 +294,12 @@ func getPartitionsToDelete(tables typed.Tables, timeLimit time.Duration, sizeLim
 		currentLastPartitionToDeleteIndex++
 	}
 
-	minPartitionAge, err := untyped.GetAgeOfPartitionInHours(sortedPartitionsList[currentLastPartitionToDeleteIndex])
-	if err == nil {
-		metricAgeOfMinimumPartition.Set(minPartitionAge)
+	// A time limit shorter than a partition can take all of them
+	if currentLastPartitionToDeleteIndex < len(sortedPartitionsList) {
+		minPartitionAge, err := untyped.GetAgeOfPartitionInHours(sortedPartitionsList[currentLastPartitionToDeleteIndex])
+		if err == nil {
+			metricAgeOfMinimumPartition.Set(minPartitionAge)
+		}
 	}
 
 	return partitionsToDelete, partitionMap
@@ -359,7 +370,7 @@ func cleanUpTimeCondition(minPartition string, maxPartition string, timeLimit ti
 	return false
 }
 
-func cleanUpFileSizeCondition(stats *storeStats, sizeLimitBytes int, gcThreshold float64, enableDeleteKeys bool, numOfKeysToDeleteForFileSizeCondition int64) bool {
+func cleanUpFileSizeCondition(stats *StoreStats, sizeLimitBytes int, gcThreshold float64, enableDeleteKeys bool, numOfKeysToDeleteForFileSizeCondition int64) bool {
 	if enableDeleteKeys {
 		return numOfKeysToDeleteForFileSizeCondition > 0
 	} else {
@@ -34,7 +34,7 @@ var (
 )
 
 func Test_cleanUpFileSizeCondition_True(t *testing.T) {
-	stats := &storeStats{
+	stats := &StoreStats{
 		DiskSizeBytes: 10,
 	}
 
@@ -47,7 +47,7 @@ func Test_cleanUpFileSizeCondition_True(t *testing.T) {
 }
 
 func Test_cleanUpFileSizeCondition_False(t *testing.T) {
-	stats := &storeStats{
+	stats := &StoreStats{
 		DiskSizeBytes: 10,
 	}
 
@@ -126,7 +126,7 @@ func Test_doCleanup_true(t *testing.T) {
 	db := help_get_db(t)
 	tables := typed.NewTableList(db)
 
-	stats := &storeStats{
+	stats := &StoreStats{
 		DiskSizeBytes: 10,
 	}
 
@@ -139,7 +139,7 @@ func Test_doCleanup_false(t *testing.T) {
 	db := help_get_db(t)
 	tables := typed.NewTableList(db)
 
-	stats := &storeStats{
+	stats := &StoreStats{
 		DiskSizeBytes: 10,
 	}
 
@@ -159,6 +159,14 @@ func Test_getPartitionsToDelete(t *testing.T) {
 	assert.Equal(t, len(partitionsToDelete), 0)
 }
 
+func Test_getPartitionsToDelete_TimeLimitShorterThanPartition(t *testing.T) {
+	db := help_get_db(t)
+	tables := typed.NewTableList(db)
+
+	partitionsToDelete, _ := getPartitionsToDelete(tables, time.Minute, 20, 10, 0.9)
+	assert.Equal(t, len(partitionsToDelete), 1)
+}
+
 func Test_getGarbageCollectionRatio(t *testing.T) {
 	ratio := getGarbageCollectionRatio(1000, 900, 0.9)
 	assert.Equal(t, 0.19, ratio)
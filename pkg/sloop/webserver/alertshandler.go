@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+type alertsData struct {
+	CurrentContext string
+	Lookback       string
+	StartTime      time.Time
+	EndTime        time.Time
+	Alerts         []queries.ApiAlert
+}
+
+// Lists the alerts fired in the time range, newest first
+func alertsHandler(config WebConfig, tables typed.Tables) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		alertsTemplate, err := getTemplate(alertsTemplateFile, _webfilesAlertsHtml)
+		if err != nil {
+			logWebError(err, "Template.New failed", request, writer)
+			return
+		}
+
+		params := request.URL.Query()
+		startTime, endTime, err := apiTimeRange(params, tables, config)
+		if err != nil {
+			logWebError(err, "Invalid time range", request, writer)
+			return
+		}
+		scope := namespaceScope(request)
+		alerts, err := queries.GetAlertList(params, tables, startTime, endTime, getRequestId(request.Context()), scope)
+		if err == nil {
+			err = scope.Err()
+		}
+		if err != nil {
+			logWebError(err, "Failed to read alerts", request, writer)
+			return
+		}
+		sort.SliceStable(alerts, func(i, j int) bool {
+			return alerts[i].LastFired.After(alerts[j].LastFired)
+		})
+
+		data := alertsData{
+			CurrentContext: config.CurrentContext,
+			Lookback:       params.Get(queries.LookbackParam),
+			StartTime:      startTime,
+			EndTime:        endTime,
+			Alerts:         alerts,
+		}
+		err = alertsTemplate.Execute(writer, data)
+		if err != nil {
+			logWebError(err, "Template.ExecuteTemplate failed", request, writer)
+			return
+		}
+	}
+}
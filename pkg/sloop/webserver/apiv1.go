@@ -0,0 +1,451 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	_ "embed"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/salesforce/sloop/pkg/sloop/export"
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+// Versioned JSON api served under /<currentContext>/api/v1/
+//
+// The /data endpoint is shaped for the UI and can change with it.  This api has typed responses, consistent
+// error bodies and pagination so it can be used by scripts and other tools.  openapi.json describes it.
+
+const (
+	apiVersionV1       = "v1"
+	apiDefaultLimit    = 100
+	apiMaxLimit        = 1000
+	apiLimitParam      = "limit"
+	apiContinueParam   = "continue"
+	apiContentTypeJson = "application/json"
+	apiContentTypeYaml = "application/yaml"
+	apiFormatYaml      = "yaml"
+)
+
+//go:embed openapi.json
+var apiV1OpenApiSpec []byte
+
+type apiListRequest struct {
+	Limit    int
+	Continue string
+	// Namespaces the caller can see
+	Scope queries.NamespaceScope
+}
+
+// One page of results from a list function
+type apiPage struct {
+	Items    interface{}
+	Count    int
+	Total    int
+	Continue string
+}
+
+type apiListMetadata struct {
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	Count     int       `json:"count"`
+	Total     int       `json:"total"`
+	Continue  string    `json:"continue,omitempty"`
+}
+
+type apiListResponse struct {
+	ApiVersion string          `json:"apiVersion"`
+	Kind       string          `json:"kind"`
+	Metadata   apiListMetadata `json:"metadata"`
+	Items      interface{}     `json:"items"`
+}
+
+type apiObjectResponse struct {
+	ApiVersion string          `json:"apiVersion"`
+	Kind       string          `json:"kind"`
+	Metadata   apiListMetadata `json:"metadata"`
+	Data       interface{}     `json:"data"`
+}
+
+type apiError struct {
+	Code    int    `json:"code"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+type apiErrorResponse struct {
+	ApiVersion string   `json:"apiVersion"`
+	Error      apiError `json:"error"`
+}
+
+func writeApiJson(w http.ResponseWriter, code int, body interface{}) {
+	bytes, err := json.MarshalIndent(body, "", " ")
+	if err != nil {
+		glog.Errorf("Failed to marshal api response: %v", err)
+		code = http.StatusInternalServerError
+		bytes = []byte(`{"error":{"code":500,"status":"Internal Server Error","message":"failed to marshal response"}}`)
+	}
+	w.Header().Set("content-type", apiContentTypeJson)
+	w.WriteHeader(code)
+	w.Write(bytes)
+}
+
+func writeApiError(w http.ResponseWriter, r *http.Request, code int, err error) {
+	if code >= http.StatusInternalServerError {
+		glog.Errorf("reqId: %v api request %v failed: %v", getRequestId(r.Context()), r.URL, err)
+	}
+	writeApiJson(w, code, apiErrorResponse{
+		ApiVersion: apiVersionV1,
+		Error: apiError{
+			Code:    code,
+			Status:  http.StatusText(code),
+			Message: err.Error(),
+		},
+	})
+}
+
+func parseApiListRequest(params url.Values, indexedAnnotations []string) (apiListRequest, error) {
+	ret := apiListRequest{Limit: apiDefaultLimit, Continue: params.Get(apiContinueParam)}
+	limitStr := params.Get(apiLimitParam)
+	if limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			return ret, fmt.Errorf("%v must be a positive integer, got %q", apiLimitParam, limitStr)
+		}
+		ret.Limit = limit
+	}
+	if ret.Limit > apiMaxLimit {
+		ret.Limit = apiMaxLimit
+	}
+	err := queries.ValidateResourceIndexParams(params, indexedAnnotations)
+	if err != nil {
+		return ret, err
+	}
+	return ret, nil
+}
+
+// Items are sorted by key, so the continue token is just the encoded key of the last item returned.
+// Returns the range [start,end) of items for this page and the token for the next page.
+func paginate(count int, keyAt func(int) string, req apiListRequest) (int, int, string, error) {
+	start := 0
+	if req.Continue != "" {
+		lastKey, err := base64.RawURLEncoding.DecodeString(req.Continue)
+		if err != nil {
+			return 0, 0, "", fmt.Errorf("invalid %v token", apiContinueParam)
+		}
+		for start < count && keyAt(start) <= string(lastKey) {
+			start++
+		}
+	}
+	end := start + req.Limit
+	if end >= count {
+		return start, count, "", nil
+	}
+	return start, end, base64.RawURLEncoding.EncodeToString([]byte(keyAt(end - 1))), nil
+}
+
+// Applies the configured default lookback when a client does not specify a time range
+func apiTimeRange(params url.Values, tables typed.Tables, config WebConfig) (time.Time, time.Time, error) {
+	if params.Get(queries.LookbackParam) == "" && params.Get(queries.StartTimeParam) == "" && params.Get(queries.EndTimeParam) == "" {
+		params.Set(queries.LookbackParam, config.DefaultLookback)
+	}
+	return queries.ComputeTimeRange(params, tables, config.MaxLookback)
+}
+
+// Wraps an api handler with method checking and time range and paging parameter parsing
+func apiListHandler(config WebConfig, tables typed.Tables, kind string, list func(params url.Values, startTime time.Time, endTime time.Time, req apiListRequest, requestId string) (apiPage, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeApiError(w, r, http.StatusMethodNotAllowed, fmt.Errorf("method %v is not supported", r.Method))
+			return
+		}
+		params := r.URL.Query()
+		req, err := parseApiListRequest(params, tables.IndexedAnnotations())
+		if err != nil {
+			writeApiError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		req.Scope = namespaceScope(r)
+		startTime, endTime, err := apiTimeRange(params, tables, config)
+		if err != nil {
+			writeApiError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		page, err := list(params, startTime, endTime, req, getRequestId(r.Context()))
+		if err == nil && req.Scope.Err() != nil {
+			writeApiError(w, r, http.StatusServiceUnavailable, req.Scope.Err())
+			return
+		}
+		if err != nil {
+			if _, ok := err.(apiBadRequestError); ok {
+				writeApiError(w, r, http.StatusBadRequest, err)
+			} else {
+				writeApiError(w, r, http.StatusInternalServerError, err)
+			}
+			return
+		}
+		writeApiJson(w, http.StatusOK, apiListResponse{
+			ApiVersion: apiVersionV1,
+			Kind:       kind,
+			Metadata: apiListMetadata{
+				StartTime: startTime,
+				EndTime:   endTime,
+				Count:     page.Count,
+				Total:     page.Total,
+				Continue:  page.Continue,
+			},
+			Items: page.Items,
+		})
+	}
+}
+
+type apiBadRequestError struct {
+	msg string
+}
+
+func (e apiBadRequestError) Error() string {
+	return e.msg
+}
+
+func apiResourcesHandler(config WebConfig, tables typed.Tables) http.HandlerFunc {
+	return apiListHandler(config, tables, "ResourceList", func(params url.Values, startTime time.Time, endTime time.Time, req apiListRequest, requestId string) (apiPage, error) {
+		resources, err := queries.ApiListResources(params, tables, startTime, endTime, requestId, req.Scope)
+		if err != nil {
+			return apiPage{}, err
+		}
+		start, end, next, err := paginate(len(resources), func(i int) string { return resources[i].Key }, req)
+		if err != nil {
+			return apiPage{}, apiBadRequestError{err.Error()}
+		}
+		return apiPage{Items: resources[start:end], Count: end - start, Total: len(resources), Continue: next}, nil
+	})
+}
+
+func apiEventsHandler(config WebConfig, tables typed.Tables) http.HandlerFunc {
+	return apiListHandler(config, tables, "EventList", func(params url.Values, startTime time.Time, endTime time.Time, req apiListRequest, requestId string) (apiPage, error) {
+		events, err := queries.ApiListEvents(params, tables, startTime, endTime, requestId, req.Scope)
+		if err != nil {
+			return apiPage{}, err
+		}
+		start, end, next, err := paginate(len(events), func(i int) string { return events[i].Key }, req)
+		if err != nil {
+			return apiPage{}, apiBadRequestError{err.Error()}
+		}
+		return apiPage{Items: events[start:end], Count: end - start, Total: len(events), Continue: next}, nil
+	})
+}
+
+func apiHistoryHandler(config WebConfig, tables typed.Tables) http.HandlerFunc {
+	return apiListHandler(config, tables, "PayloadList", func(params url.Values, startTime time.Time, endTime time.Time, req apiListRequest, requestId string) (apiPage, error) {
+		if params.Get(queries.KindParam) == "" || params.Get(queries.NameParam) == "" {
+			return apiPage{}, apiBadRequestError{fmt.Sprintf("%v and %v are required", queries.KindParam, queries.NameParam)}
+		}
+		payloads, err := queries.ApiPayloadHistory(params, tables, startTime, endTime, requestId, req.Scope)
+		if err != nil {
+			return apiPage{}, err
+		}
+		start, end, next, err := paginate(len(payloads), func(i int) string { return payloads[i].Key }, req)
+		if err != nil {
+			return apiPage{}, apiBadRequestError{err.Error()}
+		}
+		return apiPage{Items: payloads[start:end], Count: end - start, Total: len(payloads), Continue: next}, nil
+	})
+}
+
+func apiDiffHandler(config WebConfig, tables typed.Tables) http.HandlerFunc {
+	return apiListHandler(config, tables, "DiffList", func(params url.Values, startTime time.Time, endTime time.Time, req apiListRequest, requestId string) (apiPage, error) {
+		if params.Get(queries.KindParam) == "" || params.Get(queries.NameParam) == "" {
+			return apiPage{}, apiBadRequestError{fmt.Sprintf("%v and %v are required", queries.KindParam, queries.NameParam)}
+		}
+		mode := params.Get(queries.DiffModeParam)
+		if mode != "" && mode != queries.DiffModeRange && mode != queries.DiffModeConsecutive {
+			return apiPage{}, apiBadRequestError{fmt.Sprintf("%v must be %v or %v", queries.DiffModeParam, queries.DiffModeRange, queries.DiffModeConsecutive)}
+		}
+		diffs, err := queries.GetResDiffList(params, tables, startTime, endTime, requestId, req.Scope)
+		if err != nil {
+			return apiPage{}, err
+		}
+		start, end, next, err := paginate(len(diffs), func(i int) string { return diffs[i].ToKey }, req)
+		if err != nil {
+			return apiPage{}, apiBadRequestError{err.Error()}
+		}
+		return apiPage{Items: diffs[start:end], Count: end - start, Total: len(diffs), Continue: next}, nil
+	})
+}
+
+// The snapshot is taken at the end of the time range, and the time range is the window searched for live objects.
+// With format=yaml the whole snapshot is returned as a multi-document yaml dump instead of a page of json.
+func apiSnapshotHandler(config WebConfig, tables typed.Tables) http.HandlerFunc {
+	jsonHandler := apiListHandler(config, tables, "Snapshot", func(params url.Values, startTime time.Time, endTime time.Time, req apiListRequest, requestId string) (apiPage, error) {
+		objects, err := queries.GetSnapshotList(params, tables, startTime, endTime, requestId, req.Scope)
+		if err != nil {
+			return apiPage{}, err
+		}
+		start, end, next, err := paginate(len(objects), func(i int) string { return snapshotObjectKey(objects[i]) }, req)
+		if err != nil {
+			return apiPage{}, apiBadRequestError{err.Error()}
+		}
+		return apiPage{Items: objects[start:end], Count: end - start, Total: len(objects), Continue: next}, nil
+	})
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := r.URL.Query()
+		if params.Get(queries.FormatParam) != apiFormatYaml {
+			jsonHandler(w, r)
+			return
+		}
+		if r.Method != http.MethodGet {
+			writeApiError(w, r, http.StatusMethodNotAllowed, fmt.Errorf("method %v is not supported", r.Method))
+			return
+		}
+		startTime, endTime, err := apiTimeRange(params, tables, config)
+		if err != nil {
+			writeApiError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		scope := namespaceScope(r)
+		objects, err := queries.GetSnapshotList(params, tables, startTime, endTime, getRequestId(r.Context()), scope)
+		if err != nil {
+			writeApiError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		if scope.Err() != nil {
+			writeApiError(w, r, http.StatusServiceUnavailable, scope.Err())
+			return
+		}
+		data, err := queries.SnapshotToYaml(objects)
+		if err != nil {
+			writeApiError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		w.Header().Set("content-type", apiContentTypeYaml)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=sloop-snapshot-%d.yaml", endTime.Unix()))
+		w.Write(data)
+	}
+}
+
+// Streams the rows of a table in the time range as a file to download, json lines by default or parquet with
+// format=parquet.  Exports are not paged, and an error after the first row can only be logged and end the response.
+func apiExportHandler(config WebConfig, tables typed.Tables) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeApiError(w, r, http.StatusMethodNotAllowed, fmt.Errorf("method %v is not supported", r.Method))
+			return
+		}
+		params := r.URL.Query()
+		format := params.Get(queries.FormatParam)
+		if format == "" {
+			format = export.FormatJsonLines
+		}
+		if format != export.FormatJsonLines && format != export.FormatParquet {
+			writeApiError(w, r, http.StatusBadRequest, fmt.Errorf("%v must be %v or %v", queries.FormatParam, export.FormatJsonLines, export.FormatParquet))
+			return
+		}
+		columns, err := queries.ExportColumns(params, tables.IndexedAnnotations())
+		if err != nil {
+			writeApiError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		startTime, endTime, err := apiTimeRange(params, tables, config)
+		if err != nil {
+			writeApiError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		table := params.Get(queries.TableParam)
+		if table == "" {
+			table = queries.ExportWatchTable
+		}
+		w.Header().Set("content-type", export.ContentType(format))
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=sloop-%v-%d-%d.%v", table, startTime.Unix(), endTime.Unix(), format))
+		scope := namespaceScope(r)
+		writer, err := export.NewWriter(format, w, columns)
+		if err == nil {
+			_, err = queries.Export(params, tables, startTime, endTime, getRequestId(r.Context()), scope, writer)
+		}
+		if err == nil {
+			// Rows the caller can see may be missing, so the file is left without its footer
+			err = scope.Err()
+		}
+		if err == nil {
+			err = writer.Close()
+		}
+		if err != nil {
+			glog.Errorf("Export of %v failed: %v", table, err)
+		}
+	}
+}
+
+// Snapshots are sorted by kind, namespace, name and uid.  The uid keeps a recreated object from sharing a page
+// token with the one it replaced
+func snapshotObjectKey(obj queries.SnapshotObject) string {
+	return "/" + obj.Kind + "/" + obj.Namespace + "/" + obj.Name + "/" + obj.Uid
+}
+
+func apiSummaryHandler(config WebConfig, tables typed.Tables) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeApiError(w, r, http.StatusMethodNotAllowed, fmt.Errorf("method %v is not supported", r.Method))
+			return
+		}
+		params := r.URL.Query()
+		startTime, endTime, err := apiTimeRange(params, tables, config)
+		if err != nil {
+			writeApiError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		scope := namespaceScope(r)
+		summary, err := queries.ApiGetSummary(params, tables, startTime, endTime, getRequestId(r.Context()), scope)
+		if err != nil {
+			writeApiError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		if scope.Err() != nil {
+			writeApiError(w, r, http.StatusServiceUnavailable, scope.Err())
+			return
+		}
+		writeApiJson(w, http.StatusOK, apiObjectResponse{
+			ApiVersion: apiVersionV1,
+			Kind:       "Summary",
+			Metadata:   apiListMetadata{StartTime: startTime, EndTime: endTime, Count: 1, Total: 1},
+			Data:       summary,
+		})
+	}
+}
+
+func apiAlertsHandler(config WebConfig, tables typed.Tables) http.HandlerFunc {
+	return apiListHandler(config, tables, "AlertList", func(params url.Values, startTime time.Time, endTime time.Time, req apiListRequest, requestId string) (apiPage, error) {
+		alerts, err := queries.GetAlertList(params, tables, startTime, endTime, requestId, req.Scope)
+		if err != nil {
+			return apiPage{}, err
+		}
+		start, end, next, err := paginate(len(alerts), func(i int) string { return alerts[i].Key }, req)
+		if err != nil {
+			return apiPage{}, apiBadRequestError{err.Error()}
+		}
+		return apiPage{Items: alerts[start:end], Count: end - start, Total: len(alerts), Continue: next}, nil
+	})
+}
+
+func apiOpenApiHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", apiContentTypeJson)
+		w.Write(apiV1OpenApiSpec)
+	}
+}
+
+func apiNotFoundHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeApiError(w, r, http.StatusNotFound, fmt.Errorf("no api endpoint at %v", r.URL.Path))
+	}
+}
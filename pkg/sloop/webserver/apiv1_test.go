@@ -0,0 +1,237 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	badger "github.com/dgraph-io/badger/v2"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+	"github.com/stretchr/testify/assert"
+)
+
+var someApiTs = time.Date(2019, 3, 4, 3, 4, 5, 0, time.UTC)
+
+func helper_apiTables(t *testing.T, podCount int) typed.Tables {
+	untyped.TestHookSetPartitionDuration(time.Hour)
+	ts, err := ptypes.TimestampProto(someApiTs)
+	assert.Nil(t, err)
+	val := &typed.ResourceSummary{FirstSeen: ts, LastSeen: ts, CreateTime: ts}
+
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+	table := typed.OpenResourceSummaryTable()
+	err = db.Update(func(txn badgerwrap.Txn) error {
+		for i := 0; i < podCount; i++ {
+			key := typed.NewResourceSummaryKey(someApiTs, "Pod", "someNamespace", fmt.Sprintf("pod-%02d", i), fmt.Sprintf("uid-%02d", i))
+			txerr := table.Set(txn, key.String(), val)
+			if txerr != nil {
+				return txerr
+			}
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	return typed.NewTableList(db)
+}
+
+func helper_apiGet(t *testing.T, handler http.HandlerFunc, url string) (int, map[string]interface{}) {
+	req, err := http.NewRequest("GET", url, nil)
+	assert.Nil(t, err)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, "application/json", rr.Header().Get("content-type"))
+	body := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	return rr.Code, body
+}
+
+func TestApiResourcesHandler_Pagination(t *testing.T) {
+	tables := helper_apiTables(t, 5)
+	config := WebConfig{DefaultLookback: "1h", MaxLookback: 24 * time.Hour}
+	handler := apiResourcesHandler(config, tables)
+	timeRange := fmt.Sprintf("start_time=%d&end_time=%d", someApiTs.Add(-time.Hour).Unix(), someApiTs.Add(time.Hour).Unix())
+
+	var names []string
+	next := ""
+	for page := 0; page < 3; page++ {
+		code, body := helper_apiGet(t, handler, "/ctx/api/v1/resources?limit=2&"+timeRange+"&continue="+next)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "ResourceList", body["kind"])
+		metadata := body["metadata"].(map[string]interface{})
+		assert.Equal(t, float64(5), metadata["total"])
+		for _, item := range body["items"].([]interface{}) {
+			names = append(names, item.(map[string]interface{})["name"].(string))
+		}
+		if page < 2 {
+			next = metadata["continue"].(string)
+		} else {
+			assert.Nil(t, metadata["continue"])
+		}
+	}
+	assert.Equal(t, []string{"pod-00", "pod-01", "pod-02", "pod-03", "pod-04"}, names)
+}
+
+func TestApiHandlers_Errors(t *testing.T) {
+	tables := helper_apiTables(t, 1)
+	config := WebConfig{DefaultLookback: "1h", MaxLookback: 24 * time.Hour}
+	testCases := map[string]struct {
+		handler http.HandlerFunc
+		url     string
+		code    int
+	}{
+		"bad limit": {
+			apiResourcesHandler(config, tables),
+			"/ctx/api/v1/resources?limit=abc",
+			http.StatusBadRequest,
+		},
+		"bad time range": {
+			apiEventsHandler(config, tables),
+			"/ctx/api/v1/events?start_time=1",
+			http.StatusBadRequest,
+		},
+		"bad label selector": {
+			apiResourcesHandler(config, tables),
+			"/ctx/api/v1/resources?selector=app%3D(web",
+			http.StatusBadRequest,
+		},
+		"bad continue token": {
+			apiResourcesHandler(config, tables),
+			"/ctx/api/v1/resources?continue=!!",
+			http.StatusBadRequest,
+		},
+		"history without name": {
+			apiHistoryHandler(config, tables),
+			"/ctx/api/v1/history?kind=Pod",
+			http.StatusBadRequest,
+		},
+		"unknown endpoint": {
+			apiNotFoundHandler(),
+			"/ctx/api/v2/resources",
+			http.StatusNotFound,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			code, body := helper_apiGet(t, tc.handler, tc.url)
+			assert.Equal(t, tc.code, code)
+			apiErr := body["error"].(map[string]interface{})
+			assert.Equal(t, float64(tc.code), apiErr["code"])
+			assert.NotEmpty(t, apiErr["message"])
+		})
+	}
+}
+
+func TestApiSummaryHandler(t *testing.T) {
+	tables := helper_apiTables(t, 3)
+	config := WebConfig{DefaultLookback: "1h", MaxLookback: 24 * time.Hour}
+	url := fmt.Sprintf("/ctx/api/v1/summary?start_time=%d&end_time=%d", someApiTs.Add(-time.Hour).Unix(), someApiTs.Add(time.Hour).Unix())
+	code, body := helper_apiGet(t, apiSummaryHandler(config, tables), url)
+	assert.Equal(t, http.StatusOK, code)
+	data := body["data"].(map[string]interface{})
+	assert.Equal(t, float64(3), data["resourceCount"])
+}
+
+func TestApiOpenApiHandler(t *testing.T) {
+	code, body := helper_apiGet(t, apiOpenApiHandler(), "/ctx/api/v1/openapi.json")
+	assert.Equal(t, http.StatusOK, code)
+	paths := body["paths"].(map[string]interface{})
+	for _, p := range []string{"/resources", "/events", "/history", "/summary"} {
+		assert.Contains(t, paths, p)
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	keys := []string{"/a", "/b", "/c"}
+	keyAt := func(i int) string { return keys[i] }
+
+	start, end, next, err := paginate(len(keys), keyAt, apiListRequest{Limit: 3})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, start)
+	assert.Equal(t, 3, end)
+	assert.Equal(t, "", next)
+
+	start, end, next, err = paginate(len(keys), keyAt, apiListRequest{Limit: 1, Continue: "L2E"})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, start)
+	assert.Equal(t, 2, end)
+	assert.Equal(t, "L2I", next)
+}
+
+func TestPaginate_RecreatedSnapshotObject(t *testing.T) {
+	// A deleted pod and the pod which replaced it under the same name are both live in the snapshot window
+	objects := []queries.SnapshotObject{
+		{Kind: "Pod", Namespace: "someNamespace", Name: "somePod", Uid: "uid-1"},
+		{Kind: "Pod", Namespace: "someNamespace", Name: "somePod", Uid: "uid-2"},
+	}
+	keyAt := func(i int) string { return snapshotObjectKey(objects[i]) }
+
+	start, end, next, err := paginate(len(objects), keyAt, apiListRequest{Limit: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, start)
+	assert.Equal(t, 1, end)
+
+	start, end, _, err = paginate(len(objects), keyAt, apiListRequest{Limit: 1, Continue: next})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, start)
+	assert.Equal(t, 2, end)
+}
+
+func TestApiSnapshotHandler_Yaml(t *testing.T) {
+	tables := helper_apiTables(t, 0)
+	config := WebConfig{DefaultLookback: "1h", MaxLookback: 24 * time.Hour}
+	req, err := http.NewRequest("GET", "/ctx/api/v1/snapshot?format=yaml", nil)
+	assert.Nil(t, err)
+	rr := httptest.NewRecorder()
+	apiSnapshotHandler(config, tables).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/yaml", rr.Header().Get("content-type"))
+
+	code, body := helper_apiGet(t, apiSnapshotHandler(config, tables), "/ctx/api/v1/snapshot")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "Snapshot", body["kind"])
+}
+
+func TestApiExportHandler(t *testing.T) {
+	tables := helper_apiTables(t, 2)
+	config := WebConfig{DefaultLookback: "1h", MaxLookback: 24 * time.Hour}
+	url := fmt.Sprintf("/ctx/api/v1/export?table=ressum&start_time=%d&end_time=%d", someApiTs.Add(-time.Hour).Unix(), someApiTs.Add(time.Hour).Unix())
+	req, err := http.NewRequest("GET", url, nil)
+	assert.Nil(t, err)
+	rr := httptest.NewRecorder()
+	apiExportHandler(config, tables).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/x-ndjson", rr.Header().Get("content-type"))
+	lines := strings.Split(strings.TrimSpace(rr.Body.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"name":"pod-00"`)
+
+	req, err = http.NewRequest("GET", url+"&format=parquet", nil)
+	assert.Nil(t, err)
+	rr = httptest.NewRecorder()
+	apiExportHandler(config, tables).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.True(t, strings.HasPrefix(rr.Body.String(), "PAR1"))
+	assert.True(t, strings.HasSuffix(rr.Body.String(), "PAR1"))
+
+	code, _ := helper_apiGet(t, apiExportHandler(config, tables), "/ctx/api/v1/export?format=csv")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = helper_apiGet(t, apiExportHandler(config, tables), "/ctx/api/v1/export?table=alert")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -1,5 +1,6 @@
 // Code generated by go-bindata.
 // sources:
+// webfiles/alerts.html
 // webfiles/debug.html
 // webfiles/debug.js
 // webfiles/debugconfig.html
@@ -81,6 +82,26 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
+var _webfilesAlertsHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x85\x55\x6d\x8f\xda\x46\x10\xfe\xce\xaf\x98\x5a\x55\x01\x35\xb6\x0b\x17\x9d\xda\x8b\x71\x94\x70\x77\xca\x0b\xbd\x56\x47\xa4\x54\x8a\xa2\x6a\x6d\x0f\x78\xc3\xda\xeb\xec\xae\xb9\x43\xc8\xff\x3d\xb3\x6b\x20\x70\x80\x8e\x0f\x60\xe6\x99\x79\xe6\x7d\x1c\xfd\xe2\xfb\x9d\xb1\xac\x56\x8a\xcf\x73\x03\xbd\xb4\x0f\xc3\x3f\x06\x7f\xbd\x00\xcd\x04\xea\x99\x54\x29\x06\xa9\x2c\x5e\x00\x2f\xd3\xa0\xf3\x46\x08\x70\x8a\x1a\x14\x6a\x54\x4b\xcc\x82\xce\xf4\xdf\xeb\xff\xfc\x09\x4f\xb1\xd4\xe8\xbf\xcf\xb0\x34\x7c\xc6\x51\x5d\xc1\xdb\xe9\xb5\x7f\xe1\x8f\x05\xab\x35\x76\x6e\xa5\x82\x59\x4d\xf6\xa2\xd5\x04\x83\x8f\x86\xdc\x20\xc2\xe4\xfd\xf8\xe6\x6e\x7a\x13\x98\x47\x03\x33\x2e\x90\x7c\x81\xc9\x91\x5c\x54\x12\x94\x94\x06\xc8\x36\x37\xa6\xd2\x57\x61\x28\x2b\xb2\x96\xb5\x8d\x4b\xaa\x79\xb8\x61\xd3\xe1\x81\x33\xdf\x8f\x3b\x51\x6e\x0a\x61\x7f\x90\x65\x71\x07\xe8\x13\xe9\x54\xf1\xca\x80\x59\x55\x38\xf2\xac\xff\xf0\x1b\x5b\xb2\x56\xea\xb5\x3a\xf6\x93\xc9\xb4\x2e\x28\x8d\xe0\x41\x71\x83\x3d\x2f\x4a\x18\xc5\x9b\x2b\x9c\x8d\xba\xa1\x07\xbf\xc3\x03\x2f\x33\xf9\x10\x08\x99\x32\xc3\x65\x19\x54\xcc\xe4\x25\x2b\x30\xd0\x95\xe0\xa6\xd7\x0d\xbb\xfd\x2f\x83\xaf\xa4\xe8\x85\x5d\x08\x63\xaf\xff\xaa\xf5\x1f\xb6\xae\x0e\xa3\xd1\x2a\x1d\x79\x0f\x98\xd8\xcc\x75\x98\x61\x52\xcf\x83\x6f\xda\x8b\x9f\x68\x1b\x6e\x04\xc6\x53\x21\x65\x05\x6f\x04\x2a\xa3\xd7\x6b\x3e\x83\x5e\x89\x10\x8c\x6b\xa5\x28\xde\xb1\x2c\x6d\x52\xe0\x79\xfd\xa6\x01\x1f\xd6\xeb\x27\x48\xd3\xac\xd7\x58\x66\x4d\x13\x85\x2d\x5d\x4b\x2d\x78\xb9\xa0\x62\x8b\x51\x57\xe7\x52\x99\xb4\x36\xc0\x53\x59\x76\xdb\x42\x75\x79\xc1\xe6\x18\x3e\xfa\xad\xac\x2d\xc3\x2e\xde\x19\x5b\x5a\x79\x40\x5f\x36\xd5\x4e\x14\xb6\xf5\x8e\x12\x99\xad\x40\x96\x42\xb2\x6c\xe4\xd9\xef\x77\xb2\xc0\x7b\x9c\xf5\xfa\xaf\xa8\xd4\x5f\x20\x62\xc0\x09\xc9\x49\x3a\x21\xff\x5e\x6c\xf1\x28\x64\x31\x7c\x75\xa0\xf3\xe3\xb1\x8a\x87\xcb\x41\xc8\x5c\xc2\xaf\x29\xf9\x45\xc2\xd2\xc5\x88\x32\x9b\x6c\x9e\x9b\xc6\x8b\x3f\x4c\xff\xb9\x6b\x4d\xa3\x44\x51\x10\xd4\xf4\x61\xdc\x16\x89\xe2\x19\x5a\x01\x4d\x72\x01\x2c\xb5\xed\x22\x56\x07\x79\x50\xa0\xc9\x25\x05\x31\xc7\x6d\xfb\x23\xc1\x12\x14\x40\xda\x36\xe8\xd6\x83\x17\x5b\x5f\xf0\x96\x1e\xe1\x9e\x95\x73\xbc\x8a\x42\xa7\xb6\xed\x23\x0a\x4c\x0d\xd8\x01\xd8\x33\x72\xd9\xfd\xfc\x27\xcb\x34\xb7\xb6\x34\x77\x39\xd7\x81\x0d\x27\xd0\x75\x52\xd0\xb8\xf4\xf7\x46\x2f\x92\x95\x0d\x11\x96\x4c\xd4\xa4\x3b\xc8\x3d\x70\x6d\xc6\xef\xb0\xcb\x18\xac\xb8\x69\x5a\xb7\x98\x6d\x5a\x1a\x0f\xe0\x1d\xad\x45\x14\xb6\x0c\x67\x29\x2f\x4f\x53\x5e\x9e\xa2\xbc\x74\x94\xfa\x59\xce\xe1\xcb\xd3\xa4\x56\x7e\x2a\xd0\x6b\xb6\x7a\x96\x73\x70\xf9\xe7\x99\xe4\x2d\x70\x8a\xf5\x33\xe2\xe2\x59\xda\x8b\x8b\x33\x05\x70\xc0\x31\xed\xd0\xd1\x3e\x29\x01\xad\xa6\x53\xb3\xe3\x6e\x3b\x19\x77\x6e\x95\x2c\xec\xbe\x4d\x0d\x53\xe6\x13\x2f\x90\x16\xd0\x48\x2b\xb9\x29\xb3\xf6\xbf\x1b\xcd\xed\x7c\x1a\x96\xd0\x9d\x4b\xa4\xca\x90\x26\x6d\xb0\x9d\x3e\xa3\xf6\x02\x37\x79\x3c\x61\xda\xc0\x2d\x57\x98\xd1\xc6\xe6\x87\x18\x89\xcf\x83\x63\x59\x97\xe6\x58\x3c\xc5\x25\xd2\x4d\x5b\x1d\x23\xf7\xb5\xc0\x63\xe9\x47\x3a\x74\xc7\xd2\x3b\x9a\x74\x5d\xb1\xf4\x84\x81\x85\x8e\xa5\x7f\xa3\xd6\x74\x43\x7e\x02\xf4\x44\x99\xae\xd7\xca\xae\x04\x04\xed\xaa\x36\xcd\xa9\x22\x64\xb1\x5d\x76\xaa\x83\xcb\xd4\x9d\xae\xec\x08\x77\xb5\x78\x46\x01\x5d\x4d\xce\xe0\xdb\xc2\x9c\x81\x6d\x75\x6c\x6a\x67\x60\x5b\xa6\x33\xd0\xae\x56\xa7\xf0\xdd\x99\x7b\xbd\x20\x86\xd1\x8e\xe9\xb7\x72\x6b\x35\x72\x93\xba\xcf\x72\xc8\x49\x63\x2a\x34\xfd\xfe\xcf\x84\xd8\x8c\xac\x33\x2e\x98\x49\xf3\xd1\x46\x97\x64\x7b\x97\xf3\xd7\x83\xd3\xb9\x53\xb1\xf7\xf3\x64\x06\x9b\xe6\xed\xc7\xbf\x6d\x9f\xf3\x47\x3b\xe0\xa6\xd9\x2e\x83\x3d\xfa\xee\x1d\xe0\x5e\xbd\x3f\x00\xa4\xf7\x53\x81\x5c\x08\x00\x00")
+
+func webfilesAlertsHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_webfilesAlertsHtml,
+		"webfiles/alerts.html",
+	)
+}
+
+func webfilesAlertsHtml() (*asset, error) {
+	bytes, err := webfilesAlertsHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "webfiles/alerts.html", size: 2140, mode: os.FileMode(420), modTime: time.Unix(1792203840, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _webfilesDebugHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x54\xcd\x6e\xe3\x36\x10\xbe\xeb\x29\xa6\xbc\xd8\xc6\x46\x62\x37\x3d\x75\x57\x16\xb0\x6b\xa7\xd8\xa0\x4e\xd1\xc6\x45\x51\x20\xc8\x81\xa6\x46\x16\x13\x8a\xa3\x90\x23\x3b\x7e\xfb\x82\xa2\xf3\xdb\xd6\xeb\x83\x45\x0d\xbf\xbf\x21\x07\x2a\x7f\xc8\xf3\x6c\x41\xfd\xc1\x9b\x6d\xcb\x30\xd5\x33\x38\xff\xf1\xe3\xcf\x67\x10\x94\xc5\xd0\x90\xd7\x58\x68\xea\xce\xc0\x38\x5d\x64\x5f\xac\x85\x11\x18\xc0\x63\x40\xbf\xc3\xba\xc8\xd6\xbf\x2f\xff\xce\x57\x46\xa3\x0b\x98\x5f\xd6\xe8\xd8\x34\x06\xfd\x27\xf8\xba\x5e\xe6\x3f\xe5\x0b\xab\x86\x80\xd9\x2f\xe4\xa1\x19\xac\x05\x9b\x90\xc0\xf8\xc8\x67\x10\x10\x61\x75\xb9\xb8\xf8\x6d\x7d\x51\xf0\x23\x43\x63\x2c\x82\x71\xc0\x2d\x82\xc7\x9e\xc0\x13\x31\x90\x87\x96\xb9\x0f\x9f\xa4\xa4\x1e\x5d\xa0\x21\xe6\x22\xbf\x95\x47\xb5\x20\xdf\x98\xe5\x79\x95\x95\x2d\x77\x36\x3e\x50\xd5\x55\x06\x00\x50\x06\xed\x4d\xcf\xc0\x87\x1e\xe7\x22\xfa\xcb\x3b\xb5\x53\xa9\x2a\x12\x26\xfe\x6a\xd2\x43\x87\x8e\x8b\xbd\x37\x8c\x53\x51\x6e\x54\x40\x68\x3d\x36\xf3\x89\x14\xf0\x01\xf6\xc6\xd5\xb4\x2f\x2c\x69\xc5\x86\x5c\xd1\x2b\x6e\x9d\xea\xb0\x08\xbd\x35\x3c\x9d\xc8\xc9\xec\xe6\xe3\x2d\x7c\x00\x21\x27\x20\x2b\x31\xfb\x9c\xfc\x65\xb2\x7a\x9b\x26\x78\x3d\x17\x7b\xdc\xc4\xce\x83\xac\x71\x33\x6c\x8b\xbb\x20\xaa\x77\x68\x36\x6c\xb1\x5a\x5b\xa2\x1e\x96\x11\x04\x57\xe8\x86\x52\xa6\x7a\xc2\x58\xe3\xee\xc1\xa3\x9d\x4f\x42\x4b\x9e\xf5\xc0\x60\x34\xb9\x49\xea\x78\x62\x3a\xb5\x45\xf9\x98\xa7\x5a\xea\xe7\xd9\xb8\x51\xbb\x58\x2f\x8c\xa6\x98\x39\x2b\x65\x3a\xb8\x72\x43\xf5\x01\xc8\x59\x52\xf5\x5c\xc4\xff\x6f\xd4\xe1\x35\x36\xd3\xd9\x67\x51\x41\x76\x03\xa5\x02\x53\xcf\x45\x4b\x1d\xae\x8c\xbb\x17\x55\x04\x94\x52\x55\x70\x9b\x65\x65\x7b\xfe\x1f\xa1\xdb\xf3\x2a\xcb\xca\xc1\x3e\xe7\xae\x4a\x95\x02\x89\xf1\x00\xa4\x35\x81\xef\xf1\x10\xa4\xa8\xfe\x18\xd0\x1f\x60\xa9\x58\xc1\x9a\xc9\x27\xe5\x1c\xbe\x58\x4b\xfb\x00\x07\x1a\x80\x09\x1e\x46\x50\x64\x80\x72\x35\xec\x94\x1d\x30\x40\xe3\xa9\x1b\x27\x69\xa3\xea\x2d\x7a\x08\x89\x6f\xcd\xff\xfa\xb6\x26\x30\x6d\xbd\xea\xa4\x38\xc6\xfe\x35\x6a\x7e\x7b\x2a\x1f\xcd\xff\x32\xb8\x1f\x85\x47\xc7\xf6\x65\xf7\x84\xb4\x26\xd7\x98\xad\x14\xd5\x62\x5c\xbc\x57\xd2\x83\xf7\xe8\x18\x94\x66\xb3\x43\x48\x68\x68\xc8\xc3\x98\xe3\xa4\x34\xab\x4d\xbc\x42\x51\xfd\x39\x2e\x5e\x4b\x7f\x4d\x9d\xaf\xd6\x57\x30\x6e\xc2\xa5\x6b\xe8\xa4\x98\xc7\x87\x01\x03\x07\x51\x1d\xb9\xd7\xc7\x42\x94\x3d\xc9\xc4\x1d\xba\x57\xbc\x8b\xf1\xf5\xbb\xac\x9d\xf2\x2f\x9c\x2b\x64\x6f\xf4\x29\x52\x97\x10\x4f\xd7\xf3\x2f\x42\x29\xe3\x58\x65\xa5\x8c\x73\x3b\x8e\x71\xfc\x0c\xfc\x13\x00\x00\xff\xff\x18\xd3\x24\xd6\xe7\x04\x00\x00")
 
 func webfilesDebugHtmlBytes() ([]byte, error) {
@@ -161,7 +182,7 @@ func webfilesDebughistogramHtml() (*asset, error) {
 	return a, nil
 }
 
-var _webfilesDebuglistkeysHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x57\x7f\x6f\xdb\x38\x12\xfd\xdf\x9f\x62\x8e\x28\x60\xfb\x6a\x9b\xb1\x73\x17\xdc\xb9\x34\x17\x9b\xa4\x45\x8b\xa6\xdd\xdd\x24\xc0\x2e\x50\x14\x0b\x5a\x1a\x5b\xac\x69\x51\x4b\x52\xfe\xb1\x41\xbe\xfb\x82\xa4\x2c\xcb\x89\x93\xb4\x01\x12\x51\xd4\x9b\x37\x6f\x86\x43\x0e\xc3\xfe\xd5\xef\xb7\x2e\x74\xb1\x35\x72\x9e\x39\xe8\x24\x5d\x18\x9d\x0c\xff\xdf\x03\x2b\x14\xda\x99\x36\x09\x0e\x12\xbd\xec\x81\xcc\x93\x41\xeb\x67\xa5\x20\x00\x2d\x18\xb4\x68\x56\x98\x0e\x5a\x37\xbf\x5e\xfe\xd1\xbf\x92\x09\xe6\x16\xfb\x1f\x52\xcc\x9d\x9c\x49\x34\x63\x38\xbf\xb9\xec\x9f\xf6\x2f\x94\x28\x2d\xb6\xde\x69\x03\xb3\x52\x29\x50\x11\x09\x0e\x37\xae\x07\x16\x11\xae\x3e\x5c\xbc\xfd\x7c\xf3\x76\xe0\x36\x0e\x66\x52\x21\xc8\x1c\x5c\x86\x60\xb0\xd0\x60\xb4\x76\xa0\x0d\x64\xce\x15\x76\x4c\xa9\x2e\x30\xb7\xba\xf4\xba\xb4\x99\xd3\x8a\xcd\xd2\x03\x67\xfd\x3e\x6f\xb1\xcc\x2d\x95\x7f\xa0\x48\x79\x0b\x00\x80\xd9\xc4\xc8\xc2\x81\xdb\x16\x38\x21\xde\x3f\xfd\x26\x56\x22\xce\x92\x88\xf1\x3f\xa9\x4e\xca\x25\xe6\x6e\xb0\x36\xd2\x61\x87\xb0\xa9\xb0\x08\x99\xc1\xd9\xa4\x4d\x09\xbc\x86\xb5\xcc\x53\xbd\x1e\x28\x9d\x08\x27\x75\x3e\x28\x84\xcb\x72\xb1\xc4\x81\x2d\x94\x74\x9d\x36\x6d\x77\xbf\x0c\xbf\xc2\x6b\x20\xb4\x0d\x94\x93\xee\x9b\xe8\x9f\x46\x57\x87\x6a\xac\x49\x26\x64\x8d\x53\x1f\xb9\xa5\x29\x4e\xcb\xf9\xe0\x9b\x25\xfc\x7b\xd0\x56\x69\x5d\xfc\x59\xca\x63\x06\x4e\x3a\x85\xfc\xc6\x23\xe0\xd2\xb3\xc2\x6f\x25\x9a\x2d\x9c\x8b\x74\x8e\x86\xd1\xf8\x3d\x62\x95\xcc\x17\x60\x50\x4d\xda\x36\xd3\xc6\x25\xa5\x03\x99\xe8\xbc\x1d\x53\xd5\x96\x4b\x31\x47\xba\xe9\xc7\xb9\x98\x88\x5a\xc3\x4c\xac\xfc\xfc\x40\x26\xda\x07\xdb\x62\x34\x66\x9c\x4d\x75\xba\x05\x9d\x2b\x2d\xd2\x09\xf1\x7f\xdf\xeb\x25\x5e\xe3\xac\xd3\x7d\x43\x38\xb4\xbe\x00\x13\x20\xd3\x09\xc9\xf4\x12\xaf\x64\xbe\x20\xdc\x03\x18\x15\x1c\xbe\x86\x8f\xc1\x11\x09\x19\xa1\x84\xc7\x18\x3e\x61\x5e\x46\x08\x9b\x1a\xca\x5b\x2d\x96\x8d\x78\x0c\x2c\x84\xda\xb6\x55\x80\x70\x79\x0e\x97\xd2\x60\xe2\xd4\x96\xd1\x6c\xe4\xa1\x4e\x4c\x15\xc2\x74\x9e\x68\xa5\xcd\x84\x58\xa9\x56\x68\x08\xac\x65\xea\xb2\x09\xf9\xef\xc9\x49\xb1\x21\x9c\x39\xc3\x99\x4b\xc1\xba\xad\xc2\x09\x29\x44\x9a\xca\x7c\x3e\x86\x51\xf8\xda\x62\x33\x6d\x96\x20\x12\xbf\xf0\x3b\x71\x4a\x5a\xb7\xc0\xad\xa5\x04\x96\xe8\x32\x9d\x4e\xc8\x1c\x77\x15\xc5\x94\x98\xa2\x82\x99\xf7\x18\x04\x10\x7e\x1b\x74\x7c\x16\x4b\x1c\x33\x1a\x3e\xf3\x18\x4d\x5c\x69\x54\x98\x38\xf0\x05\xb5\xb3\x08\x79\xaa\x8c\xeb\x32\x65\xba\xf0\x22\x60\x25\x54\x89\x13\xb2\x16\x2e\xc9\x08\x0f\x0f\x46\xe3\xb7\x27\xc1\x06\xad\x2d\x97\x84\xc7\xe7\x8b\x70\x5c\x61\xee\x12\x5d\xe6\x8e\xf0\xfd\xf8\x45\xb3\xa0\xc5\xa7\x6a\x25\xdd\xb6\x92\xb6\x7b\x7d\xd1\x58\xe6\x0e\x4d\x2e\x14\xe1\xbb\xd1\x8b\x26\x42\x29\xc2\x85\x7a\x00\x64\x34\x66\xd4\xe7\x38\xfc\xb6\xe2\xb4\xcc\x8b\x72\x77\x18\x18\x91\x4a\x1d\xd3\x6c\x70\x8e\x1b\x52\xa5\xdf\xa2\x30\x49\xf6\x4b\x60\x23\xfb\xe4\x05\x84\xce\x93\x4c\xe4\x73\x9c\x90\xbf\x7c\xfd\x5d\x84\x97\x8e\xcb\xa4\xed\x12\x48\x32\x4c\x16\x98\x3e\x2e\x81\x68\x5c\x95\xec\x74\x0b\xd7\xfe\x7d\x57\x05\xcf\x0a\x2b\x84\x71\x32\x0a\x79\x46\x5c\x03\xf5\x9c\xc0\xc7\xc2\xf6\x86\x7b\x71\xb7\xd2\x6f\xc8\xba\x42\x9b\xd9\x4b\xe5\x0a\x12\x25\xac\xad\x43\xda\xaf\x4a\x83\x75\x81\xdb\x65\x2c\xcc\x8f\x18\x82\x7d\xbb\x81\x77\x52\x39\x34\xcd\xd2\x6f\xd8\x36\x83\xf7\x47\xf4\x2e\xd8\x9a\x28\xe4\x62\x4f\x5b\xcb\x8a\x4b\x9d\xca\xd5\x11\x85\x8d\xa4\x54\xdb\x3a\x95\xb6\x50\x62\x3b\xce\x75\x8e\x4f\x48\x57\x5a\x2f\xa6\x22\x59\x10\x7e\xa5\xf5\x02\xce\x45\xb2\x80\x6b\x9f\xc2\x23\x9b\xf6\xf1\xc6\xad\xad\x83\xde\x3d\x57\x0d\x3f\x52\xbf\x43\xc2\x87\xf0\x5e\x97\xe6\x71\xa5\x1f\x41\x9f\x12\x7e\x1a\xd0\xf6\xbb\xe0\x67\x84\x9f\xfd\x00\x7c\x38\x22\x7c\x38\xfa\x01\x83\xd1\x7f\xbc\xfa\x4b\x71\x64\x67\x1f\xa3\x3f\xfb\x9f\x87\xff\x8e\xb8\xf8\xbe\x60\x4f\xcf\x08\x1f\x05\xfc\x11\x39\x4f\x6c\xf1\x87\x2b\x5a\x1a\xd5\x28\xc6\x9b\xb0\x7d\xc6\x77\x1f\x65\x9e\x52\x7f\x18\xdb\x42\x24\x18\x46\xf7\xf0\xc4\x12\x3f\x55\x9d\x35\x73\x58\xed\xbd\x9f\xa7\xab\xb3\x21\x6b\x29\x36\x46\xaf\x2d\xe1\x9f\xc4\x06\xae\xf5\xda\x3e\xde\x1a\x4f\x3a\xde\xd9\x06\xbf\x35\xd1\x33\x27\x9d\x2d\xa7\x4b\xe9\x7b\x13\xa3\xbe\x93\xf9\xa7\x4b\x39\xa3\xbe\xeb\xd1\xd0\x62\x7c\xeb\x36\x94\xef\xfa\x6b\xd5\x34\xb5\x49\xd1\x84\x12\xad\xae\x17\xa1\x4b\xf2\x5b\xed\x84\x82\x8f\xb8\xb5\xf0\xc9\x87\x8c\x69\xe4\x73\x29\xbf\xbb\x1b\xf8\xf9\x6a\xfa\xfe\x7e\xef\xe8\x08\xc3\x8d\xfc\x1b\x41\xcf\x76\x24\x81\xb1\x66\x62\x85\x41\x4f\x17\xa0\x1e\xe9\xc9\xfc\xdc\xb3\x94\x41\x54\x5c\xe4\x86\xaa\x03\x2e\x0f\x39\xc2\x75\x24\x11\x31\x1b\x6c\x1a\x2a\xe7\x4a\x5a\xc7\xe8\x94\x8f\xab\x59\x5d\x9d\xdc\x77\x77\xc6\x9f\x0f\xf0\x6a\x81\xdb\x1e\xbc\x0a\xa5\x0b\xe3\x09\x0c\xa2\x9f\x46\x4d\x4a\xbe\xbb\xdf\xb4\xe3\x15\x62\x25\x71\xfd\xd3\x62\x72\x77\x37\xb8\xbf\x6f\xf3\xf0\xf0\xd7\x9c\x8a\x16\xf3\xf4\xfe\xbe\xc5\xa8\x77\xc4\xa8\xbf\x58\xf9\x95\x39\x7a\x25\x9c\x85\xc3\xf5\xc1\x85\x90\x35\x6f\x86\x16\xdd\x2d\x6e\x5c\xa7\x2e\x97\x1e\x34\x87\xc3\x93\x93\x13\xd2\xdd\x21\x2f\x8d\x2e\x52\xbd\xce\x3b\xd5\xf5\xa3\x07\xfb\x41\xbc\x71\x74\x0f\x49\xeb\x93\xb9\x07\x07\xe3\xc1\xbf\x8f\x91\xd6\xe7\x62\x0f\x0e\xc6\xc3\x87\xb4\xf5\x96\xea\xc1\xc1\x98\xee\x81\xd7\xbe\x55\x76\x0e\xbb\x62\x0f\x1e\xbd\xc7\x6e\xd5\x6d\x35\xb2\x43\xe3\xbf\x0a\xff\x04\x00\x00\xff\xff\xb5\xd8\x81\x2e\x0c\x0d\x00\x00")
+var _webfilesDebuglistkeysHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x95\x57\x6d\x6f\xdb\x36\x10\xfe\xee\x5f\xc1\x09\x05\x6c\x6f\xb1\x14\x3b\x5b\xb1\xb9\xb2\x86\x35\x6e\xd1\xa2\x69\xb7\x25\x01\x36\xa0\x28\x06\x4a\x3a\x5b\xac\x69\x51\x23\x29\xbf\x2c\xc8\x7f\xdf\x91\x94\x64\x25\x91\x93\xd4\x80\x23\x9a\x7a\x78\xf7\xdc\x2b\x2f\xe1\x77\xa3\x51\xef\x5c\x14\x7b\xc9\x96\x99\x26\x83\x64\x48\x26\xa7\xe3\x5f\x4e\x88\xa2\x1c\xd4\x42\xc8\x04\xfc\x44\xac\x4f\x08\xcb\x13\xbf\xf7\x1b\xe7\xc4\x02\x15\x91\xa0\x40\x6e\x20\xf5\x7b\x57\x7f\xcc\xff\x1e\x5d\xb0\x04\x72\x05\xa3\xf7\x29\xe4\x9a\x2d\x18\xc8\x29\x79\x7d\x35\x1f\x9d\x8d\xce\x39\x2d\x15\xf4\xde\x0a\x49\x16\x25\x9e\xe7\x0e\x49\x34\xec\x34\xaa\x01\x20\x17\xef\xcf\xdf\x7c\xba\x7a\xe3\xeb\x9d\x26\x0b\xc6\x01\x75\x11\x9d\x01\xaa\x28\x04\x91\x42\x68\x82\x67\x33\xad\x0b\x35\x0d\x02\x51\xe0\x69\x51\x1a\x5e\x42\x2e\x83\x4a\x9a\x0a\xee\x28\x1b\x8d\xa2\x5e\x98\xe9\x35\x37\x0f\xa0\x69\xd4\x23\xf8\x09\x55\x22\x59\xa1\x89\xde\x17\x30\xf3\x8c\xfe\xe0\x2b\xdd\x50\xb7\xeb\x39\x8c\xf9\xa4\x22\x29\xd7\x68\x86\xbf\x95\x4c\xc3\xc0\x0b\x63\x8a\x7c\x33\x09\x8b\x59\x3f\xf0\xc8\x0f\x64\xcb\xf2\x54\x6c\x7d\x2e\x12\xaa\x99\xc8\xfd\x82\xea\x2c\xa7\x6b\xf0\x55\xc1\x99\x1e\xf4\x83\xfe\xf0\xf3\xf8\x0b\x02\xbd\xa0\x4f\x82\xc8\x1b\xbe\x72\xfa\x03\xa7\xea\x2e\x1b\x25\x93\x99\xb7\x85\xd8\x58\xae\x82\x14\xe2\x72\xe9\x7f\x55\x5e\xf4\x1c\xb4\xe2\x42\x14\xff\x94\xac\xeb\x80\x66\x9a\x43\x74\x65\x10\x64\x6e\xa4\x92\x3f\x4b\x90\x7b\xf2\x9a\xa6\x4b\x90\x61\xe0\xde\x3b\x2c\x67\xf9\x0a\xdd\xcd\x67\x7d\x95\x09\xa9\x93\x52\x13\x96\x88\xbc\xef\x5c\xd5\x67\x6b\xba\x84\x60\x37\x72\x7b\xce\x11\x0d\x87\x05\xdd\x98\x7d\x1f\xff\x18\x63\x7b\x61\xe0\x3c\x1e\xc6\x22\xdd\x13\x91\x73\x41\xd3\x99\x67\xfe\xbe\x13\x6b\xb8\x84\xc5\x60\xf8\xca\x8b\x48\xef\x33\x09\x29\x61\xf8\x2a\xc3\xed\x0b\x24\xe0\x45\x06\x10\x06\x34\x22\x5f\xec\x4b\xab\xc8\xb3\x1e\x09\xbc\xc8\xd9\xf0\x11\xf2\xd2\x41\xc2\x58\xa2\x36\x8c\xef\x24\x72\x86\x59\x53\xfb\xaa\x32\x90\xcc\x5f\x93\x39\x93\x90\x68\xbe\x47\x4a\x13\x03\xd5\x34\xc6\xec\x8a\x97\x89\xe0\x42\xce\x3c\xc5\xf8\x06\xa4\x87\xe1\x4c\x75\x36\xf3\x7e\x3a\x3d\x2d\x76\xe8\x46\x2d\xf1\x9b\x12\xa5\xf7\x1c\xd3\xa4\xa0\x69\xca\xf2\xe5\x14\xcb\xc2\xbc\xed\x85\x58\x13\x6b\x42\x13\x13\xf8\x9a\x1c\x67\x4a\xaf\x60\xaf\x30\x39\xd6\xa0\x33\x81\x46\x2d\xa1\xce\xa8\x90\xd3\x18\x38\x59\x18\x8d\x96\x80\x17\x5d\x5b\x1e\x9f\x30\x63\xa6\x61\x60\x5f\x47\xce\x1a\x17\x69\xe0\xc8\x9a\x98\x84\xaa\x4f\x58\x3f\x55\x87\x9b\x34\x0d\x45\x61\x48\x90\x0d\xe5\x25\x22\xb7\x54\x27\x99\x17\xd9\x47\x18\xb8\x77\x47\xc1\x58\xbd\xaa\x5c\x7b\x91\x7b\x3e\x09\x87\x0d\x96\x43\x22\xca\x1c\x8d\x3a\xac\x9f\x3c\x66\xb9\x18\x57\x6d\x98\xde\x57\xd4\xea\x9f\x4f\x1e\xc6\xfe\x23\x51\x9d\x7d\x3c\xc7\x1e\xac\x49\xd8\x59\x8b\xec\xea\xc9\x23\x2c\xd7\x20\x73\xca\xbd\xa8\x5e\x3d\x83\x12\x37\x84\xee\x01\xb1\xee\x6c\xc4\x4c\x0c\xed\xb7\xe7\xb6\x59\x5e\x94\x75\xb3\x91\x34\x65\xc2\x85\x51\xc2\x12\x79\x56\xe1\x55\x40\x65\x92\xfd\x6e\xa5\x79\x07\x63\x2c\x42\xe4\xe8\xad\x7c\x89\x1b\xff\x9a\xfc\x3e\xb7\x3f\x06\x3a\x63\x6a\xe8\x91\x24\x83\x64\x05\xe9\xc3\x14\x73\x87\xab\x92\x88\xf7\xe4\xd2\xfc\xae\xb3\xec\x51\x62\x05\x95\xd8\x13\x2c\x91\x47\xc8\xb5\x50\x8f\x11\x7c\x48\xec\x70\xf0\x40\xee\x9a\x99\x82\x6f\x2a\xa0\xed\xbd\x94\x6d\x48\xc2\xa9\x52\x8d\x49\x87\xa8\xb4\xa4\x62\xd9\xad\x5d\xe2\x7f\x00\x6b\xec\x9b\x1d\x79\xcb\x38\x06\xb4\x5d\x5a\xad\xb3\x6d\xe3\xcd\x15\x50\x1b\xdb\x08\xb2\xbe\x38\x88\x6d\x68\xb9\x50\x23\xad\x0e\x86\x2d\xa7\x54\x6d\x23\x65\x78\x17\xd0\xfd\x34\x17\x39\x1c\xa1\x8e\xed\x6a\x15\xd3\x04\xfb\xde\x05\xae\xb0\x6d\x25\x2b\x72\x69\x5c\xd8\xd1\x14\x1e\x36\x86\xe6\xb4\xe5\x7b\x90\xd5\xc0\x3b\xf2\x77\xec\x45\x63\xf2\x0e\x2f\xcf\x87\x99\xde\x81\x3e\xf3\xa2\x33\x8b\x56\xcf\x82\xbf\xf4\xa2\x97\xdf\x00\x1f\x4f\x90\xcc\xe4\x1b\x0e\x4c\x7e\x34\xec\xe7\xb4\xa3\x73\x74\x89\x7f\xf9\xb3\x81\xff\x05\xb0\x7a\x9e\xb1\x67\xc8\x7f\x62\xf1\x1d\x74\x8e\x94\xf8\xfd\x88\x96\x92\xb7\x92\xf1\xca\x96\xcf\xf4\xe6\x03\xf6\xa3\xc0\x34\x7b\x55\xd0\x04\xec\xea\x96\x1c\x09\xf1\xb1\xec\x6c\x24\xdb\x68\x1f\xf4\x1c\xcf\xce\x16\xad\x35\xdd\x49\xb1\xc5\x09\xe1\x23\xdd\x91\x4b\x5c\x3d\x2c\x8d\xa3\x8a\xeb\xb3\x56\x6f\x23\xe8\x91\x4e\xa7\xca\x78\xcd\xcc\xdd\x17\x06\xe6\xa6\x34\x4f\x9d\xe2\x6c\x62\x6e\xd5\xc0\x5e\x61\x66\x34\x40\xa3\xeb\xfb\xbb\xba\x94\x85\x4c\x41\xda\x14\xad\xc6\x17\x7b\x0b\x47\xd7\x42\x53\x4e\xd0\x9d\x8a\x7c\x34\x26\x43\xea\xe4\xe1\xf7\xe6\xc6\x37\xfb\xd5\xf6\xed\xed\x41\x51\x87\x84\x2b\xf6\x1f\x10\xb1\xa8\x85\x58\x89\x8d\xa4\xb0\x90\x60\xc4\x59\xa8\x41\x1a\x61\x66\xef\x51\x91\x96\x94\x0b\x72\x8b\xd5\x1d\x59\x06\xd2\x21\xab\xc3\x11\xce\x1b\x61\x6c\x33\xe7\x02\xe7\x89\x30\x88\xa3\x69\xb5\x2b\xaa\xce\x7d\x73\x23\x4d\x7f\x20\x2f\xb0\x3d\x9d\x90\x17\x36\x75\xc9\x74\x46\x7c\xa7\xa7\x95\x93\x2c\xaa\xe7\xa7\xbe\x1b\x51\x36\x0c\xb6\xbf\xae\x66\x48\xec\xf6\xb6\x1f\xd9\x87\x19\xa3\x2a\xb1\x90\xa3\xff\x90\x96\x51\x84\x8a\x71\x70\x33\x91\xe9\x1c\x39\x17\xb6\xb9\xde\x1b\x38\xc3\xf6\xe4\xa9\x40\x5f\x63\x06\x0d\x9a\x74\x39\x21\xed\xe5\xf8\xf4\xf4\xd4\x1b\xd6\xc8\xb9\x14\x05\xce\xd2\xf9\xa0\x1a\x6f\x10\xd0\x2c\xdc\x44\x33\xbc\x2b\xb4\xe9\xcc\x08\x68\xaf\xfd\xef\xbb\x84\x36\x7d\x11\x11\xed\xf5\xf8\xbe\xd8\xa6\xa4\xf0\x65\x7b\x1d\x1c\x80\x97\xe6\xaa\x1c\xdc\xbd\x15\x11\x71\xff\xb7\xbb\xad\x86\xbd\x96\x77\x02\xf7\xaf\xc8\xff\x7e\x5a\xcb\xf3\x6c\x0d\x00\x00")
 
 func webfilesDebuglistkeysHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -176,7 +197,7 @@ func webfilesDebuglistkeysHtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "webfiles/debuglistkeys.html", size: 3340, mode: os.FileMode(420), modTime: time.Unix(1659992879, 0)}
+	info := bindataFileInfo{name: "webfiles/debuglistkeys.html", size: 3436, mode: os.FileMode(420), modTime: time.Unix(1792210611, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -241,7 +262,7 @@ func webfilesFaviconIco() (*asset, error) {
 	return a, nil
 }
 
-var _webfilesFilterJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x58\x6d\x6f\xdc\xc6\x11\xfe\xae\x5f\x31\x61\x83\x13\x99\xa3\x48\xb9\x41\x3f\xd4\x2a\x1b\x38\x92\xdc\x1e\xa2\x44\xb1\xcf\x36\x0a\x08\x4a\xb1\x26\x87\xc7\x8d\xf6\x76\x99\xdd\xa5\xce\x87\xc0\xff\xbd\x98\x5d\xbe\x9f\xa4\xd8\x35\xda\x03\x24\x9e\x96\xb3\xcf\xcc\x3e\xf3\xba\x4a\xbf\x39\x82\x6f\xe0\x5c\xd5\x7b\xcd\x37\x95\x85\x30\x8f\xe0\xcf\xa7\xcf\xfe\x1a\x83\x61\x02\x4d\xa9\x74\x8e\x49\xae\xb6\x31\x70\x99\x27\x24\xfb\x42\x08\x70\xb2\x06\x34\x1a\xd4\xf7\x58\xb8\xf5\xf5\xcf\x17\xff\x3a\xb9\xe2\x39\x4a\x83\x27\xab\x02\xa5\xe5\x25\x47\xfd\x1c\xbe\x5f\x5f\x9c\x7c\x7b\x72\x2e\x58\x63\x90\x04\x5f\x2a\x0d\x65\x23\x04\x08\x2f\x0c\x16\x3f\xd8\x18\x0c\x22\x5c\xad\xce\x2f\x7f\x5a\x5f\x26\xf6\x83\x85\x92\x0b\x04\x2e\xc1\x56\x08\x1a\x6b\x05\x5a\x29\x0b\x4a\x43\x65\x6d\x6d\x9e\xa7\xa9\xaa\x51\x1a\xd5\x90\x81\x4a\x6f\xd2\x16\xcd\xa4\x33\x7d\xe9\xd1\x51\xd9\xc8\xdc\x72\x25\x61\x83\xf6\xad\x16\xef\x98\x36\x61\x04\xbf\x1f\x01\x00\xdc\x33\x4d\x3f\x06\x32\xf8\xfd\xe3\x59\xbf\x54\x33\x6d\x69\x6d\xc7\x65\xa1\x76\x89\x50\x39\x23\x84\xa4\xd2\x58\x26\x1a\x6b\xc1\x72\x0c\xd3\x9b\xef\x16\xb7\xcb\xf0\xe6\x97\x6c\x71\xbb\x8c\xb2\xf0\xe6\x97\xc5\xed\x37\x51\xba\xe1\x31\x74\x2a\xc3\x6d\x7c\x87\xfb\xf8\x9e\x89\x06\x3b\x95\xad\x0e\x73\x73\x87\xfb\x5b\xc8\xc0\xbd\xf4\xaa\x3f\x46\xfe\xa9\xd1\x36\x5a\x3a\xa9\xb3\xa3\x8f\x07\x27\xf8\x99\x69\xb6\x0d\x6b\xfa\x8d\x16\x75\x0c\x05\x96\xac\x11\xd6\xab\x19\x0e\xd6\x68\xd1\x0b\x41\x36\x91\xf2\x7a\x78\x19\x3e\x78\x42\x2e\x0b\xfc\x70\x5d\x0e\x2a\x22\xf8\x3b\x9c\x3c\x83\xc5\x02\x0a\xcc\x55\x81\x6f\x5f\xaf\xce\xd5\xb6\x56\x12\xa5\x0d\xc7\xb4\xde\xf4\x5b\x6e\x23\xf8\x2a\x83\x20\x80\x68\x38\xf6\x81\x41\x9f\x8c\xd5\xf2\x33\x66\x67\x0c\xe6\x58\x4a\x53\xb8\x52\xea\x0e\x9a\xda\x45\x4d\xa3\x05\x0c\xda\x02\xf7\x35\x80\xc6\x70\xb9\x81\xa0\xe5\xe2\x1d\x71\x11\x00\x2f\x41\x2a\x0b\xa5\x6a\x64\x41\x30\xb6\x42\x09\x06\xad\xc3\x29\x95\xde\x82\xaa\x1d\xff\x3b\x6e\x2b\xe0\x05\x04\x28\x70\x8b\xd2\xae\x8a\x00\xac\x02\x5b\x31\xeb\xfd\x38\xb8\xca\xa0\xbd\xd0\xaa\x2e\xd4\x4e\x7a\x1e\x63\xe8\x37\xf5\x1e\x73\xfa\x29\xb9\x0c\x6a\xff\xc7\xaa\xfc\x91\x1b\xb2\x71\x1a\xa1\xa2\x41\xc8\x0e\xdd\x3f\x05\x8a\x86\x00\x36\x28\x30\xb7\xc4\xb1\xca\x1b\x52\x9a\x6c\xd0\x5e\x7a\xfd\xdf\xef\x57\x45\xd8\xdb\x32\xda\xe4\xce\x0f\x19\x94\x4c\x50\xee\x00\xd0\xd9\x43\x7a\xc3\x21\x83\xd3\x33\x0e\x7f\x6b\x81\x13\xcf\x87\x49\x04\xca\x8d\xad\xce\x80\x2f\x97\x23\x3f\xf3\x32\x9c\xca\xdd\xf0\xdb\xa4\x3d\x44\x1b\xf0\x30\x4e\x07\xfa\x1c\x6e\xf0\x2b\x48\x16\x59\xdd\x85\x6c\xf7\xe9\x6c\xa5\x37\xfd\x8b\x8f\xa3\x28\xe1\x25\x84\x5f\x79\xa9\xc5\xe2\x49\x86\x47\xda\x59\x5d\xa3\x2c\x42\x90\xb8\x83\x6b\x67\x49\x78\xef\x5d\xd4\x3e\x1c\x35\xb1\xd3\x1a\x45\x87\x31\xe9\x63\xe0\x7f\x1c\x8b\x54\x30\x81\xcb\xba\xb1\x9f\x19\x8f\x6f\xf0\x83\xfd\x83\x58\xfc\xb2\xa8\xe3\xb2\xfe\x9c\x98\x23\xf1\x2e\x2c\x5a\x73\xff\x8f\x5c\x6a\x56\x70\x05\x79\x85\xf9\x1d\x16\xed\x61\x89\x3b\xdd\x20\x30\x59\x40\xce\x84\xf0\xa4\x77\x14\xee\x68\x7b\xbb\x61\x42\xec\x6b\x82\xfa\x44\x66\x05\xda\xcf\x64\xf6\x31\x3a\x7d\xb9\x4f\xba\x13\x8c\xb3\xe4\xb7\x06\xf5\xfe\xbc\x62\x72\x83\xe1\xd3\xdb\xe7\x1d\x67\xe0\xfc\x1f\x68\x81\x81\xe0\xc6\x82\x2a\xfd\x1b\x03\xa5\x56\x5b\x8f\xfe\x56\x0b\x08\xdb\x06\xed\x4b\x64\x09\x0c\x7e\x35\x4a\x02\xd3\x9a\xed\x23\xc2\x58\xb9\xb4\x03\x5b\x29\x43\xdd\xdc\x2a\x28\xb4\xaa\x81\x8a\x62\x1f\xbb\xf8\x5b\xc3\xc4\x38\x82\x69\xe3\x0b\x59\x78\xba\xf7\xaa\x81\x92\xcb\x02\x58\xcb\xda\x96\xd9\xbc\x22\x5f\xf7\x71\xd0\xc7\x00\x79\x96\x5b\x72\x62\x57\x3a\x06\x2f\xd5\xaa\x6e\x04\xb3\xd8\xd5\xe4\x97\x5a\x6d\x5f\xd1\x39\xfe\xb0\x38\x77\xa7\xfd\xb2\xd4\x68\xe1\x3f\x23\x3b\xd2\x14\xd6\x96\x69\x62\xbf\xf4\x64\xfd\xda\x18\x0b\x4c\x76\xcd\xa8\x54\xda\xb1\xef\x8d\x71\x9e\xa1\x3f\xdf\xbe\xbe\x72\xfb\x5b\xbc\xff\xa6\xa6\x49\xb6\x45\x53\xb3\x1c\x69\x06\x2a\xbe\x4d\xc8\xab\x61\xcf\xc3\xd9\x4c\x26\xa1\xb4\x0a\x7b\xa6\x43\x8d\xa6\x11\x76\x5c\x5d\x3b\x53\x34\x6e\xd5\x3d\x86\xa7\xd1\x78\x10\x7a\xa0\xed\xf8\x88\x24\x94\xa4\x54\xfa\x92\xe5\x55\x38\x2a\xfe\xfd\x70\xa5\xd5\x6e\xde\x45\x00\xb8\x59\x0f\x7d\x23\xec\x9b\x0e\xc9\xce\x24\x9f\x20\x48\xab\x5d\x0c\xee\x57\x4b\xce\x80\x1a\x41\x74\x36\x57\x59\x42\x38\x16\x98\x9b\xf4\x68\xbf\x1a\x77\x2d\x18\xcd\x7f\xd3\xfe\x35\x07\xfc\x02\xc7\x8e\x9a\x64\xf4\x60\xde\x7f\xdd\x97\x8b\x28\xd1\xc8\x8a\x7d\xef\xd7\xb0\xaf\x60\x5f\x87\xc7\x7f\xea\x12\xec\x52\x16\x6f\xf8\x16\x8f\xa3\x44\xc9\xf0\xf8\xbd\x68\xf4\xf1\x68\xfa\xc5\x7b\x3b\x9b\x7a\xa1\x60\x16\x69\xc7\xbb\x36\x83\x1e\xcb\x86\xe3\x43\x0d\xa3\xc1\x15\x26\x63\x4e\x2f\xd4\x83\x8e\x95\x24\x56\xad\xad\xe6\x72\x13\x8e\xd8\x35\x68\x0c\x57\x72\x6d\x95\x66\x1b\x4c\x0c\xda\x95\xc5\xed\xa1\xd6\xf8\x41\x15\x9f\x04\x64\xd7\x07\x58\xe4\xa9\x0b\x66\x31\x8c\x12\xab\x56\xeb\xeb\xce\xae\x68\x18\xff\xe9\x67\xd2\x5b\x5e\x72\x61\x51\x9b\x17\xb2\x78\xed\x7c\xf5\xaa\x4d\xc3\xb0\x2d\x34\xd4\x1a\xdf\xb3\xfc\xae\xaf\x3c\x3f\x70\x39\xd4\xb0\x9f\xba\x2c\xed\xfc\x90\xa6\xf0\x03\x22\x75\x52\x6e\xe8\x7e\x65\xf6\x32\xf7\xd5\xa5\xbe\xdb\xa4\x46\x28\x55\xa7\x94\xe9\x1c\x4d\xea\x2a\x9a\x49\x36\xea\xa8\x2f\x48\x6a\x8b\x54\xe8\x6d\x85\x06\x41\x22\x16\x54\x6d\x2b\xee\x3b\x2a\x99\x81\xae\x70\xf3\xbc\x02\xcb\xee\xd0\xb8\x0e\x62\xad\x40\xb0\x7c\x8b\x1d\xcc\x85\xf2\x6d\x83\x51\x6f\x91\xd4\x56\xb8\x36\xb6\x7b\xfb\xe6\xfa\xe2\xfa\x39\xb8\x73\xf6\xb0\x27\x84\xcb\xc8\xd8\xa9\xd4\x0b\x61\x54\x0c\x3b\x6a\x0b\x7b\xc8\x95\x34\xbc\x40\x9a\x43\xb8\xe5\x4c\x88\x7d\x57\xf6\xa9\x5f\x10\x14\x75\x9f\x93\xa1\xfb\xcc\xaa\x67\xdf\x51\x0c\x30\xb2\x9c\xae\x79\x95\x12\x05\xea\x4e\xa9\xff\x34\xd2\x72\x41\x4a\x37\xdd\x58\xe6\xef\xb3\xc6\x3a\x5b\x3d\x5b\xb3\xb8\x81\x6c\x1e\x2a\x9b\xc7\x62\x2e\xea\xb4\xad\x4a\x68\x0c\x6a\x30\x55\x63\x7d\xcf\x24\x65\x4a\x14\xed\xbd\xd4\x8d\x29\x74\x0f\x06\xe6\x42\xcb\xaf\xc6\xfd\x84\xd3\xc6\x00\x34\x1c\x0a\x6e\x6a\xc1\xf6\xe4\x2f\x32\xc6\x2a\x90\x6a\xd7\xcf\xc9\x07\xb6\x66\x19\xc8\x46\x88\x79\xf2\x4a\xb5\x83\x0c\x1e\x0d\xe3\xd9\x34\x3d\x3e\xbb\x54\xbb\xc4\x34\xef\x8d\x97\x3c\x8d\xdd\x82\xbf\x3c\x9c\xfc\x65\x3c\x48\xa3\x30\x38\xd3\x8a\x03\x4a\xa7\x78\xa6\x60\x92\x8f\x73\xd5\xed\xf6\xa9\xa9\x89\x11\x3c\xc7\xf0\x34\x3e\x79\xd6\x69\xf7\xd3\x59\x9b\x4d\x74\xcc\x0e\x70\xb8\xc7\x05\xdd\xeb\x20\x86\xa0\x74\x89\x39\x5a\x39\x48\x48\x57\x78\x7d\x30\x28\x6d\x07\xc8\x03\x58\x7a\x1d\xc4\x6e\xbd\x85\x1d\xaf\x18\x9a\x02\xfe\x4d\x7e\x0b\xda\x9a\x1e\x1d\xf5\x2d\xd8\x8d\x43\x2e\xb8\xfc\x78\x1f\xf4\x8b\x83\x8d\x93\xa5\xa0\xdd\xec\x83\xe5\xaa\xbd\xf7\x3f\xf0\xbf\x8e\x9a\xd9\x8a\x76\x8e\xea\xe7\x30\x5b\x4e\x0e\xf3\xf8\x6c\x15\x38\xe1\xe9\xd1\x26\x4b\x97\xf7\x28\xed\x3f\x91\xd9\x1f\x59\x4d\x6b\x53\xab\x96\x41\x5a\x30\xcb\xbe\x73\x5b\xb2\x57\xbe\x2c\x2d\x3a\xca\xb3\x60\xd9\x7d\xed\x66\x12\x33\x25\xf9\x49\xd3\xfa\x01\x66\x4a\x54\xb7\x34\x2f\xa0\xf1\x93\xb6\xf5\x62\x4f\x99\x77\xc7\xdd\x08\xf0\x69\xe6\x91\x70\xcb\x52\x67\xde\x78\x69\x52\xec\xe1\xd0\xa1\x53\xf3\x48\xec\x31\xcb\xfc\x1d\x83\x59\xf6\xaa\x75\xec\x53\x40\xc1\xd2\x3d\x97\xc1\xa2\xe7\x2a\x0b\x96\xd2\x2c\x83\x87\xd0\x97\xc1\x82\x6c\xce\x82\x25\x3d\x96\xc1\x82\xc2\x3a\x0b\x96\xf4\x68\x21\x5c\x5c\x12\x44\xf7\x7d\x19\x2c\x50\x16\x2e\xdc\x49\x72\x9a\xce\xe3\x99\xa5\x37\x99\xe6\x96\xff\x04\x00\x00\xff\xff\xcc\x6c\xfa\x0a\xc9\x14\x00\x00")
+var _webfilesFilterJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbd\x58\x5b\x6f\xdb\x36\x14\x7e\xcf\xaf\x60\xb5\x21\x91\x1a\x5b\x6e\x57\xec\x61\xcd\xb2\xa1\x4b\xd2\xcd\x58\xb6\xb4\x75\x5b\x0c\x08\xb2\x81\x95\x28\x5b\x8b\x2c\x6a\x24\x95\xd4\x18\xf2\xdf\xf7\x1d\x52\xd4\xcd\x49\xda\xae\xd8\x04\x24\xb2\xc9\x73\xe3\x77\xae\xf4\xec\xe1\x0e\x7b\xc8\x8e\x64\xb5\x51\xf9\x72\x65\x58\x98\x44\xec\xab\x47\x8f\xbf\x99\x30\xcd\x0b\xa1\x33\xa9\x12\x11\x27\x72\x3d\x61\x79\x99\xc4\x44\xfb\xac\x28\x98\xa5\xd5\x4c\x09\x2d\xd4\x95\x48\xed\xfa\xe2\xc5\xf1\x6f\xd3\xd3\x3c\x11\xa5\x16\xd3\x79\x2a\x4a\x93\x67\xb9\x50\x4f\xd9\x0f\x8b\xe3\xe9\x93\xe9\x51\xc1\x6b\x2d\x88\xf0\xb9\x54\x2c\xab\x21\xa5\x70\xc4\xcc\x88\xf7\x06\xfa\x84\x60\xa7\xf3\xa3\x93\x5f\x17\x27\xb1\x79\x6f\x58\x96\x17\x02\x4a\x99\x59\x09\x28\xaa\x24\x53\x52\x1a\x06\xde\x95\x31\x95\x7e\x3a\x9b\xc9\x0a\xdc\xb2\x26\x03\xa5\x5a\xce\x1a\x69\x7a\x36\xd2\x37\xdb\xd9\xc9\xea\x32\x31\xb9\x2c\xd9\x52\x98\x37\xaa\x78\xcb\x95\x0e\x23\xf6\xf7\x0e\xc3\x73\xc5\x15\xfd\x69\x76\xc8\xfe\xbe\x39\x68\x97\x2a\xae\x0c\xad\x5d\xe7\x65\x2a\xaf\xe3\x42\x26\x9c\x24\xc4\x2b\x25\xb2\x18\xe6\x14\x3c\x11\xe1\xec\xfc\xfb\xdd\x8b\xfd\xf0\xfc\xf7\x43\xbc\xa2\x43\x7c\xd8\xbd\x78\x18\xcd\x96\xf9\x84\x79\x95\xe1\x7a\x72\x29\x36\x93\x2b\x5e\xd4\xc2\xab\x6c\x74\xe8\x73\xec\x5c\x40\x87\xdd\x74\xaa\x6f\x22\xf7\x56\xc2\xd4\xaa\xb4\x54\x07\x3b\x37\x5b\x27\x78\xc1\x15\x5f\x87\x15\xfd\x17\x46\xa8\x09\x4b\x45\xc6\xeb\xc2\x38\x35\xdd\xc1\x6a\x55\xb4\x44\x50\xd4\xa7\x72\x7a\xf2\x2c\xbc\xf5\x84\x58\x13\xef\xcf\xb2\x4e\x45\xc4\xbe\x63\xd3\xc7\x6c\x77\x17\x42\x12\x99\x8a\x37\xaf\xe6\x47\x72\x5d\xc9\x12\x7e\x0e\xfb\xb0\x9e\xb7\x2c\x17\x11\x7b\x70\xc8\x82\x80\x45\xdd\xb1\xb7\x0c\xfa\x68\x59\x0d\x3e\x7d\x74\xfa\xc2\x2c\x4a\xb3\x19\x3b\x95\xf2\x92\xd5\x95\x8d\x1a\xec\xb3\x4e\x5b\x60\x3f\x06\xac\xd6\x79\xb9\x64\x41\x83\xc5\x5b\xc2\x22\x00\x0e\xac\x44\x74\x65\xb2\x2e\x53\x12\x03\xf6\x12\x11\x69\xac\x1c\x24\xc1\x9a\xc9\xca\xe2\x7f\x9d\x9b\x15\xcb\x53\x16\x88\x42\xac\x61\xef\x3c\x0d\x98\x91\x20\xe3\xc6\xf9\xb1\x73\x15\xd8\x8f\x95\xac\x00\x6e\xe9\x70\x9c\xb0\x96\xa9\xf5\x98\xd5\x4f\xc9\x85\x4c\x72\x5f\xe6\xd9\x2f\xb9\x26\x1b\x87\x11\x8a\x1d\x00\xb6\xe5\xfe\xa1\xa0\xa8\x0b\x60\x0d\x5d\x89\x21\x8c\x65\x52\x93\xd2\x18\xbc\x27\x4e\xff\x0f\x9b\x79\x1a\xb6\xb6\xf4\x98\xec\xf9\xc1\x93\xf1\x82\x72\x07\x0f\xce\x1e\xd2\x4e\x8e\xd5\x47\x07\x39\xfb\xb6\x11\x1c\x3b\x3c\x74\x5c\x88\x72\x69\x56\x07\x2c\xdf\xdf\xef\xf9\x19\x71\x35\xa4\x3b\xcf\x2f\xe2\xe6\x10\x4d\xc0\xb3\x7e\x3a\xd0\xb3\xcd\xe0\x56\x04\x59\x64\x94\x0f\x59\xff\x78\x5b\x69\xa7\xdd\xb8\xe9\x45\x09\x7c\x1a\x3e\x70\x54\x08\xdb\xfb\x10\xee\x69\xe7\x15\xaa\x4a\x1a\xb2\x52\x5c\xb3\x33\x6b\x49\x78\xe5\x5c\xd4\xbc\x2c\x34\x13\xab\x35\x8a\xb6\x63\xd2\xc5\xc0\x7f\x1c\x8b\x54\x30\x71\xa0\xaa\x36\x9f\x18\x8f\xaf\xc1\xf8\x81\x58\xfc\xbc\xa8\x83\x51\x9f\x12\x73\x44\xee\xc3\xa2\x31\xf7\x7f\xc4\x52\xf1\x34\x97\x2c\x59\x89\xe4\x12\x31\xe6\xcc\x20\xec\xe0\x5a\xc6\x11\x35\x09\x47\x93\xb2\xa0\x7b\x08\xaf\x89\xbd\x61\x18\x00\xfb\x8a\x44\x7d\x24\xb2\x85\x30\x9f\x88\xec\x5d\x70\xba\x72\x1f\xfb\x13\xf4\xb3\xe4\xaf\x5a\xa8\xcd\xd1\x8a\x97\x4b\x11\xde\xcf\x3e\xee\x38\x1d\xe6\x3f\xc2\x50\x8e\x2e\xad\xd1\x76\x33\xb7\xa3\x59\xa6\xe4\xda\x49\x87\xe1\x2c\x6c\x1a\xb4\x2b\x91\x19\xc8\xff\xd4\x40\x84\x2b\xc5\x37\x11\xc9\x98\xdb\xb4\x03\x8d\xd4\xd4\xcd\x01\x6f\x8a\x9a\xc8\xa8\x28\xb6\xb1\x2b\xfe\xaa\x79\xd1\x8f\x60\x62\x7c\x06\x07\x58\xb8\x37\xb2\xc6\x2c\x80\x6f\xbc\x41\x6d\xcd\x4d\xb2\x22\x5f\xb7\x71\xd0\xc6\x00\x79\x36\x37\xe4\x44\x5f\x3a\x3a\x2f\x55\xb2\xaa\x0b\x6e\x84\xaf\xc9\xcf\x71\x90\x97\x74\x8e\x0f\x16\x67\x7f\xda\xcf\x4b\x8d\x46\xfc\x27\x64\x07\x50\x58\x18\xcc\x21\x00\x36\x73\x60\xfd\x59\xc3\x17\xbc\xf4\xcd\x08\xa8\x5b\xf4\x9d\x31\xd6\x33\xf4\xf5\xcd\xab\x53\xcb\xdf\xc8\xfb\x37\x35\xad\x44\x72\xe9\x0a\x33\x0e\xcd\x40\xe9\x93\x98\xbc\x1a\xb6\x38\x1c\x8c\x68\x62\x4a\xab\xb0\x45\x3a\xc4\x5c\x08\x00\xfa\xd5\xd5\x9b\xa2\xc4\x5a\x5e\x89\xf0\x51\xd4\x1f\x84\x6e\x69\x3b\x2e\x22\x49\x4a\x8c\x43\x9e\xf0\x64\x15\xf6\x8a\x7f\x3b\x5c\x29\x79\x3d\xee\x22\x28\x2a\x7a\xd1\xf5\x8d\xb0\x6d\x3a\x44\x3b\xa2\xbc\x07\x20\x50\x4f\x98\xfd\xd7\x80\xd3\x49\x8d\x58\x74\x30\x56\x89\x56\xd3\x27\x18\x9b\x74\x67\xbf\x72\xcf\x4d\xd7\xbf\x7a\xa2\xbb\xfe\x35\x16\xf8\x19\x8e\xed\xd4\xdd\x44\xb7\xe6\xfd\x97\x6d\xb9\x88\xe0\x2d\x9e\x6e\x5a\xbf\x86\x6d\x05\xfb\x32\xdc\xfb\xc2\x27\xd8\x49\x99\xbe\xce\xd7\x62\x2f\x8a\x41\xb1\xf7\xae\xa8\xd5\x5e\x6f\xfa\x15\x57\x66\x34\xf5\xb2\x14\x39\x48\x1c\x6f\x9b\x0c\xba\x2b\x1b\xf6\xb6\x35\xf4\x06\x57\x2f\x6d\x44\xd4\x0a\xed\x2b\x89\x8d\x5c\x18\x85\x8a\x11\xf6\xd0\xc5\x4d\x41\xc3\xc2\x85\x91\x8a\x2f\x05\x46\x0d\x33\x37\x62\xbd\xad\x75\x72\xab\x8a\x8f\x12\x64\x16\x5b\xb2\xc8\x53\xc7\xb0\x2d\x8c\x60\xd4\x7c\x71\xe6\xed\xf2\xe3\x2d\xde\xf4\x37\xe8\x2d\xcf\xf3\x02\x7d\x4e\xa3\x20\xbe\xb2\xbe\x7a\xd9\xa4\x61\xd8\x14\x1a\x6a\x8d\xef\x78\x72\xd9\x56\x9e\x9f\x51\x2d\xdb\x2f\xbf\xfa\x2c\xf5\x7e\x40\x55\xf9\x59\x08\xea\xa4\xb9\xa6\xfb\x95\xde\x94\x89\xab\x2e\xd5\xe5\x72\xa6\x0b\x29\xab\x19\x65\x7a\x8e\xab\x94\xad\x68\x3a\x5e\xca\x9d\xb6\x20\xc9\xb5\xa0\x42\x8f\x8c\x47\x41\x2f\x05\x92\x0c\xd5\x76\x95\xbb\x8e\x4a\x66\x08\x5b\xb8\xf3\x64\xc5\x0c\xbf\x44\xfd\xa0\x0e\x62\x0c\xee\x72\x06\x10\x78\x31\xc7\xd2\xb5\x0d\x4e\xbd\xa5\xa4\xb6\x92\x2b\x6d\xfc\xee\xeb\xb3\xe3\xb3\xa7\xcc\x9e\xb3\x15\x3b\x25\xb9\x9c\x8c\x1d\x52\x3d\x2b\xb4\x9c\xb0\x6b\x6a\x0b\x1b\x96\x60\x70\xcc\x53\x41\x73\x48\x6e\x72\xb4\xef\x8d\x2f\xfb\xd4\x2f\x48\x14\x75\x9f\x69\xd7\x7d\x46\xd5\xb3\xed\x28\x30\x9b\x2c\xa7\x6b\xde\x4a\x16\x90\xe8\x95\xba\xa7\xc6\xe5\xb6\x20\xa5\x4b\x3f\x96\xb9\xfb\x2c\x4e\x43\xb6\x3a\xb4\x46\x71\x83\xa8\x1c\x85\xca\xf2\xae\x98\x8b\xbc\xb6\x79\x86\xb1\x06\xc7\xd1\x2b\x8c\x7a\xd6\x6a\x52\x06\x83\x9a\x7b\xa9\x1d\x53\xe8\x1e\x0c\x5b\x29\xb4\xdc\xea\xa4\x9d\x70\x9a\x18\x60\x75\xce\xd2\x5c\xe3\x34\x1b\xf2\x17\x19\x03\xa7\x95\xf2\xba\x9d\x93\xb7\x6c\x45\xc1\x2c\x71\xa6\x71\xf2\x82\x07\xe7\xb8\x33\x8c\x47\xd3\x74\xff\xec\xe0\x8c\x75\xfd\x4e\x3b\xca\x47\x13\xbb\xe0\x2e\x0f\xd3\xaf\xfb\x83\xb4\x40\xc9\x1a\x69\x15\x9d\x14\xaf\x78\xa4\x60\x90\x8f\x63\xd5\x0d\xfb\xd0\xd4\x58\xd3\x0f\x06\x30\x64\xfa\xd8\x6b\x77\xd3\x59\x93\x4d\x74\x4c\x2f\xb0\xbb\xc7\x05\x7e\x3b\x98\xb0\x20\xb3\x89\xd9\x5b\xd9\x4a\x48\x5b\x78\x5d\x30\x48\x65\x3a\x91\x5b\x62\x69\x1b\x02\xe8\x69\xc4\xf6\x57\x34\x4d\x01\x7f\x90\xdf\x82\xa6\xa6\x47\x3b\x6d\x0b\xb6\xe3\x90\x0d\x2e\x37\xde\x07\xed\x62\x67\xe3\x60\x29\x88\x7a\xe1\x29\x55\x9f\xd5\xaf\x75\x9c\xfd\x95\xa0\xd1\xea\xa2\xec\xb4\xf9\xc1\xe0\x96\x1f\x49\x2a\x6e\x56\xa4\xb2\x57\x78\xbb\xa1\x74\x80\xc2\xdd\x43\x59\x60\x89\x87\x98\x0c\x96\x4e\xae\xd0\x29\x7e\x12\xdc\xfc\xc2\x2b\x5a\x1b\x5a\xb5\x1f\xcc\xd0\x03\xf8\xf7\x96\xe5\xf0\xa5\xab\x67\xbb\xde\x57\x87\xc1\xbe\xff\xe8\x87\x19\x3d\xf4\xce\xbd\xa6\xb5\x93\xcf\x10\x61\xbf\x34\xae\xbc\x93\x7b\x6d\x6b\xc9\xee\x33\xef\x32\xb7\xb3\xc3\xc7\x99\x47\xc4\x0d\x4a\xde\xbc\xfe\xd2\xa0\x4b\xb0\x6d\x87\x0e\xcd\x23\xb2\xbb\x2c\x73\x97\x13\xd0\xbe\x6c\x1c\x7b\x9f\xa0\x60\xdf\xbe\xf7\x83\xdd\x16\x2b\xac\x95\x1a\x0b\xb7\x48\xc7\x2a\xd9\x8c\x15\x7a\xe1\x1b\xe5\x03\xbe\xd1\xab\x11\x61\x03\x9a\x44\xf8\xcf\x44\xd5\x84\x2b\x96\x45\xb9\xf5\xdb\x92\xdf\x8d\x40\x89\xa2\x60\x33\x8a\x64\x0e\x2b\x46\x7f\x2c\x6a\x0f\x47\xa3\xd1\x3f\x81\x11\x1f\x51\x2c\x15\x00\x00")
 
 func webfilesFilterJsBytes() ([]byte, error) {
 	return bindataRead(
@@ -256,12 +277,12 @@ func webfilesFilterJs() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "webfiles/filter.js", size: 5321, mode: os.FileMode(420), modTime: time.Unix(1661208231, 0)}
+	info := bindataFileInfo{name: "webfiles/filter.js", size: 5420, mode: os.FileMode(420), modTime: time.Unix(1792210631, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _webfilesIndexHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x58\xed\x52\x1b\xb9\xd2\xfe\xcf\x55\xf4\xaa\xea\x2d\xa0\x96\x99\xc1\x36\x61\xb3\xc4\x9e\xda\x60\x48\x60\x43\xf2\xb2\x31\x24\x24\xa7\x4e\xa5\xe4\x51\xdb\x23\x2c\x4b\x13\x49\x63\xe3\xb8\xb8\xf7\x53\x92\x6c\x3c\x36\xe6\x23\xb5\xf1\x1f\x4b\x1a\xf5\xd3\x9f\x6a\x75\xab\xf9\x5b\x14\x6d\xb4\x55\x31\xd1\xbc\x9f\x5b\xd8\xca\xb6\xa1\xbe\x5b\xfb\x73\x07\x0c\x15\x68\x7a\x4a\x67\x18\x67\x6a\xb8\x03\x5c\x66\xf1\xc6\x6b\x21\xc0\x6f\x34\xa0\xd1\xa0\x1e\x21\x8b\x37\x3a\xe7\x47\x57\xd1\x19\xcf\x50\x1a\x8c\x4e\x19\x4a\xcb\x7b\x1c\xf5\x01\x1c\x76\x8e\xa2\x46\xd4\x16\xb4\x34\xb8\xf1\x46\x69\xe8\x95\x42\x80\x08\x3b\xc1\xe2\x8d\xdd\x01\x83\x08\x67\xa7\xed\xe3\x0f\x9d\xe3\xd8\xde\x58\xe8\x71\x81\xc0\x25\xd8\x1c\x41\x63\xa1\x40\x2b\x65\x41\x69\xc8\xad\x2d\xcc\x41\x92\xa8\x02\xa5\x51\xa5\x93\x4b\xe9\x7e\x32\x43\x33\xc9\x12\xb3\x28\x4a\x37\x9a\xbf\x1d\xfd\x7f\xfb\xe2\xcb\xf9\x31\xe4\x76\x28\xdc\x3c\x8a\x4c\x59\x14\x1a\x8d\x81\x13\x3b\x14\x97\x72\x20\xd5\x58\x5e\x50\xdd\x47\xbb\x03\x7f\x77\x2e\xa5\x46\xa3\xc4\x08\xd9\x27\xaa\x39\xed\x0a\x04\x0f\x64\xec\x44\x20\xd8\x49\x81\x2d\xe2\xa4\x4e\x32\x63\x48\xba\xd1\x4c\xfc\x87\x74\xa3\x99\x23\x65\xe9\x06\x00\x40\xd3\x64\x9a\x17\xb6\xba\xf9\x9a\x8e\x68\x58\x25\x61\x8f\xfb\x31\x95\x95\x43\x94\x36\x1e\x6b\x6e\x71\x8b\x34\xbb\xd4\x20\xe4\x1a\x7b\xad\xcd\x84\xc0\xef\x30\xe6\x92\xa9\x71\x2c\x54\x46\x2d\x57\x32\x2e\xa8\xcd\x25\x1d\x62\x6c\x0a\xc1\xed\xd6\x66\xb2\xb9\xfd\x9f\xda\x7f\xe1\x77\x20\xc9\x26\x24\x29\xd9\x7e\x15\xf8\x27\x81\xd5\x4c\x9a\x21\x5a\xea\x2d\x17\xe1\xf7\x92\x8f\x5a\xa4\xad\xa4\x45\x69\x23\x27\x1f\x81\x2c\xcc\x66\x82\x3a\x33\xbd\x82\x2c\xa7\xda\xa0\x6d\x95\xb6\x17\xbd\x9c\x49\xdc\xb4\xdc\x0a\x4c\x3b\x42\xa9\x62\x3a\xe5\x3d\xd8\x92\x08\x71\xbb\xd4\x1a\xa5\xf5\x90\x37\x16\x08\xd9\xbe\xbd\x85\x08\xa6\xd3\x95\x2f\xb7\xb7\xd3\x29\x4a\x76\x7b\xdb\x4c\x02\x4e\xc0\x14\x5c\x0e\x40\xa3\x68\x11\x6f\x46\x93\x23\x5a\xb2\x6a\xe5\x60\x12\x32\xc6\xae\x0b\x0c\x93\x18\x27\x42\x1c\xec\xbf\x8c\xb2\x69\x72\xa5\x6d\x56\x5a\xe0\x99\x92\x9b\x01\x68\x93\x0f\x69\x1f\x93\x9b\x28\xac\x05\xfb\xde\x81\xf5\xe8\xc8\xad\xc7\x3c\x53\x9b\xc9\xa3\x52\x05\x29\xe6\x21\x98\x31\x19\x5f\x1b\x86\x82\x8f\x74\x2c\xd1\x26\xb2\x18\x26\x5d\xa5\xac\xb1\x9a\x16\x7f\xed\xc5\x2f\xe2\x46\xc2\xb8\xf1\x2a\x2c\x3e\xc4\x43\x2e\xbd\xe8\x77\x51\x00\xc0\xa5\xc5\xbe\xe6\x76\xd2\x22\x26\xa7\x8d\x97\x7b\xd1\xc5\xd5\x4b\x5b\xff\xe3\x38\xfb\x78\xdc\xc0\x84\xe7\x97\x7f\xfc\x18\xfe\x73\xf3\x49\x66\x47\xaf\x27\x2f\xca\xd3\x77\x3f\xf6\xf4\xf1\xa0\x7f\x7a\x85\xef\x91\xed\xbd\xdf\xbd\x16\xbd\xd3\xa3\xf3\x51\x7f\xbf\xfc\xfe\xee\xb4\x7e\x73\xa5\xeb\x55\xf4\x4c\x2b\x63\x94\xe6\x7d\x2e\x5b\x84\x4a\x25\x27\x43\x55\x86\xd0\x0d\x21\xbb\xd1\xec\x2a\x36\x49\x37\x9a\x8c\x8f\xc0\x2b\xdc\x22\x8c\x9b\x42\xd0\xc9\x01\xf4\x04\xde\xbc\x82\x31\x67\x36\x3f\xa8\xed\xee\xfe\xdf\x2b\xc8\xd1\x9d\x7d\x3f\x99\xdb\xdf\x11\x72\xd6\x22\xde\x31\x02\x7b\x56\xd2\x11\x81\x4c\x50\x63\x56\x16\x17\xc1\xdf\x34\x05\x95\x73\x76\x3d\x25\x6d\x64\xf8\x0f\x3c\xa8\xef\x16\x37\x24\x04\x19\x8c\x76\xe3\x7a\x33\x71\xfb\xd2\x66\x57\x3f\x49\x5a\xab\x3b\xd2\x77\x65\x17\xb5\x44\x8b\x06\x4e\xb8\xb1\x4a\x4f\xe0\x13\x37\x25\x15\xfc\x87\x3f\x44\x15\x40\x0f\xfa\x78\x28\x2f\x78\x0a\xda\x45\x01\x3d\xa5\x5b\x24\x5b\xda\xb8\xc4\x72\xb6\x76\xd0\x4c\xfc\x7e\xc7\x22\xa9\x08\xce\x65\x51\x56\xf3\x02\xf1\x66\x5b\xc1\x83\x11\x15\x25\xb6\xc8\x9a\x33\x44\x80\x71\xe3\x72\x12\x6b\x11\xab\x4b\x24\x55\x3d\xfc\xf1\x5a\xf0\xea\x29\x3d\x04\x9a\x39\x9d\x5b\x84\xc0\x10\x6d\xae\x58\x8b\xf4\xb1\x9a\x82\xfc\xce\xdf\xa2\x08\x3e\x28\x0b\x26\x57\x63\x2e\xfb\x3e\xed\x7e\x2f\x51\x4f\x80\x69\x55\x00\x53\x63\x09\xd4\xc0\x18\x41\x49\x31\x81\x9c\x8e\xdc\x68\xbe\x87\x5a\x4f\x30\x54\x2e\x95\xf9\x5c\x79\x0f\xbc\x6a\xbc\x1e\x17\x16\xb5\x27\x25\xe9\x3f\xee\xaf\x6a\x2c\x48\xd2\xfb\x10\x06\x05\x66\x16\x5c\xe6\x6b\x91\x40\xe9\xed\x56\x85\x82\x9c\x33\x86\x72\x6e\x16\x97\x03\x3d\xd5\x3a\x69\x66\x26\xf3\x8c\x96\x3f\x57\xe4\x0c\xe4\xc8\x8e\x25\xbb\xe0\x43\x24\xe9\xb1\x64\xe0\x46\x0f\xf8\x76\x7e\x10\x96\x57\xee\x79\x9d\x51\x8b\x96\x0f\x31\x72\x49\x5d\x10\x30\x16\x8b\x16\xa9\x05\x85\x56\x79\xce\x54\xbe\xb7\x9c\x3c\xc1\xa4\x5b\x5a\xab\xe4\x5d\x20\x7d\x50\xe3\x39\x94\x74\x43\xc7\xca\x0d\x56\x84\x4f\x9c\xf4\x3e\x96\x1e\xb6\x4a\x30\xb9\x50\x6a\xd0\xa5\xd9\x80\xa4\x67\x4a\x0d\xe0\x90\x66\x03\xf8\x48\x65\xff\x51\xdb\x2c\x79\xf1\x0e\xa1\xe2\xc8\x05\xea\x7d\xed\x54\xe1\xe2\x78\xae\x50\x2d\x27\x69\x0d\x4e\x54\xa9\x9b\x49\xf8\xf2\x24\x49\x23\x27\x69\xc3\x93\x98\x67\xd3\xec\xe7\x24\xdd\xff\x49\x9a\x5a\xdd\xc9\x56\xff\x49\xaa\xfa\x9e\xd7\xe8\x88\x4e\x9e\xcf\x68\xff\xa5\xa7\xf9\x8c\x38\x78\xbe\x15\x1a\x4e\xa7\xba\x27\x7a\x40\xba\xbb\x83\x73\x97\x59\x9e\x08\x06\xe7\x50\x53\xd0\x0c\x49\xfa\xc6\x2f\xc0\x87\xf9\xca\xb3\xc3\x61\x81\x51\x89\x87\x0a\xf0\x7a\x09\x97\x57\x9f\x29\xee\x80\x4b\x76\x27\xe9\x3b\x2e\xd9\x01\x3c\x2d\xe5\x42\x28\x4f\x3e\x93\x3a\x40\xfd\x3b\xeb\x19\xa5\x2d\x49\x3b\x4a\x2f\xdd\x19\x8f\xd9\xca\x53\x54\x24\x0a\x08\x4f\x79\xde\x58\xaa\xad\xf5\x89\xac\xe3\x86\x3e\x95\x3d\x3b\x6e\x86\xca\x58\x1c\xa1\xb4\x86\xa4\xef\x95\xb1\x70\xec\x27\xcf\xa6\x77\x92\x93\xd4\xc5\xc5\x2f\x0c\xba\x21\xb5\x59\x1e\x50\x21\xf8\xf3\x11\x13\xde\xbf\x79\x17\x91\x17\x80\x56\x22\x6f\x86\xfe\x80\x40\x55\x38\x53\x76\x87\xbc\xea\x82\x66\xe2\xee\xde\xca\xdc\xdd\x3a\x17\xaa\xdf\x17\x08\x66\xcc\x6d\x96\x83\x55\xfe\xb6\x85\x82\x4e\x84\xa2\xcc\x15\xdc\xb2\x8f\x66\xe9\xee\xf3\x65\xd5\xbc\x88\xf2\x64\x91\xab\xd5\x29\x97\xa8\x57\xc3\xae\xf0\xd2\xcf\xd0\x2e\x7c\x55\xd2\x71\xf8\xe7\x33\xfc\x76\xc0\x6f\x26\x45\xba\xce\xb2\x4b\x5c\x48\x0a\x8f\x5f\x2f\x59\x8e\xd9\xa0\xab\x6e\x48\x95\x69\xdb\x2d\x12\x50\x32\xa8\xb2\xbc\x8e\x7a\x6b\x9b\x80\xa7\x43\xb6\x26\x5a\x7c\x41\x77\x57\x2f\x72\x86\x1a\xb4\x2a\xdd\xe9\x9a\x95\x6b\x2b\xd1\x12\xbc\x5c\x31\xf8\xfc\xe2\x5d\x2c\xdd\xf3\x5b\x33\xaf\xa7\x67\x5c\xba\xbc\x97\xd7\x2b\xb4\x74\x56\xd8\x33\xec\x96\xfd\x64\x5e\x7b\x1e\xb9\x19\xbc\x47\x59\x36\x13\xba\x5a\xc6\xcd\x49\x82\x01\x18\xb5\xd4\xf5\x12\xae\x6b\x20\xe9\x11\xb5\xd4\x85\x23\x82\x6b\x76\x2f\x72\x6e\xc0\x57\x39\x8f\xc0\xcc\x5b\x8a\x3e\xb7\x79\xd9\x75\x9d\x76\xb2\x68\xbc\x43\xb7\x43\xc0\xfa\x0e\xb5\x45\xbe\x75\x05\x75\x7c\x3a\xbe\xfd\x85\xb6\x62\xae\x18\x83\xb7\xdc\x9e\x94\xdd\x05\x93\xe9\x54\x3b\x37\x40\x7c\x86\x3d\x7b\x48\xb5\xd7\xbc\x5a\x1e\xce\x99\x4f\xa7\xf1\xa5\x16\xae\xb4\x5c\xe5\x30\x9d\xc6\x17\xbe\xea\xac\xa2\x86\x22\x73\x63\xd9\xea\x8b\x0e\x80\x35\xbe\xe5\xa8\x71\x51\xfc\x8f\xfa\x95\xa0\x9d\xd5\xec\x9b\xa1\x9d\x80\x7b\xfd\xc4\xab\xcd\xf4\x3e\xf4\xac\x99\x36\x3a\x5b\x58\x8a\x35\xae\x8d\xef\xfc\x59\x23\x1e\xbd\x88\xaf\x8d\x0f\x94\x6a\xd3\x1b\x26\x4b\x61\xb3\x84\x90\x29\x86\xf1\xb5\xaf\x1d\xbd\xc1\xc3\x30\x6a\xc4\x7b\x71\xcd\xf7\x68\xd7\x4b\x2d\xda\x6a\x93\x56\x7f\xb1\x1f\xb5\x3b\x57\x4a\x5f\x8d\xbe\x66\x17\x03\xca\x6f\xf6\xbf\x8c\xd4\xfe\x49\x51\x64\x5f\xdf\xa2\xed\x7e\x79\xff\xf6\x73\xe7\x8d\x38\x1c\xbf\x3c\xe9\xb5\xff\x56\xad\x65\xac\x87\x5a\xb2\x7f\xa9\x43\xc9\x93\x5a\x5c\xab\xc7\xb5\xb9\x36\x25\x7f\xa6\x2a\x9f\xe8\x8f\xf3\x3f\xff\xf8\xda\x1e\x5b\x1c\xbc\x36\xa3\xfe\xf9\x61\xe7\x72\x7c\xfe\xe6\x1d\xd3\xe3\xa3\x46\x29\x2f\x7b\x9d\xb7\x9f\xbe\x68\x9a\x5f\x7e\xbf\xfc\x69\x55\x82\x2e\x3e\x05\xba\xc3\xc0\x8d\x6f\x17\x04\x37\x16\x54\x0f\xc2\x2e\x03\x12\x91\x21\x83\xee\x04\x34\xce\x5e\x76\x72\x3b\x14\x3b\xd0\xc5\x8c\x96\x06\x81\x4b\x89\xda\xbf\xe2\x40\x46\x25\x48\x65\x21\x2c\x67\xa2\x64\x95\xcc\xb9\x36\x5e\x4a\x59\x0c\xfa\xde\x46\xf4\x86\x2b\x13\xfa\x72\x3f\x9c\x1b\x08\xa8\x99\xc8\xcc\x1d\xe9\x07\x5e\x6d\xd6\xfa\x66\xc5\x1f\xeb\x9e\x04\x46\x25\x06\x76\xa3\x12\x7f\x15\xa3\x85\x3a\xb2\xd0\xaa\xaf\xd1\x98\xbf\x76\xe3\x7a\xbc\xbb\x98\xff\x32\x9d\x70\x88\x9a\x67\x83\x78\x96\x9c\xb8\x4a\xae\x0d\xe3\xbd\x9e\xe0\xdd\xc4\xfd\x8f\x38\x8e\x3d\xb3\xf5\x3c\xe0\x97\x30\x11\xbc\xfb\x4c\x1e\xf7\x99\x2c\x5e\x7a\xfc\xdd\xfe\x70\xb2\x58\x64\xe6\x24\x81\xcf\x39\x4a\xd7\xf1\x6a\xf4\xf7\xa7\x0b\xd9\x82\xf6\x11\xac\x8b\xe1\x31\x17\x02\x0c\x86\xc6\x37\x53\x5a\xbb\xea\x2c\x54\x30\x5c\x49\xe3\x0a\x15\xff\xc9\xb5\xcf\x91\x6b\x9f\x0d\x74\xa9\x41\xe6\x12\x75\x41\x8d\x1b\x71\x37\xd2\x74\x88\xae\x82\x5b\xbc\x08\x52\x4b\xfd\x6d\x71\xa9\x05\xb4\x1c\x8b\x50\xd8\x98\xd7\x92\x7d\x44\x5b\x6a\x39\xff\xba\xe5\x12\xf7\x11\xf6\x68\x29\xec\xd9\xac\x71\xba\xbd\x25\x3b\x50\x59\x77\xd5\xed\xea\xda\x5d\x6d\x7e\x7b\x3b\x7f\x2e\x84\xea\x53\x64\x1f\xed\xb1\x40\x37\x3c\x9c\x9c\xb2\xad\xe5\xcb\x6d\x3b\x76\x77\x06\xb4\x96\xe4\x5c\xfb\xe6\xb8\xd6\x01\xfe\x26\xfb\x56\xf2\x75\x2e\x08\x09\xbf\x99\x84\xa7\xa8\xff\x05\x00\x00\xff\xff\xef\xab\xd7\xea\x91\x16\x00\x00")
+var _webfilesIndexHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xad\x19\x6b\x53\xdb\xb8\xf6\x3b\xbf\x42\xf5\xcc\x0e\x30\x5b\xdb\x24\xa1\xb4\x4b\x93\xcc\x42\x80\x96\x2d\xf4\xb2\x0d\xb4\xb4\x77\xee\x74\x14\x5b\x89\x45\x14\xc9\x95\xe4\x84\x94\xe1\xbf\xdf\x23\xc9\x8e\xed\x24\x50\xba\xdb\x0c\x43\x64\x59\xe7\xa9\xf3\x4e\xfb\x99\xef\x6f\xf4\x44\x3a\x97\x74\x94\x68\xb4\x15\x6d\xa3\xe6\x4e\xe3\x8f\xe7\x48\x61\x46\xd4\x50\xc8\x88\x04\x91\x98\x3c\x47\x94\x47\xc1\xc6\x01\x63\xc8\x1e\x54\x48\x12\x45\xe4\x94\xc4\xc1\x46\xff\xe2\xe8\xda\x3f\xa3\x11\xe1\x8a\xf8\xa7\x31\xe1\x9a\x0e\x29\x91\xfb\xe8\xb0\x7f\xe4\xb7\xfc\x1e\xc3\x99\x22\x1b\x27\x42\xa2\x61\x06\xf0\xcc\x9d\x44\x9a\xdc\x6a\x20\x43\x08\x3a\x3b\xed\x1d\xbf\xef\x1f\x07\xfa\x56\xa3\x21\x65\x04\x68\x21\x9d\x10\x20\x91\x0a\x24\x85\xd0\x08\x60\x13\xad\x53\xb5\x1f\x86\x22\x05\x68\x91\x19\xbe\x84\x1c\x85\x39\x36\x15\xd6\x88\xf9\x7e\x77\xa3\xfd\xec\xe8\x3f\xbd\xcb\xcf\x17\xc7\x00\x3a\x61\xe6\xd9\xf7\x55\x96\xa6\xc0\xb8\x42\x6f\x61\xeb\x8a\x8f\xb9\x98\xf1\x4b\x2c\x47\x04\x38\xf9\xab\x7f\xc5\xe1\x9d\x60\x20\xd4\x47\x2c\x29\x1e\x00\x27\x16\x91\xd2\x73\x58\xea\x79\x4a\x3a\x9e\xe1\x3a\x8c\x94\xf2\x60\x3f\xb4\x2f\x60\x91\x10\x1c\x77\x37\x10\x7c\xda\x2a\x92\x34\xd5\xd5\xc3\x37\x78\x8a\xdd\xae\xe7\xce\x98\x4f\x2c\xa2\x6c\x02\x9a\x0a\x66\x92\x6a\xb2\xe5\xb5\x07\x18\x54\x92\x48\x32\xec\x6c\x86\x1e\xfa\x1d\xcd\x28\x8f\xc5\x2c\x60\x22\xc2\x9a\x0a\x1e\xa4\x58\x27\x1c\x4f\x48\xa0\x52\x46\xf5\xd6\x66\xb8\xb9\xfd\xdf\xc6\xff\xe0\xa0\x17\x6e\xa2\xb0\xeb\x6d\xbf\x76\xf4\x43\x47\x2a\xe7\x66\x42\x34\xb6\x9a\xf3\xc9\xb7\x8c\x4e\x3b\x5e\x4f\x70\x0d\x64\x7d\xc3\x9f\x87\x22\xf7\x94\x33\x6a\xd4\xf4\x1a\x45\x09\x96\x8a\xe8\x4e\xa6\x87\xfe\xab\x9c\xe3\xb6\xa6\x1a\x04\xed\x33\x21\xd2\xbb\x3b\x3a\x44\x5b\x9c\xa0\xa0\x97\x49\x09\xd0\x16\x25\xdc\x9c\xe7\x6d\xdf\xdf\x23\x1f\xdd\xdd\x2d\xbd\xb9\xbf\xbf\xbb\x23\x3c\xbe\xbf\x6f\x87\x0e\x8f\xc3\xc9\x28\x1f\xc3\x15\xb3\x8e\x67\xd5\xa8\x12\x42\xb4\xb7\xac\x65\xa7\x12\x6f\x46\x06\xc6\x30\x54\xa8\x0c\x0b\x81\xd3\x7f\x1d\xcb\xa6\x4a\x84\xd4\x51\xa6\x11\x05\xb1\x36\x1d\xa2\x4d\x3a\xc1\x23\x12\xde\xfa\x6e\xcf\xe9\x77\x81\x6c\x88\xa7\x66\x3f\x80\x7f\x9b\xe1\xa3\x5c\x39\x2e\x0a\x13\x8c\x62\x1e\xdc\xa8\x98\x30\x3a\x95\x01\x27\x3a\xe4\xe9\x24\x1c\x80\x9d\x2a\x2d\x71\xfa\xe7\x6e\xf0\x22\x68\x85\x31\x55\x56\x84\xf2\x45\x30\xa1\xdc\xb2\xbe\xb0\x02\x04\x96\xae\xc9\x08\x4c\x60\x0e\xf4\x12\xdc\x7a\xb5\xeb\x5f\x5e\xbf\xd2\xcd\x97\xc7\xd1\x87\xe3\x16\x09\x69\x72\xf5\xf2\xfb\xe4\xef\xdb\x8f\x3c\x3a\x3a\x98\xbf\xc8\x4e\xdf\x7d\xdf\x95\xc7\xe3\xd1\xe9\x35\x39\x27\xf1\xee\xf9\xce\x0d\x1b\x9e\x1e\x5d\x4c\x47\x7b\xd9\xb7\x77\xa7\xcd\xdb\x6b\xd9\xac\x62\x8f\xa4\x50\x4a\x80\xc3\x52\xde\xf1\x30\x17\x7c\x3e\x11\x99\x33\x5d\x67\xb2\x1b\xed\x81\x88\xe7\xf0\x1c\xd3\x29\xb2\x02\x77\x3c\x60\x3c\x65\x78\xbe\x8f\x86\x8c\xdc\xbe\x06\x43\x8c\x75\xb2\xdf\xd8\xd9\xf9\xed\x35\x4a\x88\xf1\x7d\xfb\x50\xe8\xdf\x00\xd2\x18\xb8\x37\x17\xc3\xc8\x50\x73\x3c\x05\xc3\x62\x58\xa9\xa5\xcd\xd2\xf8\xdb\x2a\xc5\xbc\x20\x37\x04\x23\xf1\x15\xfd\x4e\xf6\x9b\x3b\xe9\xad\xe7\x8c\x0c\x4d\x77\x82\x26\xd8\x32\x9c\xeb\xb6\x07\xf2\x87\xa0\x8d\xa6\x01\x7d\x97\x0d\x88\x84\xfb\x20\xe0\xdf\xa0\x7d\x21\xe7\xe8\x23\x55\x19\x66\xf4\xbb\x75\xa2\x0a\x42\x8b\xd4\x99\xf2\x08\xa2\x1e\x23\x1c\xec\x99\x65\x4a\x13\xa9\xb6\x51\x03\x2c\xb9\x24\xc9\xf0\x80\x30\x04\xa1\xb0\xe3\x45\x35\xc3\xae\x51\xcc\xf7\xf6\xdb\xa1\x3d\x6f\x28\x84\x55\xbe\x09\x23\x91\xb6\xaa\x5a\x42\x82\x04\x07\x9f\xe3\x23\x10\x68\xd9\xe9\x8d\xd9\xa1\x0e\xc4\x42\xaa\x82\x29\x66\x19\x59\x13\x18\x14\xc1\x32\x4a\x3c\x23\x8d\x34\x58\x4a\x39\x2a\x32\x58\x16\x44\x6a\x00\x90\x45\xd4\xf1\xc0\x4d\xaf\x24\xbb\xbf\xf7\x90\x55\x43\xd0\xb7\x0c\x12\x70\x53\x95\xaf\x72\xaf\xed\x1a\x87\x2e\x3d\xb9\xf0\xfe\xbe\xc6\x3a\x53\xc8\x13\x63\xeb\xf7\x5b\x70\xca\x6d\xdd\xdf\x6f\x2f\xfc\xdd\x91\x34\xbc\xd9\x8d\x52\x1d\xa1\x23\x52\xdc\xc3\x0a\xe7\x96\x0e\xe6\x71\xc9\xd7\x3a\xaa\x4b\x66\x91\x5b\x5d\xe4\xb0\xf8\x60\xe2\x4c\x27\x7e\x85\x31\x08\x2f\x26\x00\x59\xe1\xcf\x21\x0d\x40\x6c\x80\xcd\x6e\x4e\x16\x51\x85\x2a\x87\x9d\x5a\x16\xc7\xf6\x51\x15\x68\x21\x61\x69\xa2\xf9\xd6\x8a\xa8\xf9\x3b\x06\xf1\xfd\xb1\xc8\xf9\x8b\xed\x8d\xf2\x34\xab\xa6\x21\x6f\xad\xe9\x95\xb6\xb0\x1c\xb2\x3d\x04\x71\xc0\xa4\x40\x80\xd2\x32\x23\x5e\xd5\x6d\x96\x24\x04\x56\x27\x08\x47\xe6\xaa\x3b\x9e\x87\x20\xe9\x24\x02\xc0\x20\xab\x56\x9c\xde\x9e\x84\x14\x8c\xde\x43\x3e\x87\x58\x0d\x76\x3c\xb2\x59\xfe\x5b\x46\xc0\x51\x63\x09\x5e\x0f\x86\xcd\x11\x56\x68\x46\xc0\x29\xd8\x1c\x25\x78\x6a\x56\xc5\x19\xac\x2d\xc0\x44\x98\xcc\x69\x53\xf3\x0a\xf2\xaa\xf2\x20\xc8\xc3\xad\x5a\x50\xaf\xfb\xb7\xf9\xaa\x2a\x0b\x92\xe6\x2a\x8a\xdc\x4b\x4d\xa2\xed\x78\x0e\xd2\xea\xad\x8a\x0a\x25\x34\x86\x22\xa7\x50\x4b\x69\xcb\x6b\xb8\xc9\x55\x66\x09\xd5\x5f\x57\xf8\x2c\xfc\xed\x98\xc7\x97\x74\x02\x28\x61\x81\xcc\xea\x81\xbb\xb5\xf0\x10\x77\xeb\x3b\x2b\xb7\x1e\x63\x4d\x34\x60\xf1\x4d\xa8\x60\x1e\x04\x4d\x92\x76\xbc\x86\x13\x68\x99\x66\x2e\xf2\xca\x76\xf8\x03\x22\x83\x4c\x6b\xc1\x17\x86\xf4\x5e\xcc\x0a\x54\xdc\x2c\x0d\x29\xb3\x58\x62\x3e\x34\xdc\x5b\x5b\x7a\x58\x2b\x4e\xe5\x90\x0c\xc6\x03\x1c\x8d\xbd\xee\x19\xac\xd0\x21\x2c\xd1\x07\x13\x2c\x1e\xd3\x4d\xed\x16\x17\x18\x2a\x17\x59\x62\x5d\x95\xae\x1e\x25\x1b\x10\x59\x1b\xe8\x2d\xd4\x9b\x65\x30\xfb\x01\x48\x0b\x40\x5a\x16\x44\x3d\x19\x66\x0f\x60\xf6\x7e\x12\xa6\xd1\x34\xbc\x35\x7f\x12\xaa\xb9\x6b\x25\x3a\xc2\xf3\xa7\x13\xda\x7b\x65\x61\x3e\x11\x32\x7e\xba\x16\x5a\x46\xa6\xa6\x05\x7a\x80\xbb\x5a\x12\x70\xd6\xf0\xb8\x31\x98\x0b\x85\x78\x1b\x81\x8b\x9c\xd8\x0d\xf4\xbe\xd8\x79\xb2\x39\x94\x38\x2a\xf6\x50\x41\xbc\x9e\xc3\xfa\xee\x13\xd9\x1d\x43\xa6\x5e\x70\xfa\x0e\x1e\xf6\xd1\x8f\xb9\x2c\x99\xb2\xe0\x39\xd7\x0e\xd5\xbf\xd3\x1e\x14\x82\x10\x8f\xfb\xf0\xbf\xaa\xac\xc7\x74\x65\x21\x2a\x1c\x39\x0c\x3f\xba\x79\xa5\xb1\xd4\xda\x06\xb2\xbe\x59\xda\x50\xf6\x64\xbb\x99\x08\x88\x53\x53\x88\xef\x50\xa6\x9e\xc3\x1a\x1d\xdb\x87\x27\xc3\x1b\xce\xbd\xae\xb1\x8b\x5f\x68\x74\x13\xac\x4d\x89\x65\xb0\x22\x77\x9f\x8f\xa8\x70\x35\xf3\x96\x96\xe7\x10\x2d\x59\x5e\x8e\xfd\xc9\xf7\x68\xf9\x17\x12\x42\xa2\x7d\xd5\xcf\x9f\xff\x01\x4b\x0b\x54\xd5\x4b\x5e\xec\x41\x17\x10\x91\x44\xb0\x98\x00\x6d\x9c\xa6\x1d\x68\x9c\x9e\x6b\xe8\xec\x9f\x75\x22\x1c\x25\xe4\x41\x96\xab\xe4\x54\x36\x98\xd0\xaa\xd5\xb4\x43\x53\x2e\x54\x9e\x4d\xa2\xbc\x14\xa3\x11\xb4\xd8\x6a\x46\x41\x15\x48\x0b\x5b\x20\xa0\x14\xcf\x99\xc0\x31\x72\xe5\xb1\xaa\xa5\x6b\xdb\x78\x14\x6d\x86\x05\xf3\x4d\x37\x8b\x29\x27\x72\xd9\x53\x52\x2b\x5e\x8e\xed\xd2\x16\x52\x7d\x83\xff\x22\xc7\xdf\x73\xf8\xdb\x61\xda\x5d\xa7\xfb\x1a\x15\x48\xf8\x8f\x67\x44\x50\x4c\x34\x1e\x88\x5b\xaf\x4a\xb4\x67\x36\xab\x95\x7e\x75\x9f\xc8\xad\x6d\x68\x99\xec\x32\x5e\x63\xe0\xd5\xda\x56\x31\x0a\xd7\x81\xa4\xc8\x4c\x40\xc8\xcb\xcf\x25\x03\x77\x56\x50\x51\x78\x51\x2b\xd4\x6a\xd2\x7a\x5b\x55\xb4\x71\x29\x21\x92\x48\x29\xa4\x5a\x34\x71\x66\xcb\xcf\xf7\xba\x39\xb2\x12\x30\x69\x76\xcf\xa0\x65\x06\xe5\xc1\xca\x75\x54\x1c\x0a\xbc\xe0\x84\x00\x9f\xd8\xf6\x13\xe5\x61\x9c\x37\xd2\x98\x11\x69\x1c\xfc\xc0\x7e\xb7\x43\x9c\x07\x44\x57\x72\x9f\xaa\x83\x18\x5a\xe5\x75\x80\x31\x19\x64\xa3\xb0\x68\x12\x8f\xcc\x13\x3a\x27\x3c\xab\xa2\x70\xc5\xe9\x4a\x91\x5a\xa0\x70\xf7\x02\xc5\x11\x36\x43\x00\xd3\xee\x7b\xdd\x23\x78\x32\x8e\x0d\xde\x2d\x24\xba\x84\x76\x0b\xd9\x7a\xb1\x44\xbb\x82\xa6\x98\x05\x8c\xa8\x4e\xb2\x81\x19\x91\x85\xe5\xc4\xcc\x8d\x29\xa0\xdb\xb0\xa3\xa5\x8e\xf7\x75\xc0\xb0\xa1\xd3\xb7\x73\x2b\x28\xdd\x63\x53\xd6\xa2\x37\x54\xbf\xcd\x06\x55\xde\xf3\x3e\xe8\x0c\x5a\xe6\x43\x2c\xad\x62\xd7\xc9\x50\xf6\x6e\xcb\x14\xe0\xcd\xa5\xad\xdf\xd7\x69\xc4\x28\xf7\x4a\x11\x59\xef\x4e\xc2\x6e\xdd\xc2\xe8\x88\x93\xd8\xa7\xdc\xcf\x94\x71\xa5\xbe\x7d\x36\x23\x39\x6c\xdb\x23\x87\xa0\x6c\x7c\x2a\x14\x36\xea\xe6\x56\x5a\x55\xdc\xfa\x9a\x80\x5d\x95\x73\x81\xe9\xa8\xe2\xad\x79\x3b\xbf\xe9\x26\x0d\x68\x65\xd4\xf0\x7a\xb3\xbb\x8a\x3a\x9f\xb3\x29\x19\x95\x77\x11\xb7\x6e\x94\x1d\x0a\xc6\xad\x60\xfa\x22\xb8\xb1\xf6\x5a\x9b\x87\xb9\x87\x9a\xbf\xd4\x30\x44\x70\x33\xc1\x8d\xad\xf3\xed\x95\xba\xa5\xdf\x0a\x76\x83\x86\x1d\xdf\xdc\xd4\xa6\x37\xcb\xf3\x9b\xe6\x8b\x3d\xbf\xd7\xbf\x16\xf2\x7a\xfa\x25\xba\x1c\x63\x7a\xbb\xf7\x79\x2a\xf6\xde\xa6\x69\xf4\xe5\x0d\xd1\x83\xcf\xe7\x6f\x3e\xf5\x4f\xd8\xe1\xec\xd5\xdb\x61\xef\x2f\xd1\xa9\xe3\x7a\x68\x5a\xf3\x2f\x65\xc8\x68\xd8\x08\x1a\xcd\xa0\x51\x48\x93\xd1\x27\x8a\xf2\x11\x7f\xbf\xf8\xe3\xe5\x97\xde\x4c\x93\xf1\x01\xdc\xd9\xc5\x61\xff\x6a\x76\x71\xf2\x2e\x96\xb3\xa3\x56\xc6\xaf\x86\xfd\x37\x1f\x3f\x4b\x9c\x5c\x7d\xbb\xfa\x69\x51\x9c\x2c\x36\xf6\x1b\x77\x83\x3f\xd3\xda\x31\x0a\x19\x5f\x0c\x91\x3b\xa5\x10\x27\x10\x46\x62\x34\x98\x9b\x71\xb3\x1b\xfa\x9a\x29\xe5\x73\x34\x20\x91\x19\xf4\x02\xd3\x60\x41\x76\xc0\x8b\x22\xb0\x61\x13\x7a\xdc\x76\xc4\xb2\xb8\x92\x32\xd6\xda\x4b\xc6\xd3\xf1\xc8\xea\x08\xdf\x52\xa1\xdc\xc8\xce\x2e\x0b\x05\x81\xcd\xcf\x79\x64\x82\xc6\x03\x03\xdd\xb5\x77\xb3\x74\x1f\xeb\xa6\x85\xd3\x8c\x38\x72\xb0\xf8\x55\x84\x4a\x71\x78\x2a\xc5\xc8\xcc\xb9\xff\xdc\x09\x9a\xc1\x4e\xf9\xfc\xcb\x64\x22\x13\x22\x69\x34\x0e\xf2\xf0\x47\x45\x08\x22\xd2\xe1\x90\xd1\x41\x68\xbe\xa7\x94\xcc\x2c\xb1\xf5\x34\xd0\x2f\x21\x02\xdf\x4f\xa4\xb1\x4a\xa4\x1c\x02\xdb\xaa\xe7\xe1\x60\x51\xc6\xfe\x30\x44\x9f\x12\xc2\xcd\x74\x42\x12\x5b\x38\x18\x93\x4d\x31\x44\x6c\x33\xa1\x43\x33\xca\x18\x52\xc4\x0d\x29\x22\x21\xa5\xa9\xa4\x5d\x39\x05\x65\xa8\x32\x35\x9c\x7d\x65\x46\x1d\xbe\x19\x75\x28\x64\xa6\xfe\xb1\x49\x05\x29\xc4\x45\x17\x65\x53\x2c\xa1\x36\x33\x85\x58\xf9\x63\x01\xa4\x27\x9b\x8f\x20\xe8\xa3\x8e\x21\xe1\x8a\x50\x75\xc0\xe3\x0f\x44\x67\x92\x17\x6f\xb7\x4c\x6a\x38\x22\x43\x9c\x31\x7d\x96\x37\xb9\x90\x26\x9e\xa3\xca\xbe\xe9\x44\x96\xf7\x16\x7d\x14\xbc\xc8\x7f\x49\xb0\x84\x8b\x5f\x29\x20\xc7\x1c\x33\x62\x96\x87\xf3\xd3\x78\xab\x9e\x3e\xb7\x8b\x39\x65\x95\xcf\xb5\x3f\x47\xac\xbd\x00\x9b\x2b\xbf\x42\x50\x5a\x73\x05\x2e\xe0\xb7\x43\x37\xa5\xfe\x3f\x1c\x32\x23\x95\xac\x1a\x00\x00")
 
 func webfilesIndexHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -276,7 +297,7 @@ func webfilesIndexHtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "webfiles/index.html", size: 5777, mode: os.FileMode(420), modTime: time.Unix(1659992879, 0)}
+	info := bindataFileInfo{name: "webfiles/index.html", size: 6828, mode: os.FileMode(420), modTime: time.Unix(1792210631, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -321,7 +342,7 @@ func webfilesResourceHtml() (*asset, error) {
 	return a, nil
 }
 
-var _webfilesSloopCss = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x56\x5b\x6f\xdb\x3a\x12\x7e\xb6\x7e\xc5\x20\xc1\x02\x69\x1a\xd9\xf2\x2d\x68\x64\xe4\xc1\x49\xdc\x6e\xda\x6e\xdb\xdc\x8a\xec\x16\x45\x41\x49\x63\x8a\x6b\x8a\xd4\x21\x29\x5b\x6e\x91\xff\x7e\x40\xc9\x52\x24\xc7\x69\x83\x03\xbf\xc8\xa3\x4f\x33\xdf\x7c\x73\x21\x7b\x87\x0e\x1c\xc2\xb9\x4c\xd7\x8a\xd1\xd8\xc0\x41\xf8\x0a\x06\x5e\xff\xe4\x08\x34\xe1\xa8\xe7\x52\x85\xd8\x0d\x65\x72\x04\x4c\x84\x5d\x8b\x9d\x72\x0e\x05\x56\x83\x42\x8d\x6a\x89\x51\x61\xbf\xf9\x72\x71\xef\x7e\x64\x21\x0a\x8d\xee\x65\x84\xc2\xb0\x39\x43\xe5\xc3\xd9\xcd\x85\x3b\x74\xcf\x39\xc9\x34\x5a\xe0\x5b\xa9\x60\x9e\x71\x0e\xbc\x04\x83\xc1\xdc\x1c\x81\x46\x84\x8f\x97\xe7\xb3\x4f\x37\xb3\xae\xc9\x0d\xcc\x19\x47\x60\x02\x4c\x8c\xa0\x30\x95\xa0\xa4\x34\x20\x15\xc4\xc6\xa4\xda\xef\xf5\x64\x8a\x42\xcb\xcc\x12\x94\x8a\xf6\x36\xde\x74\x6f\x2b\x5e\xcf\x71\x02\x19\xad\xe1\x97\xd3\x99\x4b\x61\xdc\x39\x49\x18\x5f\xfb\xa0\x89\xd0\xae\x46\xc5\xe6\x13\xe7\xc1\x71\xba\x7a\x49\xdd\x50\x0a\x43\x98\x40\x65\xd1\x31\xda\x2c\x7d\xe8\x7b\xde\xbf\x26\x4e\x67\xc5\x22\x13\xd7\xff\xe4\x12\xd5\x9c\xcb\x95\x0f\x3a\x54\x92\xf3\x89\xd3\x09\x48\xb8\xa0\x4a\x66\x22\x72\x43\xc9\xa5\xf2\x61\x15\x33\x83\x3a\x91\x0b\x2c\x42\xe8\x25\xb5\x7e\x7f\xba\x4c\x44\x98\xfb\xe0\xf6\xcb\xc8\x86\x85\x8b\x42\x84\x9a\xa3\x66\x3f\xd1\x87\xfe\x28\xcd\x0b\x44\x6c\x12\x7e\x04\x55\x16\x5b\xbc\x52\x12\x45\x4c\x50\x1f\xbc\x89\xd3\x49\x88\xa2\x4c\x94\xcf\x8f\x14\x63\x16\x45\x28\x26\x7f\x10\xa0\xa0\xf1\xcb\xe9\x6c\xc8\xef\xcf\xc6\xb3\x93\xb7\x5e\xf9\x8e\x51\x21\x15\xba\xa9\x64\xc2\xa0\x72\x71\x89\xc2\x68\x0b\x6e\x5b\x7c\x10\x52\x94\xc9\x76\x15\x96\xc5\x71\x03\xa2\x5c\x4e\x02\xe4\x16\x1f\xc9\x84\x09\x22\x8c\x1b\x10\x8d\x9c\x09\xf4\x21\x26\x82\x32\x41\x27\x0e\x00\xc0\xae\xf4\xbb\x31\x12\x93\x90\xb4\x20\x97\x29\x6d\xd9\x6d\xe2\xb6\x43\x3d\x0b\x30\x52\x72\xc3\xd2\x92\xb0\x66\x86\x49\xe1\xc3\x9c\xe5\x18\x59\x9d\x52\x12\x32\xb3\x2e\x45\x7b\x2c\x62\xbb\x7c\xb5\x2a\xc3\xb3\xd1\x60\x3c\xb0\x48\xa9\x22\x54\xae\x22\x11\xcb\xb4\x0f\x63\x4b\xb6\x13\xc8\xdc\xd5\x31\x89\xac\xea\x1e\x78\xd0\xf7\xd2\x1c\x14\x0d\xc8\x81\x77\x64\x7f\xdd\xf1\xab\x89\xd3\xb1\x79\xbb\x75\x19\x27\x8d\x96\xe8\x6f\x8a\xe4\x43\x7f\x90\xe6\xad\x12\x75\x12\x92\xd7\x1f\x8d\x8b\xd2\x5b\xcb\xa6\x2d\xc7\xad\xae\x74\xd7\x8f\x7d\xf9\xe0\x38\xfb\x86\x89\xb5\xdb\x14\xa1\xea\x19\xcb\xaf\x80\x44\x6c\xb9\x5f\xc9\xf8\xa3\x35\x06\xcd\xfe\xb2\xbe\x34\x97\x32\xe5\x38\x37\x82\x2c\x9f\x1f\x93\xe1\xa0\xf0\x5c\x7d\xed\xaa\x12\xb4\xb1\x36\x79\x92\xcc\xc8\xd2\x73\x98\x29\x85\xc2\x9c\x4b\x51\x0d\x43\xe9\xac\xf4\x6c\x07\x08\x39\x86\x8d\x17\x50\xbf\x79\x32\xbd\xb6\x97\xaa\xd8\x46\xa6\x55\xa6\x55\xd5\x2c\x7f\x1f\x0a\x89\x25\x67\x11\x04\x9c\x84\x8b\xc9\x93\x64\x5e\x34\xe5\xfb\x83\xd9\x70\x34\xf2\x1a\x3d\x72\xf1\xe6\x62\x36\x3b\xf9\xed\xcc\x6f\xab\xb8\xc3\x6d\xdd\x69\x55\x1e\x25\xe9\x7e\x5b\xd8\x66\x72\xcd\xe1\x19\x16\x96\x1d\xb3\xdc\x2e\x20\xf1\x39\x13\x0b\xf8\x05\x1b\x24\xb7\xf9\x53\x45\xd6\xf0\xf0\x04\xb9\x64\x9a\x19\x8c\x5e\x06\x8e\xad\x76\x8f\xd0\x62\x96\x76\xc0\x48\x68\xd8\x12\x77\xbb\x74\xba\x61\xa6\x8d\x4c\x7e\xa4\x4a\x52\x85\xba\x58\x38\x11\xd3\x29\x27\x6b\x1f\x98\x28\xc6\x28\xe0\xb2\xa8\xdc\x12\x95\x61\x21\xe1\x2e\xe1\x8c\x0a\x1f\x8c\x4c\x27\x4e\xc7\x36\x52\x65\x09\xb1\x5c\x08\x5b\x45\x7e\x70\x1c\xf2\xcd\x10\x45\xd1\x9c\xee\xfd\x08\x38\x11\x8b\xbd\xef\x3e\x99\x9b\xb2\xfd\x6d\x53\xa1\x1d\xc8\x4c\xf1\x83\x88\x18\xe2\xb3\x84\x50\xec\xa5\x82\x4e\xec\x0e\x3b\x1e\x1d\xb1\xaf\x67\x9f\xaf\x57\xde\x87\x77\x54\x4e\xa7\xd3\xe9\xa7\x9b\xbb\x78\x76\x47\xed\x63\xf1\xff\xc3\xf9\xf4\xbf\xd3\xe9\xf4\xfc\xd3\x7f\xf4\xeb\x13\x6b\xb8\x9a\xf1\xd9\xd5\xd7\xeb\xd1\xe0\xaf\xfb\x0f\xab\xab\xc5\xf4\x72\x9a\x5f\xdc\xdd\x45\xb9\xf9\x7c\xdc\xbb\x3e\xbb\x5a\x5c\xfd\x6f\x79\xc3\xde\x5c\xf6\xd2\x8f\xa3\x33\xf9\x6e\xd5\xbb\xff\xb2\x88\x47\xf7\x8c\x7e\x49\xf4\x1d\x8d\xbd\xe3\xc1\xf1\xf4\xff\xd7\x9a\xe6\xff\xbe\x5d\xdc\xdd\xc6\xfa\xdd\xe0\xb6\xa7\x2f\xf9\xcf\xe8\x56\xa7\xe3\xc1\xe2\xe6\xa6\xbf\xb2\x51\xce\xde\x5f\xdf\x8d\x67\x6a\xf1\x9e\x52\x7a\x7a\xfa\xaa\x79\x38\xc0\x30\xcd\xc1\x2b\x57\xd6\x83\xe3\x30\x91\x66\xe6\x9b\x59\xa7\x78\xba\x17\x11\x83\x86\x25\xe8\x72\x19\x12\xbe\xf7\xbd\x31\x6c\x03\xaf\xec\xb2\x5a\xbe\xee\x50\x61\xd2\x6e\x3b\xaf\xfb\x66\x6c\x6d\x5b\x5e\x83\xcc\x18\x29\x5a\xde\x86\xe3\x97\x38\xf3\x76\x38\xb3\x35\x6d\x13\x1b\xed\xf6\xf5\xe0\x38\xbd\x43\xb8\x95\x94\x72\x04\xbd\x62\x26\x8c\x41\x9b\x35\x67\x82\xda\x6b\x41\xb7\x34\xb5\x0f\xfd\xdd\xed\x05\x95\x78\xe5\xb8\x0d\xaa\xad\xf9\xd4\x45\xda\x74\xf2\x82\x16\x85\x86\x9b\xf6\xf1\xa4\x90\x13\x3b\x1b\x93\xe7\x7b\xbe\xde\xb5\xad\xf4\x37\x3b\x76\x43\xb8\x5c\x1a\xe5\x92\xad\x6c\x9b\x65\x5c\xec\x03\x80\x3a\x7c\x21\x72\x4b\x84\xea\x34\x2f\x40\x9c\x45\x9b\x13\xa1\xe6\x48\x02\x2d\x79\x66\x8a\xf3\x71\xfb\xdc\xed\x14\x52\xd9\xb5\x58\x52\xb0\x4f\x8f\x81\x3b\x81\x34\x46\x26\xdb\x87\x6e\xbd\xfc\xc2\x30\x9c\x38\x1d\x77\x85\xc1\x82\x19\xd7\x28\x22\xaa\x98\xdd\x91\xb6\xce\xb7\x2d\x4d\x92\x7e\x80\x73\xa9\xf0\x79\xae\xd5\x4c\xef\xed\x35\xdb\xa6\xb8\x6f\xd4\x47\x4b\xf9\xaf\x3e\x28\x1a\x94\xcb\xd5\xfa\xcc\x75\xef\x1f\xb0\x2e\x74\xf7\xc3\x18\xc3\x05\x46\xaf\x1b\x42\xef\xd0\xe5\x38\x20\xc1\x70\xdc\xfa\x70\x2e\xc3\x4c\xb7\x3e\xdb\xbe\x84\xa4\xf9\xce\x0f\xb7\x22\x36\x54\x6b\x65\x30\x97\x2a\xf1\xa1\x78\xe4\xc4\xe0\xfd\x81\x95\xc6\xee\x13\x37\xd1\x7f\x40\xfc\xf6\xed\x83\x9d\xce\x6b\x9b\x1d\x46\x50\x52\xd0\x70\xd8\x7b\x2c\x63\xb7\x48\xbd\xcc\xa8\x75\xd7\x1a\x6e\x6e\x86\xdb\xd0\x46\x06\xdb\xb7\xb3\x72\xd3\xff\x1d\x00\x00\xff\xff\x3a\xd5\xa1\xf2\xee\x0c\x00\x00")
+var _webfilesSloopCss = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9d\x56\x59\x6f\xdb\x38\x10\x7e\xae\x7f\x05\x91\x60\x81\xb4\x8d\x6c\xf9\x0a\x1a\x1b\x79\xc8\xe1\x66\xd3\x76\xdb\x26\x8e\x8b\xec\x16\x45\x41\x49\x63\x89\x6b\x4a\xd4\x92\xf4\xd5\x45\xff\xfb\x0e\xa9\xc3\x92\xea\xb4\xc1\xda\x40\x22\x8f\xe6\xfc\xe6\x9b\x21\x3b\x2f\x5a\xe4\x05\xb9\x14\xe9\x56\xb2\x30\xd2\xe4\xc8\x7f\x4e\x7a\x6e\xf7\xf4\x98\x28\xca\x41\xcd\x85\xf4\xa1\xed\x8b\xf8\x98\xb0\xc4\x6f\x1b\xdd\x73\xce\x89\xd5\x55\x44\x82\x02\xb9\x82\xc0\xca\xa7\x1f\xaf\x1e\x9c\x77\xcc\x87\x44\x81\x73\x13\x40\xa2\xd9\x9c\x81\x1c\x91\x8b\xe9\x95\xd3\x77\x2e\x39\x5d\x2a\x30\x8a\xaf\x85\x24\xf3\x25\x7a\xe1\x99\x32\xd1\xb0\xd1\x18\x0f\x80\xbc\xbb\xb9\x9c\xbc\x9f\x4e\xda\x7a\xa3\xc9\x9c\x71\xc0\xa0\x44\x47\x80\x81\x52\x41\xa4\x10\x9a\xa0\x6d\xa4\x75\xaa\x46\x9d\x8e\x48\xd1\x5a\x2c\x4d\x82\x42\x86\x9d\xdc\x9b\xea\x34\xe2\x75\x5a\x2d\x4f\x04\x5b\xf2\x6f\xeb\xd9\x5c\x24\xda\x99\xd3\x98\xf1\xed\x08\xeb\x4b\x94\x83\xf9\xb3\xf9\xb8\xf5\xbd\xd5\x6a\xab\x55\xe8\xf8\xa8\x40\x59\x02\xd2\x68\x47\x60\xaa\x1c\x91\xae\xeb\xfe\x36\x6e\x3d\x5b\xb3\x40\x47\xe5\x2f\xb1\x02\x39\xe7\x62\x8d\x7e\x7c\x29\x38\x47\x91\x47\xfd\x45\x28\xc5\x32\x09\xd0\x0f\x17\x58\xf9\x3a\x62\x1a\x54\x2c\x16\x60\x43\x60\x04\xe3\xf7\x9b\xc3\x92\x00\x36\x23\xe2\x74\xb3\xc8\x9a\xf9\x0b\x0b\x42\x99\xa3\x62\xdf\x00\x43\x0d\xd2\x8d\xd5\x88\x74\xcc\x8f\x49\x51\x45\x23\xaf\x94\x06\x01\x4b\xc2\x11\x71\xf1\x47\x4c\x65\xc8\x92\xec\x79\x97\x62\xc4\x02\xec\xc7\xf8\x17\x00\xd8\x34\xd0\x7f\x9e\xfc\xe1\x64\x38\x39\x7d\xed\x66\xef\x58\x98\x08\x09\x4e\x2a\x58\xa2\x41\x3a\xb0\xc2\xf6\x2a\xa3\x5c\x97\x8c\x48\x22\x92\xac\xd8\x36\x92\xc3\x36\xc7\xf1\xa8\x74\x38\xf5\x80\x1b\xfd\x40\xc4\x2c\xa1\x98\x85\x47\x15\x70\x84\x1a\xb3\xa3\x09\xe6\x1c\x8e\x5b\x04\x3f\xfb\xca\x6f\x47\x40\x75\x4c\x53\x9b\xdc\x52\x2a\x93\x5d\x1e\xb7\x1e\xea\x51\x05\x2d\x04\xd7\x2c\xcd\x12\x56\x4c\x33\x81\x18\xcd\xd9\x06\x02\x83\x53\x4a\x7d\xa6\xb7\x19\x68\xbb\x26\xd6\xdb\x57\xa2\xd2\xbf\x18\xf4\x86\x3d\xa3\x29\x64\x80\x85\x4b\x1a\xb0\x25\x16\x3e\x34\xc9\xa2\x70\xe3\xa8\x88\x06\x06\x75\x17\xbf\x5d\x37\xdd\x10\x19\x7a\xf4\xc8\x3d\x36\xdf\xf6\xf0\x39\x6a\x99\xba\x9d\xb2\x8d\xe3\x0a\x25\xba\x79\x93\xf0\xa9\x87\x96\xd5\x16\x61\x6f\x37\xa5\xd1\xd0\xb6\xde\x48\x72\x5a\x0e\x6b\xac\x74\xb6\x3b\x5e\x62\xfd\x87\x9a\x25\x5b\xa7\x0a\x42\xc1\x19\x93\x9f\x55\x09\xd8\xea\xb0\x80\xf1\x6b\x6d\x0c\xaa\xfc\x32\xbe\x14\x17\x22\xe5\x30\xd7\x09\x5d\x3d\x3e\x26\xfd\x9e\xf5\x5c\x58\x3b\x32\x53\xca\xa5\xd5\x3c\xe9\x52\x8b\xcc\x33\xb6\x4e\x22\x8b\x2e\x31\x7a\x3e\x0c\x99\xb3\xcc\xb3\xe9\xa3\xcf\x97\xca\xb0\x0d\x09\xc1\x75\xe4\x28\x4d\x25\x96\x16\x1e\x93\x3d\x6f\x38\x54\xc8\x2c\x24\x92\x0c\xc6\xf5\xf1\xea\x15\xfc\x4a\xc1\x30\x58\x4a\x21\xd5\x93\x6d\x1a\x01\xad\x75\xc5\x58\x5a\x6a\xed\xb5\x54\x38\x4c\x10\x60\xc7\x1d\x5c\x50\xb2\x39\xf3\x85\x1a\x4e\x07\xf8\x15\x10\xc8\x0e\x85\xe6\xa6\x32\x73\x53\xe0\xac\x45\x5a\x74\xb5\x60\xa8\xe9\x15\x22\x6f\xe8\x24\x38\x0b\x88\xc7\x91\xe3\xe3\x1f\x1a\xf7\xa4\x8d\x76\xd8\x9b\xf4\x07\x03\xb7\x32\x0f\x57\xaf\xae\x26\x93\xd3\x9f\xee\xb7\x26\x63\xf6\xb8\x2d\xa7\xaa\xa8\x23\x4b\xba\x5b\x27\x51\xb5\xb8\x2a\x66\x7d\x2b\xd9\xb3\xb7\xea\x64\xa5\x23\x1c\x3c\x5c\x71\x24\xd7\xe4\xa6\xfe\x50\xd2\x2d\xf9\xfe\x83\xe6\x8a\xe1\x9a\x80\xe0\x69\xca\x91\xc1\x6e\xa7\x6a\xf7\xc6\x1e\x35\xea\x6b\xb6\x82\xfd\x2e\x91\x50\xc8\x27\x11\x7f\x4d\xa5\x08\x71\x10\x2d\x11\x03\xa6\x52\x4e\x71\x46\x58\x62\x57\x86\xc7\x85\xed\x1c\x46\xc3\x65\x4d\xb9\x43\xd1\x05\x6e\x32\xc4\x05\xa5\x66\x68\x0a\x09\x1e\x85\x76\xf9\x35\x9a\x8c\x71\xe8\x67\x1c\x9a\x10\xf4\xd9\xc1\x57\x24\x42\xb2\x38\xf8\x32\xa2\x73\x0d\x39\x77\xd1\xca\x2c\x9f\xa5\xe4\x47\x01\xd5\x74\xc4\x62\x1a\x42\x27\xc5\xed\x6c\xf6\xf5\xc9\xe0\x98\x7d\xba\xf8\x70\xb7\x76\xdf\x5e\x87\xe2\x1c\x3f\xef\xa7\xb3\x68\x32\x0b\xcd\xa3\xfd\xfd\xf6\xf2\xfc\x4f\xfc\x77\xf9\xfe\x0f\xf5\xf2\xd4\x08\x6e\x27\x7c\x72\xfb\xe9\x6e\xd0\xfb\xe7\xe1\xed\xfa\x76\x71\x7e\x73\xbe\xb9\x9a\xcd\x82\x8d\xfe\x70\xd2\xb9\xbb\xb8\x5d\xdc\xfe\xb5\x9a\xb2\x57\x37\x9d\xf4\xdd\xe0\x42\x5c\xaf\x3b\x0f\x1f\x17\xd1\xe0\x81\x85\x1f\x63\x35\x0b\x23\xf7\xa4\x77\x72\xfe\xf7\x9d\x0a\x37\xbf\xdf\x2f\x66\xf7\x91\xba\xee\xdd\x77\xd4\x0d\xff\x16\xdc\xab\x74\xd8\x5b\x4c\xa7\xdd\xb5\x89\x72\xf1\xe6\x6e\x36\x9c\xc8\xc5\x9b\x30\x0c\xcf\xce\x9e\x57\x0f\x42\x82\xe4\xc0\xbf\xc3\x7c\xac\x58\x92\x2e\xf5\x67\xbd\x4d\xe1\xec\x00\x2b\x04\xcd\x62\x70\x10\x56\xca\x0f\xbe\x54\x86\xad\xe7\x66\x2c\x2b\xe1\x6b\xf7\x25\xc4\x75\xda\xb9\xed\x57\x43\x23\x6b\x78\xf5\x96\x5a\x8b\xa4\xe6\xad\x3f\x7c\x8a\x33\x77\x8f\x33\xd3\xd3\x7a\x62\x83\xfd\xbe\xd0\xae\xf3\x82\xdc\x8b\x30\xc4\xbd\xa7\xd6\x4c\xfb\x11\x51\x7a\x8b\xb4\x09\xcd\x15\xa8\x9d\x89\xea\x17\x9c\xfd\xf4\x22\x05\x78\xd9\xb8\xf5\xdc\x72\x6f\x35\x5d\xa4\x55\x27\x4f\xa0\x28\xa9\xb8\xa9\x1f\xc5\x12\x38\x35\xb3\x31\x7e\x9c\xf3\xe5\xb9\x52\x2b\x3f\x3f\x4f\xf2\x84\xb3\xa5\x91\x1d\x28\x85\x2c\x3f\x78\xec\x3e\x20\xa4\x0c\x6f\x41\xae\x81\x50\xdc\x5c\xac\x12\xee\xc9\xfc\xf4\x2b\x73\xa4\x1e\xae\xcf\xa5\xb6\x77\x81\xe6\x1d\xe3\x99\x85\xca\xac\xc5\x2c\x05\xf3\xb4\x0b\x8c\x8b\x18\x29\x11\x37\x2f\x18\xe5\xf2\xf3\x7d\x1f\x5f\x38\x6b\xf0\x16\x4c\x3b\x1a\x8f\x9d\x22\x66\x7b\xa0\x8c\xf3\xa6\xa4\x9a\xe4\xc8\x03\xbc\xa4\xc3\xe3\xb9\x16\x33\x7d\x70\x50\xa5\x8d\xbd\x5b\x95\x47\x4b\xf6\xab\x3c\x28\x2a\x29\x67\xab\xf5\x91\xab\xed\xff\xc8\xda\xe2\x3e\xf2\x23\xf0\x17\x10\xbc\xac\x00\xbd\x07\x97\x13\x8f\x7a\xfd\x61\xcd\x70\x2e\x70\x4d\xd6\xcc\x9a\x17\x2e\x9c\xf6\x7d\x86\x8d\x88\x15\xd4\x6a\x15\xa0\x10\x8b\xb6\x8f\xc8\x48\x78\x38\x32\xd0\x98\x7d\xe2\xc4\xea\x17\x1a\x3f\x7d\xfb\xdd\x4c\xe7\x9d\xa9\x0e\xcf\x94\x2c\x05\x85\x73\xb9\x6b\x63\xdb\x96\x9e\x55\x54\xbb\x57\xf6\xf3\x5b\x70\x53\xb5\x52\x41\xf3\x26\x9a\x6d\xfa\xff\x00\xd0\x4d\x90\x98\xda\x0d\x00\x00")
 
 func webfilesSloopCssBytes() ([]byte, error) {
 	return bindataRead(
@@ -336,12 +357,12 @@ func webfilesSloopCss() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "webfiles/sloop.css", size: 3310, mode: os.FileMode(420), modTime: time.Unix(1659992879, 0)}
+	info := bindataFileInfo{name: "webfiles/sloop.css", size: 3546, mode: os.FileMode(420), modTime: time.Unix(1792205152, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _webfilesSloop_uiJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x7c\x7d\x73\x1b\x37\xce\xf8\xff\xfe\x14\xe8\x26\xf3\x78\x15\xaf\x56\x92\x9d\xa4\x8e\x6d\xf9\x19\xcb\x2f\xd7\xfc\x2e\x49\xfb\xab\xd3\xbb\x66\x3c\x9e\x86\xda\x85\x24\x9e\x57\xa4\x8e\xa4\x2c\xa9\x39\x7f\xf7\x67\x48\xee\x0b\xf7\xcd\x2f\xb9\x36\x77\xee\x4c\xb3\x22\x01\x10\x00\x41\x10\x00\xb9\xdb\x7b\xb1\x05\x2f\xe0\x94\x2f\x36\x82\x4e\x67\x0a\xfc\xa8\x03\xbb\xfd\xc1\x9b\x00\x24\x49\x50\x4e\xb8\x88\x30\x8c\xf8\x3c\x00\xca\xa2\x50\xc3\x9e\x24\x09\x18\x58\x09\x02\x25\x8a\x5b\x8c\x4d\xfb\xe5\x4f\x67\xbf\x76\xdf\xd1\x08\x99\xc4\xee\xdb\x18\x99\xa2\x13\x8a\xe2\x00\x46\x97\x67\xdd\xbd\xee\x69\x42\x96\x12\x35\xe0\x05\x17\x30\x59\x26\x09\x24\x16\x18\x14\xae\x55\x00\x12\x11\xde\xbd\x3d\x3d\xff\x70\x79\x1e\xaa\xb5\x82\x09\x4d\x10\x28\x03\x35\x43\x10\xb8\xe0\x20\x38\x57\xc0\x05\xcc\x94\x5a\xc8\x83\x5e\x8f\x2f\x90\x49\xbe\xd4\x0c\x72\x31\xed\xa5\xd4\x64\xaf\x32\x5e\x6f\x6b\x2b\xe2\x4c\x2a\x58\x90\x04\x95\x42\x18\xc2\x97\x2d\x00\x80\x31\x91\x78\x46\xc4\xcd\x01\x5c\x79\xcf\x76\xcf\xf7\x5e\xbe\xec\x7b\x01\x78\xcf\xf6\x46\x2f\x77\x5f\xed\x9a\xc7\x97\x7b\x2f\x4f\x5f\x9d\xdb\xc7\xd3\x57\xaf\x5f\x9f\x78\xd7\x41\x8e\xfb\x4e\x2b\xc1\x20\x9f\xed\x9f\x9d\x9f\xbf\x31\x60\xe7\xaf\xce\xdf\x5c\x58\x3a\xe7\xa7\xe7\x17\x17\x2f\xcd\xe3\xc5\xde\xc5\xde\xc5\x79\x86\xbc\x10\x74\x4e\xc4\xc6\xa0\xee\x5f\x8c\x4e\x47\x23\x03\xb4\xbf\x7f\xda\x3f\xb3\xa8\xfb\x83\x93\xc1\xe9\xc0\x3c\xbe\x3a\xdf\x1f\x9c\x9c\x66\xa8\x33\x3a\x9d\x25\xf9\xb8\xa3\x8b\xd7\x83\xd7\x27\x06\xec\xac\xbf\xff\xfd\xf7\xe9\xb8\xa3\xd3\xd1\xbe\x25\x79\xb2\x37\x3a\xdf\x3f\xf5\x2c\xae\xfe\xf3\x9e\x8d\x5e\xee\x9f\x9f\x9c\x79\x81\xf7\xec\xe4\xec\x64\x7f\xf4\x5a\x3f\xc5\xe4\xfb\xd7\xaf\xfa\xfa\xe9\xec\xe5\x9b\xd7\x27\xdf\x9b\xde\xd1\xe9\xcb\x93\x51\x09\xf5\xe4\xe5\xc9\xeb\xb3\x5d\xdd\xf9\x66\x30\x3a\xbf\xb0\x4f\xdf\x8f\x06\x27\x86\xc8\xfe\xc9\x9b\xd1\xeb\xfd\x8c\x51\x89\xb7\x28\xa8\xd2\x42\x6e\x3f\x7b\x39\x3a\xdb\x7f\xf5\x6a\x3b\x80\xed\x67\xe7\xfd\xf3\x7e\xbf\x6f\x1e\xcf\xf6\x5f\x8e\x5e\x8e\xb6\xaf\x83\xad\xbb\xc3\xad\xad\xad\x5e\x0f\xfe\x92\xf0\x31\x49\x24\xbc\xa3\xb7\x08\x3f\xa0\xc0\xad\x04\x15\x28\xbe\x38\x59\x53\x19\xc0\x98\x2b\xc5\xe7\xfa\xf9\xd0\x80\x7f\x9c\xa1\x44\x98\x2c\x59\xa4\x28\x67\x12\xa6\xa8\x20\x22\x49\x82\x31\xac\x66\xc8\x34\x07\xc6\x7a\x16\x82\x2f\x50\x28\x8a\x12\xf8\x04\x90\xaa\x19\x0a\x20\x6b\x2a\x81\x08\x84\x68\x46\xd8\x14\x63\x77\xa8\x33\x41\x56\x17\x4b\x16\xb9\x43\x66\x6d\xee\xd0\x1a\x3d\xde\x03\xa9\xc4\x32\x52\xd2\x50\x58\x6b\xd8\xcb\x88\x24\x18\xc0\x46\x3f\x8f\x08\x8b\x2d\xce\x89\x10\x64\x03\x11\x67\x8a\x50\x46\xd9\x14\x62\xa2\x08\x08\x54\x82\xe2\x2d\xc6\x30\x11\x7c\x0e\x32\xe1\x7c\x01\x66\x59\x09\x43\x50\x03\xe5\x63\x82\xa2\x73\x84\x98\xca\x45\x42\x36\x18\x03\x67\x10\xa1\xd0\xf4\x60\xce\x97\x12\xb9\x16\x99\xb0\xd8\xfe\x9a\xf3\x5b\x04\xbc\x45\x96\xf2\xa6\x66\xf8\x91\xce\xd1\x95\x20\xc6\x09\x65\x68\xb4\x34\x27\x6b\x3a\x5f\xce\x21\x16\x64\xa5\xb9\x93\x0b\x12\xa1\x1e\x41\x77\xae\x28\x8b\xf9\x2a\x84\xb7\x10\x73\xb6\xad\x49\x51\x76\xa3\xc9\xa8\x19\x95\x40\xa5\x01\x8a\xb8\x10\x18\x29\x58\x91\x8d\x56\xf4\x52\x6a\x32\xca\x8c\x73\x4b\x84\x84\x2e\x50\x05\x31\x47\xa9\x29\x08\x24\x49\xb2\xd1\x2e\x64\x61\x70\xcc\x00\xfa\x27\xfd\x9d\xb2\xa9\x26\xad\xe5\x58\x21\x15\x31\xcc\x29\xd3\xac\x49\xd3\x94\x4a\x0f\x32\x22\x89\x1e\x20\xe2\xcb\x24\x86\x05\x57\xda\xe3\x18\x9a\x91\x5e\xf9\x7a\xd6\xc7\x09\xce\x65\x68\xd5\x68\xb1\xde\x93\xf5\xaf\x81\xf3\xe3\x93\x55\xc6\xdf\xb4\x79\x44\x24\x31\x42\x6b\xa2\x63\x54\x2b\x44\x06\x63\x22\x64\xea\x3e\x04\x5a\x67\x33\x22\x22\x03\xbf\x4c\xa1\x87\xd0\x0f\x77\x2d\x25\x79\x3b\x05\x81\x13\x14\xc8\x22\x84\x09\x17\x20\x90\xc5\x28\x8c\x46\x6f\xa7\xa9\x52\xe2\x3d\xc3\x94\xbc\x9d\x5a\xac\x4b\xaa\xa1\x57\xb8\xad\x0d\x2a\xd5\xbf\x1e\x5a\xab\xdf\xfc\xbb\xa2\x5a\xe3\x46\xcb\x92\xcc\xb1\x30\xad\x15\x8d\xd5\x0c\xba\x76\x46\xa9\x84\x39\x11\x53\xca\xd2\x79\xb5\xd3\x62\x27\x32\x93\xc8\xba\x53\x2b\x8a\xa6\x0d\x5d\xa3\x55\xaa\xb6\xa5\x63\x9b\x9a\xde\xd8\x4c\x80\x1d\x38\x1d\x3b\x1f\xd6\xb2\x3f\x27\x49\x32\x22\xe2\xbd\x19\xf3\x70\xcb\x34\xa6\x0c\x64\x4e\x56\xf1\xc5\x01\xec\xf6\xad\x53\x48\x70\xa2\x0e\x60\xd0\xef\x9b\x15\x9f\xda\x14\x67\x66\xd2\xb5\x5f\x4e\x38\x89\x2f\xff\xf6\x97\xc3\xad\xad\x6c\x51\x03\x65\x54\xcf\x2a\xfd\x1d\xcf\xe8\x1c\x99\xd4\x0b\xdd\xef\xa4\xc4\x9d\x49\x85\x21\xc4\x3c\x5a\xce\x91\xa9\x30\x7b\x38\x4f\xd0\xfc\x8e\x12\x8a\x4c\xfd\x5d\x6b\xea\xb0\x82\xf7\xe9\x61\xbc\x1f\x50\xfb\xdb\xc3\xad\xbb\xad\xad\x18\x15\xa1\x09\xc6\x1f\x39\x4f\x3e\xd2\xc5\x5b\xf9\x37\x2a\xe9\x38\xd1\xac\x4f\x48\x22\x31\x55\x01\xe3\x97\x5c\xa8\x0b\xad\x84\x5c\x8e\x9c\x67\x81\x6a\x29\x18\x58\x15\x58\xcb\x8a\xf8\x7c\x41\x04\x5e\x2a\x52\xc3\x22\x01\x8c\x33\x4c\x3a\x01\x9f\x84\x37\x94\xc5\xf0\xdd\x10\xc6\xe6\x29\xeb\x73\x28\xa7\xd4\xfe\x4a\x59\x6c\xd1\x0d\xc0\x9d\x3b\x38\x09\xa5\x1e\x0b\xba\x30\xb6\x4f\x87\x9a\x9b\x12\x33\xef\xb9\x54\xe7\xc6\x75\x7c\x13\x8e\xc6\xa1\x76\x5d\x09\xd9\xc8\x30\x41\x36\xd5\x26\x0d\xa4\xda\x56\xe7\xf2\x03\x99\xe3\x37\xe1\xcf\xdf\xde\x86\x1d\x20\xa1\x0e\x55\x3a\x61\xc2\xb5\x83\x3f\xb5\x68\xfe\xd8\xb6\x1a\xee\xf4\xf4\x47\xf3\x85\xe1\x29\x33\x03\xd7\x9c\x53\x0b\xcf\xad\x61\x41\x36\xba\x49\x5b\xe1\x5e\xf8\x0f\xc9\x99\xaf\xfd\xfd\xff\x5f\xa2\xd8\xfc\x22\x92\xce\xa1\x0b\x14\xaa\x19\x32\xbf\x90\x54\xa0\x5c\x26\xca\x95\xa7\x79\xb1\x1c\xe6\xfd\xda\x01\x0d\x53\x87\x94\xa1\x17\xbd\x63\xca\xe2\xf7\x7a\xdf\xb0\xf3\xee\xcb\xdb\xa9\xd3\x4b\x16\x0b\x64\xf1\xc9\x1a\xab\x1d\x96\x9c\x5e\x12\x8a\x2e\xb2\xd1\xee\xb4\x3a\xb6\x72\x69\xad\x97\xfb\x29\x15\x56\xf1\xe9\x34\x41\x90\x2b\xaa\xa2\x99\xd9\xc3\xcc\x16\x0c\x8a\xe7\xce\x3d\xd3\x4b\xd6\x43\xa3\x1b\x59\x68\x31\xed\x3d\x9d\x61\x74\x83\xc2\x2f\xa6\xdb\xcf\xd7\xf2\x14\xb3\x65\x3c\xda\xbc\x8d\x7d\xcf\x45\xf1\x3a\x61\x64\x50\x63\x18\x0e\x41\x89\x25\xba\x4a\x34\xdb\x74\xa8\x37\xe3\x26\x6a\x72\xb4\x39\x4d\x88\x94\xda\xf2\x1c\xaa\x9a\x4b\xaf\xd3\xc9\x89\xe8\xbf\x70\x4e\x16\x3e\xc2\xf0\x18\x30\x94\x6a\x93\x60\x98\x49\x37\x04\x6f\x9c\x70\xcd\x48\xaa\x2d\xc0\x44\xe2\x9f\xc1\xc3\xfd\x4c\x30\xce\x30\xe7\x41\x3b\xb8\x5e\x0f\xe0\x2c\xed\x8f\xe9\xc4\x6c\x63\x0a\xfe\xa9\xcd\x11\xe6\xa8\x66\x3c\x96\x26\xf4\x35\x91\x87\x20\x31\xe5\x70\x4b\x92\x25\x16\x53\x63\x60\x2d\x2f\xbe\x01\x70\xd7\xa2\x69\x08\x0d\x06\x0c\x87\x43\xf0\x04\x4e\x71\xed\x7d\xad\xf6\x53\xec\xaf\xd5\xfa\xd3\x15\x2d\x14\xd5\x42\x3e\x69\xc8\xb2\x8e\x5b\x35\xe1\x10\xff\x66\xda\x70\x59\xfb\x36\xca\x28\x5b\xbd\xb6\xb8\xdc\x70\xca\x5e\x29\xd5\x41\x16\x00\xc3\x50\x87\x73\x11\x4a\x79\xc2\x62\xed\x55\x7f\x4e\x23\x18\x59\x76\x63\x19\xfc\x68\xa3\x9d\x79\x00\xda\xe1\xcb\x40\xa7\x92\x0a\x05\xc6\x67\x36\x96\xce\x67\xe1\x3b\x0d\xeb\xea\xbb\x88\xde\xad\x47\xd6\x31\x26\xfe\xa2\x22\xbf\x13\x0a\x63\xd2\x57\x36\xbc\x09\x75\x24\x13\x94\xe2\x8f\x2e\x38\x5d\xd7\x8e\x56\xf3\x98\xc9\x21\xa9\x7f\xfa\x9d\x70\x41\xe2\x98\xb2\xa9\xdf\x1e\x5a\x6a\xcf\x99\x11\xaa\x24\x27\x96\x9c\x4e\x63\x3e\xf2\x85\x5f\x70\xee\x7a\xf4\x5a\xf6\x52\x20\x8d\x4c\x5f\x33\x9e\xab\x2f\x18\xc2\xd5\x75\xb3\x97\x2a\x34\x6d\xc9\x32\x94\xca\xef\x84\x37\xb8\xf1\x63\x6d\x00\xb1\xdd\x70\x43\x64\x3a\xc5\x91\x66\x6b\x73\x46\x31\x93\xa3\x31\x73\x32\xc6\x76\x32\x54\xdc\xb8\xc2\x8f\x89\x38\xe5\x09\x17\x7f\x41\x56\xc8\x61\x74\xf9\xa3\x88\x29\x23\x89\xdf\x09\x63\x3e\x27\x94\xf9\x86\x6e\x36\x61\x69\xd2\x1f\xe6\x89\xb3\xbb\x1d\xa6\x39\x6a\x0b\xe1\x77\x94\x21\x11\x05\xdd\xab\x7e\x00\x83\x00\x76\xaf\xab\xb4\x33\x3a\x2e\xbf\xed\x96\x54\x5e\x2d\x19\xed\x78\x2f\x9c\x53\xbb\xfb\x07\x90\xaa\xc0\x84\x66\x9d\x40\xa3\xcf\xc9\xba\xdc\x87\x2c\xee\x5c\x57\x68\x3d\xd9\x44\x1f\x61\xa3\x8d\xdc\xc6\x7b\xe9\x58\x9a\xa5\x34\x38\xab\xba\x81\x32\x33\x8a\x2f\x02\x70\xc1\xe1\x05\xf8\x7b\xfd\x4e\xa7\x60\x4a\xf1\x45\x55\xa0\xa7\xad\x8f\x72\x3a\x62\x92\xb2\x01\xbc\x28\x64\x0b\xc7\x59\xbe\x64\x02\x92\x76\x6b\x0f\x23\xce\x22\xa2\x42\xb2\x58\x24\x1b\xff\xea\x3a\x68\x31\x51\xe3\xbe\x65\xa7\x65\xe1\x84\x13\x2e\xce\x49\x34\xcb\xa0\x23\x6d\x65\x56\xbf\xe6\xd1\xaf\x98\xb4\x9f\x2e\x97\x4e\xee\x1e\xb7\x9e\xbe\xea\x9f\xba\xe2\x73\xaf\x29\x6f\xa7\x26\x41\x82\xa1\x63\xba\xe9\x24\x76\xae\x06\xd7\xb0\x03\xfe\x2e\xbc\x70\x2d\xc8\xf1\xb9\xf2\x76\x6a\xd3\x24\x18\x3a\xfa\x6e\xc5\x56\x7c\x91\x8d\xdd\xeb\x81\x48\x0b\x15\x6b\x2a\x55\x96\x26\xe7\x29\xb4\xcd\xf9\x05\x46\x02\x89\x42\xa0\x2a\x84\x2c\xd6\xd3\x61\xa8\xe3\x8d\x6c\x70\xab\xad\x17\x13\x8c\x94\xef\x3d\x8b\xf7\x7e\x9b\xa1\x28\x6d\x71\xf2\x76\x9a\xf6\x9f\x24\x89\xbf\xfd\x62\xbb\x13\xda\xe1\xfd\xd2\x16\x7d\x0f\xad\x9c\x54\x68\x23\x62\xdf\x93\xb7\xd3\x52\xb3\x52\xc2\xf7\x6e\x29\xae\x46\x7c\xed\x05\xf0\xb9\x0f\x7d\x78\xfe\x25\x53\xf0\x9d\x7d\xb6\xea\xba\xfb\xec\x20\x46\x7a\x77\x45\x4b\xb0\xab\x53\x71\x64\xca\x0b\x6c\x7c\xaa\xed\xd5\x40\x6a\xbe\xb4\x10\xd9\xe0\xd3\x4c\xba\x5e\x0f\x4e\xad\x8e\x74\xa2\x3e\x15\x64\x31\x33\x15\x0d\x81\x0b\x81\x12\x99\x22\x66\x9b\xe5\x13\x40\x12\xcd\xf2\x12\x80\x25\x2a\xf8\x72\xa1\x5d\xf1\xb4\xe0\xa6\xd0\x92\x57\x12\x4f\x2f\x05\xdf\xb5\x73\xa7\x0f\x99\xd2\xe1\x78\x5d\x45\x0d\x0a\x52\x82\x30\x39\xe1\x62\xee\x69\xc7\x10\x00\xed\xe8\x65\xf2\xd9\x34\x27\x44\xa1\xaf\x95\x96\xdb\x92\x4f\x3b\xb0\x53\x59\xe1\x77\x1d\x57\x7b\x5a\x2a\xdf\x5a\xc9\xcf\x85\xbb\xc8\xcc\x2c\x8f\x67\x4c\x7c\x7a\x69\x64\xe3\xc2\xf7\xc6\x3c\xde\x78\x9d\xb0\x50\x80\x79\x38\x74\x53\x3f\x79\x3b\xd5\x81\x4a\xe6\xe4\x75\x62\x87\x2b\x78\x4f\x16\xfe\xd5\x95\xf7\x81\x8b\x39\x49\xbc\xa0\x7f\x1d\x5c\x79\x7f\x27\x82\x51\x36\xf5\x82\x81\xfe\x75\x2e\x04\x17\x5e\xb0\x7b\x6d\x3c\x6d\x91\xbb\xdc\x1f\xc7\x38\x81\x8f\x36\xa1\x1f\x17\xb6\xb4\xa9\xb3\x36\xdd\x1f\xea\xc6\xdf\xb8\x6d\x3d\x74\x22\x99\xb4\x5b\xf0\x95\xec\x54\xb6\x68\x18\xc2\x97\xbb\xf6\x1d\xbc\xa0\xad\x91\x0b\xff\xf6\xa5\xe4\x8b\xb3\xa4\xb6\x5c\xab\x38\x2c\xc1\xa4\x09\x9d\xef\x30\x1e\x4a\x2e\x4a\x29\x6a\x4e\x8f\x48\x04\xcf\xec\x70\x8a\xce\xd1\x3b\xa8\x41\x3c\x76\xd4\xec\x6f\x2c\x90\xdc\xd4\xbb\xec\x40\x8c\x3c\x76\x0c\x5b\x52\xf8\x8a\x21\xe6\x5c\x2a\x5b\x6c\x7d\xdc\x40\x6e\x85\xe5\x49\xc3\xc5\x38\x21\xcb\x44\xb5\x0c\xc2\x99\xe4\x09\x86\x09\x9f\xfa\xde\x2f\xec\x86\xf1\x15\x03\x3d\x09\x07\xe0\xc1\x0e\xd4\xa6\xe6\xd1\x23\xdf\x6d\x95\x7e\x5a\x93\xc9\xcb\x7c\xee\x5f\x18\x86\x71\x50\x6b\x35\x53\x7d\x90\x45\x35\xbf\xc5\xda\x53\xbd\x80\x41\xbf\xdf\xaf\xc3\x22\x8b\x0f\xc0\x6f\x00\xd5\x4e\xc0\x8f\xc3\x78\x29\xac\x37\x4b\x5b\xeb\x14\xb2\xca\x91\x1e\x30\xaf\x22\xe5\x99\x49\x9d\x67\xb0\x1e\x14\xb3\x52\xf6\x8f\x16\x27\x2d\xed\xa7\xe5\xd4\x18\x28\x6b\xc3\x5c\xdc\x4c\x7b\xa6\x76\xdf\xd3\x1e\x86\xa2\xec\xa9\xcd\x02\x65\x38\xe5\x8d\x18\x66\xd3\x5c\x24\x54\x7d\xc4\xb5\xd6\x22\x9a\x1a\x52\x68\x9a\x7c\x0f\xbc\x4e\x2b\xd6\x8a\x0b\xa9\x2e\x0b\x67\x94\x06\x87\x39\xb1\xc0\x1c\xa7\xb5\x4b\x09\x8e\x67\x4b\xa9\xe8\x24\xcf\x77\xc7\x3f\xf0\xf4\xa6\xdd\xcc\xc3\x9d\x1b\x72\x55\x99\x4b\x55\xdd\x68\x16\xd9\x5f\x18\x86\x58\x9f\xb0\xec\x2f\x35\x13\x1f\x1b\x26\xbf\x1d\xcb\x1a\x4c\x13\x8e\x36\x18\x7c\x84\xc1\xe4\xe3\xe7\xe7\x56\x25\x45\xb7\x23\x08\x24\x92\xb3\x83\x74\x06\xdb\xe1\x22\xbe\x64\xea\xa0\x98\xf4\xab\xdd\xeb\x66\xe0\xbb\xe6\x25\x99\xce\x59\xaa\xe1\x1a\xc8\x5d\x79\xb6\x2a\x44\x52\x64\xbb\x68\xb7\x0a\x1c\xe3\x03\x7c\xe3\x98\x4a\x15\x3d\x03\xad\x37\x87\x86\x44\xbd\x56\x2d\x2d\x17\xb6\xb3\x4a\xa9\x4d\xfd\xaa\x95\x52\xd3\x5a\x22\x57\xa9\x2b\x66\xfb\x1f\x65\x58\x89\x74\x74\x53\x3d\x8c\xd8\x0c\xbc\xa0\x21\xe4\xec\x5f\xd7\x21\x77\x1b\x21\x07\x75\x48\xa9\x04\xbf\x41\x2f\x00\x4f\x4c\xc7\xc4\xef\x07\xe6\xbf\xf0\x55\xc7\x1d\xde\xd4\x36\x7c\x6f\xc1\xa9\x0e\x7a\xba\xa9\xe7\x0f\x8a\xaa\x8a\x1b\xbd\x5b\x51\x9e\x1e\x17\xdd\x1b\x13\x39\xc2\x96\x43\xa1\x88\x24\x89\x5f\xc9\x1b\xda\x85\xcc\xb2\xd8\xfc\x48\xbb\xac\x92\x3c\x2a\x4d\x09\x3a\x11\x29\x94\x12\x8e\x3f\x57\xc6\x41\x93\x8c\xf5\x6c\xe7\xdf\x17\xb3\xa0\xe9\x48\x5a\x2f\x54\xe5\xf5\xee\xfc\x9c\xcb\xfc\x2e\x67\x0d\x36\xba\xac\xab\x24\xa6\xb7\x5e\xb3\x8a\x0d\x91\x6c\xe0\xd2\xb0\x4d\xd5\xf9\x74\x6c\xbd\x4a\x38\xf3\xbd\xfc\xd0\xd7\x0b\x1a\x0e\x9e\x20\xf5\xd1\x57\xeb\x00\x36\xd7\xe9\xce\xa1\x31\x7c\x35\xa3\xd2\xf5\xea\x3a\xa0\x74\x92\x40\xca\x6e\x51\x28\x7f\xdd\x81\x23\x37\x37\x4c\x6b\x01\xda\xfc\xe0\x5f\xff\x6a\xc1\x38\x6e\xc4\x18\x5c\x77\xaa\x31\x61\x29\x6e\xc9\x8f\x63\xc7\x44\x00\x5f\x2a\x9d\xb5\x8c\xf9\x92\xc5\x12\xd6\x8e\xe2\xd2\x70\x56\xb3\xbb\x81\xa3\x46\x2f\x60\x38\xdb\xc0\x71\xf3\xc2\xff\x5a\x26\x14\x5f\xd4\xd9\x28\x93\xd2\xde\xaa\xc1\xda\x1d\x3b\x7f\xfe\x65\x7d\x07\xfd\xce\xe7\x4a\x08\x96\x1e\xd2\x97\xf3\xf0\x5c\xa1\x65\x58\x5b\xc3\x6c\x39\x94\x6c\x8a\xba\xed\x1d\x07\x63\x64\xbf\x5a\x0b\x30\x7e\x2b\x5c\x90\x29\xfe\x5a\xdf\x77\x1c\xf0\x4f\x55\xf0\x4f\x75\xf0\x05\x97\xa6\x24\x9c\xad\x8d\x6c\xa4\x20\x27\xd2\xa9\xc6\x94\xe5\xa7\xbb\xbc\x2e\xe3\x66\xe9\x5e\x98\x25\xab\x5e\xa7\xb0\x73\xbd\x11\x96\xec\xbc\x74\xb2\xf7\x24\xcd\x14\x2b\xd6\xac\x84\x74\xda\x26\x34\x49\xbc\x20\x2b\xdc\x84\x31\x11\xe6\xac\xa9\x3a\x5d\x56\xb2\x6c\x3b\xe0\x0b\x12\x51\xb5\xf1\x02\x18\x74\x6a\xc2\x15\xcc\x27\x48\x2a\xab\xf4\xcf\xe2\xfe\x91\xc5\xa6\x07\xc5\xe9\xdf\x27\x4e\xcd\xe7\x7c\xbd\x34\x19\x03\x33\x35\x4f\xfc\x29\x2a\x27\x97\x3f\xb5\x25\x11\xbf\x66\x77\xcd\xb1\xa6\xa2\x2a\x41\x1d\xff\xb7\xc7\x65\x5a\x05\x07\x69\x99\xba\x19\x42\xe7\x8d\xe6\xfe\x84\x06\xcb\x7f\x34\xc3\xea\x3c\xf6\x20\x5b\xbf\xf5\x00\xad\xd4\xd2\x69\x51\x67\x94\xd0\xe8\xa6\x5d\x95\x72\xc6\x57\x67\x8e\x26\xf5\x2a\x8b\x83\x7c\x61\x06\x90\xba\x72\x48\x17\x13\xd8\xe4\xe4\x2d\x53\x4b\xaa\xe8\x2d\x26\x1b\xd8\x8e\xb7\x35\x99\x65\x12\xc3\xd8\xd6\x8a\xb6\x67\x48\xd4\x9c\x2c\xb6\x01\xed\x49\x0f\x74\x61\xbc\x54\xe6\xf2\xca\x6a\x46\x94\xb9\x47\x65\xc3\xdc\x8c\xa0\x46\x33\x23\x9a\x6d\x49\x66\x37\x7f\x92\x8d\x41\xd4\x43\xa4\x79\x54\x7e\xd3\x24\x25\x1d\xc2\x07\xae\x40\x2e\x05\xc2\x6a\xb6\x81\x2e\xbc\x4d\xaf\x12\xa5\x84\xe3\xbd\x94\xa2\x8d\xa7\x74\xfe\xa5\xdd\x75\xb2\x81\x84\xde\x68\x76\x89\x0a\x1b\x1c\x44\x2a\xc1\x9f\xe4\x1f\xb4\x1b\xd4\xf1\x2b\x53\xa7\x59\x0d\xb7\xe2\x14\x0e\x6b\xf0\x69\x9c\xfe\x96\xc5\xb8\x86\xa1\x46\x97\xf8\x96\xd9\x35\xaa\xf3\xad\x13\xa5\x04\x1d\x2f\x15\xfa\x1e\xd5\x30\x5e\x75\x25\xda\x0b\x5b\x54\xfe\x98\xa7\x54\x45\x26\x7b\xe5\x52\xbf\xae\xe4\x63\x65\x7f\x10\x5a\xc6\xd3\x33\xbc\x4e\x1e\x9a\xb8\x3e\xb5\xe4\x31\x1c\x41\x2b\x1c\x7d\x9d\xa3\x71\x64\xb0\xf7\x24\x3a\xae\x2b\xad\x89\x9c\xd6\x3d\x1b\x33\x48\x8d\x7e\x00\x55\x82\xf5\xc5\x78\xff\xb2\x7e\xec\x92\x7e\xc0\x7f\xa4\x89\xaa\xcb\x8d\x69\x6a\xa9\x66\xb8\x70\x58\x65\xeb\xae\xa2\x88\xc7\xce\xfb\xd3\x67\xa7\xe9\xc8\xab\x34\x45\xf9\x59\x56\xa7\x6d\xc7\x6b\x63\x27\x34\x0a\x83\x21\x34\x98\xb8\xe9\xf2\x9a\xf7\x9a\x7a\xfd\xe8\xbe\xbd\x34\x07\xca\x36\x88\x1f\xec\xd2\xcf\x36\x87\xd4\x7e\x5c\xa6\xbf\xe5\xf6\xfb\xe4\xe5\x96\x7a\x92\xa6\xa5\xf0\x4d\x5d\xc8\xa3\x4d\xe9\x09\x16\xf4\x6f\x86\x16\x7f\xf0\x5e\xd8\xb0\x6d\x54\xae\xce\xfc\x69\x9b\xc7\xfa\x27\xae\xd3\xe3\x9d\x66\xc5\xae\xab\x0b\xc3\x94\xf9\xd2\xc3\xb8\x16\x1c\xd3\xdd\x84\x37\xcb\x8e\xe1\x5a\x10\x6d\x7f\x13\xa6\x86\xb2\x9a\x78\xa4\xb1\xa5\xc5\xb4\x32\xa5\x5b\x22\xd2\x4b\x5b\x23\xce\x93\x7a\xba\xd2\xcc\x15\x8d\xbd\x6a\xad\xc8\x63\x3c\x4a\xe7\x65\x38\x84\x7e\xe3\x19\x42\x3e\x4e\x71\x01\xd3\xed\x6f\x4c\xcc\x6a\x88\x3a\xd5\xbe\xb7\xd4\xfd\xe0\xb6\x74\xff\x46\x91\x85\x85\x99\x76\x03\x68\xe1\xe7\xc0\xe5\xeb\xde\xfd\xa1\xcd\x8e\x02\x6b\x69\x5d\x78\x55\xb6\x93\x20\x35\xa7\x1d\x18\xf4\x1f\xb5\xa9\xa7\x56\x12\x64\xe6\xd4\x80\xf8\xc7\x78\x6f\xab\x92\xff\x98\xf3\xfe\xaf\x5d\x9c\x4f\x99\xee\x9d\x96\xe9\xee\x0e\xfa\x2d\xf3\xd9\x6d\x9b\xcd\x47\x39\xe7\x4a\x3d\xac\xbe\x07\xc7\xee\x29\x26\x49\x92\x9f\x4d\xee\x60\xae\xfa\x54\x8f\x39\x42\x81\xf1\x32\x42\xdf\x17\x01\x24\x01\xd0\x00\x48\xa7\x7c\x76\x51\x3d\x29\x49\x9c\x43\x8a\xc3\x32\x54\xba\xf1\xa4\x80\x45\xa5\x7d\x70\xdd\x0c\x78\xca\x63\x53\x64\x76\x8f\x41\x5c\xac\x16\xfa\x59\x12\x50\xbd\xfe\x73\xe5\xd2\xbd\xae\x95\xd3\x3f\x1f\x29\x71\x5c\x4f\x1c\x8f\x54\x7c\x0c\x47\xe3\x63\x78\xfe\xa5\x18\xbb\x7f\x7d\x07\x47\xbd\xf1\x31\x1c\xf5\x54\xfc\x58\xa4\xdd\x12\x52\xdd\xcb\x64\x58\x60\x26\x79\xe8\x99\xc0\xe3\xe0\xf9\x97\x92\x5c\x77\xde\x71\xd1\xa2\xc9\xde\xdd\xcb\x47\x4f\x89\xe3\xcf\xb0\x03\xc2\xda\x46\x00\x9e\xe7\xde\x2a\x51\xc4\xde\x8e\xff\x7c\x64\x9e\x8e\x41\xeb\xc0\xf0\x61\x6d\xc2\x72\xaa\x7f\xeb\x9c\x59\xc2\x25\xa2\xd3\x96\x1d\xbe\xa4\x2d\x7a\x2c\x78\xfe\xa5\x30\x28\x2d\xae\xa5\xfb\xb9\x74\x62\xff\xf9\x03\xd1\x2e\xf7\x68\x7c\xfc\xfc\x4b\x6c\xc3\x52\x23\xc5\xd1\x58\xf4\x0a\x21\xfe\x6a\xb2\x84\x14\x48\xa7\x0a\x0d\x30\x1f\x8a\x5c\x21\x05\xcc\x13\x86\x0c\x1a\x1c\xf0\xe7\x5f\x0c\x3b\x77\x4e\xc3\x84\x8b\x39\x51\x67\x44\x99\xaa\x40\x76\xa6\xd9\xb9\x83\x6e\x53\x27\xb2\xb8\x73\xf7\xb9\xba\xbc\x1a\x6a\x20\x71\xe5\xd4\xe5\xf3\x51\x4c\x6f\x81\xc6\x43\x4f\x51\xb6\xe9\x66\x85\xe4\xe3\xfb\x34\xf1\x19\x76\x72\x46\x3f\xdf\xa3\x8d\x12\xdc\x23\x34\x52\xc1\x30\x2d\x0d\xb2\xea\x7d\xb1\x73\x77\xd4\x8b\xe9\xad\x9e\xbf\x8a\xcc\xe5\x8d\x21\x76\x6f\x11\xc7\xa1\xdd\x27\x1b\x6e\xf2\xff\xdb\x6a\xf8\xa9\x7c\xef\x9c\xa8\xa6\x59\xb2\x9c\x43\xca\xba\xb5\xfc\x6a\xa8\xf1\x47\x31\xf4\x81\x57\xef\xc2\x3f\x9e\xa7\x92\x4e\xab\x18\x15\x03\x62\xb8\x02\xdd\xe9\xc7\x9d\x50\xf1\x5f\x3e\x9e\x5e\x2a\x41\xd9\xd4\x2f\x1f\x42\xd4\xee\xd7\x14\x74\xec\x7b\x19\x98\x94\x4e\x40\x9c\x10\xdc\xf6\xcb\x75\xa9\xb0\x9d\xaf\x07\xc7\x67\xac\x60\x08\xef\x89\x9a\x99\x63\xed\x12\xa8\x5e\x1d\xd0\x6d\x42\x0f\x8a\xf8\xc4\x8e\x43\xe5\x3b\x32\xc6\xe4\xe7\x74\xbf\xf5\xe5\x1a\x8e\x4b\x77\x21\x7b\xb0\x0b\xff\xab\xd9\xd9\x81\x15\x1c\x95\xba\x0e\x74\x73\x17\x56\x70\x0c\xfd\x8c\x31\x4c\xf2\x29\xc9\x4f\x71\x04\x46\xaa\x7e\xb6\xa5\xb7\x65\xb9\xae\x35\xe7\x3b\x70\xe3\x75\x44\xe8\xda\xfb\x71\xe5\x0b\x4e\x9d\x1a\x95\x7c\x7f\xaf\xf5\xa4\xd9\x59\x5b\x99\xb7\x00\xcf\x0f\x9b\xf2\x8c\xb4\x7c\xa0\x67\x5e\x60\x82\x21\xf4\x8b\x0b\x7a\x3f\x09\xca\x8a\xb3\xfd\xb4\xd6\x66\xca\x83\xe6\x2a\x19\x1f\xff\x03\x23\x7b\xaa\xec\x5c\xb6\xc8\x6e\x3f\x16\x61\x5a\xda\xe5\x2e\x5b\x3b\x59\x69\xc7\xe5\xaf\x65\xdb\xe0\x6e\x59\xc5\xd9\x8b\x4b\x48\x7f\x6f\xc6\xa9\x9b\x4a\x95\x5a\x29\x60\xf4\x0b\x16\x8e\xf4\xec\x99\x63\x23\xa7\x71\x27\x1f\xae\x03\xc7\xc6\x9a\x76\x60\xd5\xa9\x9d\x1f\xb1\x9d\x9d\x72\x58\x55\x3a\x50\xca\xd2\xf0\xf2\x59\x92\x7d\x45\x30\x2b\x0e\x38\x81\x6f\xe3\xa9\x92\xde\x53\x6d\x78\xe3\x79\x0d\xc9\x95\xad\xb0\xb5\x94\xce\xf2\xf8\xeb\xbe\x64\xc7\x31\xf4\x87\x0c\xbe\x62\x81\xda\xf0\x73\x8d\xb5\x42\x6d\x5a\x97\xc0\x0b\xe8\x87\x83\x57\xad\x88\x42\xd3\x7f\xdd\xde\xbd\xb9\xb7\xfb\x81\xe5\xa7\xc7\x6e\x47\xce\x56\x5d\x6e\x72\x1a\xfc\xfb\x76\x56\x1f\x55\x74\xe3\xb5\x72\x49\x2b\xbd\xc6\x33\xec\x33\x22\x6e\xae\xf6\x1a\x2e\xe9\x94\x90\xba\x19\xef\xde\x60\xb1\x6e\x9f\x3b\x5b\x9f\xb3\x57\x87\xda\x81\x9a\xcf\x31\xfb\xf0\xfc\x4b\xf7\x9e\x9b\x99\x15\x2a\xb6\x7a\x10\xe8\xb5\xd2\x00\x93\x7b\xa7\xac\xa2\x9f\x1d\x85\x67\x10\x77\xe5\x7a\x8e\xb5\xfa\xac\x54\x40\x14\x7c\x37\x04\xb6\x4c\x92\xd2\x1d\x48\xa7\xbf\xc1\x2d\xe9\x9d\x53\x2a\x32\x5f\x54\xd7\x4d\xaf\x07\x24\x8e\x61\x9c\x90\xe8\xc6\xbc\x81\x06\x73\x22\x6e\xf4\xfe\x6b\xef\x08\x98\x45\x4c\x58\x0c\x5d\x18\xf4\x06\xfd\xec\xe7\x1f\xb8\x9c\x1c\xf7\x95\x73\xf9\xc2\x5c\x60\xba\x77\x7d\xbd\x81\x17\xe0\x37\x1b\x7a\x4f\x6f\x94\x5f\xbb\x4a\x7a\xd0\x6e\xf3\x99\x9d\xed\x3e\xb4\x2a\xbc\xd5\x8c\x2a\x6c\x97\x3b\xb3\x8f\x62\x5a\xee\xb1\x92\x72\x01\xaf\x6a\x2b\x55\xca\xb1\xbd\x9e\x93\x95\x95\x0a\x9b\x2a\xdf\xb8\x77\x63\xcc\xb8\xcd\xa4\xf2\xee\xaf\xb0\x28\x81\x71\xd9\x9e\x14\x5f\x94\x8c\xe9\xd5\x7f\x87\x2d\x7d\x13\x73\x10\x18\xff\xe7\x8c\x21\x33\x85\xac\xb3\xcd\x24\x30\xc9\x35\xad\x5d\xa4\x1b\xf5\xe9\xdf\xd9\xc6\xdb\x14\x0b\x96\x22\xd1\xff\xb5\xa1\xe5\x2b\x1b\x63\xea\xd0\x73\xc7\x55\x62\xf5\x24\xae\x7a\x63\xa9\xdf\x78\x63\x29\x8b\x1e\xba\x63\x22\xba\x89\x1e\xac\x26\x7c\x56\xd7\xd1\x5c\x76\x09\x8b\x66\x5c\xd4\x59\xf3\x90\xc5\x1e\x1c\xa4\xb7\xb2\xbd\x4e\x39\x27\xc3\x5b\x92\xfc\xbf\xcb\x0b\xc1\xe7\x3f\xa8\x79\xe2\xcf\xd4\x3c\x71\xcb\x3c\x0c\x57\xe9\xa1\x87\xfb\x46\xbd\xcd\x17\xd2\x0e\x7f\x3b\xa6\xb7\xdb\xa9\x62\x0b\xf8\x90\x32\x86\xe2\x87\x8f\xef\xdf\xc1\x10\x34\x59\xe7\xb5\x92\x48\xd0\x85\x92\xf6\xca\x7d\x06\x5e\x7a\x23\xf1\x23\x99\xda\xf7\x11\x2d\x68\x16\x40\xe9\xa0\xca\xd7\x14\xa8\x89\x64\x81\xea\xb0\xce\x12\xcb\xde\x25\x87\x9d\x1d\xea\xae\x4f\x2d\x9f\x9f\xc2\x5c\xd1\xeb\x82\xab\xc6\xb7\x15\xab\x57\x5d\xd6\x01\x6c\x5c\x75\x38\x97\x6c\xd6\x87\xd5\xd6\x4f\x30\x84\x8d\xb3\x81\x35\xe4\x26\x2e\x67\x95\xda\x9c\x48\x17\xa5\x5f\x7e\xb5\x2b\x1b\xb1\x03\x3b\xe0\x2d\x4a\x65\xcb\x0a\x81\x04\x27\x1a\xdf\xf8\xb4\xe6\xe4\xb5\x19\x21\x97\xe9\xa1\x01\x32\x0e\x9d\x11\x0a\x61\x37\x25\x61\x3f\x3d\x20\xac\xdd\x69\xcb\xd2\x7e\x2a\xa4\xfd\xf4\xb0\xb4\x8a\x2f\xee\x17\xb6\xd7\x83\xb7\x0a\x12\xce\x6f\x64\xf6\x51\x92\x29\xe7\x93\x8d\xe6\x76\xc3\x97\xf6\x83\x27\x21\xec\xf6\x17\x6b\xa0\x12\xc8\x58\xc7\xef\xe6\xbb\x1a\xf4\x77\xd4\x8e\xda\x7c\x3c\x85\x2f\xa5\xf9\x58\x0a\x81\x41\x7f\xbf\x6f\x3e\x4e\x82\xf9\xb7\x4a\xee\xe7\x2d\xb7\x8a\x1d\xd8\xed\x3f\x28\x4f\xae\x91\x46\xed\x3e\xa6\xf0\x9d\x11\xcc\x1d\x08\x9d\x32\x2e\xb0\x5b\xbb\xca\x6a\x8e\x54\x1e\x30\x91\x07\x89\x14\x8e\xa8\xbc\x82\x5a\x8e\xef\xd2\xb3\x3b\x7b\x8c\xda\xb2\xa2\x6a\x77\xd6\x2a\x6b\xab\x76\x49\xed\xb1\x9a\xd1\x74\x9c\x77\x0d\xb5\xa3\x83\x61\x4b\x05\xae\xb4\xb1\x3c\xf9\x50\xe8\x8f\xba\xa2\xd0\x76\xe3\xa8\x28\x41\x36\x58\x92\x39\x7c\xa9\xc8\xe9\x80\x3d\xe9\x1a\xdf\x43\x9f\x44\x69\xb6\x9e\xe6\x7b\xa8\xe9\xa4\x3b\xb9\xf9\x43\x13\xff\x98\xc9\x87\xbc\x64\x90\x89\xfc\x33\xfe\x73\x89\x52\xfd\x44\xcc\x21\x4e\x51\x08\x29\xe0\x9f\x87\xe4\x1f\x64\xed\x97\xa7\x75\x29\x92\x83\x26\x1a\xe5\x79\x89\x89\x22\x07\x4d\x67\x8e\x09\x8d\x6e\x7e\xb3\x33\xd6\x74\xb3\x53\xef\x6b\xa6\x32\xd7\xf0\x72\x02\x33\xd5\xc2\x36\x5b\x7a\xac\xb5\xb4\xdb\xdc\x5d\xf9\xa7\x5c\x46\x11\x4a\x79\x00\xf7\x7d\x6b\xe4\x11\x06\x50\x3f\x59\x85\x06\x2b\x2c\x7f\x84\x24\xfb\xab\x84\x1b\xad\x70\x8f\x30\xd6\x96\x85\x71\xe7\x6e\xec\xcf\xf3\x2f\x1e\x74\x42\x81\x24\xde\xe4\xe1\x7c\x7e\x7f\xba\xd7\xbb\x34\x9f\x37\x5a\x03\x49\x12\xbe\xc2\x18\x6c\x91\x53\xbb\x79\xa2\xb0\x67\x3e\xcd\xa5\x38\x30\xbe\x32\xf0\xd6\xe6\x18\x5f\xa5\x6f\x0c\x9a\xea\x6a\xa9\x48\xb9\x54\xd1\x87\x72\x37\xe3\x2b\x6d\x07\xbf\x7c\x3c\xbd\x58\x26\xc9\x27\xf3\xfa\x79\x00\x45\xeb\x7b\xce\x74\xe8\xed\x36\x59\xb2\x6e\xcb\x0f\x7c\x29\x64\x05\x8f\xb2\xa5\xc2\x4a\xe3\x25\x46\x9c\xc5\xb2\xb8\x87\xd5\xeb\x65\xdf\xfe\x58\x4a\x14\x85\x78\xc8\x62\xfb\xe1\x31\xce\x60\x49\x81\x4c\x14\x0a\x6b\xcf\x20\x97\xe3\x39\x55\x30\x5e\x2a\xc5\x19\x98\x52\xd6\x44\xa0\x9c\xd9\xef\xae\x91\x29\x3a\xaa\xd3\x3f\xb3\x97\xd7\xb4\xa2\x7e\xf9\x78\x9a\x93\x9d\x50\x21\x95\xf9\x3a\x0e\x65\xd3\xdc\xa7\x6b\x36\xb4\x7c\x30\x4c\x75\x95\xdf\x68\x34\xdf\x6f\xd1\x7e\xbd\xce\xe4\xcc\x44\xea\x98\x7f\xba\x6a\x0f\xa4\x15\x34\xdf\x0a\x24\x4a\x49\x39\xbb\x54\x5c\x90\x29\x6a\x6d\xbc\x55\x38\xf7\xb7\x25\xaa\xcb\x94\xdc\x39\x8b\xf5\x62\xdc\xee\xc0\x77\x43\x9b\xfe\xe5\x76\xf3\x3f\xff\x03\xdf\x99\xd9\x0a\xcd\x25\x89\x27\x51\xeb\xc0\x51\x31\xd9\x99\x74\xc5\xca\x87\x2e\xec\x99\x8c\xac\x58\x66\x8e\x0a\x72\xc4\xf6\x11\xab\xc3\xb9\xef\x34\xe7\xc3\x49\x54\x99\x3d\xb8\x2c\xe4\x36\x02\x5d\xa8\xb2\xf6\x3b\x67\xf8\xe3\x64\x22\x51\xe5\xd7\xc1\xca\xe4\x92\x84\xa6\x5a\xf6\x4d\x5c\x92\x7e\x67\xab\xe5\x6b\x3c\x75\x4e\xb3\x4f\xa1\x14\x64\x15\x7f\x7b\xf9\x63\x76\x08\x11\xca\x84\x46\xe8\xf7\x03\xe8\x0e\x32\x6b\x7d\xee\x6f\x3f\x63\x7c\xb5\xdd\x09\x8d\x29\x3a\x0b\xb6\x5a\x5f\x16\x28\x51\x7d\x68\x5c\x88\x60\x5f\x65\x34\xdd\xf7\x49\xf2\x95\xd2\xe4\xa4\xef\x93\x26\x77\xbb\xe5\x69\xb5\xef\xa5\xb7\xcc\x6c\x2b\x96\x6c\x33\x86\xe0\x31\xcc\xb8\xd7\xa5\xf4\xff\xfe\x2f\x00\x00\xff\xff\x79\x05\xf5\x67\x1e\x54\x00\x00")
+var _webfilesSloop_uiJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcd\x3c\x6b\x77\xdb\x38\xae\xdf\xf3\x2b\x38\x6a\xcf\x46\x6e\x6c\xd9\x79\xb5\x69\x1e\xdd\x13\x3b\xce\x34\x77\xfb\xba\x93\xce\xa3\x27\x37\x67\x22\x5b\x8c\xad\x89\x2c\x7a\x25\x3a\xb1\x77\xd6\xff\xfd\x02\x7c\x89\x7a\x39\x4e\x67\xa6\xbb\x99\x3d\x5b\x99\x04\x40\x10\x04\x40\x00\xa4\xd4\x7e\xb1\x41\x5e\x90\x1e\x9b\x2e\x92\x70\x34\xe6\xc4\x1d\x36\xc8\x4e\x67\xfb\x75\x93\xa4\x7e\x44\xd3\x5b\x96\x0c\xa9\x37\x64\x93\x26\x09\xe3\xa1\x87\xb0\xa7\x51\x44\x04\x6c\x4a\x12\x9a\xd2\xe4\x9e\x06\xa2\xfd\xf2\xd3\xd9\x2f\xad\x77\xe1\x90\xc6\x29\x6d\x5d\x04\x34\xe6\xe1\x6d\x48\x93\x43\xd2\xbd\x3c\x6b\xed\xb6\x7a\x91\x3f\x4b\x29\x02\x9e\xb3\x84\xdc\xce\x80\x4a\x24\x81\x09\xa7\x73\x0e\xe3\x51\x4a\xde\x5d\xf4\xfa\x1f\x2e\xfb\x1e\x9f\x73\x72\x1b\x46\x14\x06\x25\x7c\x4c\x61\xa0\x29\x23\x09\x63\x9c\x00\xee\x98\xf3\x69\x7a\xd8\x6e\xb3\x29\x60\xb3\x19\x32\xc8\x92\x51\x5b\x51\x4b\xdb\x85\xf1\xda\x1b\x1b\x43\x16\xa7\x9c\x4c\x61\x42\x9c\x53\x72\x42\x7e\xdf\x20\xf0\x37\xf0\x53\x7a\xe6\x27\x77\x87\xe4\xca\x79\xb6\xd3\xdf\xdd\xdb\xeb\x38\x4d\xe2\x3c\xdb\xed\xee\xed\xec\xef\x88\xc7\xbd\xdd\xbd\xde\x7e\x5f\x3e\xf6\xf6\x5f\xbe\x3c\x75\xae\x9b\x06\xf7\x1d\x0a\x41\x20\x9f\x1d\x9c\xf5\xfb\xaf\x05\x58\x7f\xbf\xff\xfa\x5c\xd2\xe9\xf7\xfa\xe7\xe7\x7b\xe2\xf1\x7c\x17\xfe\xeb\x6b\xe4\x69\x12\x4e\xfc\x64\x21\x50\x0f\xce\xbb\xbd\x6e\x57\x00\x1d\x1c\xf4\x3a\x67\x12\xf5\x60\xfb\x74\xbb\xb7\x2d\x1e\xf7\xfb\xf0\xa3\xa7\x51\xc7\x30\x66\x64\xc6\xed\x9e\xbf\xdc\x06\x9e\x10\xec\xac\x73\xf0\xea\x95\x1a\x17\x28\x1e\x48\x92\xa7\xbb\xdd\xfe\x41\xcf\x91\xb8\xf8\x07\x38\x7b\x07\xfd\xd3\x33\xa7\x09\x9d\x67\xa7\x07\xdd\x97\xf8\x14\xf8\xaf\x5e\xee\x77\xf0\xe9\x6c\xef\xf5\xcb\xd3\x57\xa2\xb7\xdb\xdb\x3b\xed\xe6\x50\x4f\xf7\x4e\x5f\x9e\xed\x60\xe7\xeb\xed\x6e\xff\x5c\x3e\xbd\xea\x6e\x9f\x0a\x22\x07\xa7\xaf\xbb\x2f\x0f\x34\xa3\x29\xbd\xa7\x49\xc8\x71\x92\x9b\xcf\xf6\xba\x67\x07\xfb\xfb\x9b\x4d\xb2\xf9\xac\xdf\xe9\x77\x3a\x1d\xf1\x78\x76\xb0\xd7\xdd\xeb\x6e\x02\xc2\xf2\x68\x63\x63\xa3\xdd\x26\xdf\x47\x6c\xe0\x47\x29\x79\x17\xde\x53\xf2\x96\x26\x74\x03\x56\x8c\x70\x36\x3d\x9d\x87\x69\x93\x0c\x18\xe7\x6c\x82\xcf\x47\x02\xfc\xf3\x18\xf4\x0f\x54\x29\x1e\xf2\x10\x56\x98\x8c\x00\x78\xe8\x47\x11\x0d\xc8\xc3\x98\xc6\xc8\x81\xd0\x9e\x69\x02\xaa\x92\xf0\x90\xa6\x84\xdd\x12\x1a\x42\x5b\x42\x7c\x20\x43\xfc\x84\x92\xe1\xd8\x8f\x47\x34\xb0\x87\x3a\x4b\xfc\x87\x73\x20\x6b\x0f\xa9\xdb\xec\xa1\x11\x3d\xd8\x25\x29\x4f\x66\x43\x9e\x0a\x0a\x73\x84\xbd\x04\x2e\x68\x93\x2c\xf0\xb9\xeb\xc7\x81\xc4\x39\x4d\x12\x7f\x41\x40\x17\xb9\x1f\xc6\x61\x3c\x22\x81\xcf\x7d\x50\x6d\x9e\x84\xc0\x6a\x40\x6e\x13\x36\x21\x69\xc4\xd8\x94\x08\xb3\x4a\x04\x41\x04\x32\x63\x12\x1e\x4e\x60\xc8\x30\x9d\x46\xfe\x02\x50\x58\x4c\x86\x30\x33\xa0\x47\x26\x0c\xd4\x9d\xe1\x94\x61\x40\xf9\x6b\x02\x3f\x09\x90\x8e\x15\x6f\x30\xef\xcf\x80\x6f\xcf\x20\xa0\xb7\x61\x4c\x85\x94\x26\x20\x91\xc9\x6c\x42\x02\x98\x28\x72\x97\x4e\xfd\x21\xc5\x11\xb0\x13\x5a\x02\xf6\xe0\x91\x0b\x12\xb0\x78\x13\x49\x85\xf1\x1d\x92\x81\x87\x94\xc0\xff\x10\x68\xc8\x92\x84\x0e\x39\x79\x80\x69\x82\xa0\x67\x29\x92\xe1\x62\x9c\x7b\x3f\x49\x49\x8b\x84\x30\x1f\x46\x53\xa4\x90\x50\x58\xa9\x05\xba\x90\xa9\xc0\x11\x03\xe0\xcf\xf0\x5f\x80\x86\xa4\x71\x1e\x0f\x34\x4c\x60\x36\x20\x2f\x60\x2d\x15\x4d\x6a\xf6\x24\x05\x21\xe3\x00\x43\x36\x8b\x02\x32\x65\x1c\x3d\x8e\xa0\x39\x44\xcb\xc7\x55\x1f\x44\x74\x92\x7a\x52\x8c\x12\xeb\xbd\x3f\xff\xa5\x69\xfd\xf8\x22\x85\xf1\x13\xaa\x07\xd0\x13\x93\x46\xa2\x03\xca\x1f\x28\x8d\xc1\xce\x93\x54\xb9\x0f\x60\x4d\x38\x9b\xae\x9f\x68\xf0\x4b\x05\x7d\x42\x3a\xde\x8e\xa4\x94\xde\x8f\x00\xf2\x16\x74\x37\x06\xe9\x81\xfb\x84\x5f\x71\x00\xa6\x80\x12\x85\x3e\x29\x94\x60\x57\x30\x05\x0d\x12\xeb\x32\x44\xe8\x07\xba\x89\x0a\xa5\xe4\x8f\x43\xa3\xf8\xc5\xbf\x0f\x21\x4a\x5c\x48\x39\xf5\x41\x05\x8c\x6a\x3d\x84\x01\x1f\x93\x96\x5c\x51\x58\x07\x70\x2c\x23\x00\x94\xeb\x2a\x97\x45\x2e\xa4\x9e\x91\x74\xa7\x72\x2a\x48\x1b\x56\x05\xa5\x1a\xf2\xcd\xd4\xd2\x4d\xa4\x37\x10\x0b\x20\x07\x56\x63\x9b\x61\x25\xfb\x13\x10\x37\x88\xe3\xbd\x18\x13\x66\x82\x8d\x8a\x01\xed\x64\xc1\xa2\x0e\x61\x43\x91\x4e\x21\xa2\xb7\xe0\xb8\xb6\x3b\x1d\x61\xf1\x4a\xa7\x58\x2c\x16\x1d\xfd\x72\xc4\xfc\xe0\xf2\xa7\xef\xa1\x4f\x1b\x35\x0c\x1c\xe2\xaa\x42\xff\x19\xa8\x6e\x9c\xa2\xa1\xbb\x0d\x45\xdc\x5a\x54\xc0\x0e\xd8\x70\x06\x20\xdc\xd3\x0f\x7d\x58\x7e\xfc\x3d\x8c\x42\xf8\xe7\x67\x94\xd4\x51\x01\xef\xcb\xe3\x78\x6f\x29\xfa\xdb\xa3\x8d\xe5\xc6\x46\x40\x41\x3c\xe0\x5e\x3e\x33\x16\x7d\x0e\xa7\x17\xe9\x4f\x61\x1a\x82\x92\x01\x91\x5b\xf0\x5b\x54\x89\x20\x66\x97\x2c\xe1\xe7\x28\x04\x33\x0f\xc3\x33\xd8\xfb\x2c\x89\x89\x14\x81\xd4\x2c\xd8\x5e\xa7\xe0\x4a\x2e\xb9\x5f\xc2\xf2\xc1\x05\x69\xcc\xf0\x16\x7e\x7b\x77\x20\x35\xf2\xdd\x09\x19\x88\x27\xdd\x67\x51\x56\xd4\xfe\x01\xbd\x12\x5d\x00\x2c\xed\xc1\x7d\x2f\xc5\xb1\x60\xe9\x07\xf2\xe9\x08\xb9\xc9\x31\xf3\x9e\xa5\xbc\x2f\x5c\xc7\x37\xe1\x68\xe0\xa1\xeb\x82\x35\x49\xbd\x88\xc6\x23\x54\x69\xe0\xb2\xd0\x56\xe6\xf2\x03\xd8\xc2\x37\xe1\xcf\xdd\xdc\x24\x5b\xc0\x11\x86\x2a\x0d\x2f\x62\xe8\xe0\x7b\x12\xcd\x1d\xc8\x56\xc1\x1d\x2e\xff\x70\x32\x15\x3c\x69\x35\xb0\xd5\x59\x69\xb8\xd1\x86\xa9\xbf\xc0\x26\xd4\xc2\x5d\xef\xb7\x94\xc5\x2e\xfa\xfb\xff\x9d\xd1\x64\xf1\x63\x12\x35\x8e\x6c\x20\x0f\x2c\x30\x76\xb3\x99\x82\xd9\xcc\x22\x6e\xcf\x27\x1d\xb3\x87\x4f\x94\x26\xfd\x24\x61\x49\xaa\x00\xbc\x29\xb4\xfc\x4a\x45\x93\x22\x28\x64\x53\x69\x58\x59\x3f\x3a\xab\x13\xe5\xbc\xf4\x50\x59\xef\x00\x64\xf5\x1e\xf7\x18\xa9\x23\x2e\x40\x5b\xbd\xfe\x14\x42\xb3\xe0\x74\x4e\x8b\x1d\x92\x1c\x9a\x0f\x0f\xa7\x7a\xb4\x25\x8a\x6e\xc3\x48\x46\x7a\xc4\x8b\x98\xdc\x52\x80\xf5\xc5\x5c\x27\x2c\x00\x3f\xc6\xc0\xf1\xe1\x64\xd0\xcb\x2d\xc8\xd8\x87\xdd\xed\x56\xd8\x23\x6c\xf7\x18\x2c\xa2\x87\x62\xb8\xb9\xa7\x6a\xd9\xa0\x27\x61\x0f\x69\x26\xfe\x82\x80\xa6\xe6\xd1\xd6\x98\xef\xca\xcd\x99\x26\x1c\x59\xba\x21\xf7\x95\x7b\xdb\x85\x40\x08\xa2\xbc\x47\x77\x71\x11\xb8\x0e\x92\x92\xa2\x77\x1a\xda\xf7\xdc\x0b\x7d\xe9\x81\xaf\x05\x30\x40\x76\xde\x87\x69\x6a\xe2\x01\x8c\x02\x0e\x89\x03\xda\x96\xb1\xe1\x4d\xfc\xa9\x0b\x5e\xe6\x0d\xa1\x62\x35\x1b\xde\x6f\x2c\x8c\x5d\x0c\xf1\x6c\xaa\x21\x17\xae\xa8\x1e\x0f\x88\x3a\x92\x36\xf5\x04\x57\x9a\xd0\xff\xc5\x8e\x58\x04\x94\xfc\x27\xa5\x92\x9c\x8d\x46\x40\x2f\x85\x1d\x60\x38\x16\x91\x86\x08\x94\xa0\xdd\x6c\xc1\x5a\x7b\x75\x4f\x38\xbc\xb3\x84\xad\x7a\x7b\x63\x3a\xbc\x03\x1d\xca\x44\xec\xd6\x8b\xcb\x42\x71\x1a\xde\x50\xa0\x82\x75\x9c\x10\x08\xb1\xa8\xbd\x1a\x22\x98\xf2\x50\x58\x55\xd4\xd2\xee\x02\xe2\xff\x34\x45\xff\x60\x51\x45\x2e\x9d\x46\xc3\x10\xc1\x3f\x5b\x46\x29\x5f\x44\xd4\xd3\xb3\x83\x95\x19\x80\xa5\xdf\x69\x19\x2f\x09\x05\x1f\xff\x57\xf0\xb0\x9a\x89\x98\xc5\xd4\xf0\xa0\x16\x89\x9c\xa9\xfe\x20\xbc\x15\xc1\x06\x27\xff\x44\xa7\x41\x26\x94\x8f\x59\x90\x8a\x04\x45\xc4\x87\x89\x1f\x84\x0c\xe2\xaf\x68\x46\xb3\xa5\x11\xb0\x92\x17\x57\x00\xd8\xfa\x2f\x1a\x3c\x81\x01\x92\x07\x06\x12\x3a\xa2\x73\xe7\x6b\xa5\xaf\xb0\xbf\x56\xea\x4f\x17\x34\xc4\x66\x38\xc9\x27\x0d\x99\x97\x71\xad\x24\x2c\xe2\xdf\x4c\x1a\x36\x6b\xdf\x46\x18\x79\xad\x47\x8d\x33\x8a\x93\xdf\x0f\x94\x0c\x74\x9a\x82\xbe\x27\x61\x43\x9a\xa6\xa7\x71\x80\x7b\xdf\x0f\x2a\xce\x4c\xf3\x1b\x88\x86\xef\x2e\x70\xcb\x6d\x12\xdc\x96\x21\xbd\x83\x84\x9f\x83\x2a\x07\x67\x32\xe3\xc9\xfc\x31\xc2\xda\xf2\xce\x72\x2c\xb9\x6f\x62\x26\x40\x7f\xe4\x43\xb7\xe1\x25\x42\xa5\xaf\x64\x10\xea\x61\xbc\xd9\xcc\x45\x89\x2d\x62\x75\x5d\x5b\x52\x35\x91\xad\x45\x12\x7f\x02\xcd\xa9\x1f\x04\xe0\x9e\xdd\xfa\x04\x00\xf7\x2c\x4d\xa8\x90\x42\x4a\x72\x98\x6c\x7e\x66\x53\x37\xe3\xdc\xde\x4b\x4b\x39\x66\x86\xd4\x15\x7d\xd5\x78\xb6\xbc\x00\xe3\xea\xba\xda\x4b\x65\x92\x96\x64\x21\x2d\xe0\x30\xab\x3b\xba\x70\x03\x54\x80\x40\x86\x45\x1e\x28\x0f\x24\xa2\xa9\x08\x40\xac\x51\xc4\xe2\x20\xa6\x21\x23\x74\x47\xa3\xd2\x85\x3d\x79\xc8\x27\x7a\x2c\x62\xc9\xf7\x34\xce\xe6\x21\x64\xf9\x31\x01\x19\xfa\x11\x0c\x1c\xb0\x09\xe4\x18\xae\xa0\xab\x17\x4c\x95\x66\x3c\x53\xde\xb0\x03\x11\x55\x49\xa8\x21\xfc\x0e\xf2\x1c\x3f\xc9\xe8\x5e\x75\x9a\x64\xbb\x49\x76\xae\x8b\xb4\x35\x1d\x9b\xdf\x7a\x4d\xca\x5b\x8b\xa6\x0d\x20\x90\x8b\x0a\x11\x81\x5e\x49\x11\x88\x00\xba\xd1\x44\x74\x48\xa1\xf3\x7d\x60\x2d\x8d\xeb\x02\xad\x27\xab\xe8\x1a\x3a\x5a\xc9\x2d\x80\xc8\xb1\x90\x25\x15\x42\x17\xdd\x40\x9e\x19\xd0\xdd\x26\xb1\xc1\xc9\x0b\xe2\xee\x76\x1a\x8d\x8c\x29\x00\x29\x4e\xe8\x69\xf6\x91\x4f\x1a\x45\xea\xbc\x0d\xc3\x98\xb9\x79\x03\x9d\xd5\x8a\x50\xb0\x5e\xdb\x3d\xc8\x04\x86\x3e\xf7\x20\xd8\x8c\x16\xee\xd5\x75\xb3\x46\x45\x85\xfb\x4e\x1b\x35\x86\xe3\x41\x8a\xde\xf7\x87\x63\x0d\x3d\x44\x2d\x93\xf2\x15\x8f\x6e\x41\xa5\x5d\x65\x2e\x0d\xe3\x1e\x75\x9a\xfb\x04\xab\x7f\xaa\xc5\x1b\xaf\x09\xd1\xb4\x48\x63\x01\x3c\x03\x50\x8b\xd8\xb8\xda\xbe\x86\xc8\xce\xdd\x01\x69\x5a\x1a\x64\xf9\x5c\xc0\x96\xc9\x2c\xa0\x67\xf2\xae\xc5\x86\x39\xe9\xb1\x21\xe2\x48\x54\x39\x09\xd0\xb8\x2e\x66\x98\x42\x87\xac\xcc\x24\x74\x98\x50\x9f\x53\x12\x72\x8f\xe8\x58\x0f\x13\x00\x3b\x45\x11\x69\x05\x6a\x2f\x8d\xe8\x90\xbb\xce\xb3\x60\xf7\x57\x08\xd9\x73\x5b\x1c\x00\xa9\xfe\xd3\x28\x72\x37\x5f\x6c\x82\x2d\x8b\xe1\xdd\xdc\x16\xbd\x82\x96\x21\xe5\xc9\x5c\xc4\x75\x00\x38\xd7\xcc\x79\xe2\x3a\xf7\x21\x7d\xe8\xb2\x39\x04\xd2\x37\x1d\xd2\x21\xcf\x7f\xd7\x02\x5e\xca\x67\x29\xae\xe5\x8d\x85\x38\xc4\xdd\x95\x4a\x82\xad\xa1\x0c\xe2\x01\x5f\xc4\xa7\xa8\xaf\x02\x12\xf9\xc2\x49\xe8\xc1\x47\x7a\x76\x20\xc8\x9e\x94\x11\x26\x2b\xa3\xc4\x9f\x8e\x45\xdd\x29\xa1\x53\x2c\xa6\xc7\x5c\xe6\x3b\x58\xa6\x04\xa5\x34\x85\x1a\x49\x34\x61\xb3\x29\xba\xe2\x51\xc6\x4d\x26\x25\x27\x37\x3d\x34\x05\xd7\xd6\x73\xab\x0f\x46\xc1\x70\xbc\x2c\xa2\x0a\x01\x71\xd0\x0e\x3c\x04\x98\x38\xe8\x18\x9a\x24\x6c\xa0\x99\xdc\x88\xe6\x08\xa6\xe1\xa2\xd0\x8c\x2e\xb9\xd0\xbd\x55\xb0\xf0\x65\xc3\x96\x1e\xce\xca\x95\x5a\xf2\x43\xe6\x2e\xb4\x9a\x99\x78\x46\xc4\xa7\x97\x62\x6e\x60\x82\xce\x80\x05\x0b\x48\x07\x32\x01\x88\x87\x23\x3b\x41\x07\x69\x63\xa0\xa2\x9d\x3c\xa6\xdf\xf4\x81\xbc\x07\x37\x70\x75\xe5\x7c\x80\x09\xf8\x91\xd3\xec\x5c\x37\xaf\x9c\x9f\xfd\x04\x2b\x5c\x4e\x73\x1b\x7f\x89\x44\xc9\x69\xee\x5c\x0b\x4f\x9b\xe5\x2e\xab\xe3\x18\x2b\xf0\x41\x15\xfa\x38\x95\x05\x68\xcc\x97\x45\xca\x8d\x8d\xbf\x32\xd9\x7a\x64\x45\x32\xaa\x1b\x93\xd2\x46\x61\x8b\xc6\x8a\xd9\xb2\x7e\x07\xcf\x68\x23\x72\xe6\xdf\x32\x28\xfc\xd3\xa5\x87\x7c\x45\xe9\x28\x07\xa3\x12\x3a\xd7\x62\xdc\x4b\x61\x92\x8d\x02\x2d\x41\x0f\xb2\x08\xe2\x88\x1d\x0e\x2b\xcf\xce\x61\x09\x62\xdd\x51\xf5\xdf\x00\xd6\xfe\xae\xdc\x25\x07\x8a\xfd\x75\xc7\x90\x85\x9f\xaf\x18\x62\xc2\x52\x2e\x4b\xe2\xeb\x0d\x64\xd7\xc1\x9e\x34\x5c\x40\x6f\x7d\x58\xae\x9a\x41\x40\xe8\x0c\x3c\x77\xc4\x46\xae\xf3\x63\x7c\x17\xb3\x07\x50\x61\x58\x04\x99\x9d\x97\x96\x66\xed\x91\x97\x1b\xb9\x9f\x52\x65\x4c\x31\xd6\xfe\xf3\x3c\x2f\x68\x96\x5a\xc5\x52\x1f\xea\xa8\xe6\xd7\x00\x3d\xd5\x0b\xac\xd8\x76\xca\xb0\xe0\x33\x0e\xc1\x29\x94\x41\xd1\x09\x40\x7b\x30\x53\xd5\x1b\xd5\x5a\xa6\xa0\xeb\x7b\x38\xa0\xa9\xf5\x99\xcc\xa4\xcc\x33\xfe\x81\x07\xa5\xfa\xc0\xe1\xa3\xc4\x51\x07\x30\xaa\xe8\x1d\x90\x30\xae\xc3\x9c\xde\x8d\xda\xe2\x84\xa5\x8d\x1e\x06\xa2\xdd\x36\x5f\x4c\x69\xea\x8d\x58\x25\x86\xd8\x34\xa7\x51\xc8\x3f\xd3\x39\x4a\x91\x8a\xca\x8d\x27\x9a\x5c\x87\x38\x8d\x5a\xac\x07\x96\xa4\xfc\x32\x73\x46\x2a\x38\x34\xc4\x9a\xe2\xd0\xb3\x7e\x96\xf8\xa7\x3d\x9b\xa2\x82\x49\x9e\x6b\x8f\x7f\xe8\xe0\xa6\x5d\xcd\xc3\xd2\x0e\xb9\x8a\xcc\x29\x51\x57\xaa\x85\xfe\x03\xf5\xa0\xe5\x05\xd3\x7f\x4a\x4d\x5c\x5a\xb1\xf8\xf5\x58\x52\x61\xaa\x70\x50\x61\xe8\x1a\x0a\x63\xc6\x37\xa7\x8b\x39\x41\xd7\x23\x80\xa5\xa4\x2c\x3e\x54\x2b\x58\x0f\x37\x64\xb3\x18\x26\x66\xd6\xe9\x6a\xe7\xba\x1a\x78\x59\x6d\x92\x6a\xcd\x94\x84\x4b\x20\xcb\xfc\x6a\x15\x88\x28\x64\x69\xb4\x1b\x19\x8e\xf0\x01\xae\x70\x4c\xb9\x5a\xaa\x80\xc6\xcd\xa1\x22\x51\x2f\xd5\xb4\xf3\xc7\x0f\xba\x9e\x2d\x53\xbf\x62\x3d\x5b\xb4\xe6\xc8\x15\x2a\xba\x7a\xff\xc3\xb3\xc3\x7c\xa4\x83\x4d\xe5\x30\x62\x81\x67\xd9\xe5\x90\xb3\x73\x5d\x86\xdc\xa9\x84\xdc\x2e\x43\x82\xd1\xb3\x3b\x8a\x35\xd0\x64\x34\xf0\xdd\x4e\x53\xfc\xe7\xed\x37\xec\xe1\x45\x6d\xc3\x75\xa6\x2c\xc4\xa0\xa7\xa5\x3c\x7f\x33\xab\xaa\xd8\xd1\xbb\x9c\xca\xd3\xe3\xa2\x95\x31\x91\x35\xd9\x7c\x28\x84\x27\xd5\x6e\x21\x6f\xa8\x9f\xa4\xce\x62\xcd\xc5\x83\xbc\x48\x4c\x54\xaa\x08\x5a\x11\x29\xf6\x67\x09\xc7\x5f\x3b\xc7\xed\xaa\x39\x96\xb3\x9d\x3f\x3e\xcd\x8c\xa6\x35\xd3\x72\xa1\xca\x9c\x34\x98\xd3\x48\xf1\x3b\x9f\x35\xc8\xe8\xb2\x2c\x92\x20\xbc\x77\xaa\x45\x2c\x88\xe8\x81\x73\xc3\x56\x9d\x8b\xa8\xb1\xd1\x4a\x58\xec\x3a\xe6\x68\x1e\x08\x94\x8f\x07\x85\x59\x81\x8f\xbe\x9a\x83\x19\x5c\xab\x9d\x03\x31\x5c\x3c\x69\xb7\xbd\x3a\x06\x94\x56\x12\x18\xc6\xe0\x73\xb8\x3b\x6f\x90\x63\x3b\x37\x54\xb5\x00\x54\x3f\xf2\xef\x7f\xd7\x60\xbc\xa9\xc4\x00\xc9\x17\x63\xc2\x5c\xdc\x62\x0e\xcd\xf1\x14\x99\xcd\x38\x66\x2d\x03\xf0\x9f\x41\x4a\xe6\x96\xe0\x54\x38\x8b\xec\x2e\x80\xb7\x2a\xc3\x10\x9c\x2d\x80\x8d\x4a\xc3\xff\x5a\x26\xc0\x14\xca\x6c\xe4\x49\xa1\xb7\xaa\xd0\x76\x4b\xcf\x9f\xff\x3e\x5f\x92\x0e\x28\x75\xde\x55\xab\xab\x14\xf9\x3c\xdc\x08\x34\x0f\x2b\x6b\x98\x35\x47\xc7\x55\x51\xb7\xbc\x89\x22\x94\xec\x17\xa9\x01\xc2\x6f\x79\x53\x7f\x44\x7f\x29\xef\x3b\x16\xf8\x97\x22\xf8\x97\x32\xf8\x94\xa5\xa2\x24\xac\x6d\x43\x8f\xd4\x34\x44\x1a\xc5\x98\x32\xff\xb4\x34\x75\x19\x3b\x4b\x77\x3c\x9d\xac\x42\xa6\x66\xf4\x1c\x37\xc2\x9c\x9e\xe7\xce\x5f\x9f\x24\x99\xcc\x62\x85\x25\xa8\x65\x83\x1c\x17\x12\x3b\x5d\xb8\x81\xbc\x37\x11\x67\x4d\xc5\xe5\x92\x33\xd3\xdb\x01\xc3\xb2\x14\x5f\x00\xde\x76\xa3\x34\xb9\x8c\xf9\x88\xfa\x05\x2b\xfd\xab\xb8\x5f\xb3\xd8\xf4\xe8\x74\x3a\xab\xa6\x53\xf2\x39\x5f\x3f\x1b\xcd\xc0\x98\x4f\x22\x17\xe2\x52\x2b\x97\x57\xe7\x9a\x6e\x49\xef\xaa\x63\x4d\x71\x68\x89\xf1\x7f\x7d\x5c\x86\x22\x38\x54\x65\xea\x6a\x08\xcc\x1b\xc5\x2d\x17\x04\x33\x3f\xaa\x61\x87\xd1\x0c\x32\xc0\x04\x21\xd5\x63\x35\x1c\xe6\xbb\x87\xda\xce\xcb\x81\x5c\xae\xa5\x51\x23\xf6\x61\x14\x0e\xef\xea\x45\x8e\xa7\xd1\x67\x96\xc4\xd1\x1a\x83\xa6\x31\xe0\x26\x51\x2e\x5f\x52\x34\x05\xa4\x8b\x98\xcf\xc0\x82\xef\x69\xb4\x20\x9b\xc1\x26\x92\xc1\x1b\x50\x03\x59\x53\xda\x1c\x53\x9f\x43\x0e\xb5\x09\xfe\x4e\x9c\x08\xe1\x2d\x0f\xf0\x8b\x78\x15\xe9\x61\xec\x73\x71\x2b\x4e\x86\xc3\x9a\x20\xa2\x89\x11\xc5\xf6\x95\xea\x7b\x5c\x40\x1e\x11\x71\x08\x95\x6f\x99\x7b\x43\x8a\xb4\x47\x3e\x30\xc8\x90\x66\x09\x05\xd2\x0b\x18\xe8\x42\x5d\x0c\x53\x84\x83\x5d\x45\x51\xc6\x5d\x98\xa7\xa1\x5b\x07\xc2\x51\x78\x87\xec\xfa\xdc\xab\x70\x24\x6a\x06\x7f\x91\x1f\x41\x77\x89\x71\x6e\xcc\x7b\xba\xd6\x5b\x70\x1e\x47\x25\x78\x15\xcf\x5f\x40\x4c\x31\xc7\x53\x2e\x3f\x49\x29\x2c\x83\xb0\x65\xcc\xcb\x4e\xc1\x9a\x43\x10\x16\x18\x63\x88\x30\x4e\xd1\x62\xe5\xf5\xbb\x30\xfd\x68\x52\xaf\x2c\xe3\xbd\xb2\xa9\x5f\x17\xf2\xb6\xbc\xdf\xf0\x24\xe3\xea\xac\xaf\x61\x42\x18\xdb\xf7\xe6\x3c\x8b\x35\xd1\x02\x47\x5f\xe7\x90\xac\x39\xc8\x5b\x2f\x0d\xdb\xe5\x96\xa6\x3c\x34\x97\x1c\xca\xd6\x8f\xe8\x87\xa4\x48\xb0\x6c\x8c\xab\xcd\x7f\x5d\xd3\x7f\xc4\xcf\xa8\x84\xd6\xe6\x46\x34\xd5\x54\x3d\x6c\x38\x5a\x64\x6b\x59\x10\xc4\xba\xeb\xfe\xf4\xd5\xa9\x3a\x1a\xcb\x2d\x91\x39\xf3\x6a\xd4\xed\x8c\x75\xec\x98\xdb\x24\x15\x2a\x2e\xba\x9c\xea\x3d\xa9\x5c\x67\x5a\xb5\xe7\x1a\x20\xbd\x91\xbc\x95\xa6\xaf\x37\x11\xa5\x3f\x36\xd3\xdf\x72\x9b\x7e\xb2\xb9\x29\x4f\x52\x65\x0a\xdf\xd4\x85\xac\xad\x4a\x4f\xd0\xa0\x3f\x18\x82\xfc\xc9\x7b\x61\xc5\xb6\x51\xb8\x62\xf3\x97\x6d\x1e\xf3\x4f\x0c\xd3\xe8\xad\x6a\xc1\xce\x8b\x86\x21\xca\x81\xea\xd0\xae\x06\x47\x74\x57\xe1\x8d\xf5\x71\x5d\x0d\xa2\xec\xaf\xc2\x44\x28\x29\x89\x35\x95\x4d\x15\xdd\xf2\x94\xee\x21\xa9\x92\x97\xbb\xba\x20\x98\x72\x5a\x53\xcd\x55\x18\x38\xc5\x9a\x92\x13\xb3\xa1\x5a\x97\x93\x13\xd0\x91\xaa\xb3\x06\x33\x4e\x76\x9d\xd6\xee\xaf\x4c\xe0\x4a\x88\x98\x92\xaf\x2c\x89\x3f\xba\x2d\xad\xde\x28\x74\x58\xa8\xa5\xdb\x24\x35\xfc\x1c\xda\x7c\xad\xdc\x1f\xea\xf4\xa8\x29\x35\xad\x45\xf6\xf3\x7a\xd2\x54\xea\xb4\x05\x0b\xb6\xd6\xa6\xae\xb4\xa4\xa9\xd5\xa9\x02\xf1\xcf\xf1\xde\x52\x24\xff\x31\xe7\xfd\x5f\x6b\x9c\x4f\x59\xee\xad\x9a\xe5\x6e\xc1\x9a\x55\xaf\x67\xab\x6e\x35\xd7\x72\xce\x85\xba\x59\x79\x0f\x0e\xec\xd3\x4e\x3f\x8a\x7e\x10\xb9\x83\xb8\x12\x54\x3c\x0e\x81\x7d\x31\x98\x0d\xa9\xeb\x26\x4d\x12\x35\x49\xd8\x24\x7e\x23\x7f\xc6\x51\x3c\x51\x89\xac\xc3\x8c\xa3\x3c\x94\xda\x78\x14\x60\x56\x91\xdf\xbe\xae\x06\xec\xe1\x1d\xe1\x93\xfc\x71\x89\x8d\x55\x43\x5f\x27\x01\xc5\x6b\x42\x57\x36\x5d\x6b\x48\x55\x40\xbf\x39\xe6\xc9\x9b\x72\xe2\x78\xcc\x83\x37\xe4\x78\xf0\x06\xaf\x0f\x98\xb1\x3b\xd7\x4b\x72\xdc\x86\xc6\xe3\x36\x74\xaf\x89\xb4\x93\x43\x2a\x7b\x19\x8d\x45\xc4\x22\x9f\x38\x22\xf0\x38\x04\x0a\xf6\xbc\x96\xce\x9b\xac\x05\xc9\x2e\x57\xf2\xd1\x86\x39\xdd\x80\x06\x26\x52\x37\x9a\xc4\x31\xda\x2b\xf6\x14\x5f\xbe\xeb\x00\x73\xc7\x27\xa0\x03\xf0\x82\x0f\xa9\x13\x92\x53\xfc\x8d\x39\x73\x4a\x2e\x29\xb5\xda\xf4\x21\x8d\x6a\xc1\xb1\x60\xc2\x99\x42\xe1\x74\x25\xdd\x9b\xdc\xc9\xfe\x0d\x9e\xf5\x1e\xa2\x7c\x9e\xff\x1e\xc8\xb0\x54\xcc\xe2\x78\x90\xb4\xb3\x49\xfc\x43\x64\x09\x0a\x08\x53\x85\x0a\x98\x0f\x59\xae\xa0\x00\x4d\xc2\xa0\xa1\x89\x05\xfe\xfc\x77\xc1\xce\xd2\x6a\xc0\xfa\xa0\xcf\xcf\x20\x8b\xc6\x19\xea\xb3\xcf\xc6\x12\x9c\x74\x45\x27\xde\xfe\x5a\xde\x14\xcd\xab\xa2\x56\x12\x14\x4e\x67\x6e\x8e\xf1\x5a\x79\x18\x9c\x40\xa8\x1d\x2f\x5a\xba\xe0\xfc\x66\x95\x24\x60\xdd\x0c\xa3\xae\xa9\x6d\x90\xbf\x93\x9b\x9e\xae\x78\x28\x44\xd5\x65\xa3\x1e\x92\x9b\x9b\x86\x45\xe0\x66\x85\x38\xed\x81\x6e\xd6\x10\x69\x01\x43\xb4\x54\x08\x0b\x37\xd6\x06\xe0\xc0\xcc\x51\x01\x0a\x42\xcb\xef\x2c\x46\x5e\x22\x06\xf1\xe4\x46\x5b\xf1\x62\xc7\x1f\x95\xe3\xcd\xa7\xfc\x05\x77\x9f\x57\x2d\xb3\xe4\x9c\x28\xd6\xa5\xe9\x14\x63\x95\x3f\x8b\xa1\x0f\xac\x78\xe9\x7e\x7d\x9e\x72\x32\x2d\x62\x14\x34\x10\x2f\xcf\x60\x27\x74\x78\x9c\xfd\xf8\xb9\x77\xc9\xf1\x15\x36\x37\x7f\xda\x51\xba\xc8\x93\xd1\x91\xaf\xe9\xd0\x28\x77\xd4\x62\xc5\xf0\xb2\x3f\x9d\xe7\x2a\xe8\xc6\xa0\x2c\xa7\xf3\x00\x10\xef\x7d\x3e\x16\xe7\xe7\x39\x50\x34\x2f\x30\xbc\x0a\xf4\x66\x16\xe0\xc8\x71\xc2\xf4\x9d\x3f\xa0\xd1\x0f\x6a\xc3\x76\x61\xdc\x37\xb9\x4b\x97\x6d\xb2\x03\xa6\x02\xcd\x5b\x30\xe0\x71\xae\xeb\x10\x9b\x5b\xd0\xfc\x86\x74\x34\x63\x34\x32\x4b\x62\x8e\x8b\xb0\x64\x56\x3e\x44\xc3\x7d\x3d\x9d\x97\x9a\xcd\x16\x5e\x79\xef\x11\x86\x13\x17\xf1\xf2\x37\xa9\x1a\x25\x2a\x26\x40\x28\xf5\xa8\xf4\xae\xae\x9e\x9c\x81\x9b\x53\x2d\x93\xd2\xe6\x4f\x0e\xc5\xfb\x6c\x78\x4b\x33\xbb\x09\xf8\x09\x34\x21\xbb\x44\xa0\x8a\x75\xa2\xbe\x28\xee\xac\xb1\xc1\x6f\x20\x09\x01\x6c\xdd\xea\xd0\xd7\x2c\xb3\x38\x4f\x75\xd9\x66\x2b\x17\x4b\x75\x5c\xfe\x92\xd7\x0d\x66\xd7\x65\xac\xcd\x3c\x87\xf4\x73\x35\x4e\x59\x55\x8a\xd4\x72\x11\xa7\x9b\xb1\x70\x8c\xab\x27\xce\xa7\xac\xc6\x2d\x33\x1c\x9e\x9d\xb9\x52\x6d\x1a\xa5\x83\xaa\x78\x6b\x2b\x1f\x97\xe5\x4e\xae\x74\x1e\x9f\x3f\xb4\x92\x6f\x8c\xea\xea\x82\x15\x39\x57\x1e\x5f\xe1\xa6\x2c\xe3\x23\xc7\xa9\xc8\xce\x64\x89\xae\xa6\xf6\x66\x02\xb8\x55\xd9\x92\xa5\xe8\x99\x86\x55\x2b\x7c\xd6\x6f\x14\xdf\x48\xac\x16\x6a\x51\x6b\x02\x2f\xf0\x62\xf0\x7e\x2d\x62\x82\xf4\x5f\xd6\x77\x2f\x56\x76\x3f\x62\x7e\x38\x76\x3d\xb2\xb6\x3a\xa3\x72\x08\xfe\xaa\x9e\xd5\xb5\xaa\x76\xac\x54\x6f\xa9\xa5\x57\x79\x58\x8e\x1f\x32\xb8\xda\xad\xb8\x0d\x94\x43\x6a\x69\xde\x9d\xed\xe9\xbc\x7e\xed\x64\x81\x4f\xde\x51\xaa\x07\xaa\x3e\x30\xc5\x8b\x01\xad\x15\x57\x40\x0b\x54\x64\xf9\xa1\x89\xb6\x52\x01\x63\xbc\x93\x3e\x12\xd0\x67\xee\x1a\xc2\xca\x62\xf0\x41\x6a\xbd\xae\x35\xc0\xd6\xf8\xdd\x09\x89\x67\x51\x94\xbb\x6c\x69\xf5\x57\xb8\x25\xdc\x39\xc1\x29\x4c\xa6\x45\xbb\xc1\x37\xd2\x83\x80\x0c\x22\x7f\x78\x27\x5e\x75\xc3\x7b\xd2\x77\xb8\xff\xca\xcb\x08\xc2\x88\xf1\x1a\x74\x8b\x6c\xb7\xb7\x3b\xfa\xe7\x9f\x68\x4e\x96\xfb\x32\x5c\xbe\x10\x37\xa5\x56\xda\xd7\x6b\xbc\xc5\x5f\xad\xe8\x6d\xdc\x28\xbf\xd6\x4a\xda\xa4\x5e\xe7\xb5\x9e\xed\x3c\x66\x15\xce\xc3\x38\xe4\xb4\x7e\xde\x5a\x3f\xb2\x65\x59\xa1\x25\xf9\x0a\x60\x51\x57\x8a\x94\x03\x79\x0f\x48\xd7\xa5\x32\x9d\xca\x5f\xed\xb7\x63\xcc\xa0\x4e\xa5\x4c\xf7\x57\x68\x14\x24\xcd\x79\x7d\xe2\x6c\x9a\x53\xa6\xfd\xff\x0e\x5d\xfa\x26\xea\x00\xc2\xf8\xcf\x29\x83\x56\x05\xdd\x59\xa7\x12\x34\x32\x92\x46\x17\x69\x47\x7d\xf8\x5b\x6f\xbc\x55\xb1\x60\x2e\x12\xfd\xbb\x0c\x2d\xf7\x65\x8c\x89\xa1\xe7\x96\x2d\xc4\xe2\x51\x5e\xf1\x6a\x54\xa7\xf2\x6a\x94\x8e\x1e\x5a\x10\xfc\xb5\x22\x1c\xac\x34\x79\x5d\x18\x42\x2e\x5b\x7e\x3c\x1c\xe3\x15\xf8\x22\x6b\x0e\xcc\xcf\x01\xce\xe4\xf5\x6f\xf5\x7e\xb0\xd1\x6a\x7a\xef\x47\xff\x73\x79\x9e\xb0\xc9\x5b\x2c\xfe\x61\x05\xd0\xae\x13\x41\xfe\xa0\x4e\x4d\xec\xb7\xa3\x65\xbe\xa0\x3a\xdc\x4d\xc8\x4d\x36\x95\x60\x33\x78\x2f\x8c\x63\x9a\xbc\xfd\xfc\xfe\x1d\x60\x22\x59\xeb\xfd\x95\x61\x12\x4e\x79\x2a\xef\xf6\x6b\xf0\xdc\xab\x8f\x9f\xfd\x91\x7c\xf1\x51\x82\xea\x00\x0a\x83\x2a\x17\x29\x84\x22\x92\x85\x7f\x8e\x35\x31\xfd\x69\x01\xb2\xb5\x15\xda\xf6\x89\xf3\x73\x15\xcc\x55\x78\x9d\x71\x55\xf9\x5a\x64\xf1\x4e\x0d\x5e\xde\xb2\xc5\x61\xdd\xe6\x99\x1f\x15\x5b\xf1\xd2\xce\xc2\xda\xc0\x2a\x72\x13\x9b\xb3\x42\x71\x2f\x51\x46\xe9\xe6\xdf\x21\xd3\x23\xe2\xd5\x5a\x67\x9a\xab\x7b\x16\x08\xe0\x4b\x42\xb8\x01\xa3\x4f\xab\x4e\x5e\xab\x11\xcc\x9c\x1e\x1b\x40\x73\x68\x8d\x90\x4d\x76\x91\x9b\xec\x97\x47\x26\x2b\x77\xda\xfc\x6c\xbf\x64\xb3\xfd\xf2\xf8\x6c\xf1\x52\xd8\xca\xc9\xe2\x05\x0b\x4e\x22\xc6\xee\x52\xfd\x8d\x9a\x11\x63\xb7\x0b\xe4\x76\xc1\x66\xf2\xfb\x37\x1e\xd9\xe9\x4c\xe7\x78\x2d\xc2\x1f\x60\xfc\x2e\x3e\xb3\x82\xdf\x30\x01\x47\x2d\xbe\xa5\x83\xa5\x6d\x7c\xfb\xda\x87\x0d\xf6\xa0\x23\xbe\x55\x43\xcd\xa7\x6b\x56\xf3\x66\xb4\x62\x0b\x06\x79\x74\x3e\x46\x22\x95\xd2\x5d\xa7\x72\xae\x09\x1a\x07\x12\x8e\x62\x96\xd0\x56\xe9\xce\xac\x38\x93\x79\x44\x45\x1e\x25\x92\x39\xa2\xbc\x05\xd5\x9c\xff\xa9\xc3\x3f\x79\x0e\x5b\x63\x51\xa5\xcb\x71\x05\xdb\x2a\xdd\x86\x5b\x57\x32\x48\xc7\x7a\xa9\x11\x1d\x1d\xbe\x7c\xf4\xf8\x75\xa7\x27\x9f\x2a\xfd\x39\x77\x1c\xd6\xb9\xda\x54\x7f\xad\x29\xab\x73\x56\x68\x9b\x38\xe1\x29\xc8\xc2\x02\x7b\xd2\x9d\xc2\xc7\xbe\xa2\x53\xad\x61\xd5\x97\x62\x95\x62\x58\xf9\xfb\x63\xca\x51\x80\xa9\x54\x10\x21\xcc\xdc\x47\x9f\x7e\xa0\xff\x9c\x41\xc8\xf1\xc9\x17\x27\x45\x59\xb1\x24\x83\x7f\xee\xf9\xbf\xf9\x73\x37\xbf\xf4\xb3\x24\x3a\xac\xa2\x91\x5f\x17\xbc\xc2\x7f\x58\x75\xb0\x89\x47\xe6\xbf\xca\x15\xab\xba\x66\x8a\x7b\x9f\xa8\xde\x55\xbc\x29\x11\x8b\x8a\x62\x9d\xbe\xad\xab\x51\xab\xf5\xf2\x31\x7d\x5b\xe6\x7f\xa6\xb3\x21\xbe\x53\x77\x68\x9d\xf5\x95\x3f\x61\x63\x64\x52\xaf\x24\xe5\x23\x5e\xfc\x2b\x6a\x6a\xfe\x7b\x35\xfa\xaf\x10\xb6\xd4\xc2\xad\xa1\xd0\x35\xc6\xb3\xb4\x03\x84\xe7\xe6\x13\x0d\x78\x36\xe6\x07\x0b\x93\x16\x98\x0b\xdf\xed\xf6\xa5\xf8\x6a\xd6\x1c\xcf\xd6\xd8\x03\xec\x12\xb2\x58\x8a\xdb\x05\x04\x4b\x6d\xf1\xc5\x37\xce\x48\xcc\x1e\x04\xbc\xd4\x4b\xf8\xa5\x5e\x71\x14\x55\xda\x5c\xb1\x73\xc6\x87\x1f\xf2\xdd\x00\x8d\xba\xf2\xe3\xe7\xde\x39\xec\x12\x5f\xc4\xfb\xf2\x4d\x92\xb5\xbe\x07\x27\x36\xce\x37\x49\xb2\x76\xcb\x5b\x50\xe2\xb4\x80\x17\xc6\x33\x4e\x0b\x8d\x97\x14\xd8\x08\xd2\xec\x42\x58\xbb\xad\x3f\x56\x02\x16\x9b\x64\xd3\x83\xf8\x52\x7e\xcf\x0e\x34\x61\x16\x12\xff\x16\xcf\x2b\x84\xce\x83\xaa\x0c\x26\x21\xc7\xeb\x87\x1c\xdf\x86\xc5\x92\xd8\x2d\xac\xd5\x58\x7e\xce\x0f\x0c\xd5\x12\x1d\xfe\xd4\x6f\xdb\xa1\xa0\x80\x05\x43\xf6\x36\x4c\x52\x2e\x3e\xba\x84\xdf\x34\x43\x1c\x34\x7d\x64\x03\xe7\x07\x22\x92\xb2\x32\x57\x2b\xc5\x07\x67\x70\x7f\x28\x33\x39\x16\x11\x3f\x35\x5f\x44\xdb\x05\x18\x31\x51\xb3\xa5\xa4\xa0\xdc\xb0\xb2\x97\x9c\x25\xc0\x12\x4a\xe3\x82\xd3\x89\xbb\x99\x52\x7e\xa9\xc8\xf5\xe3\x00\x0d\x76\xb3\x01\x59\xa4\x4c\x23\x8d\xde\xfc\xed\x6f\xe4\x3b\xb1\x5a\x9e\xb8\xad\xf1\x24\x6a\x78\xe7\xdf\x2c\xb6\x9e\x5d\xe6\x1d\x20\x40\xda\x15\x99\x5d\x66\x66\x96\x08\x0c\x62\xfd\x88\xc5\xe1\xb4\x86\xdb\x94\x3c\x60\x4c\xeb\x83\xcd\x82\xd1\x11\xe0\xa2\xc8\xda\xbf\x58\x4c\x3f\xde\xde\x02\xa6\xb9\x97\x96\x27\x17\x45\xa1\x92\xb2\x2b\xe2\x1b\x09\x53\xf7\xf9\xa0\x32\xa7\xfa\xdb\x2d\x19\x59\xce\x2e\x2e\x3f\xea\xc3\x0c\x2f\xc5\x8f\x86\xba\x9d\x26\x69\x6d\x6b\x6d\x7d\xee\x6e\x3e\x03\x65\x06\x5c\xa1\x8a\x96\xc1\x16\xeb\xd4\xf8\xc2\x36\xff\x50\x69\x88\xf8\xa7\xbb\x57\xcd\xe4\x2b\x67\x63\x48\xaf\x9a\x8d\xa6\x5f\x58\x56\xf9\x22\x7d\xcd\xca\xd6\x62\xa5\x75\xca\xd0\x5c\x87\x19\xfb\xde\x16\xfe\xdf\xff\x03\xd6\x06\x4b\x44\x75\x56\x00\x00")
 
 func webfilesSloop_uiJsBytes() ([]byte, error) {
 	return bindataRead(
@@ -356,7 +377,7 @@ func webfilesSloop_uiJs() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "webfiles/sloop_ui.js", size: 21534, mode: os.FileMode(420), modTime: time.Unix(1659992879, 0)}
+	info := bindataFileInfo{name: "webfiles/sloop_ui.js", size: 22133, mode: os.FileMode(420), modTime: time.Unix(1792204548, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -413,6 +434,7 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
+	"webfiles/alerts.html":         webfilesAlertsHtml,
 	"webfiles/debug.html":          webfilesDebugHtml,
 	"webfiles/debug.js":            webfilesDebugJs,
 	"webfiles/debugconfig.html":    webfilesDebugconfigHtml,
@@ -473,6 +495,7 @@ type bintree struct {
 
 var _bintree = &bintree{nil, map[string]*bintree{
 	"webfiles": &bintree{nil, map[string]*bintree{
+		"alerts.html":         &bintree{webfilesAlertsHtml, map[string]*bintree{}},
 		"debug.html":          &bintree{webfilesDebugHtml, map[string]*bintree{}},
 		"debug.js":            &bintree{webfilesDebugJs, map[string]*bintree{}},
 		"debugconfig.html":    &bintree{webfilesDebugconfigHtml, map[string]*bintree{}},
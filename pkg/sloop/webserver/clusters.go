@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/salesforce/sloop/pkg/sloop/auth"
+	"github.com/salesforce/sloop/pkg/sloop/store/typed"
+)
+
+const (
+	ClusterStatusOk       = "ok"
+	ClusterStatusStarting = "starting"
+	ClusterStatusStale    = "stale"
+	ClusterStatusError    = "error"
+)
+
+// A kubernetes context served by this process.  Each cluster has its own store and its pages live under /<Context>
+type Cluster struct {
+	Context string
+	Tables  typed.Tables
+	// Returns the current state of the cluster's watcher and processing.  Nil means always ok
+	Health func() ClusterHealth
+	// Checks what each user can see with the cluster's api server.  Nil when rbac authorization is off
+	Authorizer *auth.RbacAuthorizer
+}
+
+type ClusterHealth struct {
+	Status string `json:"status"`
+	// Explains a status other than ok
+	Message string `json:"message,omitempty"`
+	// When a watch record for this cluster was last written to the store
+	LastUpdate time.Time `json:"lastUpdate"`
+}
+
+type clusterStatus struct {
+	Context string `json:"context"`
+	Url     string `json:"url"`
+	ClusterHealth
+	Selected bool `json:"-"`
+}
+
+func getClusterStatuses(clusters []Cluster, currentContext string) []clusterStatus {
+	ret := make([]clusterStatus, 0, len(clusters))
+	for _, cluster := range clusters {
+		status := clusterStatus{Context: cluster.Context, Url: path.Join("/", cluster.Context), Selected: cluster.Context == currentContext}
+		if cluster.Health != nil {
+			status.ClusterHealth = cluster.Health()
+		} else {
+			status.Status = ClusterStatusOk
+		}
+		ret = append(ret, status)
+	}
+	return ret
+}
+
+// Returns the clusters served by this process and their health as json
+func clustersHandler(clusters []Cluster) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("content-type", "application/json")
+		data, err := json.MarshalIndent(getClusterStatuses(clusters, ""), "", " ")
+		if err != nil {
+			logWebError(err, "Failed to marshal clusters", request, writer)
+			return
+		}
+		writer.Write(data)
+	}
+}
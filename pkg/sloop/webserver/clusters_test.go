@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func helper_clusters(t *testing.T) []Cluster {
+	return []Cluster{
+		{Context: "east", Tables: helper_apiTables(t, 2)},
+		{Context: "west", Tables: helper_apiTables(t, 5), Health: func() ClusterHealth {
+			return ClusterHealth{Status: ClusterStatusStale, Message: "nothing has been recorded for 1h0m0s", LastUpdate: someApiTs}
+		}},
+	}
+}
+
+func Test_ClustersHandler(t *testing.T) {
+	req, err := http.NewRequest("GET", "/clusters", nil)
+	assert.Nil(t, err)
+	rr := httptest.NewRecorder()
+	clustersHandler(helper_clusters(t)).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var statuses []clusterStatus
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &statuses))
+	assert.Equal(t, []clusterStatus{
+		{Context: "east", Url: "/east", ClusterHealth: ClusterHealth{Status: ClusterStatusOk}},
+		{Context: "west", Url: "/west", ClusterHealth: ClusterHealth{Status: ClusterStatusStale, Message: "nothing has been recorded for 1h0m0s", LastUpdate: someApiTs}},
+	}, statuses)
+}
+
+func Test_RegisterRoutes_ServesEachCluster(t *testing.T) {
+	mux := http.NewServeMux()
+	config := WebConfig{DefaultLookback: "1h", MaxLookback: 24 * time.Hour}
+	registerRoutes(mux, config, helper_clusters(t))
+
+	// Each cluster reads its own store
+	url := "/api/v1/resources?start_time=1551668400&end_time=1551672000"
+	code, body := helper_apiGet(t, mux.ServeHTTP, "/east"+url)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, body["items"], 2)
+	code, body = helper_apiGet(t, mux.ServeHTTP, "/west"+url)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, body["items"], 5)
+
+	// The root redirects to the first cluster
+	req, err := http.NewRequest("GET", "/", nil)
+	assert.Nil(t, err)
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+	assert.Equal(t, "/east", rr.Header().Get("Location"))
+}
+
+func Test_IndexHandler_ClusterPicker(t *testing.T) {
+	clusters := helper_clusters(t)
+	req, err := http.NewRequest("GET", "/west", nil)
+	assert.Nil(t, err)
+	rr := httptest.NewRecorder()
+	indexHandler(WebConfig{CurrentContext: "west"}, clusters).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	page := rr.Body.String()
+	assert.Contains(t, page, `<option value="/east" >east</option>`)
+	assert.Contains(t, page, `<option value="/west" selected>west (stale)</option>`)
+	assert.Contains(t, page, "Cluster is stale: nothing has been recorded for 1h0m0s")
+}
@@ -48,40 +48,24 @@ func viewKeyHandler(tables typed.Tables) http.HandlerFunc {
 		writer.Header().Set("content-type", "text/html")
 
 		key := request.FormValue("k")
+		if !namespaceScope(request).AllowsKey(key) {
+			http.Error(writer, "Forbidden", http.StatusForbidden)
+			return
+		}
 		data := keyView{}
 		data.Key = key
 
 		var valueFromTable interface{}
 
 		err := tables.Db().View(func(txn badgerwrap.Txn) error {
-			if (&typed.WatchTableKey{}).ValidateKey(key) == nil {
-				kwr, err := tables.WatchTable().Get(txn, key)
-				if err != nil {
-					return err
-				}
-				valueFromTable = *kwr
+			var err error
+			valueFromTable, err = typed.GetValue(tables, txn, key)
+			if err != nil {
+				return err
+			}
+			if kwr, ok := valueFromTable.(*typed.KubeWatchResult); ok {
 				data.ExtraName = "$.Payload"
 				data.ExtraValue = template.HTML(jsonPrettyPrint(kwr.Payload))
-			} else if (&typed.ResourceSummaryKey{}).ValidateKey(key) == nil {
-				rs, err := tables.ResourceSummaryTable().Get(txn, key)
-				if err != nil {
-					return err
-				}
-				valueFromTable = *rs
-			} else if (&typed.EventCountKey{}).ValidateKey(key) == nil {
-				ec, err := tables.EventCountTable().Get(txn, key)
-				if err != nil {
-					return err
-				}
-				valueFromTable = *ec
-			} else if (&typed.WatchActivityKey{}).ValidateKey(key) == nil {
-				wa, err := tables.WatchActivityTable().Get(txn, key)
-				if err != nil {
-					return err
-				}
-				valueFromTable = *wa
-			} else {
-				return fmt.Errorf("Invalid key: %v", key)
 			}
 			return nil
 		})
@@ -138,7 +122,7 @@ func listKeysHandler(tables typed.Tables) http.HandlerFunc {
 		var tablesToSearch []string
 
 		if table == "all" {
-			tablesToSearch = append(tablesToSearch, "watch", "eventcount", "ressum", "watchactivity")
+			tablesToSearch = append(tablesToSearch, "watch", "eventcount", "ressum", "watchactivity", "alert", "resindex")
 		} else {
 			tablesToSearch = append(tablesToSearch, table)
 		}
@@ -188,6 +172,12 @@ func listKeysHandler(tables typed.Tables) http.HandlerFunc {
 					case "watchactivity":
 						key := &typed.WatchActivityKey{}
 						keys = append(keys, tables.WatchActivityTable().GetAllKeysForGivenPartitions(tables.Db(), key, maxRows, lookBack, keySearch)...)
+					case "alert":
+						key := &typed.AlertKey{}
+						keys = append(keys, tables.AlertTable().GetAllKeysForGivenPartitions(tables.Db(), key, maxRows, lookBack, keySearch)...)
+					case "resindex":
+						key := &typed.ResourceIndexKey{}
+						keys = append(keys, tables.ResourceIndexTable().GetAllKeysForGivenPartitions(tables.Db(), key, maxRows, lookBack, keySearch)...)
 					}
 				}
 				count = len(keys)
@@ -200,6 +190,14 @@ func listKeysHandler(tables typed.Tables) http.HandlerFunc {
 			logWebError(err, "Could not list keys", request, writer)
 			return
 		}
+		if scope := namespaceScope(request); scope.IsRestricted() {
+			keys = scope.FilterKeys(keys)
+			count = len(keys)
+			if scope.Err() != nil {
+				logWebError(scope.Err(), "Could not check access to keys", request, writer)
+				return
+			}
+		}
 
 		writer.Header().Set("content-type", "text/html")
 
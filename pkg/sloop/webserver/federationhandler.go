@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/salesforce/sloop/pkg/sloop/federation"
+	"github.com/salesforce/sloop/pkg/sloop/queries"
+)
+
+// Lists the peers which failed as json when a federated query only partly succeeded
+const peerErrorsHeader = "Sloop-Peer-Errors"
+
+// Like queryHandler, but the query is answered by the peers
+func federatedQueryHandler(fed *federation.Federation) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("content-type", "application/json")
+
+		// Peers answer with their own credentials, so namespace rules can not be applied to what they return
+		if namespaceScope(request).IsRestricted() {
+			http.Error(writer, "Forbidden", http.StatusForbidden)
+			return
+		}
+
+		queryName := request.URL.Query().Get(queries.QueryParam)
+		data, peerErrors, err := fed.RunQuery(request.Context(), queryName, request.URL.Query())
+		if len(peerErrors) > 0 {
+			peerErrorsJson, jsonErr := json.Marshal(peerErrors)
+			if jsonErr == nil {
+				writer.Header().Set(peerErrorsHeader, string(peerErrorsJson))
+			}
+		}
+		if err != nil {
+			logWebError(err, "Failed to run federated query", request, writer)
+			return
+		}
+
+		writer.Write(data)
+	}
+}
+
+// Returns the peers as json
+func peersHandler(fed *federation.Federation) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("content-type", "application/json")
+		data, err := json.MarshalIndent(fed.Peers(), "", " ")
+		if err != nil {
+			logWebError(err, "Failed to marshal peers", request, writer)
+			return
+		}
+		writer.Write(data)
+	}
+}
+
+// Registers the routes for federation mode.  There is no local store, so only the pages which read through /data
+// are served
+func registerFederationRoutes(mux *http.ServeMux, config WebConfig, fed *federation.Federation) {
+	config.Federated = true
+	mux.Handle("/", middlewareChain("root", redirectHandler(config.CurrentContext)))
+	mux.Handle("/healthz", metricCountMiddleware("healthz", healthHandler()))
+	mux.Handle("/metrics", metricCountMiddleware("metrics", promhttp.HandlerFor(
+		prometheus.DefaultGatherer,
+		promhttp.HandlerOpts{
+			EnableOpenMetrics: true,
+		},
+	)))
+
+	ccPrefix := fmt.Sprintf("/%s", config.CurrentContext)
+	mux.HandleFunc(ccPrefix, middlewareChain("index", indexHandler(config, nil)))
+	mux.HandleFunc(ccPrefix+"/webfiles/", middlewareChain("webFile", webFileHandler(config.CurrentContext)))
+	mux.HandleFunc(ccPrefix+"/data", middlewareChain("query", federatedQueryHandler(fed)))
+	mux.HandleFunc(ccPrefix+"/resource", middlewareChain("resource", resourceHandler(config.ResourceLinks, config.CurrentContext)))
+	mux.HandleFunc(ccPrefix+"/peers", middlewareChain("peers", peersHandler(fed)))
+}
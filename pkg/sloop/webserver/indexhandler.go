@@ -9,6 +9,8 @@ package webserver
 
 import (
 	"net/http"
+
+	"github.com/salesforce/sloop/pkg/sloop/auth"
 )
 
 type indexData struct {
@@ -17,9 +19,13 @@ type indexData struct {
 	DefaultKind      string
 	LeftBarLinks     []ComputedLink
 	CurrentContext   string
+	Clusters         []clusterStatus
+	Federated        bool
+	User             string
+	IsAdmin          bool
 }
 
-func indexHandler(config WebConfig) http.HandlerFunc {
+func indexHandler(config WebConfig, clusters []Cluster) http.HandlerFunc {
 	return func(writer http.ResponseWriter, request *http.Request) {
 		indexTemplate, err := getTemplate(indexTemplateFile, _webfilesIndexHtml)
 		if err != nil {
@@ -31,6 +37,14 @@ func indexHandler(config WebConfig) http.HandlerFunc {
 		data.DefaultNamespace = config.DefaultNamespace
 		data.DefaultKind = config.DefaultResources
 		data.CurrentContext = config.CurrentContext
+		data.Clusters = getClusterStatuses(clusters, config.CurrentContext)
+		data.Federated = config.Federated
+		// Without auth there is no identity and everyone is an admin
+		identity := auth.IdentityFrom(request.Context())
+		data.IsAdmin = identity == nil || identity.IsAdmin()
+		if identity != nil {
+			data.User = identity.User
+		}
 		data.LeftBarLinks, err = makeLeftBarLinks(config.LeftBarLinks)
 		if err != nil {
 			logWebError(err, "Could not make left bar links", request, writer)
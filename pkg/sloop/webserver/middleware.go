@@ -19,6 +19,8 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
+	"github.com/salesforce/sloop/pkg/sloop/auth"
+	"github.com/salesforce/sloop/pkg/sloop/queries"
 	"github.com/salesforce/sloop/pkg/sloop/server/server_metrics"
 )
 
@@ -118,8 +120,75 @@ func middlewareChain(handlerName string, next http.Handler) http.HandlerFunc {
 	)
 }
 
+// Like middlewareChain, but also checks what the caller can see with the rbac authorizer of the cluster.  Admins and
+// requests without an identity are not checked
+func clusterMiddlewareChain(authorizer *auth.RbacAuthorizer) func(string, http.Handler) http.HandlerFunc {
+	return func(handlerName string, next http.Handler) http.HandlerFunc {
+		if authorizer == nil {
+			return middlewareChain(handlerName, next)
+		}
+		return middlewareChain(handlerName, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			identity := auth.IdentityFrom(r.Context())
+			if identity != nil && !identity.IsAdmin() {
+				r = r.WithContext(context.WithValue(r.Context(), kindAuthorizerKey{}, authorizer.ForUser(identity)))
+			}
+			next.ServeHTTP(w, r)
+		}))
+	}
+}
+
 func metricCountMiddleware(handlerName string, next http.Handler) http.HandlerFunc {
 	return promhttp.InstrumentHandlerCounter(
 		metricWebServerRequestCount.MustCurryWith(prometheus.Labels{"handler": handlerName}),
 		userMetricsMiddleware(handlerName, next))
 }
+
+// Paths which are served without credentials so probes and prometheus keep working
+var unauthenticatedPaths = map[string]bool{"/healthz": true, "/metrics": true}
+
+// Rejects requests without valid credentials and puts the identity of the rest in the request context.  This wraps
+// the whole mux, so it also covers paths which are not registered
+func authMiddleware(authenticator *auth.Authenticator, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if unauthenticatedPaths[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+		identity, err := authenticator.Authenticate(r)
+		if err != nil {
+			glog.Infof("Rejected request for %v from %v: %v", r.URL.Path, r.RemoteAddr, err)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="sloop"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(auth.WithIdentity(r.Context(), identity)))
+	})
+}
+
+// Only lets admins through.  A request without an identity was not authenticated because auth is off
+func adminOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity := auth.IdentityFrom(r.Context())
+		if identity != nil && !identity.IsAdmin() {
+			glog.Infof("Rejected request for %v from non-admin user %v", r.URL.Path, identity.User)
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+type kindAuthorizerKey struct{}
+
+// The namespaces the caller can see.  A request without an identity was not authenticated because auth is off
+func namespaceScope(r *http.Request) queries.NamespaceScope {
+	scope := queries.Unrestricted
+	identity := auth.IdentityFrom(r.Context())
+	if identity != nil && identity.NamespaceRestricted {
+		scope = queries.NewNamespaceScope(identity.Namespaces)
+	}
+	if authorizer, ok := r.Context().Value(kindAuthorizerKey{}).(queries.KindAuthorizer); ok {
+		scope = scope.WithAuthorizer(authorizer)
+	}
+	return scope
+}
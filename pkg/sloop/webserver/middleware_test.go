@@ -0,0 +1,176 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+package webserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/salesforce/sloop/pkg/sloop/auth"
+)
+
+func helper_authMux(t *testing.T) http.Handler {
+	authenticator, err := auth.NewAuthenticator(auth.Config{
+		Tokens:      []auth.StaticToken{{Token: "viewer-token", User: "viewer"}, {Token: "admin-token", User: "admin", Groups: []string{"sre"}}},
+		AdminGroups: []string{"sre"},
+	})
+	assert.Nil(t, err)
+	mux := http.NewServeMux()
+	config := WebConfig{DefaultLookback: "1h", MaxLookback: 24 * time.Hour}
+	registerRoutes(mux, config, helper_clusters(t))
+	return authMiddleware(authenticator, mux)
+}
+
+func helper_authGet(t *testing.T, handler http.Handler, url string, token string) int {
+	req, err := http.NewRequest("GET", url, nil)
+	assert.Nil(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr.Code
+}
+
+func Test_AuthMiddleware(t *testing.T) {
+	handler := helper_authMux(t)
+	apiUrl := "/east/api/v1/resources?start_time=1551668400&end_time=1551672000"
+
+	assert.Equal(t, http.StatusUnauthorized, helper_authGet(t, handler, apiUrl, ""))
+	assert.Equal(t, http.StatusUnauthorized, helper_authGet(t, handler, apiUrl, "wrong"))
+	assert.Equal(t, http.StatusOK, helper_authGet(t, handler, apiUrl, "viewer-token"))
+	// Unregistered paths are covered too
+	assert.Equal(t, http.StatusUnauthorized, helper_authGet(t, handler, "/nowhere", ""))
+	// Probes and scrapes do not need credentials
+	assert.Equal(t, http.StatusOK, helper_authGet(t, handler, "/healthz", ""))
+	assert.Equal(t, http.StatusOK, helper_authGet(t, handler, "/metrics", ""))
+}
+
+func Test_AuthMiddleware_AdminOnlyRoutes(t *testing.T) {
+	handler := helper_authMux(t)
+
+	for _, url := range []string{"/east/debug/", "/east/debug/config/", "/west/debug/listkeys/", "/east/data/backup"} {
+		assert.Equal(t, http.StatusForbidden, helper_authGet(t, handler, url, "viewer-token"), url)
+		assert.NotEqual(t, http.StatusForbidden, helper_authGet(t, handler, url, "admin-token"), url)
+	}
+}
+
+func Test_IndexHandler_HidesDebugLinkFromViewers(t *testing.T) {
+	handler := helper_authMux(t)
+	for token, wantLink := range map[string]bool{"viewer-token": false, "admin-token": true} {
+		req, err := http.NewRequest("GET", "/east", nil)
+		assert.Nil(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, wantLink, strings.Contains(rr.Body.String(), `href="debug/"`), token)
+		assert.Contains(t, rr.Body.String(), "Signed in as")
+	}
+}
+
+func Test_AuthMiddleware_NamespaceRules(t *testing.T) {
+	authenticator, err := auth.NewAuthenticator(auth.Config{
+		Tokens: []auth.StaticToken{{Token: "allowed-token", User: "allowed"}, {Token: "other-token", User: "other"}},
+		NamespaceRules: []auth.NamespaceRule{
+			{Users: []string{"allowed"}, Namespaces: []string{"some*"}},
+			{Users: []string{"other"}, Namespaces: []string{"otherNamespace"}},
+		},
+	})
+	assert.Nil(t, err)
+	mux := http.NewServeMux()
+	registerRoutes(mux, WebConfig{DefaultLookback: "1h", MaxLookback: 24 * time.Hour}, helper_clusters(t))
+	handler := authMiddleware(authenticator, mux)
+
+	for token, wantCount := range map[string]int{"allowed-token": 2, "other-token": 0} {
+		req, err := http.NewRequest("GET", "/east/api/v1/resources?start_time=1551668400&end_time=1551672000", nil)
+		assert.Nil(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		body := map[string]interface{}{}
+		assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Len(t, body["items"], wantCount, token)
+	}
+}
+
+func Test_ClusterMiddlewareChain_RbacAuthorizer(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{{Name: "pods", Kind: "Pod", Namespaced: true}}},
+	}
+	client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		sar := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		sar.Status.Allowed = sar.Spec.User == "allowed" && sar.Spec.ResourceAttributes.Namespace == "someNamespace"
+		return true, sar, nil
+	})
+	authenticator, err := auth.NewAuthenticator(auth.Config{
+		Tokens:     []auth.StaticToken{{Token: "allowed-token", User: "allowed"}, {Token: "other-token", User: "other"}, {Token: "admin-token", User: "admin"}},
+		AdminUsers: []string{"admin"},
+	})
+	assert.Nil(t, err)
+	clusters := helper_clusters(t)
+	clusters[0].Authorizer = auth.NewRbacAuthorizer(client, time.Minute)
+	mux := http.NewServeMux()
+	registerRoutes(mux, WebConfig{DefaultLookback: "1h", MaxLookback: 24 * time.Hour}, clusters)
+	handler := authMiddleware(authenticator, mux)
+
+	for token, wantCount := range map[string]int{"allowed-token": 2, "other-token": 0, "admin-token": 2} {
+		req, err := http.NewRequest("GET", "/east/api/v1/resources?start_time=1551668400&end_time=1551672000", nil)
+		assert.Nil(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		body := map[string]interface{}{}
+		assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Len(t, body["items"], wantCount, token)
+	}
+}
+
+func Test_ClusterMiddlewareChain_RbacReviewFails(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{{Name: "pods", Kind: "Pod", Namespaced: true}}},
+	}
+	reviewCount := 0
+	client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		reviewCount++
+		return true, nil, fmt.Errorf("api server is down")
+	})
+	authenticator, err := auth.NewAuthenticator(auth.Config{Tokens: []auth.StaticToken{{Token: "some-token", User: "someUser"}}})
+	assert.Nil(t, err)
+	clusters := helper_clusters(t)
+	clusters[0].Authorizer = auth.NewRbacAuthorizer(client, time.Minute)
+	mux := http.NewServeMux()
+	registerRoutes(mux, WebConfig{DefaultLookback: "1h", MaxLookback: 24 * time.Hour}, clusters)
+	handler := authMiddleware(authenticator, mux)
+
+	// The request fails instead of returning what could be checked, after asking the api server once
+	req, err := http.NewRequest("GET", "/east/api/v1/resources?start_time=1551668400&end_time=1551672000", nil)
+	assert.Nil(t, err)
+	req.Header.Set("Authorization", "Bearer some-token")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	assert.Equal(t, 1, reviewCount)
+}
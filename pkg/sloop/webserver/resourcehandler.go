@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"html/template"
 	"net/http"
+	"net/url"
 	"path"
 	"strings"
 	"time"
@@ -29,6 +30,7 @@ type resourceData struct {
 	Name          string
 	Kind          string
 	Uuid          string
+	Cluster       string
 	ClickTime     time.Time
 	SelfUrl       string
 	Links         []ComputedLink
@@ -66,6 +68,7 @@ func resourceHandler(resLinks []ResourceLinkTemplate, currentContext string) htt
 		d.Name = cleanStringFromParam(request, queries.NameParam, "")
 		d.Kind = cleanStringFromParam(request, queries.KindParam, "")
 		d.Uuid = cleanStringFromParam(request, queries.UuidParam, "")
+		d.Cluster = cleanStringFromParam(request, queries.ClusterParam, "")
 		d.ClickTime, err = timeFromUnixTimeParam(request, queries.ClickTimeParam, time.Time{}, time.Millisecond)
 		if err != nil || d.ClickTime == (time.Time{}) {
 			logWebError(err, "Invalid click time", request, writer)
@@ -84,10 +87,16 @@ func resourceHandler(resLinks []ResourceLinkTemplate, currentContext string) htt
 		queryStart := d.ClickTime.Add(-1 * d.PlusMinusTime).Unix()
 		queryEnd := d.ClickTime.Add(d.PlusMinusTime).Unix()
 
-		dataParams := fmt.Sprintf("?query=%v&namespace=%v&start_time=%v&end_time=%v&kind=%v&name=%v", "GetEventData", d.Namespace, queryStart, queryEnd, d.Kind, d.Name)
+		// In federation mode the cluster param picks the peer the data comes from
+		clusterParam := ""
+		if d.Cluster != "" {
+			clusterParam = "&" + queries.ClusterParam + "=" + url.QueryEscape(d.Cluster)
+		}
+
+		dataParams := fmt.Sprintf("?query=%v&namespace=%v&start_time=%v&end_time=%v&kind=%v&name=%v%v", "GetEventData", d.Namespace, queryStart, queryEnd, d.Kind, d.Name, clusterParam)
 		d.EventsUrl = path.Join("/", currentContext, "data"+dataParams)
 
-		dataParams = fmt.Sprintf("?query=%v&namespace=%v&start_time=%v&end_time=%v&kind=%v&name=%v", "GetResPayload", d.Namespace, queryStart, queryEnd, d.Kind, d.Name)
+		dataParams = fmt.Sprintf("?query=%v&namespace=%v&start_time=%v&end_time=%v&kind=%v&name=%v%v", "GetResPayload", d.Namespace, queryStart, queryEnd, d.Kind, d.Name, clusterParam)
 		d.PayloadUrl = path.Join("/", currentContext, "data"+dataParams)
 
 		err = resourceTemplate.Execute(writer, d)
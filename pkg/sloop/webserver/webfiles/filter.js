@@ -117,13 +117,14 @@ function setFiltersAndReturnQueryUrl(defaultLookback, defaultKind, defaultNamesp
     sort =            setDropdown("sort",     "filtersort",     "start_time", false)
 
     namematch = setText("namematch", "filternamematch", "")
+    selector = setText("selector", "filterselector", "")
 
     windowLocation = window.location.pathname.toString()
     query =           populateDropdownFromQuery("query",     "filterquery",     "EventHeatMap",  windowLocation+"/data?query=Queries&lookback="+lookback);
     ns =              populateDropdownFromQuery("namespace", "filternamespace", defaultNamespace, windowLocation+"/data?query=Namespaces&lookback="+lookback);
     kind =            populateDropdownFromQuery("kind",      "filterkind",      defaultKind,      windowLocation+"/data?query=Kinds&lookback="+lookback);
 
-    dataQuery = windowLocation+"/data?query="+query+"&namespace="+ns+"&lookback="+lookback+"&kind="+kind+"&sort="+sort+"&namematch="+namematch+"&end_time="+selectedEndTime
+    dataQuery = windowLocation+"/data?query="+query+"&namespace="+ns+"&lookback="+lookback+"&kind="+kind+"&sort="+sort+"&namematch="+namematch+"&selector="+encodeURIComponent(selector)+"&end_time="+selectedEndTime
     return dataQuery
 }
 
@@ -88,6 +88,7 @@ let cmpFn = noSortFn;
 function loadSVG() {
     payload = d3.json(dataQueryUrl);
     payload.then(function (result) {
+        showPeerErrors(result.peer_errors);
         initializeDimensions();
         svg = render(result);
         bindMouseEvents(svg);
@@ -97,6 +98,16 @@ function loadSVG() {
 }
 loadSVG();
 
+// In federation mode some peers may have failed while the others returned rows
+function showPeerErrors(peerErrors) {
+    if (!peerErrors) {
+        return;
+    }
+    let div = document.getElementById("peererrors");
+    div.textContent = "Missing data from: " + peerErrors.map(e => e.peer).join(", ");
+    div.title = peerErrors.map(e => e.peer + ": " + e.error).join("\n");
+}
+
 // Payload toggle switch on change to display payload change ticks
 function payloadChecker() {
     if(document.getElementById("payloadCheck").checked == true) {
@@ -315,6 +326,7 @@ function bindMouseEvents(svg) {
                     title: d.text,
                     kind: d.kind,
                     namespace: d.namespace,
+                    cluster: d.cluster,
                     time: theTime
                 }
             ))
@@ -424,6 +436,7 @@ function getHeatmapContent(d) {
 
 function getResourceBarContent(d) {
     return `<div id="tiny-tooltip">Name: <b>${d.title}</b><br/>` +
+        (d.cluster ? `Cluster: <b>${d.cluster}</b><br/>` : ``) +
         `Kind: <b>${d.kind}</b><br/>` +
         `Namespace: <b>${d.namespace}</b><br/>` +
         `<br/>${formatDateTime(d.time)}</div>`;
@@ -577,6 +590,7 @@ function showDetailedTooltip(d, event, parent) {
                 title: d.text,
                 kind: d.kind,
                 namespace: d.namespace,
+                cluster: d.cluster,
                 time: theTime
             }
         );
@@ -596,6 +610,7 @@ function showDetailedTooltip(d, event, parent) {
                 name: d.text,
                 namespace: d.namespace,
                 kind: d.kind,
+                cluster: d.cluster,
             },
             success: function (result) {
                 detailedToolTipIsVisible = true;
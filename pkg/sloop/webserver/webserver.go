@@ -23,8 +23,10 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/klauspost/compress/zstd"
+	"github.com/salesforce/sloop/pkg/sloop/auth"
+	"github.com/salesforce/sloop/pkg/sloop/backup"
 	"github.com/salesforce/sloop/pkg/sloop/common"
+	"github.com/salesforce/sloop/pkg/sloop/federation"
 	"github.com/spf13/afero"
 
 	"github.com/salesforce/sloop/pkg/sloop/queries"
@@ -48,21 +50,31 @@ const (
 	debugBadgerTablesTemplateFile = "debugtables.html"
 	indexTemplateFile             = "index.html"
 	resourceTemplateFile          = "resource.html"
+	alertsTemplateFile            = "alerts.html"
 )
 
 type WebConfig struct {
-	BindAddress       string
-	Port              int
-	WebFilesPath      string
-	DefaultNamespace  string
-	DefaultLookback   string
-	DefaultResources  string
-	MaxLookback       time.Duration
-	ConfigYaml        string
-	ResourceLinks     []ResourceLinkTemplate
-	LeftBarLinks      []LinkTemplate
+	BindAddress      string
+	Port             int
+	WebFilesPath     string
+	DefaultNamespace string
+	DefaultLookback  string
+	DefaultResources string
+	MaxLookback      time.Duration
+	ConfigYaml       string
+	ResourceLinks    []ResourceLinkTemplate
+	LeftBarLinks     []LinkTemplate
+	// Set to the context of each cluster when its routes are registered
 	CurrentContext    string
 	EnableUserMetrics bool
+	// Nil when auth is off
+	Auth *auth.Authenticator
+	// Set to the rbac authorizer of each cluster when its routes are registered
+	Authorizer *auth.RbacAuthorizer
+	// Queries are answered by federation peers and there is no local store
+	Federated bool
+	// Backups are envelope encrypted with this key when it is set
+	BackupEncryptionKey []byte
 }
 
 // This is not going to change and we don't want to pass it to every function
@@ -107,7 +119,9 @@ func webFileHandler(currentContext string) http.HandlerFunc {
 // backupHandler streams a download of a backup of the database.
 // It is a simple HTTP translation of the Badger DB's built-in online backup function.
 // If the optional `since` query parameter is provided, the backup will only include versions since the version provided.
-func backupHandler(db badgerwrap.DB, currentContext string) http.HandlerFunc {
+// When encryptionKey is set the compressed backup is envelope encrypted, and it is sent as a plain binary file since
+// a client can not decode the compression of an encrypted body.
+func backupHandler(db badgerwrap.DB, currentContext string, encryptionKey []byte) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		sinceStr := r.URL.Query().Get("since")
 		if sinceStr == "" {
@@ -119,25 +133,18 @@ func backupHandler(db badgerwrap.DB, currentContext string) http.HandlerFunc {
 			return
 		}
 
-		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=sloop-%s-%d.bak.zst", currentContext, since))
 		// The 'Content-Length' header is not set, because we do not know the size of the backup before we write it to the body.
-		w.Header().Set("Content-Encoding", "zstd")
-		w.Header().Set("Content-Type", "application/zstd")
-		w.Header().Set("Transfer-Encoding", "chunked")
-
-		zw, err := zstd.NewWriter(w)
-		if err != nil {
-			logWebError(err, "Error configuring compression", r, w)
-			return
-		}
-
-		_, err = db.Backup(zw, since)
-		if err != nil {
-			logWebError(err, "Error writing backup", r, w)
-			return
+		if encryptionKey != nil {
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=sloop-%s-%d.bak.zst.enc", currentContext, since))
+			w.Header().Set("Content-Type", "application/octet-stream")
+		} else {
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=sloop-%s-%d.bak.zst", currentContext, since))
+			w.Header().Set("Content-Encoding", "zstd")
+			w.Header().Set("Content-Type", "application/zstd")
 		}
+		w.Header().Set("Transfer-Encoding", "chunked")
 
-		err = zw.Close()
+		_, err = backup.Write(db, w, since, encryptionKey)
 		if err != nil {
 			logWebError(err, "Error writing backup", r, w)
 			return
@@ -157,7 +164,11 @@ func queryHandler(tables typed.Tables, maxLookBack time.Duration) http.HandlerFu
 		writer.Header().Set("content-type", "application/json")
 
 		queryName := request.URL.Query().Get(queries.QueryParam)
-		data, err := queries.RunQuery(queryName, request.URL.Query(), tables, maxLookBack, getRequestId(request.Context()))
+		scope := namespaceScope(request)
+		data, err := queries.RunQuery(queryName, request.URL.Query(), tables, maxLookBack, getRequestId(request.Context()), scope)
+		if err == nil {
+			err = scope.Err()
+		}
 		if err != nil {
 			logWebError(err, "Failed to run query", request, writer)
 			return
@@ -187,10 +198,10 @@ func redirectHandler(currentContext string) http.HandlerFunc {
 	}
 }
 
-// Registers routes on mux router
-func registerRoutes(mux *http.ServeMux, config WebConfig, tables typed.Tables) {
+// Registers routes on mux router.  The first cluster is the default one that / redirects to
+func registerRoutes(mux *http.ServeMux, config WebConfig, clusters []Cluster) {
 	// root pages
-	mux.Handle("/", middlewareChain("root", redirectHandler(config.CurrentContext)))
+	mux.Handle("/", middlewareChain("root", redirectHandler(clusters[0].Context)))
 	// Only metric on endpoints scraped by bots to avoid noise in logs.
 	mux.Handle("/healthz", metricCountMiddleware("healthz", healthHandler()))
 	mux.Handle("/metrics", metricCountMiddleware("metrics", promhttp.HandlerFor(
@@ -199,40 +210,74 @@ func registerRoutes(mux *http.ServeMux, config WebConfig, tables typed.Tables) {
 			EnableOpenMetrics: true,
 		},
 	)))
+	mux.HandleFunc("/clusters", middlewareChain("clusters", clustersHandler(clusters)))
 
-	// /<currentContext> pages
+	for _, cluster := range clusters {
+		clusterConfig := config
+		clusterConfig.CurrentContext = cluster.Context
+		clusterConfig.Authorizer = cluster.Authorizer
+		registerClusterRoutes(mux, clusterConfig, cluster.Tables, clusters)
+	}
+}
+
+// Registers the /<currentContext> pages for one cluster
+func registerClusterRoutes(mux *http.ServeMux, config WebConfig, tables typed.Tables, clusters []Cluster) {
 	ccPrefix := fmt.Sprintf("/%s", config.CurrentContext)
-	mux.HandleFunc(ccPrefix, middlewareChain("index", indexHandler(config)))
-	mux.HandleFunc(ccPrefix+"/webfiles/", middlewareChain("webFile", webFileHandler(config.CurrentContext)))
-	mux.HandleFunc(ccPrefix+"/data/backup", middlewareChain("backup", backupHandler(tables.Db(), config.CurrentContext)))
-	mux.HandleFunc(ccPrefix+"/data", middlewareChain("query", queryHandler(tables, config.MaxLookback)))
-	mux.HandleFunc(ccPrefix+"/resource", middlewareChain("resource", resourceHandler(config.ResourceLinks, config.CurrentContext)))
+	chain := clusterMiddlewareChain(config.Authorizer)
+	mux.HandleFunc(ccPrefix, chain("index", indexHandler(config, clusters)))
+	mux.HandleFunc(ccPrefix+"/webfiles/", chain("webFile", webFileHandler(config.CurrentContext)))
+	mux.HandleFunc(ccPrefix+"/data/backup", chain("backup", adminOnly(backupHandler(tables.Db(), config.CurrentContext, config.BackupEncryptionKey))))
+	mux.HandleFunc(ccPrefix+"/data", chain("query", queryHandler(tables, config.MaxLookback)))
+	mux.HandleFunc(ccPrefix+"/resource", chain("resource", resourceHandler(config.ResourceLinks, config.CurrentContext)))
+	mux.HandleFunc(ccPrefix+"/alerts", chain("alerts", alertsHandler(config, tables)))
+	// Versioned api
+	mux.HandleFunc(ccPrefix+"/api/v1/resources", chain("apiResources", apiResourcesHandler(config, tables)))
+	mux.HandleFunc(ccPrefix+"/api/v1/events", chain("apiEvents", apiEventsHandler(config, tables)))
+	mux.HandleFunc(ccPrefix+"/api/v1/history", chain("apiHistory", apiHistoryHandler(config, tables)))
+	mux.HandleFunc(ccPrefix+"/api/v1/diff", chain("apiDiff", apiDiffHandler(config, tables)))
+	mux.HandleFunc(ccPrefix+"/api/v1/snapshot", chain("apiSnapshot", apiSnapshotHandler(config, tables)))
+	mux.HandleFunc(ccPrefix+"/api/v1/summary", chain("apiSummary", apiSummaryHandler(config, tables)))
+	mux.HandleFunc(ccPrefix+"/api/v1/alerts", chain("apiAlerts", apiAlertsHandler(config, tables)))
+	mux.HandleFunc(ccPrefix+"/api/v1/export", chain("apiExport", apiExportHandler(config, tables)))
+	mux.HandleFunc(ccPrefix+"/api/v1/openapi.json", chain("apiOpenApi", apiOpenApiHandler()))
+	mux.HandleFunc(ccPrefix+"/api/", chain("api", apiNotFoundHandler()))
 	// Debug pages
-	mux.HandleFunc(ccPrefix+"/debug/listkeys/", middlewareChain("debug", listKeysHandler(tables)))
-	mux.HandleFunc(ccPrefix+"/debug/histogram/", middlewareChain("debug", histogramHandler(tables)))
-	mux.HandleFunc(ccPrefix+"/debug/tables/", middlewareChain("debug", debugBadgerTablesHandler(tables.Db())))
-	mux.HandleFunc(ccPrefix+"/debug/view", middlewareChain("debug", viewKeyHandler(tables)))
-	mux.HandleFunc(ccPrefix+"/debug/config/", middlewareChain("debug", configHandler(config.ConfigYaml)))
+	mux.HandleFunc(ccPrefix+"/debug/listkeys/", chain("debug", adminOnly(listKeysHandler(tables))))
+	mux.HandleFunc(ccPrefix+"/debug/histogram/", chain("debug", adminOnly(histogramHandler(tables))))
+	mux.HandleFunc(ccPrefix+"/debug/tables/", chain("debug", adminOnly(debugBadgerTablesHandler(tables.Db()))))
+	mux.HandleFunc(ccPrefix+"/debug/view", chain("debug", adminOnly(viewKeyHandler(tables))))
+	mux.HandleFunc(ccPrefix+"/debug/config/", chain("debug", adminOnly(configHandler(config.ConfigYaml))))
 	// Badger uses the trace package, which registers /debug/requests and /debug/events
-	mux.HandleFunc(ccPrefix+"/debug/requests", middlewareChain("debug", http.HandlerFunc(trace.Traces)))
-	mux.HandleFunc(ccPrefix+"/debug/events", middlewareChain("debug", http.HandlerFunc(trace.Events)))
+	mux.HandleFunc(ccPrefix+"/debug/requests", chain("debug", adminOnly(http.HandlerFunc(trace.Traces))))
+	mux.HandleFunc(ccPrefix+"/debug/events", chain("debug", adminOnly(http.HandlerFunc(trace.Events))))
 	// Badger also uses expvar which exposes prometheus compatible metrics on /debug/vars
-	mux.HandleFunc(ccPrefix+"/debug/vars", middlewareChain("debug", http.HandlerFunc(expvar.Handler().ServeHTTP)))
-	mux.HandleFunc(ccPrefix+"/debug/", middlewareChain("debug", debugHandler()))
+	mux.HandleFunc(ccPrefix+"/debug/vars", chain("debug", adminOnly(http.HandlerFunc(expvar.Handler().ServeHTTP))))
+	mux.HandleFunc(ccPrefix+"/debug/", chain("debug", adminOnly(debugHandler())))
 }
 
-func Run(config WebConfig, tables typed.Tables) error {
-	webFilesPath = config.WebFilesPath
-	enableUserMetrics = config.EnableUserMetrics
+func Run(config WebConfig, clusters []Cluster) error {
+	mux := http.NewServeMux()
+	registerRoutes(mux, config, clusters)
+	return serve(config, mux)
+}
 
+// RunFederation serves the UI for queries answered by the peers of fed instead of a local store
+func RunFederation(config WebConfig, fed *federation.Federation) error {
 	mux := http.NewServeMux()
-	registerRoutes(mux, config, tables)
+	registerFederationRoutes(mux, config, fed)
+	return serve(config, mux)
+}
+
+// Serves mux until the process is interrupted
+func serve(config WebConfig, mux *http.ServeMux) error {
+	webFilesPath = config.WebFilesPath
+	enableUserMetrics = config.EnableUserMetrics
 
 	addr := fmt.Sprintf("%v:%v", config.BindAddress, config.Port)
 
 	h := &http.Server{
 		Addr:     addr,
-		Handler:  mux,
+		Handler:  authMiddleware(config.Auth, mux),
 		ErrorLog: log.New(os.Stdout, "http: ", log.LstdFlags),
 	}
 	if config.BindAddress != "" {
@@ -1,11 +1,14 @@
 package webserver
 
 import (
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	badger "github.com/dgraph-io/badger/v2"
+	"github.com/klauspost/compress/zstd"
+	"github.com/salesforce/sloop/pkg/sloop/common"
 	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
 	"github.com/stretchr/testify/assert"
 )
@@ -67,9 +70,36 @@ func TestBackupHandler(t *testing.T) {
 
 	// Create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
 	rr := httptest.NewRecorder()
-	handler := http.HandlerFunc(backupHandler(db, "clusterContext"))
+	handler := http.HandlerFunc(backupHandler(db, "clusterContext", nil))
 	handler.ServeHTTP(rr, req)
 
 	assert.Equal(t, http.StatusOK, rr.Code)
 	assert.NotNil(t, rr.Body.String())
 }
+
+func TestBackupHandler_Encrypted(t *testing.T) {
+	req, err := http.NewRequest("GET", "/clusterContext/data/backup", nil)
+	assert.Nil(t, err)
+
+	db, err := (&badgerwrap.MockFactory{}).Open(badger.DefaultOptions(""))
+	assert.Nil(t, err)
+
+	key := []byte("0123456789abcdef")
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(backupHandler(db, "clusterContext", key))
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "", rr.Header().Get("Content-Encoding"))
+	assert.Contains(t, rr.Header().Get("Content-Disposition"), ".bak.zst.enc")
+	assert.True(t, common.IsEnvelope(rr.Body.Bytes()))
+
+	// The envelope holds a zstd stream
+	reader, err := common.NewEnvelopeReader(rr.Body, [][]byte{key})
+	assert.Nil(t, err)
+	decoder, err := zstd.NewReader(reader)
+	assert.Nil(t, err)
+	defer decoder.Close()
+	_, err = ioutil.ReadAll(decoder)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2019, salesforce.com, inc.
+ * All rights reserved.
+ * SPDX-License-Identifier: BSD-3-Clause
+ * For full license text, see LICENSE.txt file in the repo root or https://opensource.org/licenses/BSD-3-Clause
+ */
+
+// sloopmigrate copies all tables from one sloop store to another, for example from badger to bolt:
+//
+//	sloopmigrate --from-dir=./data/mycontext --to-dir=./data-bolt/mycontext --to-engine=bolt
+//
+// An encrypted badger store is read with --from-encryption-key-file.  Sloop must not be running against either
+// directory, and the destination has to be empty or not exist yet.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped"
+	"github.com/salesforce/sloop/pkg/sloop/store/untyped/badgerwrap"
+)
+
+var (
+	fromDir    = flag.String("from-dir", "", "Store directory to copy from, including the kube context sub directory")
+	fromEngine = flag.String("from-engine", badgerwrap.EngineBadger, "Storage engine of the source store")
+	fromKey    = flag.String("from-encryption-key-file", "", "File with the key the source store is encrypted with")
+	toDir      = flag.String("to-dir", "", "Store directory to copy to.  Must be empty or not exist yet")
+	toEngine   = flag.String("to-engine", badgerwrap.EngineBolt, "Storage engine of the destination store")
+	batchSize  = flag.Int("batch-size", 1000, "Number of keys written per transaction")
+)
+
+func main() {
+	// Same as sloop, print to console unless told otherwise
+	_ = flag.Set("logtostderr", "true")
+	flag.Parse()
+
+	err := migrate()
+	if err != nil {
+		glog.Errorf("Migration failed: %v", err)
+		os.Exit(1)
+	}
+}
+
+func openDb(engine string, dir string, keyFile string) (badgerwrap.DB, error) {
+	factory, err := badgerwrap.NewFactory(engine)
+	if err != nil {
+		return nil, err
+	}
+	config := &untyped.Config{
+		RootPath:                dir,
+		ConfigPartitionDuration: time.Hour,
+		EncryptionKeyFile:       keyFile,
+	}
+	return untyped.OpenStore(factory, config)
+}
+
+func migrate() error {
+	if *fromDir == "" || *toDir == "" {
+		return errors.New("both --from-dir and --to-dir are required")
+	}
+	if *fromDir == *toDir {
+		return errors.New("--from-dir and --to-dir must be different")
+	}
+	// Opening a missing store would create an empty one, so a typo would copy nothing
+	_, err := os.Stat(*fromDir)
+	if err != nil {
+		return errors.Wrapf(err, "there is no store at %v", *fromDir)
+	}
+	// Keys already in the destination would be mixed in with the copied ones
+	entries, err := ioutil.ReadDir(*toDir)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to read %v", *toDir)
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("--to-dir %v is not empty", *toDir)
+	}
+
+	src, err := openDb(*fromEngine, *fromDir, *fromKey)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open %v store at %v", *fromEngine, *fromDir)
+	}
+	defer untyped.CloseStore(src)
+
+	dst, err := openDb(*toEngine, *toDir, "")
+	if err != nil {
+		return errors.Wrapf(err, "failed to open %v store at %v", *toEngine, *toDir)
+	}
+	defer untyped.CloseStore(dst)
+
+	before := time.Now()
+	glog.Infof("Copying %v store at %v to %v store at %v", *fromEngine, *fromDir, *toEngine, *toDir)
+	copied, err := untyped.CopyStore(src, dst, *batchSize)
+	if err != nil {
+		return errors.Wrapf(err, "failed after copying %v keys", copied)
+	}
+	glog.Infof("Copied %v keys in %v", copied, time.Since(before))
+	return nil
+}